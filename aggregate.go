@@ -0,0 +1,88 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+// Aggregate folds the subtree rooted at the given Node into a single value.
+//
+// The fold is bottom-up: the children are folded before their parent, and the given Node comes last.
+// It computes sums, minimums, maximums or any custom rollup, such as the headcount of a department,
+// without collecting the descendants into a slice first.
+//
+// Parameters:
+//   - tree: The Tree holding the subtree.
+//   - node: The root of the subtree to fold.
+//   - seed: The initial value of the accumulator.
+//   - fn: The function combining the accumulator with a Node of the subtree.
+//
+// Returns:
+//   - A: The accumulator once every Node of the subtree has been folded.
+//   - bool: false when the Node is not part of the Tree, in which case the seed is returned.
+//
+// Notes:
+//   - The subtree is walked without locking, hence changes applied concurrently may or may not be reflected.
+//   - fn is called while the subtree is being walked, hence it must not modify the Tree.
+//
+// Example usage:
+//
+//	headcount, ok := Aggregate(tree, department, 0, func(count int, node Node[Employee]) int {
+//	    return count + 1
+//	})
+//
+//	payroll, ok := Aggregate(tree, department, 0.0, func(total float64, node Node[Employee]) float64 {
+//	    return total + node.Value().Salary
+//	})
+func Aggregate[T, A any](tree *Tree[T], node Node[T], seed A, fn func(A, Node[T]) A) (A, bool) {
+	start, ok := tree.getNode(node.ID())
+	if !ok {
+		return seed, false
+	}
+
+	// walk iteratively so that deep Trees do not exhaust the stack
+	type frame struct {
+		node     *treeNode[T]
+		children []*treeNode[T]
+	}
+
+	accumulator := seed
+	guard := tree.newCycleGuard(false, start)
+	stack := []frame{{node: start, children: start.Descendants.Items()}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if len(top.children) == 0 {
+			if value := top.node.GetValue(); value != nil {
+				accumulator = fn(accumulator, value)
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		child := top.children[0]
+		top.children = top.children[1:]
+		if guard.visit(top.node, child) {
+			stack = append(stack, frame{node: child, children: child.Descendants.Items()})
+		}
+	}
+	return accumulator, true
+}
@@ -0,0 +1,69 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	tree := NewTree[int]()
+	require.NoError(t, tree.AddByID(NewNode("root", 1), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", 2), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", 3), "root"))
+	require.NoError(t, tree.AddByID(NewNode("a1", 4), "a"))
+	require.NoError(t, tree.AddByID(NewNode("a2", 5), "a"))
+
+	t.Run("folds a subtree", func(t *testing.T) {
+		count, ok := Aggregate(tree, NewNode("a", 0), 0, func(count int, _ Node[int]) int { return count + 1 })
+		require.True(t, ok)
+		assert.Equal(t, 3, count)
+
+		sum, ok := Aggregate(tree, NewNode("root", 0), 0, func(sum int, node Node[int]) int { return sum + node.Value() })
+		require.True(t, ok)
+		assert.Equal(t, 15, sum)
+
+		leaf, ok := Aggregate(tree, NewNode("b", 0), 0, func(maximum int, node Node[int]) int { return max(maximum, node.Value()) })
+		require.True(t, ok)
+		assert.Equal(t, 3, leaf)
+	})
+
+	t.Run("folds the children before their parent", func(t *testing.T) {
+		order, ok := Aggregate(tree, NewNode("root", 0), []string(nil), func(ids []string, node Node[int]) []string {
+			return append(ids, node.ID())
+		})
+		require.True(t, ok)
+		assert.Equal(t, []string{"a1", "a2", "a", "b", "root"}, order)
+	})
+
+	t.Run("returns the seed for a missing node", func(t *testing.T) {
+		seed, ok := Aggregate(tree, NewNode("missing", 0), 42, func(sum int, node Node[int]) int { return sum + node.Value() })
+		assert.False(t, ok)
+		assert.Equal(t, 42, seed)
+	})
+}
@@ -0,0 +1,82 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import "slices"
+
+// AncestorOrder defines the order in which AncestorsOrdered returns the ancestors of a Node
+type AncestorOrder int
+
+const (
+	// RootFirst lists the ancestors from the root down to the parent of the Node
+	RootFirst AncestorOrder = iota
+	// NearestFirst lists the ancestors from the parent of the Node up to the root
+	NearestFirst
+)
+
+// AncestorsOrdered retrieves the ancestor Nodes of a given Node in genealogical order.
+//
+// Unlike Ancestors, which sorts the ancestors by ID, AncestorsOrdered keeps the order of the ancestor
+// chain, hence the position of an ancestor in the result tells its distance to the Node.
+//
+// Parameters:
+//   - node: The Node[T] for which the ancestors are to be retrieved.
+//   - order: The AncestorOrder of the result, RootFirst or NearestFirst.
+//
+// Returns:
+//   - ancestors: The ancestors of the Node in the requested order. It is empty for the root.
+//   - ok: A boolean indicating whether the Node exists in the Tree.
+//
+// Example usage:
+//
+//	ancestors, ok := tree.AncestorsOrdered(node, RootFirst)
+//	if ok {
+//	    // the breadcrumb of the node
+//	    for _, ancestor := range ancestors {
+//	        fmt.Print(ancestor.ID(), " > ")
+//	    }
+//	    fmt.Println(node.ID())
+//	}
+func (x *Tree[T]) AncestorsOrdered(node Node[T], order AncestorOrder) (ancestors []Node[T], ok bool) {
+	chain, ok := x.parents.Load(node.ID())
+	if !ok {
+		// the root has no parent entry
+		if _, ok = x.getNode(node.ID()); !ok {
+			return nil, false
+		}
+		return []Node[T]{}, true
+	}
+
+	ancestors = make([]Node[T], 0, chain.Depth()+1)
+	x.WalkUp(node, func(ancestor Node[T]) bool {
+		ancestors = append(ancestors, ancestor)
+		return true
+	})
+
+	if order == RootFirst {
+		slices.Reverse(ancestors)
+	}
+	return ancestors, true
+}
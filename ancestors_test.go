@@ -0,0 +1,62 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAncestorsOrdered(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+	require.NoError(t, tree.AddByID(NewNode("z", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("a", ""), "z"))
+	require.NoError(t, tree.AddByID(NewNode("m", ""), "a"))
+
+	m, _ := tree.Find("m")
+	ancestors, ok := tree.AncestorsOrdered(m, RootFirst)
+	require.True(t, ok)
+	assert.Equal(t, []string{"root", "z", "a"}, queryIDs(ancestors))
+
+	ancestors, ok = tree.AncestorsOrdered(m, NearestFirst)
+	require.True(t, ok)
+	assert.Equal(t, []string{"a", "z", "root"}, queryIDs(ancestors))
+
+	ancestors, ok = tree.AncestorsOrdered(tree.Root(), RootFirst)
+	require.True(t, ok)
+	assert.Empty(t, ancestors)
+
+	_, ok = tree.AncestorsOrdered(NewNode("missing", ""), NearestFirst)
+	assert.False(t, ok)
+
+	// the parents of a moved node follow the move
+	require.NoError(t, tree.MoveByID("m", "z"))
+	ancestors, ok = tree.AncestorsOrdered(m, RootFirst)
+	require.True(t, ok)
+	assert.Equal(t, []string{"root", "z"}, queryIDs(ancestors))
+}
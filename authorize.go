@@ -0,0 +1,209 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"fmt"
+)
+
+// AddContext behaves like Add once the authorizer set with WithAuthorizer has allowed the addition.
+//
+// The authorizer is consulted with OperationAdd and the parent the Node is added under, or the Node
+// itself when it is added as the root, so that the permissions are granted per subtree.
+//
+// Parameters:
+//   - ctx: The context of the caller, passed to the authorizer.
+//   - node: The Node[T] to be added to the Tree.
+//   - parent: The Node[T] under which the `node` will be added, nil for the root.
+//
+// Returns:
+//   - err: The errors of Add, the error of the context when it is done, or an error wrapping
+//     ErrPermissionDenied along with the error of the authorizer.
+//
+// Notes:
+//   - The context-aware methods behave like their counterparts when no authorizer is set, except that
+//     the wait for the mutation limiter set with WithMutationLimiter ends with the context.
+//   - The authorization and the operation are not atomic: the Tree may change in between.
+//   - A Tree with an authorizer rejects the methods changing it without context, such as Add,
+//     with ErrPermissionDenied.
+//
+// Example usage:
+//
+//	tree := NewTree[string](WithAuthorizer(func(ctx context.Context, operation Operation, node Node[string]) error {
+//	    if !canWrite(ctx, node.ID()) {
+//	        return fmt.Errorf("tenant %s cannot write under %s", tenantOf(ctx), node.ID())
+//	    }
+//	    return nil
+//	}))
+//	err := tree.AddContext(ctx, child, parent)
+func (x *Tree[T]) AddContext(ctx context.Context, node, parent Node[T]) error {
+	target := parent
+	if target == nil {
+		target = node
+	}
+	if err := x.authorize(ctx, OperationAdd, target, opAdd, node.ID(), parentID(parent)); err != nil {
+		return err
+	}
+	return x.addContext(ctx, node, parent, nil)
+}
+
+// UpdateContext behaves like Update once the authorizer has allowed the update of the Node,
+// which is given its current value held by the Tree.
+func (x *Tree[T]) UpdateContext(ctx context.Context, node Node[T]) error {
+	if err := x.authorizeExisting(ctx, OperationUpdate, node.ID(), opUpdate, ""); err != nil {
+		return err
+	}
+	return x.updateContext(ctx, node)
+}
+
+// DeleteContext behaves like Delete once the authorizer has allowed the deletion of the Node,
+// which is given its current value held by the Tree.
+func (x *Tree[T]) DeleteContext(ctx context.Context, node Node[T]) error {
+	if err := x.authorizeExisting(ctx, OperationDelete, node.ID(), opDelete, ""); err != nil {
+		return err
+	}
+	return x.deleteContext(ctx, node.ID())
+}
+
+// MoveContext behaves like Move once the authorizer has allowed both the move of the Node, given
+// its current value held by the Tree, with OperationMove and the addition under the new parent
+// with OperationAdd. A nil parent is rejected with ErrInvalidOperation since the root cannot be replaced
+// by a move.
+func (x *Tree[T]) MoveContext(ctx context.Context, node, parent Node[T]) error {
+	if parent == nil {
+		return newNodeError(opMove, node.ID(), "", ErrInvalidOperation)
+	}
+	if err := x.authorizeExisting(ctx, OperationMove, node.ID(), opMove, parent.ID()); err != nil {
+		return err
+	}
+	if x.authorizer != nil {
+		if target, ok := x.getNode(parent.ID()); ok {
+			if err := x.authorize(ctx, OperationAdd, target.GetValue(), opMove, node.ID(), parent.ID()); err != nil {
+				return err
+			}
+		}
+	}
+	return x.moveContext(ctx, node.ID(), parent.ID())
+}
+
+// ResetContext behaves like Reset once the authorizer has allowed the removal of every Node with
+// OperationReset, which is given the root of the Tree. Unlike Reset, it returns the refusals.
+func (x *Tree[T]) ResetContext(ctx context.Context, opts ...ResetOption) error {
+	if root := x.rootNode.Load(); root != nil {
+		if err := x.authorize(ctx, OperationReset, root.GetValue(), opReset, root.ID, ""); err != nil {
+			return err
+		}
+	} else if err := ctx.Err(); err != nil {
+		return err
+	}
+	return x.resetContext(ctx, newResetConfig(opts...))
+}
+
+// FindContext behaves like Find once the authorizer has allowed the read of the Node with
+// OperationFind. It returns ErrNotFound when the Node is not part of the Tree.
+func (x *Tree[T]) FindContext(ctx context.Context, id string) (Node[T], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	node, ok := x.Find(id)
+	if !ok {
+		return nil, newNodeError(opFind, id, "", ErrNotFound)
+	}
+	if err := x.authorize(ctx, OperationFind, node, opFind, id, ""); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// DescendantsContext behaves like Descendants once the authorizer has allowed the read of the
+// subtree with OperationDescendants. The authorizer is consulted once, with the root of the subtree.
+// It returns ErrNotFound when the Node is not part of the Tree.
+func (x *Tree[T]) DescendantsContext(ctx context.Context, node Node[T]) ([]Node[T], error) {
+	if err := x.authorizeExisting(ctx, OperationDescendants, node.ID(), opFind, ""); err != nil {
+		return nil, err
+	}
+
+	descendants, ok := x.Descendants(node)
+	if !ok {
+		return nil, newNodeError(opFind, node.ID(), "", ErrNotFound)
+	}
+	return descendants, nil
+}
+
+// authorize consults the authorizer about the given operation applied to the given Node.
+// The rejections are reported as NodeError values for the given op and Nodes
+func (x *Tree[T]) authorize(ctx context.Context, operation Operation, node Node[T], op, id, parentID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if x.authorizer == nil {
+		return nil
+	}
+	if err := x.authorizer(ctx, operation, node); err != nil {
+		return newNodeError(op, id, parentID, fmt.Errorf("%w: %w", ErrPermissionDenied, err))
+	}
+	return nil
+}
+
+// requireContext rejects the changes made without a context on a Tree with an authorizer: the authorizer
+// is consulted with the context of the caller, hence such a Tree only accepts the context-aware methods
+func (x *Tree[T]) requireContext(op, id, parentID string) error {
+	if x.authorizer == nil {
+		return nil
+	}
+	return newNodeError(op, id, parentID, fmt.Errorf("%w: the Tree only accepts the context-aware methods", ErrPermissionDenied))
+}
+
+// authorizeExisting consults the authorizer with the current value of the Node with the given ID.
+// The missing Nodes are left to the operation, which reports them
+func (x *Tree[T]) authorizeExisting(ctx context.Context, operation Operation, id, op, parentID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if x.authorizer == nil {
+		return nil
+	}
+
+	n, ok := x.getNode(id)
+	if !ok {
+		return nil
+	}
+	return x.authorize(ctx, operation, n.GetValue(), op, id, parentID)
+}
+
+// authorizeLoad consults the authorizer before the Tree is replaced with the given records: the
+// replacement of the current root with OperationReset, or the addition of the new root with
+// OperationAdd when the Tree is empty
+func (x *Tree[T]) authorizeLoad(ctx context.Context, records []Record[T]) error {
+	if root := x.rootNode.Load(); root != nil {
+		return x.authorize(ctx, OperationReset, root.GetValue(), opLoad, root.ID, "")
+	}
+	if len(records) > 0 {
+		return x.authorize(ctx, OperationAdd, NewNode(records[0].ID, records[0].Value), opLoad, records[0].ID, "")
+	}
+	return ctx.Err()
+}
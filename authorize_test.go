@@ -0,0 +1,191 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizer(t *testing.T) {
+	type tenantKey struct{}
+
+	// tenants may only operate on the subtree named after them
+	authorizer := func(ctx context.Context, operation Operation, node Node[string]) error {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		if tenant == "admin" || strings.HasPrefix(node.ID(), tenant) {
+			return nil
+		}
+		return fmt.Errorf("%s cannot %s %s", tenant, operation, node.ID())
+	}
+
+	admin := context.WithValue(context.Background(), tenantKey{}, "admin")
+	newAuthorizedTree := func(t *testing.T) *Tree[string] {
+		tree := NewTree[string](WithAuthorizer(authorizer))
+		root, acme := NewNode("root", ""), NewNode("acme", "")
+		require.NoError(t, tree.AddContext(admin, root, nil))
+		require.NoError(t, tree.AddContext(admin, acme, root))
+		require.NoError(t, tree.AddContext(admin, NewNode("globex", ""), root))
+		require.NoError(t, tree.AddContext(admin, NewNode("acme-1", ""), acme))
+		return tree
+	}
+	acme := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	t.Run("allows the operations within the subtree", func(t *testing.T) {
+		tree := newAuthorizedTree(t)
+		parent, err := tree.FindContext(acme, "acme")
+		require.NoError(t, err)
+		require.NoError(t, tree.AddContext(acme, NewNode("acme-2", "v1"), parent))
+		require.NoError(t, tree.UpdateContext(acme, NewNode("acme-2", "v2")))
+		require.NoError(t, tree.MoveContext(acme, NewNode("acme-2", ""), NewNode("acme-1", "")))
+		descendants, err := tree.DescendantsContext(acme, parent)
+		require.NoError(t, err)
+		assert.Len(t, descendants, 2)
+		require.NoError(t, tree.DeleteContext(acme, NewNode("acme-2", "")))
+	})
+
+	t.Run("rejects the operations outside the subtree", func(t *testing.T) {
+		tree := newAuthorizedTree(t)
+		globex := NewNode("globex", "")
+
+		err := tree.AddContext(acme, NewNode("acme-2", ""), globex)
+		require.ErrorIs(t, err, ErrPermissionDenied)
+		var nodeErr *NodeError
+		require.ErrorAs(t, err, &nodeErr)
+		assert.Equal(t, "acme-2", nodeErr.NodeID())
+		assert.Equal(t, "globex", nodeErr.ParentID())
+		assert.Contains(t, err.Error(), "acme cannot add globex")
+
+		assert.ErrorIs(t, tree.UpdateContext(acme, globex), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.DeleteContext(acme, globex), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.MoveContext(acme, NewNode("acme-1", ""), globex), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.AddContext(acme, NewNode("acme-root", ""), nil), ErrInvalidOperation)
+		_, err = tree.FindContext(acme, "globex")
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+		_, err = tree.DescendantsContext(acme, tree.Root())
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+
+		// nothing has changed
+		assert.EqualValues(t, 4, tree.Size())
+		ancestors, _ := tree.AncestorsByID("acme-1")
+		assert.Equal(t, []string{"acme", "root"}, queryIDs(ancestors))
+
+		require.NoError(t, tree.DeleteContext(admin, globex))
+	})
+
+	t.Run("rejects the changes made without context", func(t *testing.T) {
+		tree := newAuthorizedTree(t)
+		acme1 := NewNode("acme-1", "")
+
+		err := tree.Add(NewNode("acme-2", ""), acme1)
+		require.ErrorIs(t, err, ErrPermissionDenied)
+		var nodeErr *NodeError
+		require.ErrorAs(t, err, &nodeErr)
+		assert.Equal(t, "add", nodeErr.Op())
+		assert.ErrorIs(t, tree.Update(acme1), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.UpdateIf("acme-1", 1, acme1), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.AddOrReplace(acme1, NewNode("acme", "")), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.InsertChildAt(acme1, NewNode("acme-2", ""), 0), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.Delete(acme1), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.SoftDelete(acme1), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.Move(acme1, NewNode("globex", "")), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.MoveAfter(NewNode("globex", ""), NewNode("acme", "")), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.SetMeta("acme-1", "owner", "globex"), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.Tag("acme-1", "hot"), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.Collapse(NewNode("acme", ""), func([]Node[string]) string { return "" }), ErrPermissionDenied)
+		assert.ErrorIs(t, tree.Decode(strings.NewReader("")), ErrPermissionDenied)
+		assert.Zero(t, tree.Prune(func(Node[string]) bool { return true }))
+		tree.Reset()
+
+		// nothing has changed
+		assert.EqualValues(t, 4, tree.Size())
+		meta, _ := tree.Meta("acme-1")
+		assert.Empty(t, meta)
+		tags, _ := tree.Tags("acme-1")
+		assert.Empty(t, tags)
+		ancestors, _ := tree.AncestorsByID("acme-1")
+		assert.Equal(t, []string{"acme", "root"}, queryIDs(ancestors))
+
+		assert.ErrorIs(t, tree.ResetContext(acme), ErrPermissionDenied)
+		assert.EqualValues(t, 4, tree.Size())
+		require.NoError(t, tree.ResetContext(admin))
+		assert.Zero(t, tree.Size())
+	})
+
+	t.Run("rejects a move to a nil parent", func(t *testing.T) {
+		tree := newAuthorizedTree(t)
+		assert.ErrorIs(t, tree.MoveContext(acme, NewNode("acme-1", ""), nil), ErrInvalidOperation)
+	})
+
+	t.Run("authorizes the loads", func(t *testing.T) {
+		source := NewTree[string]()
+		require.NoError(t, source.AddByID(NewNode("acme", ""), ""))
+		require.NoError(t, source.AddByID(NewNode("acme-1", ""), "acme"))
+		store := &memoryBlobStore{objects: make(map[string][]byte)}
+		require.NoError(t, source.SaveTo(context.Background(), store, "tree"))
+
+		// replacing the tree is authorized with its root
+		tree := newAuthorizedTree(t)
+		assert.ErrorIs(t, tree.LoadFrom(acme, store, "tree"), ErrPermissionDenied)
+		assert.EqualValues(t, 4, tree.Size())
+		require.NoError(t, tree.LoadFrom(admin, store, "tree"))
+		assert.EqualValues(t, 2, tree.Size())
+
+		// loading an empty tree is authorized with the root of the snapshot
+		globex := context.WithValue(context.Background(), tenantKey{}, "globex")
+		empty := NewTree[string](WithAuthorizer(authorizer))
+		assert.ErrorIs(t, empty.LoadFrom(globex, store, "tree"), ErrPermissionDenied)
+		assert.Zero(t, empty.Size())
+		require.NoError(t, empty.LoadFrom(acme, store, "tree"))
+		assert.EqualValues(t, 2, empty.Size())
+	})
+
+	t.Run("reports the missing nodes", func(t *testing.T) {
+		tree := newAuthorizedTree(t)
+		_, err := tree.FindContext(acme, "acme-9")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorIs(t, tree.DeleteContext(acme, NewNode("acme-9", "")), ErrNotFound)
+		_, err = tree.DescendantsContext(acme, NewNode("acme-9", ""))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("honors the context", func(t *testing.T) {
+		tree := NewTree[string]()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, tree.AddContext(ctx, NewNode("root", ""), nil), context.Canceled)
+		assert.Zero(t, tree.Size())
+
+		require.NoError(t, tree.AddContext(context.Background(), NewNode("root", ""), nil))
+		root, err := tree.FindContext(context.Background(), "root")
+		require.NoError(t, err)
+		assert.Equal(t, "root", root.ID())
+	})
+}
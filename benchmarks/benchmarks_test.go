@@ -0,0 +1,119 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package benchmarks
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tochemey/gotree"
+)
+
+var sizesFlag = flag.String("gotree.sizes", "10000", "comma separated list of tree sizes to benchmark (e.g. 10000,1000000,10000000)")
+
+// sizes returns the tree sizes to benchmark
+func sizes(b *testing.B) []int {
+	var output []int
+	for _, raw := range strings.Split(*sizesFlag, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || size < 2 {
+			b.Fatalf("invalid tree size %q", raw)
+		}
+		output = append(output, size)
+	}
+	return output
+}
+
+// run executes the given benchmark against every shape and size
+func run(b *testing.B, bench func(b *testing.B, shape Shape, size int, tree *gotree.Tree[int], nodes []gotree.Node[int])) {
+	for _, size := range sizes(b) {
+		for _, shape := range Shapes {
+			b.Run(fmt.Sprintf("%s/%d", shape.Name, size), func(b *testing.B) {
+				tree, nodes := shape.Build(size)
+				b.ReportAllocs()
+				b.ResetTimer()
+				bench(b, shape, size, tree, nodes)
+			})
+		}
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	run(b, func(b *testing.B, _ Shape, size int, tree *gotree.Tree[int], nodes []gotree.Node[int]) {
+		random := rand.New(rand.NewSource(int64(size))) //nolint:gosec
+		for i := 0; i < b.N; i++ {
+			parent := nodes[random.Intn(size)]
+			_ = tree.Add(NewNode(size+i), parent)
+		}
+	})
+}
+
+func BenchmarkDelete(b *testing.B) {
+	run(b, func(b *testing.B, shape Shape, size int, tree *gotree.Tree[int], nodes []gotree.Node[int]) {
+		random := rand.New(rand.NewSource(int64(size))) //nolint:gosec
+		firstLeaf := shape.FirstLeaf(size)
+		for i := 0; i < b.N; i++ {
+			position := firstLeaf + random.Intn(size-firstLeaf)
+			_ = tree.Delete(nodes[position])
+
+			// put the leaf back so that the tree keeps its shape
+			b.StopTimer()
+			_ = tree.Add(nodes[position], nodes[shape.ParentPosition(position)])
+			b.StartTimer()
+		}
+	})
+}
+
+func BenchmarkFind(b *testing.B) {
+	run(b, func(b *testing.B, _ Shape, size int, tree *gotree.Tree[int], nodes []gotree.Node[int]) {
+		random := rand.New(rand.NewSource(int64(size))) //nolint:gosec
+		for i := 0; i < b.N; i++ {
+			_, _ = tree.Find(nodes[random.Intn(size)].ID())
+		}
+	})
+}
+
+func BenchmarkAncestors(b *testing.B) {
+	run(b, func(b *testing.B, shape Shape, size int, tree *gotree.Tree[int], nodes []gotree.Node[int]) {
+		random := rand.New(rand.NewSource(int64(size))) //nolint:gosec
+		firstLeaf := shape.FirstLeaf(size)
+		for i := 0; i < b.N; i++ {
+			_, _ = tree.Ancestors(nodes[firstLeaf+random.Intn(size-firstLeaf)])
+		}
+	})
+}
+
+func BenchmarkDescendants(b *testing.B) {
+	run(b, func(b *testing.B, _ Shape, _ int, tree *gotree.Tree[int], nodes []gotree.Node[int]) {
+		// the first child of the root holds a significant share of the tree
+		for i := 0; i < b.N; i++ {
+			_, _ = tree.Descendants(nodes[1])
+		}
+	})
+}
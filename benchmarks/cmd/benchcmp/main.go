@@ -0,0 +1,81 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Command benchcmp compares two `go test -bench` outputs and reports
+// the benchmarks whose time per operation regressed beyond a threshold.
+//
+// Usage:
+//
+//	benchcmp [-threshold percent] old.txt new.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tochemey/gotree/benchmarks"
+)
+
+func main() {
+	threshold := flag.Float64("threshold", 10, "regression threshold in percent")
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: benchcmp [-threshold percent] old.txt new.txt")
+		os.Exit(2)
+	}
+
+	old, err := parseFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	current, err := parseFile(flag.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	regressed := false
+	for _, delta := range benchmarks.Compare(old, current, *threshold) {
+		fmt.Println(delta)
+		regressed = regressed || delta.Regression
+	}
+
+	if regressed {
+		os.Exit(1)
+	}
+}
+
+// parseFile parses the benchmark results of the given file
+func parseFile(path string) (map[string]benchmarks.Result, error) {
+	file, err := os.Open(path) // #nosec G304 -- the path is supplied by the operator
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return benchmarks.ParseResults(file)
+}
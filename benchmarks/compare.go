@@ -0,0 +1,149 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package benchmarks
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// benchLine matches the name, iterations and metrics of a `go test -bench` result line
+var benchLine = regexp.MustCompile(`^(Benchmark\S+?)(?:-\d+)?\s+(\d+)\s+(.+)$`)
+
+// Result holds the averaged metrics of a benchmark across all the runs found in an output
+type Result struct {
+	// Name is the benchmark name without the GOMAXPROCS suffix
+	Name string
+	// NsPerOp is the average time per operation in nanoseconds
+	NsPerOp float64
+	// BytesPerOp is the average number of bytes allocated per operation
+	BytesPerOp float64
+	// AllocsPerOp is the average number of allocations per operation
+	AllocsPerOp float64
+	// Runs is the number of runs the averages are computed from
+	Runs int
+}
+
+// Delta describes how a benchmark evolved between two runs
+type Delta struct {
+	// Name is the benchmark name
+	Name string
+	// Old is the baseline Result
+	Old Result
+	// New is the compared Result
+	New Result
+	// Change is the relative change of the time per operation in percent.
+	// A positive value means the benchmark got slower
+	Change float64
+	// Regression is true when the change exceeds the comparison threshold
+	Regression bool
+}
+
+// String returns a human-readable representation of the Delta
+func (d Delta) String() string {
+	status := "ok"
+	if d.Regression {
+		status = "REGRESSION"
+	}
+	return fmt.Sprintf("%-60s %14.1f ns/op %14.1f ns/op %+8.2f%%  %s", d.Name, d.Old.NsPerOp, d.New.NsPerOp, d.Change, status)
+}
+
+// ParseResults reads a `go test -bench` output and returns the averaged Result of every benchmark found
+func ParseResults(r io.Reader) (map[string]Result, error) {
+	results := make(map[string]Result)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		matches := benchLine.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if matches == nil {
+			continue
+		}
+
+		name := matches[1]
+		result := results[name]
+		result.Name = name
+
+		fields := strings.Fields(matches[3])
+		for i := 0; i+1 < len(fields); i += 2 {
+			metric, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid metric %q for %s: %w", fields[i], name, err)
+			}
+
+			switch fields[i+1] {
+			case "ns/op":
+				result.NsPerOp = average(result.NsPerOp, metric, result.Runs)
+			case "B/op":
+				result.BytesPerOp = average(result.BytesPerOp, metric, result.Runs)
+			case "allocs/op":
+				result.AllocsPerOp = average(result.AllocsPerOp, metric, result.Runs)
+			}
+		}
+
+		result.Runs++
+		results[name] = result
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Compare compares the benchmarks present in both the old and current results.
+// A benchmark is flagged as a regression when its time per operation increased
+// by more than the threshold, expressed in percent. The deltas are sorted by name.
+func Compare(old, current map[string]Result, threshold float64) []Delta {
+	var deltas []Delta
+	for name, oldResult := range old {
+		newResult, ok := current[name]
+		if !ok || oldResult.NsPerOp == 0 {
+			continue
+		}
+
+		change := (newResult.NsPerOp - oldResult.NsPerOp) / oldResult.NsPerOp * 100
+		deltas = append(deltas, Delta{
+			Name:       name,
+			Old:        oldResult,
+			New:        newResult,
+			Change:     change,
+			Regression: change > threshold,
+		})
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].Name < deltas[j].Name
+	})
+	return deltas
+}
+
+// average adds the given value to the running mean computed over count values
+func average(mean, value float64, count int) float64 {
+	return mean + (value-mean)/float64(count+1)
+}
@@ -0,0 +1,80 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package benchmarks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	old := `goos: linux
+BenchmarkFind/wide/10000-8   	1000000	       100.0 ns/op	      53 B/op	       0 allocs/op
+BenchmarkFind/wide/10000-8   	1000000	       300.0 ns/op	      53 B/op	       0 allocs/op
+BenchmarkAdd/wide/10000-8    	1000000	      1000 ns/op	     499 B/op	       9 allocs/op
+PASS`
+	current := `BenchmarkFind/wide/10000-8   	1000000	       250.0 ns/op	      53 B/op	       0 allocs/op
+BenchmarkAdd/wide/10000-8    	1000000	      1050 ns/op	     499 B/op	       9 allocs/op
+BenchmarkDelete/wide/10000-8 	1000000	      1050 ns/op	      61 B/op	       1 allocs/op`
+
+	oldResults, err := ParseResults(strings.NewReader(old))
+	require.NoError(t, err)
+	require.Len(t, oldResults, 2)
+	assert.EqualValues(t, 200, oldResults["BenchmarkFind/wide/10000"].NsPerOp)
+	assert.EqualValues(t, 2, oldResults["BenchmarkFind/wide/10000"].Runs)
+	assert.EqualValues(t, 9, oldResults["BenchmarkAdd/wide/10000"].AllocsPerOp)
+
+	newResults, err := ParseResults(strings.NewReader(current))
+	require.NoError(t, err)
+	require.Len(t, newResults, 3)
+
+	deltas := Compare(oldResults, newResults, 10)
+	require.Len(t, deltas, 2)
+	assert.Equal(t, "BenchmarkAdd/wide/10000", deltas[0].Name)
+	assert.InDelta(t, 5, deltas[0].Change, 0.001)
+	assert.False(t, deltas[0].Regression)
+	assert.Equal(t, "BenchmarkFind/wide/10000", deltas[1].Name)
+	assert.InDelta(t, 25, deltas[1].Change, 0.001)
+	assert.True(t, deltas[1].Regression)
+}
+
+func TestShape(t *testing.T) {
+	shape := Shape{Name: "binary", Branching: 2}
+	tree, nodes := shape.Build(7)
+	assert.EqualValues(t, 7, tree.Size())
+	assert.Len(t, nodes, 7)
+	assert.Equal(t, 3, shape.FirstLeaf(7))
+
+	descendants, ok := tree.Descendants(nodes[1])
+	require.True(t, ok)
+	assert.Len(t, descendants, 2)
+
+	ancestors, ok := tree.Ancestors(nodes[6])
+	require.True(t, ok)
+	assert.Len(t, ancestors, 2)
+}
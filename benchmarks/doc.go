@@ -0,0 +1,40 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package benchmarks holds the gotree performance suite and the helpers
+// used to compare benchmark runs in order to catch performance regressions.
+//
+// The suite covers Add, Delete, Find, Ancestors and Descendants against trees
+// of various shapes (wide, balanced and deep) and sizes. Only the smallest size
+// is benchmarked by default; bigger trees are opted in via the sizes flag:
+//
+//	go test ./benchmarks -run '^$' -bench . -benchmem -count 5 -gotree.sizes=10000,1000000 > new.txt
+//
+// Two runs can then be compared with:
+//
+//	go run ./benchmarks/cmd/benchcmp -threshold 10 old.txt new.txt
+//
+// The comparison exits with a non-zero status whenever a benchmark regressed
+// beyond the given threshold (in percent), which makes it usable in CI.
+package benchmarks
@@ -0,0 +1,105 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package benchmarks
+
+import (
+	"strconv"
+
+	"github.com/tochemey/gotree"
+)
+
+// Shape defines the form of a generated tree
+type Shape struct {
+	// Name identifies the Shape in the benchmark results
+	Name string
+	// Branching is the number of children of every non-leaf node
+	Branching int
+}
+
+// Shapes lists the tree shapes used by the suite
+var Shapes = []Shape{
+	{Name: "wide", Branching: 1000},
+	{Name: "balanced", Branching: 10},
+	{Name: "deep", Branching: 2},
+}
+
+// node implements gotree.Node
+type node struct {
+	id    string
+	value int
+}
+
+var _ gotree.Node[int] = (*node)(nil)
+
+// ID returns the node identifier
+func (n *node) ID() string {
+	return n.id
+}
+
+// Value returns the node value
+func (n *node) Value() int {
+	return n.value
+}
+
+// NewNode creates a benchmark node for the given position
+func NewNode(position int) gotree.Node[int] {
+	return &node{
+		id:    strconv.Itoa(position),
+		value: position,
+	}
+}
+
+// ParentPosition returns the position of the parent of the node at the given position
+// in a complete tree of the given Shape. The root, located at position zero, has no parent.
+func (s Shape) ParentPosition(position int) int {
+	return (position - 1) / s.Branching
+}
+
+// FirstLeaf returns the position of the first leaf in a complete tree
+// of the given Shape holding size nodes. All positions from there are leaves.
+func (s Shape) FirstLeaf(size int) int {
+	if size < 2 {
+		return 0
+	}
+	return (size-2)/s.Branching + 1
+}
+
+// Build creates a complete tree of the given Shape holding size nodes.
+// Nodes are identified by their breadth-first position, the root being "0".
+func (s Shape) Build(size int) (*gotree.Tree[int], []gotree.Node[int]) {
+	tree := gotree.NewTree[int](gotree.WithExpectedSize(uint64(size)))
+	nodes := make([]gotree.Node[int], size)
+	for position := 0; position < size; position++ {
+		nodes[position] = NewNode(position)
+		var parent gotree.Node[int]
+		if position > 0 {
+			parent = nodes[s.ParentPosition(position)]
+		}
+		if err := tree.Add(nodes[position], parent); err != nil {
+			panic(err)
+		}
+	}
+	return tree, nodes
+}
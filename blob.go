@@ -0,0 +1,129 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// BlobStore is the minimal interface of an object storage, such as an S3-compatible bucket,
+// holding the snapshots saved with SaveTo. The gotrees3 package implements it for S3.
+type BlobStore interface {
+	// Put stores the content read from r under the given key, replacing the existing object if any.
+	// The content is streamed: its size is not known beforehand.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get returns the content stored under the given key. The caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// SaveTo uploads the Nodes of the Tree to the given object storage.
+//
+// The Tree is encoded as done by EncodeCompressed with Zstd and streamed to the store while it is
+// encoded, hence very large Trees are uploaded without being held in memory as a whole, provided
+// that the store streams them as well (e.g. with multipart uploads).
+//
+// Parameters:
+//   - ctx: The context of the upload.
+//   - store: The object storage.
+//   - key: The key of the object holding the snapshot.
+//
+// Returns:
+//   - err: An error when the Tree cannot be encoded or the store fails.
+//
+// Example usage:
+//
+//	store, _ := gotrees3.New("https://s3.eu-west-1.amazonaws.com", "backups", credentials)
+//	if err := tree.SaveTo(ctx, store, "org/tree.gotree"); err != nil {
+//	    log.Println("Cannot upload the tree:", err)
+//	}
+func (x *Tree[T]) SaveTo(ctx context.Context, store BlobStore, key string) error {
+	reader, writer := io.Pipe()
+	count := make(chan int, 1)
+	go func() {
+		n, err := x.encode(writer, Zstd, nil)
+		count <- n
+		_ = writer.CloseWithError(err)
+	}()
+
+	err := store.Put(ctx, key, reader)
+	// unblock the encoding when the store stops reading early
+	_ = reader.CloseWithError(io.ErrClosedPipe)
+	n := <-count
+	if err != nil {
+		return fmt.Errorf("gotree: cannot upload %s: %w", key, err)
+	}
+
+	x.log(slog.LevelDebug, "tree uploaded", slog.String("key", key), slog.Int("count", n))
+	return nil
+}
+
+// LoadFrom replaces the Nodes of the Tree with the ones of the snapshot downloaded from the given
+// object storage, uploaded with SaveTo.
+//
+// Parameters:
+//   - ctx: The context of the download.
+//   - store: The object storage.
+//   - key: The key of the object holding the snapshot.
+//
+// Returns:
+//   - err: An error when the snapshot cannot be downloaded or decoded, see Decode, or an error wrapping
+//     ErrPermissionDenied when the authorizer rejects the load.
+//
+// Notes:
+//   - The Tree must not be changed while it is loaded.
+//   - Like the other context-aware methods, LoadFrom consults the authorizer set with WithAuthorizer once
+//     the snapshot is decoded and before the Tree is replaced: with OperationReset and the current root,
+//     or with OperationAdd and the root of the snapshot when the Tree is empty.
+//
+// Example usage:
+//
+//	tree := NewTree[string]()
+//	if err := tree.LoadFrom(ctx, store, "org/tree.gotree"); err != nil {
+//	    log.Fatal("Cannot download the tree:", err)
+//	}
+func (x *Tree[T]) LoadFrom(ctx context.Context, store BlobStore, key string) error {
+	body, err := store.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("gotree: cannot download %s: %w", key, err)
+	}
+	defer body.Close()
+
+	records, err := decodeFile[T](body, nil, x.cfg.migrations)
+	if err == nil {
+		err = x.authorizeLoad(ctx, records)
+	}
+	if err == nil {
+		err = x.restore(ctx, records)
+	}
+	if err != nil {
+		return fmt.Errorf("gotree: cannot load %s: %w", key, err)
+	}
+
+	x.log(slog.LevelDebug, "tree downloaded", slog.String("key", key), slog.Int("count", len(records)))
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBlobStore is a BlobStore keeping the objects in memory
+type memoryBlobStore struct {
+	objects map[string][]byte
+	// err is returned by Put before reading the content when set
+	err error
+}
+
+func (s *memoryBlobStore) Put(_ context.Context, key string, r io.Reader) error {
+	if s.err != nil {
+		return s.err
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.objects[key] = content
+	return nil
+}
+
+func (s *memoryBlobStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	content, ok := s.objects[key]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func TestSaveTo(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", "A"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("a1", "A1"), "a"))
+
+	t.Run("round-trips the tree", func(t *testing.T) {
+		store := &memoryBlobStore{objects: make(map[string][]byte)}
+		require.NoError(t, tree.SaveTo(context.Background(), store, "tree"))
+		assert.EqualValues(t, Zstd, store.objects["tree"][8])
+
+		loaded := NewTree[string]()
+		require.NoError(t, loaded.LoadFrom(context.Background(), store, "tree"))
+		assert.Equal(t, tree.Dump(), loaded.Dump())
+	})
+
+	t.Run("returns the errors of the store", func(t *testing.T) {
+		failure := errors.New("store unavailable")
+		store := &memoryBlobStore{objects: make(map[string][]byte), err: failure}
+		assert.ErrorIs(t, tree.SaveTo(context.Background(), store, "tree"), failure)
+		assert.ErrorIs(t, NewTree[string]().LoadFrom(context.Background(), store, "tree"), fs.ErrNotExist)
+	})
+}
@@ -0,0 +1,88 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import "sync/atomic"
+
+// readCache is a small lock-free direct-mapped cache of recently resolved treeNodes.
+//
+// Every entry is stamped with the cache epoch at the time the treeNode was
+// resolved. Any structural change that can make an entry stale (e.g. a deletion)
+// bumps the epoch, which invalidates all the entries at once without locking.
+type readCache[T any] struct {
+	slots []atomic.Pointer[cacheEntry[T]]
+	mask  uint64
+	epoch atomic.Uint64
+}
+
+// cacheEntry defines a readCache entry
+type cacheEntry[T any] struct {
+	id    string
+	node  *treeNode[T]
+	epoch uint64
+}
+
+// newReadCache creates a readCache with at least the given number of slots.
+// The number of slots is rounded up to the next power of two.
+func newReadCache[T any](size int) *readCache[T] {
+	slots := 1
+	for slots < size {
+		slots <<= 1
+	}
+	return &readCache[T]{
+		slots: make([]atomic.Pointer[cacheEntry[T]], slots),
+		mask:  uint64(slots - 1),
+	}
+}
+
+// Epoch returns the current cache epoch. It must be read before resolving
+// the treeNode that will be put in the cache
+func (c *readCache[T]) Epoch() uint64 {
+	return c.epoch.Load()
+}
+
+// Get returns the cached treeNode of the given id.
+// An entry whose treeNode has been recycled for another id is a miss.
+func (c *readCache[T]) Get(id string) (*treeNode[T], bool) {
+	entry := c.slots[fnv64(id)&c.mask].Load()
+	if entry == nil || entry.id != id || entry.epoch != c.epoch.Load() || entry.node.ID != id {
+		return nil, false
+	}
+	return entry.node, true
+}
+
+// Put caches the treeNode of the given id resolved at the given epoch
+func (c *readCache[T]) Put(id string, node *treeNode[T], epoch uint64) {
+	c.slots[fnv64(id)&c.mask].Store(&cacheEntry[T]{
+		id:    id,
+		node:  node,
+		epoch: epoch,
+	})
+}
+
+// Invalidate discards all the cached entries
+func (c *readCache[T]) Invalidate() {
+	c.epoch.Add(1)
+}
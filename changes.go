@@ -0,0 +1,156 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"time"
+)
+
+// ChangesSince returns the changes applied to the Tree after the given sequence number.
+//
+// It requires the change feed, enabled with WithChangeFeed, which assigns a sequence number to
+// every change and retains the latest ones. Consumers record the sequence number of the last
+// change they processed and catch up from it after a restart, which makes incremental
+// replication possible.
+//
+// To resume watching the Tree without missing any change, call Watch first and then ChangesSince
+// with the last processed sequence number; the Events delivered by Watch whose sequence number
+// has already been processed are then skipped.
+//
+// Parameters:
+//   - seq: The sequence number of the last processed change. Zero returns every retained change
+//     when no change has been evicted yet.
+//
+// Returns:
+//   - events: The changes applied after the given sequence number, in order.
+//   - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The changes were returned.
+//   - ErrSequenceExpired: Some of the changes following the sequence number are no longer retained.
+//   - ErrInvalidOperation: The change feed is not enabled.
+//
+// Example usage:
+//
+//	tree := NewTree[string](WithChangeFeed(10_000))
+//	events, err := tree.ChangesSince(lastSeq)
+//	if err == nil {
+//	    for _, event := range events {
+//	        apply(event)
+//	        lastSeq = event.Seq
+//	    }
+//	}
+func (x *Tree[T]) ChangesSince(seq uint64) (events []Event[T], err error) {
+	return x.feed.since(seq)
+}
+
+// LastSeq returns the sequence number of the last change published by the Tree,
+// zero when no change has been published yet.
+//
+// Changes are published when the change feed is enabled with WithChangeFeed
+// or the Tree is being watched.
+func (x *Tree[T]) LastSeq() uint64 {
+	x.feed.mu.Lock()
+	defer x.feed.mu.Unlock()
+	return x.feed.seq
+}
+
+// AsOf returns a read-only view of the Tree as it was at the given time.
+//
+// The view is rebuilt by replaying the changes of the change feed, enabled with WithChangeFeed, up to
+// the given time, hence it is consistent: it holds the structure and the values of the Tree exactly as
+// they were once the changes published at or before that time were applied. The view is detached from
+// the Tree and does not change afterwards.
+//
+// Parameters:
+//   - at: The point in time.
+//
+// Returns:
+//   - view: The read-only view of the Tree at that time. It is empty when the time precedes the first change.
+//   - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The view was built.
+//   - ErrSequenceExpired: The change feed no longer retains the first changes of the Tree. The feed must
+//     be large enough to retain every change for AsOf to go back in time.
+//   - ErrInvalidOperation: The change feed is not enabled.
+//
+// Notes:
+//   - Rebuilding the view replays the retained changes, which takes time proportional to their number.
+//
+// Example usage:
+//
+//	tree := NewTree[string](WithChangeFeed(1_000_000))
+//	// ...
+//	view, err := tree.AsOf(time.Now().Add(-24 * time.Hour))
+//	if err == nil {
+//	    fmt.Println("Nodes yesterday:", view.Size())
+//	}
+func (x *Tree[T]) AsOf(at time.Time) (view ReadOnlyTree[T], err error) {
+	events, err := x.ChangesSince(0)
+	if err != nil {
+		return ReadOnlyTree[T]{}, err
+	}
+
+	past := NewTree[T](OptionFunc(func(cfg *config) {
+		cfg.pathSeparator = x.cfg.pathSeparator
+		cfg.pathEscape, cfg.pathUnescape = x.cfg.pathEscape, x.cfg.pathUnescape
+		cfg.ancestorIndex = x.cfg.ancestorIndex
+		cfg.multiParent = x.cfg.multiParent
+	}))
+	for _, event := range events {
+		if event.Time.After(at) {
+			break
+		}
+		if err := past.replay(event); err != nil {
+			return ReadOnlyTree[T]{}, fmt.Errorf("gotree: cannot replay change %d: %w", event.Seq, err)
+		}
+	}
+	return past.Freeze(), nil
+}
+
+// since returns the retained events following the given sequence number
+func (p *publisher[T]) since(seq uint64) ([]Event[T], error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.retained) == 0 {
+		return nil, fmt.Errorf("%w: change feed not enabled", ErrInvalidOperation)
+	}
+
+	if seq >= p.seq {
+		return nil, nil
+	}
+
+	// the retained events are the latest ones
+	oldest := p.seq - uint64(p.count) + 1
+	if seq+1 < oldest {
+		return nil, fmt.Errorf("%w: %d is older than %d", ErrSequenceExpired, seq, oldest-1)
+	}
+
+	start := int(seq + 1 - oldest)
+	events := make([]Event[T], 0, p.count-start)
+	for i := start; i < p.count; i++ {
+		events = append(events, p.retained[(p.head+i)%len(p.retained)])
+	}
+	return events, nil
+}
@@ -0,0 +1,214 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangesSince(t *testing.T) {
+	describe := func(events []Event[string]) []string {
+		var result []string
+		for _, event := range events {
+			result = append(result, fmt.Sprintf("%d %s %s", event.Seq, event.Type, event.Node.ID()))
+		}
+		return result
+	}
+
+	t.Run("Without change feed", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.Add(NewNode("root", "root"), nil))
+		_, err := tree.ChangesSince(0)
+		require.ErrorIs(t, err, ErrInvalidOperation)
+		assert.Zero(t, tree.LastSeq())
+	})
+	t.Run("With change feed", func(t *testing.T) {
+		tree := NewTree[string](WithChangeFeed(4))
+		events, err := tree.ChangesSince(0)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		root := NewNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(NewNode("a", "a"), root))
+		require.NoError(t, tree.Update(NewNode("a", "a2")))
+		assert.EqualValues(t, 3, tree.LastSeq())
+
+		events, err = tree.ChangesSince(0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1 add root", "2 add a", "3 update a"}, describe(events))
+
+		events, err = tree.ChangesSince(2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"3 update a"}, describe(events))
+
+		events, err = tree.ChangesSince(3)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		// the oldest changes are evicted
+		require.NoError(t, tree.Add(NewNode("b", "b"), root))
+		require.NoError(t, tree.DeleteByID("a"))
+		events, err = tree.ChangesSince(1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2 add a", "3 update a", "4 add b", "5 delete a"}, describe(events))
+
+		_, err = tree.ChangesSince(0)
+		require.ErrorIs(t, err, ErrSequenceExpired)
+
+		// sequence numbers keep increasing across resets
+		tree.Reset()
+		events, err = tree.ChangesSince(5)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"6 delete root", "7 delete b"}, describe(events))
+	})
+	t.Run("Resuming a watcher", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tree := NewTree[string](WithChangeFeed(100))
+		root := NewNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(NewNode("a", "a"), root))
+		processed := uint64(1)
+
+		watched := tree.Watch(ctx, nil)
+		require.NoError(t, tree.Add(NewNode("b", "b"), root))
+
+		missed, err := tree.ChangesSince(processed)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2 add a", "3 add b"}, describe(missed))
+		processed = missed[len(missed)-1].Seq
+
+		// the watcher delivers the changes already caught up with
+		event := <-watched
+		assert.LessOrEqual(t, event.Seq, processed)
+
+		require.NoError(t, tree.Add(NewNode("c", "c"), root))
+		event = <-watched
+		assert.EqualValues(t, 4, event.Seq)
+	})
+}
+
+func TestAsOf(t *testing.T) {
+	// checkpoint returns a time strictly between the changes made before and after it
+	checkpoint := func() time.Time {
+		time.Sleep(time.Millisecond)
+		defer time.Sleep(time.Millisecond)
+		return time.Now()
+	}
+
+	t.Run("rebuilds the past states", func(t *testing.T) {
+		tree := NewTree[string](WithChangeFeed(100))
+		empty := checkpoint()
+		require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "b"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", "a1"), "a"))
+		first := checkpoint()
+		firstDump := tree.Dump()
+
+		require.NoError(t, tree.Update(NewNode("a1", "changed")))
+		require.NoError(t, tree.MoveByID("a1", "b"))
+		b, _ := tree.Find("b")
+		a, _ := tree.Find("a")
+		require.NoError(t, tree.MoveBefore(b, a))
+		require.NoError(t, tree.DeleteByID("a"))
+		second := checkpoint()
+		secondDump := tree.Dump()
+		require.NoError(t, tree.AddByID(NewNode("c", "c"), "root"))
+
+		view, err := tree.AsOf(empty)
+		require.NoError(t, err)
+		assert.Zero(t, view.Size())
+
+		view, err = tree.AsOf(first)
+		require.NoError(t, err)
+		assert.Equal(t, firstDump, view.Dump())
+		a1, ok := view.Find("a1")
+		require.True(t, ok)
+		assert.Equal(t, "a1", a1.Value())
+
+		view, err = tree.AsOf(second)
+		require.NoError(t, err)
+		assert.Equal(t, secondDump, view.Dump())
+		a1, _ = view.Find("a1")
+		assert.Equal(t, "changed", a1.Value())
+
+		// the view is detached from the tree
+		require.NoError(t, tree.DeleteByID("b"))
+		assert.EqualValues(t, 3, view.Size())
+
+		view, err = tree.AsOf(time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, tree.Dump(), view.Dump())
+	})
+
+	t.Run("rebuilds the past edges, metadata, tags and links", func(t *testing.T) {
+		tree := NewTree[string](WithChangeFeed(100), WithMultiParent())
+		require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+		root, _ := tree.Find("root")
+		require.NoError(t, tree.AddWithEdge(NewNode("b", "b"), root, EdgeData{Label: "right"}))
+		require.NoError(t, tree.SetMeta("a", "owner", "alice"))
+		require.NoError(t, tree.Tag("b", "critical"))
+		require.NoError(t, tree.Link(NewNode("b", ""), NewNode("a", "")))
+		first := checkpoint()
+		snapshot := tree.Snapshot()
+
+		require.NoError(t, tree.DeleteMeta("a", "owner"))
+		require.NoError(t, tree.Untag("b", "critical"))
+		require.NoError(t, tree.Unlink(NewNode("b", ""), NewNode("a", "")))
+		require.NoError(t, tree.SetEdge(NewNode("b", ""), EdgeData{Label: "left"}))
+
+		view, err := tree.AsOf(first)
+		require.NoError(t, err)
+		assert.Equal(t, snapshot.Records, view.Snapshot().Records)
+		parents, _ := view.Parents(NewNode("b", ""))
+		assert.Len(t, parents, 2)
+
+		view, err = tree.AsOf(time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, tree.Snapshot().Records, view.Snapshot().Records)
+	})
+
+	t.Run("requires the whole change feed", func(t *testing.T) {
+		_, err := NewTree[string]().AsOf(time.Now())
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+
+		tree := NewTree[string](WithChangeFeed(2))
+		require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "b"), "root"))
+		_, err = tree.AsOf(time.Now())
+		assert.ErrorIs(t, err, ErrSequenceExpired)
+	})
+}
@@ -0,0 +1,151 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// CheckpointPolicy defines when StartCheckpointer saves a snapshot of the Tree.
+// A snapshot is saved as soon as one of the thresholds is reached. A zero threshold is disabled.
+type CheckpointPolicy struct {
+	// Interval is the maximum time between two snapshots, provided that the Tree changed
+	Interval time.Duration
+	// MaxChanges is the maximum number of changes appended to the Storage since the last snapshot
+	MaxChanges int
+	// MaxLogSize is the maximum size in bytes of the log of the Storage. It applies to the
+	// Storage reporting the size of their log with a LogSize() int64 method, such as WAL.
+	MaxLogSize int64
+}
+
+// StartCheckpointer starts a background goroutine saving snapshots of the Tree to the Storage
+// set with WithStorage or OpenTree according to the given policy.
+//
+// Every snapshot is saved as done by Persist, which compacts the changes it captures (e.g. WAL
+// truncates its log), hence the time needed by Recover or OpenTree stays bounded for long-running
+// services whatever the number of changes applied.
+//
+// Parameters:
+//   - ctx: The context of the checkpointer. The goroutine stops once it is done.
+//   - policy: The thresholds triggering a snapshot. See CheckpointPolicy.
+//
+// Returns:
+//   - done: A channel closed once the goroutine has stopped, allowing a clean shutdown.
+//
+// Notes:
+//   - The goroutine stops right away when no Storage is set.
+//   - A snapshot that cannot be saved is logged at the error level and attempted again
+//     once a threshold is reached.
+//   - No snapshot is saved when the checkpointer stops. Call Persist on shutdown if needed.
+//
+// Example usage:
+//
+//	tree, err := OpenTree[string]("/var/lib/app/tree")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	done := tree.StartCheckpointer(ctx, CheckpointPolicy{
+//	    Interval:   time.Minute,
+//	    MaxLogSize: 64 << 20,
+//	})
+//
+//	// on shutdown
+//	cancel()
+//	<-done
+//	_ = tree.Close()
+func (x *Tree[T]) StartCheckpointer(ctx context.Context, policy CheckpointPolicy) (done <-chan struct{}) {
+	stopped := make(chan struct{})
+	if x.feed.storage == nil {
+		close(stopped)
+		return stopped
+	}
+
+	var tick <-chan time.Time
+	var ticker *time.Ticker
+	if policy.Interval > 0 {
+		ticker = time.NewTicker(policy.Interval)
+		tick = ticker.C
+	}
+
+	go func() {
+		defer close(stopped)
+		if ticker != nil {
+			defer ticker.Stop()
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick:
+				if x.pendingChanges() > 0 {
+					x.checkpoint()
+				}
+			case <-x.feed.appended:
+				if x.checkpointDue(policy) {
+					x.checkpoint()
+					if ticker != nil {
+						ticker.Reset(policy.Interval)
+					}
+				}
+			}
+		}
+	}()
+	return stopped
+}
+
+// checkpoint saves a snapshot of the Tree and logs the failure
+func (x *Tree[T]) checkpoint() {
+	if err := x.Persist(); err != nil {
+		x.log(slog.LevelError, "tree checkpoint failed", slog.String("error", err.Error()))
+	}
+}
+
+// checkpointDue states whether the size thresholds of the given policy are reached
+func (x *Tree[T]) checkpointDue(policy CheckpointPolicy) bool {
+	if policy.MaxChanges > 0 && x.pendingChanges() >= uint64(policy.MaxChanges) {
+		return true
+	}
+
+	if policy.MaxLogSize > 0 {
+		if sizer, ok := x.feed.storage.(interface{ LogSize() int64 }); ok {
+			return sizer.LogSize() >= policy.MaxLogSize
+		}
+	}
+	return false
+}
+
+// pendingChanges returns the number of changes applied since the last snapshot
+func (x *Tree[T]) pendingChanges() uint64 {
+	last, persisted := x.LastSeq(), x.feed.persisted.Load()
+	if last < persisted {
+		return 0
+	}
+	return last - persisted
+}
@@ -0,0 +1,131 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartCheckpointer(t *testing.T) {
+	snapshotSeq := func(storage Storage[string]) func() uint64 {
+		return func() uint64 {
+			snapshot, err := storage.LoadSnapshot()
+			if err != nil {
+				return 0
+			}
+			return snapshot.Seq
+		}
+	}
+
+	t.Run("saves a snapshot once enough changes are appended", func(t *testing.T) {
+		storage := NewMemoryStorage[string]()
+		tree := NewTree[string](WithStorage[string](storage))
+		ctx, cancel := context.WithCancel(context.Background())
+		done := tree.StartCheckpointer(ctx, CheckpointPolicy{MaxChanges: 3})
+		defer func() {
+			cancel()
+			<-done
+		}()
+
+		require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "A"), "root"))
+		require.Never(t, func() bool { return snapshotSeq(storage)() > 0 }, 50*time.Millisecond, 5*time.Millisecond)
+
+		require.NoError(t, tree.AddByID(NewNode("b", "B"), "root"))
+		require.Eventually(t, func() bool { return snapshotSeq(storage)() == 3 }, time.Second, 5*time.Millisecond)
+
+		recovered := NewTree[string](WithStorage[string](storage))
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, tree.Dump(), recovered.Dump())
+	})
+
+	t.Run("saves a snapshot every interval when the tree changed", func(t *testing.T) {
+		storage := NewMemoryStorage[string]()
+		tree := NewTree[string](WithStorage[string](storage))
+		ctx, cancel := context.WithCancel(context.Background())
+		done := tree.StartCheckpointer(ctx, CheckpointPolicy{Interval: 10 * time.Millisecond})
+		defer func() {
+			cancel()
+			<-done
+		}()
+
+		require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+		require.Eventually(t, func() bool { return snapshotSeq(storage)() == 1 }, time.Second, 5*time.Millisecond)
+
+		require.NoError(t, tree.AddByID(NewNode("a", "A"), "root"))
+		require.Eventually(t, func() bool { return snapshotSeq(storage)() == 2 }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("truncates the log once it exceeds the size threshold", func(t *testing.T) {
+		dir := t.TempDir()
+		tree, err := OpenTree[string](dir)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = tree.Close()
+		})
+		wal := tree.feed.storage.(*WAL[string])
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := tree.StartCheckpointer(ctx, CheckpointPolicy{MaxLogSize: 512})
+		defer func() {
+			cancel()
+			<-done
+		}()
+
+		require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+		for i := range 50 {
+			require.NoError(t, tree.AddByID(NewNode(strconv.Itoa(i), "Value"), "root"))
+		}
+		require.Eventually(t, func() bool { return snapshotSeq(wal)() > 0 }, time.Second, 5*time.Millisecond)
+		assert.Less(t, wal.LogSize(), int64(1024))
+
+		dump := tree.Dump()
+		cancel()
+		<-done
+		require.NoError(t, tree.Close())
+
+		recovered, err := OpenTree[string](dir)
+		require.NoError(t, err)
+		defer recovered.Close()
+		assert.Equal(t, dump, recovered.Dump())
+		assert.EqualValues(t, 51, recovered.LastSeq())
+	})
+
+	t.Run("stops right away without storage", func(t *testing.T) {
+		tree := NewTree[string]()
+		done := tree.StartCheckpointer(context.Background(), CheckpointPolicy{Interval: time.Millisecond})
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("checkpointer not stopped")
+		}
+	})
+}
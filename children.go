@@ -0,0 +1,301 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import "sync"
+
+// children is a thread-safe ordered set of child treeNodes.
+//
+// It keeps the insertion order of the children in a doubly-linked list
+// and indexes every link by the child ID so that looking up or removing
+// a child among a large number of siblings is done in constant time.
+type children[T any] struct {
+	mu    sync.RWMutex
+	index map[string]*childLink[T]
+	head  *childLink[T]
+	tail  *childLink[T]
+}
+
+// childLink defines a link in the children list
+type childLink[T any] struct {
+	node *treeNode[T]
+	prev *childLink[T]
+	next *childLink[T]
+}
+
+// newChildren creates an empty children set
+func newChildren[T any]() *children[T] {
+	return &children[T]{}
+}
+
+// Len returns the number of children
+func (c *children[T]) Len() int {
+	c.mu.RLock()
+	l := len(c.index)
+	c.mu.RUnlock()
+	return l
+}
+
+// Append adds a child at the end of the set.
+// When the child already exists it is moved to the end of the set.
+func (c *children[T]) Append(node *treeNode[T]) {
+	c.mu.Lock()
+	c.append(node)
+	c.mu.Unlock()
+}
+
+// InsertIfLess inserts a child at the given index unless the set already holds limit children.
+// A negative index, or an index past the last child, appends the child. A limit of zero means no limit.
+// It reports whether the child has been inserted along with the ID of the child following it,
+// empty when it is the last one.
+func (c *children[T]) InsertIfLess(node *treeNode[T], index, limit int) (next string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.index[node.ID]; !exists && limit > 0 && len(c.index) >= limit {
+		return "", false
+	}
+
+	var at *childLink[T]
+	if index >= 0 {
+		at = c.head
+		for i := 0; i < index && at != nil; i++ {
+			at = at.next
+		}
+	}
+	return c.insertBefore(node, at), true
+}
+
+// Place inserts a child right before the sibling with the given id, or right after it when after is set.
+// When the child already exists it is moved. It reports whether the sibling has been found along with
+// the ID of the child following the placed one, empty when it is the last one.
+func (c *children[T]) Place(node *treeNode[T], sibling string, after bool) (next string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	link, ok := c.placement(node.ID, sibling, after)
+	if !ok {
+		return "", false
+	}
+	return c.insertBefore(node, link), true
+}
+
+// Placement returns the ID of the child that would follow the child with the given id once placed with Place,
+// empty when it would be the last one, without placing it. It reports whether the sibling has been found.
+func (c *children[T]) Placement(id, sibling string, after bool) (next string, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	link, ok := c.placement(id, sibling, after)
+	if !ok || link == nil {
+		return "", ok
+	}
+	return link.node.ID, true
+}
+
+// At returns the ID of the child at the given index, which a child inserted at that index is placed before.
+// It is empty when the index is negative or past the last child.
+func (c *children[T]) At(index int) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if index < 0 {
+		return ""
+	}
+
+	link := c.head
+	for i := 0; i < index && link != nil; i++ {
+		link = link.next
+	}
+	if link == nil {
+		return ""
+	}
+	return link.node.ID
+}
+
+// IndexOf returns the position of the child with the given id, -1 when it is not a child
+func (c *children[T]) IndexOf(id string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	index := 0
+	for link := c.head; link != nil; link = link.next {
+		if link.node.ID == id {
+			return index
+		}
+		index++
+	}
+	return -1
+}
+
+// placement returns the link the child with the given id is placed before when placed right before the sibling
+// with the given id, or right after it when after is set, nil for the end of the set. It reports whether the
+// sibling has been found. It must be called with the lock held
+func (c *children[T]) placement(id, sibling string, after bool) (*childLink[T], bool) {
+	link, ok := c.index[sibling]
+	if !ok || sibling == id {
+		return nil, false
+	}
+
+	if after {
+		link = link.next
+		if link != nil && link.node.ID == id {
+			// the child already follows the sibling
+			link = link.next
+		}
+	}
+	return link, true
+}
+
+// append adds a child at the end of the set.
+// It must be called with the lock held
+func (c *children[T]) append(node *treeNode[T]) {
+	c.insertBefore(node, nil)
+}
+
+// insertBefore inserts a child right before the given link, at the end of the set when nil.
+// When the child already exists it is moved. It returns the ID of the child following the
+// inserted one, empty when it is the last one. It must be called with the lock held
+func (c *children[T]) insertBefore(node *treeNode[T], next *childLink[T]) string {
+	if c.index == nil {
+		c.index = make(map[string]*childLink[T])
+	}
+
+	if link, ok := c.index[node.ID]; ok {
+		if link == next {
+			next = link.next
+		}
+		c.unlink(link)
+	}
+
+	link := &childLink[T]{node: node, next: next}
+	if next == nil {
+		link.prev = c.tail
+		c.tail = link
+	} else {
+		link.prev = next.prev
+		next.prev = link
+	}
+
+	if link.prev != nil {
+		link.prev.next = link
+	} else {
+		c.head = link
+	}
+	c.index[node.ID] = link
+
+	if next == nil {
+		return ""
+	}
+	return next.node.ID
+}
+
+// Get returns the child with the given id
+func (c *children[T]) Get(id string) (*treeNode[T], bool) {
+	c.mu.RLock()
+	link, ok := c.index[id]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return link.node, true
+}
+
+// First returns the first child
+func (c *children[T]) First() (*treeNode[T], bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.head == nil {
+		return nil, false
+	}
+	return c.head.node, true
+}
+
+// Last returns the last child
+func (c *children[T]) Last() (*treeNode[T], bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.tail == nil {
+		return nil, false
+	}
+	return c.tail.node, true
+}
+
+// Next returns the child following the one with the given id
+func (c *children[T]) Next(id string) (*treeNode[T], bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	link, ok := c.index[id]
+	if !ok || link.next == nil {
+		return nil, false
+	}
+	return link.next.node, true
+}
+
+// Remove removes the child with the given id and reports whether it was found
+func (c *children[T]) Remove(id string) bool {
+	c.mu.Lock()
+	link, ok := c.index[id]
+	if ok {
+		c.unlink(link)
+	}
+	c.mu.Unlock()
+	return ok
+}
+
+// Items returns a copy of the children in order
+func (c *children[T]) Items() []*treeNode[T] {
+	c.mu.RLock()
+	items := make([]*treeNode[T], 0, len(c.index))
+	for link := c.head; link != nil; link = link.next {
+		items = append(items, link.node)
+	}
+	c.mu.RUnlock()
+	return items
+}
+
+// Reset removes all the children
+func (c *children[T]) Reset() {
+	c.mu.Lock()
+	c.index = nil
+	c.head = nil
+	c.tail = nil
+	c.mu.Unlock()
+}
+
+// unlink removes the given link from the list and the index.
+// It must be called with the lock held
+func (c *children[T]) unlink(link *childLink[T]) {
+	if link.prev != nil {
+		link.prev.next = link.next
+	} else {
+		c.head = link.next
+	}
+
+	if link.next != nil {
+		link.next.prev = link.prev
+	} else {
+		c.tail = link.prev
+	}
+
+	link.prev = nil
+	link.next = nil
+	delete(c.index, link.node.ID)
+}
@@ -0,0 +1,132 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildren(t *testing.T) {
+	set := newChildren[string]()
+	assert.Zero(t, set.Len())
+	assert.Empty(t, set.Items())
+
+	for i := 0; i < 5; i++ {
+		set.Append(&treeNode[string]{ID: fmt.Sprintf("child%d", i)})
+	}
+	assert.EqualValues(t, 5, set.Len())
+
+	child, ok := set.Get("child2")
+	require.True(t, ok)
+	assert.Equal(t, "child2", child.ID)
+
+	// remove the head, a middle and the tail
+	assert.True(t, set.Remove("child0"))
+	assert.True(t, set.Remove("child2"))
+	assert.True(t, set.Remove("child4"))
+	assert.False(t, set.Remove("child4"))
+
+	_, ok = set.Get("child2")
+	assert.False(t, ok)
+
+	items := set.Items()
+	require.Len(t, items, 2)
+	assert.Equal(t, "child1", items[0].ID)
+	assert.Equal(t, "child3", items[1].ID)
+
+	// appending an existing child moves it to the end
+	set.Append(&treeNode[string]{ID: "child1"})
+	items = set.Items()
+	require.Len(t, items, 2)
+	assert.Equal(t, "child3", items[0].ID)
+	assert.Equal(t, "child1", items[1].ID)
+
+	set.Reset()
+	assert.Zero(t, set.Len())
+	assert.Empty(t, set.Items())
+}
+
+func TestChildrenPositions(t *testing.T) {
+	ids := func(set *children[string]) []string {
+		var ids []string
+		for _, item := range set.Items() {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+
+	set := newChildren[string]()
+	next, ok := set.InsertIfLess(&treeNode[string]{ID: "b"}, 0, 0)
+	require.True(t, ok)
+	assert.Empty(t, next)
+	next, ok = set.InsertIfLess(&treeNode[string]{ID: "a"}, 0, 0)
+	require.True(t, ok)
+	assert.Equal(t, "b", next)
+	_, ok = set.InsertIfLess(&treeNode[string]{ID: "d"}, 10, 0)
+	require.True(t, ok)
+	next, ok = set.InsertIfLess(&treeNode[string]{ID: "c"}, 2, 0)
+	require.True(t, ok)
+	assert.Equal(t, "d", next)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ids(set))
+
+	_, ok = set.InsertIfLess(&treeNode[string]{ID: "e"}, 0, 4)
+	assert.False(t, ok)
+	assert.Equal(t, 2, set.IndexOf("c"))
+	assert.Equal(t, -1, set.IndexOf("e"))
+
+	// placing an existing child moves it
+	next, ok = set.Place(&treeNode[string]{ID: "d"}, "a", false)
+	require.True(t, ok)
+	assert.Equal(t, "a", next)
+	assert.Equal(t, []string{"d", "a", "b", "c"}, ids(set))
+
+	next, ok = set.Place(&treeNode[string]{ID: "d"}, "c", true)
+	require.True(t, ok)
+	assert.Empty(t, next)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ids(set))
+
+	next, ok = set.Place(&treeNode[string]{ID: "b"}, "a", true)
+	require.True(t, ok)
+	assert.Equal(t, "c", next)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ids(set))
+
+	next, ok = set.Place(&treeNode[string]{ID: "a"}, "b", true)
+	require.True(t, ok)
+	assert.Equal(t, "c", next)
+	assert.Equal(t, []string{"b", "a", "c", "d"}, ids(set))
+
+	last, ok := set.Last()
+	require.True(t, ok)
+	assert.Equal(t, "d", last.ID)
+
+	_, ok = set.Place(&treeNode[string]{ID: "a"}, "missing", false)
+	assert.False(t, ok)
+	_, ok = set.Place(&treeNode[string]{ID: "a"}, "a", false)
+	assert.False(t, ok)
+}
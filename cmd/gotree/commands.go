@@ -0,0 +1,288 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/tochemey/gotree"
+	"github.com/tochemey/gotree/gotreetui"
+)
+
+// render prints a tree as ASCII art, a Graphviz DOT graph or a Mermaid flowchart
+func render(args []string, loader *loader, stdout io.Writer) error {
+	flags := newFlagSet("render", loader)
+	format := flags.String("format", "ascii", "output format: ascii, dot or mermaid")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	renderers := map[string]func(tree *gotree.Tree[string]) string{
+		"ascii":   renderASCII,
+		"dot":     renderDOT,
+		"mermaid": renderMermaid,
+	}
+
+	renderer, ok := renderers[*format]
+	if !ok {
+		return fmt.Errorf("unknown output format %q", *format)
+	}
+
+	tree, err := loader.load(args[0])
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(stdout, renderer(tree))
+	return err
+}
+
+// query prints the path of the Nodes matching a glob pattern
+func query(args []string, loader *loader, stdout io.Writer) error {
+	args, err := parseArgs(newFlagSet("query", loader), args, 2)
+	if err != nil {
+		return err
+	}
+
+	tree, err := loader.load(args[0])
+	if err != nil {
+		return err
+	}
+
+	nodes := tree.Glob(args[1])
+	if len(nodes) == 0 {
+		return errNoMatch
+	}
+
+	for _, node := range nodes {
+		path, _ := tree.PathOf(node)
+		if _, err := fmt.Fprintln(stdout, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diff prints the differences between two trees, one Node per line ordered by ID:
+//   - "+ id (parent)" for an added Node.
+//   - "- id" for a deleted Node.
+//   - "> id: old -> new" for a Node moved under another parent.
+//   - "~ id: old -> new" for a Node whose value changed.
+func diff(args []string, loader *loader, stdout io.Writer) error {
+	args, err := parseArgs(newFlagSet("diff", loader), args, 2)
+	if err != nil {
+		return err
+	}
+
+	before, err := loader.load(args[0])
+	if err != nil {
+		return err
+	}
+
+	after, err := loader.load(args[1])
+	if err != nil {
+		return err
+	}
+
+	ids := append(before.IDs(), after.IDs()...)
+	sort.Strings(ids)
+
+	var changes []string
+	for i, id := range ids {
+		if i > 0 && ids[i-1] == id {
+			continue
+		}
+
+		old, inBefore := before.Find(id)
+		current, inAfter := after.Find(id)
+		switch {
+		case !inAfter:
+			changes = append(changes, fmt.Sprintf("- %s", id))
+		case !inBefore:
+			changes = append(changes, fmt.Sprintf("+ %s (%s)", id, parentOf(after, current)))
+		default:
+			if from, to := parentOf(before, old), parentOf(after, current); from != to {
+				changes = append(changes, fmt.Sprintf("> %s: %s -> %s", id, from, to))
+			}
+			if old.Value() != current.Value() {
+				changes = append(changes, fmt.Sprintf("~ %s: %q -> %q", id, old.Value(), current.Value()))
+			}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if _, err := io.WriteString(stdout, strings.Join(changes, "\n")+"\n"); err != nil {
+		return err
+	}
+	return errNoMatch
+}
+
+// stats prints the statistics of a tree
+func stats(args []string, loader *loader, stdout io.Writer) error {
+	args, err := parseArgs(newFlagSet("stats", loader), args, 1)
+	if err != nil {
+		return err
+	}
+
+	tree, err := loader.load(args[0])
+	if err != nil {
+		return err
+	}
+
+	stats := tree.Stats()
+	_, err = fmt.Fprintf(stdout, "nodes: %d\nleaves: %d\nmax depth: %d\naverage branching: %.2f\nwidest level: %d (%d nodes)\n",
+		stats.Nodes, stats.Leaves, stats.MaxDepth, stats.AverageBranching, stats.WidestLevel, stats.WidestLevelSize)
+	return err
+}
+
+// browse explores a tree interactively in the terminal
+func browse(args []string, loader *loader, _ io.Writer) error {
+	args, err := parseArgs(newFlagSet("browse", loader), args, 1)
+	if err != nil {
+		return err
+	}
+
+	if args[0] == "-" {
+		return errors.New("browse reads the keyboard from the standard input and cannot read the tree from it")
+	}
+
+	tree, err := loader.load(args[0])
+	if err != nil {
+		return err
+	}
+	return gotreetui.Run(context.Background(), tree)
+}
+
+// renderASCII renders the tree as indented lines, omitting the empty values
+func renderASCII(tree *gotree.Tree[string]) string {
+	var builder strings.Builder
+	walk(tree, func(node gotree.Node[string], _ int, prefix string) {
+		builder.WriteString(prefix)
+		builder.WriteString(label(node, ": "))
+		builder.WriteString("\n")
+	})
+	return builder.String()
+}
+
+// renderDOT renders the tree as a Graphviz DOT graph
+func renderDOT(tree *gotree.Tree[string]) string {
+	var builder strings.Builder
+	builder.WriteString("digraph tree {\n")
+	walk(tree, func(node gotree.Node[string], _ int, _ string) {
+		fmt.Fprintf(&builder, "  %s [label=%s];\n", dotQuote(node.ID()), dotQuote(label(node, "\n")))
+		if parent, ok := tree.ParentAt(node, 0); ok {
+			fmt.Fprintf(&builder, "  %s -> %s;\n", dotQuote(parent.ID()), dotQuote(node.ID()))
+		}
+	})
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// renderMermaid renders the tree as a Mermaid flowchart.
+// The Nodes are identified by their position since Mermaid restricts the identifiers
+func renderMermaid(tree *gotree.Tree[string]) string {
+	var builder strings.Builder
+	builder.WriteString("graph TD\n")
+	names := make(map[string]string)
+	walk(tree, func(node gotree.Node[string], index int, _ string) {
+		name := fmt.Sprintf("n%d", index)
+		names[node.ID()] = name
+		fmt.Fprintf(&builder, "  %s[\"%s\"]\n", name, strings.ReplaceAll(label(node, ": "), `"`, "#quot;"))
+		if parent, ok := tree.ParentAt(node, 0); ok {
+			fmt.Fprintf(&builder, "  %s --> %s\n", names[parent.ID()], name)
+		}
+	})
+	return builder.String()
+}
+
+// walk visits the Nodes of the tree in pre-order with their index and their ASCII art prefix
+func walk(tree *gotree.Tree[string], visit func(node gotree.Node[string], index int, prefix string)) {
+	root, ok := tree.RootOK()
+	if !ok {
+		return
+	}
+
+	index := 0
+	var visitNode func(node gotree.Node[string], prefix, indent string)
+	visitNode = func(node gotree.Node[string], prefix, indent string) {
+		visit(node, index, prefix)
+		index++
+
+		children := childrenOf(tree, node)
+		for i, child := range children {
+			if i == len(children)-1 {
+				visitNode(child, indent+"└── ", indent+"    ")
+				continue
+			}
+			visitNode(child, indent+"├── ", indent+"│   ")
+		}
+	}
+	visitNode(root, "", "")
+}
+
+// childrenOf returns the children of the given Node in insertion order
+func childrenOf(tree *gotree.Tree[string], node gotree.Node[string]) []gotree.Node[string] {
+	cursor, ok := tree.Cursor(node)
+	if !ok {
+		return nil
+	}
+
+	var children []gotree.Node[string]
+	for child, ok := cursor.FirstChild(); ok; child, ok = cursor.NextSibling() {
+		children = append(children, child)
+	}
+	return children
+}
+
+// parentOf returns the ID of the parent of the given Node, "<root>" for the root
+func parentOf(tree *gotree.Tree[string], node gotree.Node[string]) string {
+	if parent, ok := tree.ParentAt(node, 0); ok {
+		return parent.ID()
+	}
+	return "<root>"
+}
+
+// label returns the ID of the given Node followed by its value when not empty
+func label(node gotree.Node[string], separator string) string {
+	if node.Value() == "" {
+		return node.ID()
+	}
+	return node.ID() + separator + node.Value()
+}
+
+// dotQuote quotes the given string as a DOT identifier
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
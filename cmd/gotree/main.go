@@ -0,0 +1,124 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Command gotree inspects serialized tree snapshots.
+//
+// Usage:
+//
+//	gotree render [-input format] [-format ascii|dot|mermaid] file
+//	gotree query [-input format] file pattern
+//	gotree diff [-input format] old new
+//	gotree stats [-input format] file
+//	gotree browse [-input format] file
+//
+// The trees are read from JSON, CSV or edge list files, the format being derived from the
+// file extension (.json, .csv, anything else being an edge list) unless set with -input.
+// The file "-" reads the standard input, as JSON by default:
+//   - json: a nested object {"id": "root", "value": "...", "children": [...]}.
+//   - csv: records "id,parent[,value]", the root having an empty parent. A header starting
+//     with "id" is skipped.
+//   - edges: lines "parent child" or "parent -> child"; blank lines and lines starting
+//     with "#" are ignored.
+//
+// query prints the path of the Nodes matching a glob pattern such as "/root/*/config/**" and
+// exits with status 1 when none matches. diff exits with status 1 when the trees differ.
+// browse explores the tree interactively in the terminal.
+// Errors exit with status 2.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// usage describes the commands
+const usage = `usage:
+  gotree render [-input format] [-format ascii|dot|mermaid] file
+  gotree query [-input format] file pattern
+  gotree diff [-input format] old new
+  gotree stats [-input format] file
+  gotree browse [-input format] file
+`
+
+// errNoMatch reports a successful command whose outcome is negative (no match, differences)
+var errNoMatch = errors.New("no match")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command described by the given arguments and returns the exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	commands := map[string]func(args []string, loader *loader, stdout io.Writer) error{
+		"render": render,
+		"query":  query,
+		"diff":   diff,
+		"stats":  stats,
+		"browse": browse,
+	}
+
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n%s", args[0], usage)
+		return 2
+	}
+
+	if err := command(args[1:], &loader{stdin: stdin, stderr: stderr}, stdout); err != nil {
+		if errors.Is(err, errNoMatch) {
+			return 1
+		}
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(stderr, "gotree:", err)
+		}
+		return 2
+	}
+	return 0
+}
+
+// newFlagSet creates the flag set of the given command along with its -input flag
+func newFlagSet(name string, loader *loader) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(loader.stderr)
+	flags.StringVar(&loader.format, "input", "", "input format: json, csv or edges (default: derived from the file extension)")
+	return flags
+}
+
+// parseArgs parses the flags of a command and checks its number of positional arguments
+func parseArgs(flags *flag.FlagSet, args []string, count int) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() != count {
+		return nil, fmt.Errorf("%s expects %d argument(s), got %d\n%s", flags.Name(), count, flags.NArg(), usage)
+	}
+	return flags.Args(), nil
+}
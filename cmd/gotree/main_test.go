@@ -0,0 +1,194 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	jsonTree = `{"id": "root", "value": "Root", "children": [
+		{"id": "a", "value": {"port": 80}, "children": [{"id": "a1"}]},
+		{"id": "b", "value": "say \"hi\""}
+	]}`
+	csvTree = "id,parent,value\na1,a\nroot,,Root\na,root,\"{\"\"port\"\":80}\"\nb,root,\"say \"\"hi\"\"\"\n"
+	edges   = "# services\nroot -> a\nroot b\n\na a1\n"
+)
+
+// write writes the given content to a temporary file with the given name
+func write(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// execute runs the command and returns its exit status, standard output and standard error
+func execute(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRender(t *testing.T) {
+	ascii := "root: Root\n├── a: {\"port\":80}\n│   └── a1\n└── b: say \"hi\"\n"
+
+	t.Run("json", func(t *testing.T) {
+		code, stdout, _ := execute("", "render", write(t, "tree.json", jsonTree))
+		require.Zero(t, code)
+		assert.Equal(t, ascii, stdout)
+	})
+
+	t.Run("csv in any order", func(t *testing.T) {
+		code, stdout, _ := execute("", "render", write(t, "tree.csv", csvTree))
+		require.Zero(t, code)
+		assert.Equal(t, ascii, stdout)
+	})
+
+	t.Run("edges", func(t *testing.T) {
+		code, stdout, _ := execute("", "render", write(t, "tree.txt", edges))
+		require.Zero(t, code)
+		assert.Equal(t, "root\n├── a\n│   └── a1\n└── b\n", stdout)
+	})
+
+	t.Run("standard input", func(t *testing.T) {
+		code, stdout, _ := execute(jsonTree, "render", "-")
+		require.Zero(t, code)
+		assert.Equal(t, ascii, stdout)
+
+		code, stdout, _ = execute(edges, "render", "-input", "edges", "-")
+		require.Zero(t, code)
+		assert.Equal(t, "root\n├── a\n│   └── a1\n└── b\n", stdout)
+	})
+
+	t.Run("dot", func(t *testing.T) {
+		code, stdout, _ := execute(jsonTree, "render", "-format", "dot", "-")
+		require.Zero(t, code)
+		assert.Equal(t, `digraph tree {
+  "root" [label="root\nRoot"];
+  "a" [label="a\n{\"port\":80}"];
+  "root" -> "a";
+  "a1" [label="a1"];
+  "a" -> "a1";
+  "b" [label="b\nsay \"hi\""];
+  "root" -> "b";
+}
+`, stdout)
+	})
+
+	t.Run("mermaid", func(t *testing.T) {
+		code, stdout, _ := execute(jsonTree, "render", "-format", "mermaid", "-")
+		require.Zero(t, code)
+		assert.Equal(t, `graph TD
+  n0["root: Root"]
+  n1["a: {#quot;port#quot;:80}"]
+  n0 --> n1
+  n2["a1"]
+  n1 --> n2
+  n3["b: say #quot;hi#quot;"]
+  n0 --> n3
+`, stdout)
+	})
+
+	t.Run("empty tree", func(t *testing.T) {
+		code, stdout, _ := execute("null", "render", "-")
+		require.Zero(t, code)
+		assert.Empty(t, stdout)
+	})
+}
+
+func TestQuery(t *testing.T) {
+	path := write(t, "tree.json", jsonTree)
+
+	code, stdout, _ := execute("", "query", path, "/root/a/**")
+	require.Zero(t, code)
+	assert.Equal(t, "/root/a\n/root/a/a1\n", stdout)
+
+	code, stdout, _ = execute("", "query", path, "/root/missing")
+	assert.Equal(t, 1, code)
+	assert.Empty(t, stdout)
+}
+
+func TestDiff(t *testing.T) {
+	before := write(t, "before.json", jsonTree)
+	after := write(t, "after.txt", "root a\nroot c\nc a1\n")
+
+	code, stdout, _ := execute("", "diff", before, after)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, `~ a: "{\"port\":80}" -> ""
+> a1: a -> c
+- b
++ c (root)
+~ root: "Root" -> ""
+`, stdout)
+
+	code, stdout, _ = execute("", "diff", before, write(t, "same.csv", csvTree))
+	assert.Zero(t, code)
+	assert.Empty(t, stdout)
+}
+
+func TestStats(t *testing.T) {
+	code, stdout, _ := execute(jsonTree, "stats", "-")
+	require.Zero(t, code)
+	assert.Equal(t, "nodes: 4\nleaves: 2\nmax depth: 2\naverage branching: 1.50\nwidest level: 1 (2 nodes)\n", stdout)
+}
+
+func TestErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		stdin  string
+		args   []string
+		stderr string
+	}{
+		{name: "no command", stderr: "usage:"},
+		{name: "unknown command", args: []string{"bogus"}, stderr: `unknown command "bogus"`},
+		{name: "missing argument", args: []string{"render"}, stderr: "render expects 1 argument(s), got 0"},
+		{name: "unknown output format", args: []string{"render", "-format", "svg", "-"}, stderr: `unknown output format "svg"`},
+		{name: "unknown input format", args: []string{"stats", "-input", "yaml", "-"}, stderr: `unknown input format "yaml"`},
+		{name: "missing file", args: []string{"stats", "missing.json"}, stderr: "missing.json"},
+		{name: "invalid json", stdin: "{", args: []string{"stats", "-"}, stderr: "unexpected EOF"},
+		{name: "invalid csv record", stdin: "a\n", args: []string{"stats", "-input", "csv", "-"}, stderr: "line 1: expected id,parent[,value], got 1 fields"},
+		{name: "invalid edge", stdin: "a b c\n", args: []string{"stats", "-input", "edges", "-"}, stderr: `line 1: expected "parent child", got "a b c"`},
+		{name: "duplicate node", stdin: "a,\nb,a\nb,a\n", args: []string{"stats", "-input", "csv", "-"}, stderr: `duplicate node "b"`},
+		{name: "several roots", stdin: "a,\nb,\n", args: []string{"stats", "-input", "csv", "-"}, stderr: "expected a single root, got 2: a, b"},
+		{name: "unreachable nodes", stdin: "r,\na,b\nb,a\n", args: []string{"stats", "-input", "csv", "-"}, stderr: "nodes not reachable from the root: a, b"},
+		{name: "browse standard input", args: []string{"browse", "-"}, stderr: "cannot read the tree from it"},
+		{name: "cycle of edges", stdin: "a b\nb a\n", args: []string{"stats", "-input", "edges", "-"}, stderr: "expected a single root, got 0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, stdout, stderr := execute(tc.stdin, tc.args...)
+			assert.Equal(t, 2, code)
+			assert.Empty(t, stdout)
+			assert.Contains(t, stderr, tc.stderr)
+		})
+	}
+}
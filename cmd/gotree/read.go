@@ -0,0 +1,299 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tochemey/gotree"
+)
+
+// the supported input formats
+const (
+	formatJSON  = "json"
+	formatCSV   = "csv"
+	formatEdges = "edges"
+)
+
+// record describes a Node read from a file
+type record struct {
+	id     string
+	parent string
+	value  string
+}
+
+// jsonNode is the nested JSON representation of a Node
+type jsonNode struct {
+	ID       string      `json:"id"`
+	Value    any         `json:"value"`
+	Children []*jsonNode `json:"children"`
+}
+
+// loader reads trees from files
+type loader struct {
+	stdin  io.Reader
+	stderr io.Writer
+	format string
+}
+
+// load reads the tree held by the given file, "-" being the standard input
+func (l *loader) load(path string) (*gotree.Tree[string], error) {
+	format := l.format
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			format = formatJSON
+		case ".csv":
+			format = formatCSV
+		default:
+			format = formatEdges
+		}
+		if path == "-" {
+			format = formatJSON
+		}
+	}
+
+	reader := l.stdin
+	if path != "-" {
+		file, err := os.Open(path) // #nosec G304 -- the path is supplied by the operator
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	var (
+		records []record
+		err     error
+	)
+	switch format {
+	case formatJSON:
+		records, err = readJSON(reader)
+	case formatCSV:
+		records, err = readCSV(reader)
+	case formatEdges:
+		records, err = readEdges(reader)
+	default:
+		return nil, fmt.Errorf("unknown input format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	tree, err := build(records)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return tree, nil
+}
+
+// readJSON reads the records of a nested JSON tree
+func readJSON(reader io.Reader) ([]record, error) {
+	var root *jsonNode
+	if err := json.NewDecoder(reader).Decode(&root); err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, nil
+	}
+
+	var (
+		records []record
+		walk    func(node *jsonNode, parent string) error
+	)
+	walk = func(node *jsonNode, parent string) error {
+		value, err := stringify(node.Value)
+		if err != nil {
+			return err
+		}
+		records = append(records, record{id: node.ID, parent: parent, value: value})
+		for _, child := range node.Children {
+			if child == nil {
+				return fmt.Errorf("node %q has a null child", node.ID)
+			}
+			if err := walk(child, node.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return records, walk(root, "")
+}
+
+// stringify renders the given JSON value as a string, strings being rendered as is
+func stringify(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		bytes, err := json.Marshal(v)
+		return string(bytes), err
+	}
+}
+
+// readCSV reads the "id,parent[,value]" records of a CSV file
+func readCSV(reader io.Reader) ([]record, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	var records []record
+	for line := 1; ; line++ {
+		fields, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if line == 1 && strings.EqualFold(fields[0], "id") {
+			continue
+		}
+
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("line %d: expected id,parent[,value], got %d fields", line, len(fields))
+		}
+
+		rec := record{id: fields[0], parent: fields[1]}
+		if len(fields) == 3 {
+			rec.value = fields[2]
+		}
+		records = append(records, rec)
+	}
+}
+
+// readEdges reads the "parent child" lines of an edge list.
+// The root is the only parent that is never a child
+func readEdges(reader io.Reader) ([]record, error) {
+	var (
+		records  []record
+		parents  []string
+		children = make(map[string]struct{})
+		scanner  = bufio.NewScanner(reader)
+	)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(strings.Replace(text, "->", " ", 1))
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected \"parent child\", got %q", line, text)
+		}
+
+		records = append(records, record{id: fields[1], parent: fields[0]})
+		parents = append(parents, fields[0])
+		children[fields[1]] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// the roots are the parents that are never children
+	seen := make(map[string]struct{})
+	var roots []record
+	for _, parent := range parents {
+		if _, ok := children[parent]; ok {
+			continue
+		}
+		if _, ok := seen[parent]; !ok {
+			seen[parent] = struct{}{}
+			roots = append(roots, record{id: parent})
+		}
+	}
+	return append(roots, records...), nil
+}
+
+// build creates a Tree from the given records, whatever their order
+func build(records []record) (*gotree.Tree[string], error) {
+	tree := gotree.NewTree[string](gotree.WithExpectedSize(uint64(len(records))))
+
+	children := make(map[string][]record)
+	ids := make(map[string]struct{}, len(records))
+	var roots []string
+	for _, rec := range records {
+		if rec.id == "" {
+			return nil, errors.New("node with an empty id")
+		}
+		if _, ok := ids[rec.id]; ok {
+			return nil, fmt.Errorf("duplicate node %q", rec.id)
+		}
+		ids[rec.id] = struct{}{}
+
+		if rec.parent == "" {
+			roots = append(roots, rec.id)
+		}
+		children[rec.parent] = append(children[rec.parent], rec)
+	}
+
+	if len(records) == 0 {
+		return tree, nil
+	}
+	if len(roots) != 1 {
+		return nil, fmt.Errorf("expected a single root, got %d: %s", len(roots), strings.Join(roots, ", "))
+	}
+
+	// add the nodes from the root so that every parent is added before its children
+	queue := []string{""}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, rec := range children[parent] {
+			if err := tree.AddByID(gotree.NewNode(rec.id, rec.value), rec.parent); err != nil {
+				return nil, err
+			}
+			queue = append(queue, rec.id)
+		}
+		delete(children, parent)
+	}
+
+	// the remaining records refer to unknown parents or form cycles
+	if len(children) > 0 {
+		var orphans []string
+		for _, recs := range children {
+			for _, rec := range recs {
+				orphans = append(orphans, rec.id)
+			}
+		}
+		sort.Strings(orphans)
+		return nil, fmt.Errorf("nodes not reachable from the root: %s", strings.Join(orphans, ", "))
+	}
+	return tree, nil
+}
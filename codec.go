@@ -0,0 +1,60 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import "encoding/json"
+
+// Codec encodes the values of the Nodes exchanged with the outside of the Tree, such as the
+// adapters persisting them to a database or serving them over the network.
+type Codec[T any] interface {
+	// Marshal encodes the given value
+	Marshal(value T) ([]byte, error)
+	// Unmarshal decodes the given bytes
+	Unmarshal(data []byte) (T, error)
+}
+
+// jsonCodec is a Codec based on encoding/json
+type jsonCodec[T any] struct{}
+
+// JSONCodec returns a Codec encoding the values with encoding/json
+//
+// Example usage:
+//
+//	storage, err := gotreebolt.Open("tree.db", gotree.JSONCodec[string]())
+func JSONCodec[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+// Marshal implements Codec
+func (jsonCodec[T]) Marshal(value T) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Unmarshal implements Codec
+func (jsonCodec[T]) Unmarshal(data []byte) (T, error) {
+	var value T
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
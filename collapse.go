@@ -0,0 +1,172 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"time"
+)
+
+// Collapse replaces the subtree of the given Node with a single summary Node.
+//
+// The descendants of the Node are removed and the Node is given the value computed by the summarizer
+// from the Node and its descendants, e.g. the total of the values. The Node keeps its position,
+// hence the enormous hierarchies can be presented as progressively detailed views, the collapsed
+// branches being summarized until they are expanded again.
+//
+// Parameters:
+//   - node: The Node[T] whose subtree is collapsed.
+//   - summarizer: The function computing the summary value from the Node followed by its
+//     descendants, in depth-first order.
+//
+// Returns:
+//   - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The subtree was collapsed.
+//   - ErrNotFound: The Node does not exist in the Tree, or it was removed concurrently.
+//   - ErrVersionMismatch: The subtree changed while the summary was computed and nothing was collapsed.
+//   - ErrThrottled: The mutation limiter set with WithMutationLimiter refused the collapse.
+//   - ErrNotPersisted: The Storage failed to append a change, which was not applied. The changes
+//     of the collapse are written ahead one by one, hence the ones appended before remain applied.
+//
+// Notes:
+//   - The summary is computed first, without any lock held, hence the summarizer may read the Tree.
+//     The descendants are then removed and the summary is set at once: the readers see either
+//     the whole subtree or the summary Node, and the changes are published together.
+//   - The versions of the Nodes of the subtree are captured along with their values, like
+//     FindWithVersion does, and checked again before the collapse, like UpdateIf does: the summary
+//     never misses a change made to the subtree in the meantime. Retry to collapse the current subtree.
+//   - The collapse is reported to the Metrics and the Tracer as OperationCollapse.
+//   - The delete hooks are notified of the removed descendants and the update hooks of the summary,
+//     and the Nodes shared with other parents are released rather than removed, like Delete.
+//
+// Example usage:
+//
+//	err := tree.Collapse(region, func(nodes []Node[int]) int {
+//	    total := 0
+//	    for _, node := range nodes {
+//	        total += node.Value()
+//	    }
+//	    return total
+//	})
+func (x *Tree[T]) Collapse(node Node[T], summarizer func([]Node[T]) T) (err error) {
+	id := node.ID()
+	if err := x.requireContext(opCollapse, id, ""); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opCollapse, id, ""); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationCollapse, time.Now(), &err)
+	}
+
+	// capture the subtree along with the versions of its Nodes to summarize it without any lock held
+	x.mu.RLock()
+	start, ok := x.getNode(id)
+	if !ok {
+		x.mu.RUnlock()
+		return newNodeError(opCollapse, id, "", ErrNotFound)
+	}
+	subtree := append([]*treeNode[T]{start}, x.collectDescendants(start)...)
+	nodes := make([]Node[T], len(subtree))
+	versions := make([]uint64, len(subtree))
+	for i, n := range subtree {
+		current := n.Value.Load()
+		nodes[i], versions[i] = current.Data(), current.version
+	}
+	x.mu.RUnlock()
+
+	summary := NewNode(id, summarizer(nodes))
+
+	end := x.trace(OperationCollapse)
+	collapsed, err := x.collapse(subtree, versions, summary)
+	end(len(collapsed.removed), err)
+
+	for _, moved := range collapsed.moves {
+		x.notifyMove(moved.node, moved.from, moved.to)
+	}
+	x.notifyDelete(collapsed.removed)
+	if collapsed.updated {
+		x.notifyUpdate(summary, collapsed.parent)
+	}
+	return err
+}
+
+// collapsed describes the changes applied by a collapse
+type collapsed[T any] struct {
+	// moves are the Nodes shared with other parents, released rather than removed
+	moves []move[T]
+	// removed are the removed descendants
+	removed []mutation[T]
+	// parent is the parent of the collapsed Node when the update is observed
+	parent Node[T]
+	// updated states whether the summary was set
+	updated bool
+}
+
+// collapse removes the descendants of the first Node of the given subtree and sets the given summary
+// under the exclusive structural lock, publishing the changes at once. The subtree is rejected when
+// its Nodes no longer hold the given versions
+func (x *Tree[T]) collapse(subtree []*treeNode[T], versions []uint64, summary Node[T]) (result collapsed[T], err error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	// the version of a Node changes with its value and with its children
+	start := subtree[0]
+	if current, ok := x.getNode(start.ID); !ok || current != start {
+		return result, newNodeError(opCollapse, start.ID, "", ErrNotFound)
+	}
+	for i, n := range subtree {
+		if current, ok := x.getNode(n.ID); !ok || current != n || n.GetVersion() != versions[i] {
+			return result, newNodeError(opCollapse, start.ID, "", ErrVersionMismatch)
+		}
+	}
+
+	// every change is written ahead on its own and the changes are published at once
+	_, commit := x.feed.batch()
+	defer commit()
+
+	for _, child := range start.Descendants.Items() {
+		moves, released, err := x.release(child.ID)
+		result.moves = append(result.moves, moves...)
+		if err != nil {
+			return result, err
+		}
+		if released {
+			continue
+		}
+
+		removed, err := x.remove(child.ID)
+		result.removed = append(result.removed, removed...)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	result.parent, err = x.replaceValue(start, summary, nil)
+	result.updated = err == nil
+	return result, err
+}
@@ -0,0 +1,155 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollapse(t *testing.T) {
+	sum := func(nodes []Node[int]) int {
+		total := 0
+		for _, node := range nodes {
+			total += node.Value()
+		}
+		return total
+	}
+
+	t.Run("replaces the subtree with a summary", func(t *testing.T) {
+		tree, err := FromSExpr[int](`(root 1 (a 2 (a1 3 (a11 4)) (a2 5)) (b 6))`)
+		require.NoError(t, err)
+
+		var summarized []string
+		require.NoError(t, tree.Collapse(NewNode("a", 0), func(nodes []Node[int]) int {
+			summarized = queryIDs(nodes)
+			return sum(nodes)
+		}))
+		assert.Equal(t, []string{"a", "a1", "a11", "a2"}, summarized)
+		assert.Equal(t, []Record[int]{
+			{ID: "root", Value: 1},
+			{ID: "a", ParentID: "root", Value: 14},
+			{ID: "b", ParentID: "root", Value: 6},
+		}, tree.Snapshot().Records)
+		assert.EqualValues(t, 3, tree.Size())
+
+		require.NoError(t, tree.Collapse(NewNode("root", 0), sum))
+		assert.Equal(t, []Record[int]{{ID: "root", Value: 21}}, tree.Snapshot().Records)
+
+		require.NoError(t, tree.Collapse(NewNode("root", 0), sum))
+		assert.Equal(t, []Record[int]{{ID: "root", Value: 21}}, tree.Snapshot().Records)
+	})
+
+	t.Run("summarizes the subtree before removing it", func(t *testing.T) {
+		tree, err := FromSExpr[int](`(root 1 (a 2 (a1 3) (a2 5)))`)
+		require.NoError(t, err)
+
+		require.NoError(t, tree.Collapse(NewNode("a", 0), func(nodes []Node[int]) int {
+			// the summarizer reads the whole subtree
+			_, ok := tree.Find("a2")
+			assert.True(t, ok)
+			assert.EqualValues(t, 4, tree.Size())
+			return sum(nodes)
+		}))
+		descendants, ok := tree.Descendants(NewNode("root", 0))
+		require.True(t, ok)
+		assert.Equal(t, []string{"a"}, queryIDs(descendants))
+	})
+
+	t.Run("publishes the changes at once", func(t *testing.T) {
+		tree := NewTree[int](WithChangeFeed(16))
+		require.NoError(t, tree.AddByID(NewNode("root", 1), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", 2), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", 3), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a11", 4), "a1"))
+		seq := tree.LastSeq()
+
+		require.NoError(t, tree.Collapse(NewNode("a", 0), sum))
+		events, err := tree.ChangesSince(seq)
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		assert.Equal(t, []EventType{EventDelete, EventDelete, EventUpdate}, []EventType{events[0].Type, events[1].Type, events[2].Type})
+		assert.Equal(t, []string{"a1", "a11", "a"}, []string{events[0].Node.ID(), events[1].Node.ID(), events[2].Node.ID()})
+		assert.Equal(t, 9, events[2].Node.Value())
+		assert.Equal(t, seq+3, events[2].Seq)
+	})
+
+	t.Run("rejects the changes not persisted", func(t *testing.T) {
+		storage := &failingStorage[int]{MemoryStorage: NewMemoryStorage[int]()}
+		tree := NewTree[int](WithStorage[int](storage))
+		require.NoError(t, tree.AddByID(NewNode("root", 1), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", 2), "root"))
+
+		var updated []string
+		tree.OnUpdate(func(node, _ Node[int]) { updated = append(updated, node.ID()) })
+		storage.broken = true
+		assert.ErrorIs(t, tree.Collapse(NewNode("root", 0), sum), ErrNotPersisted)
+		assert.EqualValues(t, 2, tree.Size())
+		assert.Empty(t, updated)
+	})
+
+	t.Run("rejects a subtree changed while summarizing it", func(t *testing.T) {
+		for name, change := range map[string]func(tree *Tree[int]) error{
+			"update of the node":  func(tree *Tree[int]) error { return tree.Update(NewNode("a", 20)) },
+			"update of a leaf":    func(tree *Tree[int]) error { return tree.Update(NewNode("a11", 40)) },
+			"addition of a child": func(tree *Tree[int]) error { return tree.AddByID(NewNode("a12", 7), "a1") },
+			"move of a child":     func(tree *Tree[int]) error { return tree.MoveByID("a11", "root") },
+		} {
+			t.Run(name, func(t *testing.T) {
+				tree, err := FromSExpr[int](`(root 1 (a 2 (a1 3 (a11 4))))`)
+				require.NoError(t, err)
+				size := tree.Size()
+
+				err = tree.Collapse(NewNode("a", 0), func(nodes []Node[int]) int {
+					require.NoError(t, change(tree))
+					return sum(nodes)
+				})
+				assert.ErrorIs(t, err, ErrVersionMismatch)
+				_, ok := tree.Find("a1")
+				assert.True(t, ok)
+				assert.GreaterOrEqual(t, tree.Size(), size)
+			})
+		}
+	})
+
+	t.Run("reports the collapse as its own operation", func(t *testing.T) {
+		metrics, tracer := new(recordingMetrics), new(recordingTracer)
+		tree := NewTree[int](WithMetrics(metrics), WithTracer(tracer))
+		require.NoError(t, tree.AddByID(NewNode("root", 1), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", 2), "root"))
+
+		require.NoError(t, tree.Collapse(NewNode("root", 0), sum))
+		assert.Equal(t, OperationCollapse, metrics.observations[len(metrics.observations)-1].operation)
+		assert.Equal(t, span{operation: OperationCollapse, nodes: 1}, tracer.spans[len(tracer.spans)-1])
+	})
+
+	t.Run("rejects a missing node", func(t *testing.T) {
+		tree, err := FromSExpr[int](`(root 1)`)
+		require.NoError(t, err)
+		assert.ErrorIs(t, tree.Collapse(NewNode("missing", 0), sum), ErrNotFound)
+	})
+}
@@ -0,0 +1,495 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package crdt implements a conflict-free replicated tree on top of gotree, so that several
+// processes can mutate replicas of the same tree concurrently and converge without a coordinator.
+//
+// The replication follows the move operation CRDT described in "A highly-available move
+// operation for replicated trees" (Kleppmann et al.). Every mutation is an Op stamped with
+// a Lamport Timestamp. Replicas exchange their Ops in any order, any number of times, and
+// apply them in timestamp order, undoing and redoing the Ops applied out of order. A move
+// that would introduce a cycle is skipped, so that concurrent moves cannot corrupt the tree,
+// and deleted Nodes are moved to a hidden trash.
+//
+// The values are replicated apart from the positions, as last-writer-wins registers: an update
+// is an Op of its own kind, so that a concurrent move and update of a Node both take effect.
+//
+// Every replica materializes its state into a gotree.Tree holding the Nodes reachable from
+// the shared root. The replicas converge to the same hierarchy and values; the order of the
+// siblings is not replicated. The log of the applied Ops grows with every mutation.
+//
+//	replica, _ := crdt.NewReplica("replica-1", gotree.NewNode("root", "Root"))
+//	op, err := replica.Add("a", "root", "A")
+//	if err == nil {
+//	    broadcast(op) // peers call replica.Apply(op)
+//	}
+package crdt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/tochemey/gotree"
+)
+
+// trash is the parent of the deleted Nodes. It is never part of the Tree
+const trash = "\x00trash"
+
+// Timestamp is a Lamport timestamp totally ordering the Ops across replicas
+type Timestamp struct {
+	// Counter is the logical clock of the replica that issued the Op
+	Counter uint64 `json:"counter"`
+	// Replica is the identifier of the replica that issued the Op, breaking the ties
+	Replica string `json:"replica"`
+}
+
+// Less reports whether the Timestamp is ordered before the given one
+func (t Timestamp) Less(other Timestamp) bool {
+	if t.Counter != other.Counter {
+		return t.Counter < other.Counter
+	}
+	return t.Replica < other.Replica
+}
+
+// OpKind is the kind of an Op
+type OpKind int
+
+const (
+	// OpMove moves the Child under the Parent, creating it with the Value when it does not exist yet.
+	// Additions, moves and deletions are all moves, a deletion moving the Child to a hidden trash.
+	OpMove OpKind = iota + 1
+	// OpSet sets the Value of the Child, leaving its position untouched.
+	// The Op with the highest Timestamp wins.
+	OpSet
+)
+
+// String returns the name of the OpKind
+func (k OpKind) String() string {
+	switch k {
+	case OpMove:
+		return "move"
+	case OpSet:
+		return "set"
+	default:
+		return fmt.Sprintf("OpKind(%d)", int(k))
+	}
+}
+
+// Op is a replicated mutation of a Node. Additions, moves, updates and deletions are all
+// expressed as Ops, the position and the value of the Nodes being changed by distinct kinds.
+type Op[T any] struct {
+	// Timestamp orders the Op
+	Timestamp Timestamp `json:"timestamp"`
+	// Kind states whether the Op moves the Child or sets its value
+	Kind OpKind `json:"kind"`
+	// Parent is the ID of the new parent of the Child. It is empty for deletions and for the OpSet
+	Parent string `json:"parent"`
+	// Child is the ID of the Node affected by the Op
+	Child string `json:"child"`
+	// Value is the new value of the Child for the OpSet and its initial value for the OpMove adding
+	// it. It is ignored by the OpMove of an existing Child
+	Value T `json:"value"`
+}
+
+// state is the position and the value of a Node
+type state[T any] struct {
+	parent string
+	value  T
+}
+
+// entry is an applied Op along with the state of its Child before the Op
+type entry[T any] struct {
+	op      Op[T]
+	existed bool
+	before  state[T]
+}
+
+// Replica is a replica of a conflict-free replicated tree.
+//
+// The local mutations return the Op to broadcast to the other replicas, which apply it with
+// Apply. A Replica is safe for concurrent use.
+type Replica[T any] struct {
+	mu       sync.Mutex
+	id       string
+	root     string
+	clock    uint64
+	states   map[string]state[T]
+	children map[string]map[string]struct{}
+	log      []entry[T]
+	tree     *gotree.Tree[T]
+}
+
+// NewReplica creates a Replica with the given identifier. The identifier must be unique
+// across the replicas and all the replicas must be created with the same root Node, which
+// cannot be moved nor deleted.
+func NewReplica[T any](id string, root gotree.Node[T]) (*Replica[T], error) {
+	if id == "" {
+		return nil, errors.New("replica id is required")
+	}
+
+	tree := gotree.NewTree[T]()
+	if err := tree.Add(root, nil); err != nil {
+		return nil, err
+	}
+
+	return &Replica[T]{
+		id:       id,
+		root:     root.ID(),
+		states:   map[string]state[T]{root.ID(): {value: root.Value()}},
+		children: make(map[string]map[string]struct{}),
+		tree:     tree,
+	}, nil
+}
+
+// Tree returns the Tree materializing the replica. It must only be read or watched:
+// mutating it directly breaks the replication.
+func (r *Replica[T]) Tree() *gotree.Tree[T] {
+	return r.tree
+}
+
+// Add adds a Node under the given parent and returns the Op to broadcast.
+// It returns gotree.ErrDuplicateID when the Node exists and gotree.ErrParentNodeNotFound
+// when the parent is not part of the Tree.
+func (r *Replica[T]) Add(id, parentID string, value T) (Op[T], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tree.Find(id); ok {
+		return Op[T]{}, fmt.Errorf("add %q: %w", id, gotree.ErrDuplicateID)
+	}
+	if _, ok := r.tree.Find(parentID); !ok {
+		return Op[T]{}, fmt.Errorf("add %q under %q: %w", id, parentID, gotree.ErrParentNodeNotFound)
+	}
+	return r.local(OpMove, parentID, id, value)
+}
+
+// Move moves a Node along with its descendants under the given parent and returns the Op to broadcast.
+// It returns gotree.ErrNotFound or gotree.ErrParentNodeNotFound when the Nodes are not part of the Tree,
+// and gotree.ErrInvalidOperation when moving the root or moving a Node under its own subtree.
+func (r *Replica[T]) Move(id, parentID string) (Op[T], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tree.Find(id); !ok {
+		return Op[T]{}, fmt.Errorf("move %q: %w", id, gotree.ErrNotFound)
+	}
+	if _, ok := r.tree.Find(parentID); !ok {
+		return Op[T]{}, fmt.Errorf("move %q under %q: %w", id, parentID, gotree.ErrParentNodeNotFound)
+	}
+	if id == r.root || id == parentID || r.isAncestor(id, parentID) {
+		return Op[T]{}, fmt.Errorf("move %q under %q: %w", id, parentID, gotree.ErrInvalidOperation)
+	}
+	return r.local(OpMove, parentID, id, *new(T))
+}
+
+// Update replaces the value of a Node and returns the Op to broadcast.
+// It returns gotree.ErrNotFound when the Node is not part of the Tree and
+// gotree.ErrInvalidOperation for the root, whose value is shared by the replicas.
+func (r *Replica[T]) Update(id string, value T) (Op[T], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tree.Find(id); !ok {
+		return Op[T]{}, fmt.Errorf("update %q: %w", id, gotree.ErrNotFound)
+	}
+	if id == r.root {
+		return Op[T]{}, fmt.Errorf("update %q: %w", id, gotree.ErrInvalidOperation)
+	}
+	return r.local(OpSet, "", id, value)
+}
+
+// Delete deletes a Node along with its descendants and returns the Op to broadcast.
+// It returns gotree.ErrNotFound when the Node is not part of the Tree and
+// gotree.ErrInvalidOperation for the root.
+func (r *Replica[T]) Delete(id string) (Op[T], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tree.Find(id); !ok {
+		return Op[T]{}, fmt.Errorf("delete %q: %w", id, gotree.ErrNotFound)
+	}
+	if id == r.root {
+		return Op[T]{}, fmt.Errorf("delete %q: %w", id, gotree.ErrInvalidOperation)
+	}
+	return r.local(OpMove, "", id, *new(T))
+}
+
+// Apply applies the Ops received from the other replicas, in any order.
+// Applying an Op more than once has no effect, and the Ops of an unknown kind are rejected.
+func (r *Replica[T]) Apply(ops ...Op[T]) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for _, op := range ops {
+		if op.Kind != OpMove && op.Kind != OpSet {
+			errs = append(errs, fmt.Errorf("apply %q: unknown op kind %s", op.Child, op.Kind))
+			continue
+		}
+		r.clock = max(r.clock, op.Timestamp.Counter)
+		errs = append(errs, r.apply(op))
+	}
+	return errors.Join(errs...)
+}
+
+// Ops returns the Ops applied by the replica in timestamp order. Applying them
+// to a new replica brings it up to date.
+func (r *Replica[T]) Ops() []Op[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ops := make([]Op[T], len(r.log))
+	for i, e := range r.log {
+		ops[i] = e.op
+	}
+	return ops
+}
+
+// local stamps and applies a local Op. It must be called with the lock held
+func (r *Replica[T]) local(kind OpKind, parentID, id string, value T) (Op[T], error) {
+	r.clock++
+	op := Op[T]{
+		Timestamp: Timestamp{Counter: r.clock, Replica: r.id},
+		Kind:      kind,
+		Parent:    parentID,
+		Child:     id,
+		Value:     value,
+	}
+	return op, r.apply(op)
+}
+
+// apply inserts the given Op in the log, undoing and redoing the Ops ordered after it,
+// then synchronizes the Tree with the resulting state. It must be called with the lock held
+func (r *Replica[T]) apply(op Op[T]) error {
+	position := sort.Search(len(r.log), func(i int) bool {
+		return !r.log[i].op.Timestamp.Less(op.Timestamp)
+	})
+	if position < len(r.log) && r.log[position].op.Timestamp == op.Timestamp {
+		return nil
+	}
+
+	// remember the state of the affected Nodes to synchronize the Tree afterward
+	touched := map[string]*state[T]{}
+	touch := func(id string) {
+		if _, ok := touched[id]; ok {
+			return
+		}
+		touched[id] = nil
+		if s, ok := r.states[id]; ok {
+			touched[id] = &s
+		}
+	}
+
+	touch(op.Child)
+	for i := len(r.log) - 1; i >= position; i-- {
+		touch(r.log[i].op.Child)
+		r.undo(r.log[i])
+	}
+
+	redo := append([]entry[T]{{op: op}}, r.log[position:]...)
+	r.log = r.log[:position]
+	for _, e := range redo {
+		r.log = append(r.log, r.do(e.op))
+	}
+	return r.sync(touched)
+}
+
+// do applies the given Op to the state and returns its log entry.
+// A move under the Node itself or one of its descendants, any move of the root and the value set
+// on a missing Node are skipped
+func (r *Replica[T]) do(op Op[T]) entry[T] {
+	before, existed := r.states[op.Child]
+	e := entry[T]{op: op, existed: existed, before: before}
+
+	if op.Kind == OpSet {
+		if existed && op.Child != r.root {
+			r.states[op.Child] = state[T]{parent: before.parent, value: op.Value}
+		}
+		return e
+	}
+
+	parent := op.Parent
+	if parent == "" {
+		parent = trash
+	}
+
+	if op.Child == r.root || op.Child == parent || r.isAncestor(op.Child, parent) {
+		return e
+	}
+
+	// the value of an existing Node is only changed by the OpSet
+	value := op.Value
+	if existed {
+		r.unlink(op.Child, before.parent)
+		value = before.value
+	}
+	r.link(op.Child, parent)
+	r.states[op.Child] = state[T]{parent: parent, value: value}
+	return e
+}
+
+// undo restores the state preceding the given log entry
+func (r *Replica[T]) undo(e entry[T]) {
+	current, ok := r.states[e.op.Child]
+	if !ok {
+		return
+	}
+
+	r.unlink(e.op.Child, current.parent)
+	if !e.existed {
+		delete(r.states, e.op.Child)
+		return
+	}
+	r.link(e.op.Child, e.before.parent)
+	r.states[e.op.Child] = e.before
+}
+
+// link records the given child under the given parent
+func (r *Replica[T]) link(child, parent string) {
+	if r.children[parent] == nil {
+		r.children[parent] = make(map[string]struct{})
+	}
+	r.children[parent][child] = struct{}{}
+}
+
+// unlink removes the given child from the children of the given parent
+func (r *Replica[T]) unlink(child, parent string) {
+	delete(r.children[parent], child)
+	if len(r.children[parent]) == 0 {
+		delete(r.children, parent)
+	}
+}
+
+// isAncestor reports whether the given ancestor is an ancestor of the given Node in the state
+func (r *Replica[T]) isAncestor(ancestor, id string) bool {
+	for steps := 0; steps <= len(r.states); steps++ {
+		s, ok := r.states[id]
+		if !ok {
+			return false
+		}
+		if s.parent == ancestor {
+			return true
+		}
+		id = s.parent
+	}
+	return false
+}
+
+// depth returns the depth of the given Node when it is reachable from the root, -1 otherwise
+func (r *Replica[T]) depth(id string) int {
+	for depth := 0; depth <= len(r.states); depth++ {
+		if id == r.root {
+			return depth
+		}
+		s, ok := r.states[id]
+		if !ok {
+			return -1
+		}
+		id = s.parent
+	}
+	return -1
+}
+
+// sync applies the changes of the touched Nodes to the Tree.
+// The reachable Nodes are added or moved from the shallowest to the deepest, so that their
+// parent is in place, before the Nodes that are no longer reachable are deleted.
+func (r *Replica[T]) sync(touched map[string]*state[T]) error {
+	type target struct {
+		id    string
+		depth int
+	}
+
+	var reachable, unreachable []target
+	for id := range touched {
+		if depth := r.depth(id); depth >= 0 {
+			reachable = append(reachable, target{id: id, depth: depth})
+			continue
+		}
+		unreachable = append(unreachable, target{id: id})
+	}
+
+	sort.Slice(reachable, func(i, j int) bool {
+		if reachable[i].depth != reachable[j].depth {
+			return reachable[i].depth < reachable[j].depth
+		}
+		return reachable[i].id < reachable[j].id
+	})
+
+	var errs []error
+	added := make(map[string]struct{})
+	for _, t := range reachable {
+		if _, ok := added[t.id]; ok {
+			continue
+		}
+
+		after := r.states[t.id]
+		node, ok := r.tree.Find(t.id)
+		if !ok {
+			errs = append(errs, r.addSubtree(t.id, added))
+			continue
+		}
+
+		if parent, _ := r.tree.ParentAt(node, 0); parent == nil || parent.ID() != after.parent {
+			errs = append(errs, r.tree.MoveByID(t.id, after.parent))
+		}
+
+		if before := touched[t.id]; before == nil || !reflect.DeepEqual(before.value, after.value) {
+			errs = append(errs, r.tree.Update(gotree.NewNode(t.id, after.value)))
+		}
+	}
+
+	for _, t := range unreachable {
+		if err := r.tree.DeleteByID(t.id); err != nil && !errors.Is(err, gotree.ErrNotFound) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// addSubtree adds the given Node to the Tree along with its descendants missing from the Tree,
+// recording the added Nodes
+func (r *Replica[T]) addSubtree(id string, added map[string]struct{}) error {
+	s := r.states[id]
+	if err := r.tree.AddByID(gotree.NewNode(id, s.value), s.parent); err != nil {
+		return err
+	}
+	added[id] = struct{}{}
+
+	children := make([]string, 0, len(r.children[id]))
+	for child := range r.children[id] {
+		children = append(children, child)
+	}
+	sort.Strings(children)
+
+	var errs []error
+	for _, child := range children {
+		if _, ok := r.tree.Find(child); !ok {
+			errs = append(errs, r.addSubtree(child, added))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,267 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package crdt
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tochemey/gotree"
+)
+
+// snapshot returns the parent and the value of every Node of the replica Tree
+func snapshot(t *testing.T, replica *Replica[string]) map[string]string {
+	tree := replica.Tree()
+	require.Empty(t, tree.Validate())
+
+	nodes := make(map[string]string)
+	for _, node := range tree.Nodes() {
+		parent := "<root>"
+		if p, ok := tree.ParentAt(node, 0); ok {
+			parent = p.ID()
+		}
+		nodes[node.ID()] = parent + " " + node.Value()
+	}
+	return nodes
+}
+
+func newReplica(t *testing.T, id string) *Replica[string] {
+	replica, err := NewReplica(id, gotree.NewNode("root", "Root"))
+	require.NoError(t, err)
+	return replica
+}
+
+func TestReplica(t *testing.T) {
+	replica := newReplica(t, "r1")
+
+	add, err := replica.Add("a", "root", "A")
+	require.NoError(t, err)
+	assert.Equal(t, Op[string]{Timestamp: Timestamp{Counter: 1, Replica: "r1"}, Kind: OpMove, Parent: "root", Child: "a", Value: "A"}, add)
+
+	_, err = replica.Add("b", "root", "B")
+	require.NoError(t, err)
+	_, err = replica.Add("a1", "a", "A1")
+	require.NoError(t, err)
+
+	move, err := replica.Move("a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, OpMove, move.Kind)
+	assert.Empty(t, move.Value)
+
+	update, err := replica.Update("a1", "A1'")
+	require.NoError(t, err)
+	assert.Equal(t, OpSet, update.Kind)
+	assert.Empty(t, update.Parent)
+
+	assert.Equal(t, map[string]string{
+		"root": "<root> Root",
+		"a":    "b A",
+		"a1":   "a A1'",
+		"b":    "root B",
+	}, snapshot(t, replica))
+
+	del, err := replica.Delete("a")
+	require.NoError(t, err)
+	assert.Empty(t, del.Parent)
+	assert.Equal(t, map[string]string{"root": "<root> Root", "b": "root B"}, snapshot(t, replica))
+	assert.Len(t, replica.Ops(), 6)
+
+	t.Run("rejects invalid mutations", func(t *testing.T) {
+		_, err := replica.Add("b", "root", "B")
+		assert.ErrorIs(t, err, gotree.ErrDuplicateID)
+		_, err = replica.Add("c", "a", "C")
+		assert.ErrorIs(t, err, gotree.ErrParentNodeNotFound)
+		_, err = replica.Move("a", "root")
+		assert.ErrorIs(t, err, gotree.ErrNotFound)
+		_, err = replica.Move("b", "missing")
+		assert.ErrorIs(t, err, gotree.ErrParentNodeNotFound)
+		_, err = replica.Move("root", "b")
+		assert.ErrorIs(t, err, gotree.ErrInvalidOperation)
+		_, err = replica.Move("b", "b")
+		assert.ErrorIs(t, err, gotree.ErrInvalidOperation)
+		_, err = replica.Update("missing", "")
+		assert.ErrorIs(t, err, gotree.ErrNotFound)
+		_, err = replica.Update("root", "")
+		assert.ErrorIs(t, err, gotree.ErrInvalidOperation)
+		_, err = replica.Delete("missing")
+		assert.ErrorIs(t, err, gotree.ErrNotFound)
+		_, err = replica.Delete("root")
+		assert.ErrorIs(t, err, gotree.ErrInvalidOperation)
+		assert.Error(t, replica.Apply(Op[string]{Timestamp: Timestamp{Counter: 9, Replica: "r2"}, Child: "b"}))
+		assert.Len(t, replica.Ops(), 6)
+	})
+
+	t.Run("a new replica catches up", func(t *testing.T) {
+		other := newReplica(t, "r2")
+		ops := replica.Ops()
+		// in reverse order, twice
+		for i := len(ops) - 1; i >= 0; i-- {
+			require.NoError(t, other.Apply(ops[i], ops[i]))
+		}
+		assert.Equal(t, snapshot(t, replica), snapshot(t, other))
+
+		// the local clock follows the applied ops
+		op, err := other.Add("c", "root", "C")
+		require.NoError(t, err)
+		assert.Equal(t, Timestamp{Counter: 7, Replica: "r2"}, op.Timestamp)
+	})
+
+	_, err = NewReplica("", gotree.NewNode("root", ""))
+	assert.Error(t, err)
+}
+
+func TestConcurrentMovesDoNotCycle(t *testing.T) {
+	r1, r2 := newReplica(t, "r1"), newReplica(t, "r2")
+	for _, id := range []string{"a", "b"} {
+		op, err := r1.Add(id, "root", id)
+		require.NoError(t, err)
+		require.NoError(t, r2.Apply(op))
+	}
+
+	// each replica moves one node under the other one
+	op1, err := r1.Move("a", "b")
+	require.NoError(t, err)
+	op2, err := r2.Move("b", "a")
+	require.NoError(t, err)
+
+	require.NoError(t, r1.Apply(op2))
+	require.NoError(t, r2.Apply(op1))
+
+	// the move with the highest timestamp (r2 wins the tie) is skipped
+	expected := map[string]string{"root": "<root> Root", "a": "b a", "b": "root b"}
+	assert.Equal(t, expected, snapshot(t, r1))
+	assert.Equal(t, expected, snapshot(t, r2))
+}
+
+func TestConcurrentMoveAndUpdate(t *testing.T) {
+	r1, r2 := newReplica(t, "r1"), newReplica(t, "r2")
+	for _, id := range []string{"a", "b"} {
+		op, err := r1.Add(id, "root", id)
+		require.NoError(t, err)
+		require.NoError(t, r2.Apply(op))
+	}
+
+	// the move is ordered after the update (r2 wins the tie) and keeps the updated value
+	update, err := r1.Update("a", "a'")
+	require.NoError(t, err)
+	move, err := r2.Move("a", "b")
+	require.NoError(t, err)
+	require.True(t, update.Timestamp.Less(move.Timestamp))
+
+	require.NoError(t, r1.Apply(move))
+	require.NoError(t, r2.Apply(update))
+
+	expected := map[string]string{"root": "<root> Root", "a": "b a'", "b": "root b"}
+	assert.Equal(t, expected, snapshot(t, r1))
+	assert.Equal(t, expected, snapshot(t, r2))
+
+	// the last writer wins the concurrent updates
+	first, err := r1.Update("b", "b1")
+	require.NoError(t, err)
+	second, err := r2.Update("b", "b2")
+	require.NoError(t, err)
+	require.NoError(t, r1.Apply(second))
+	require.NoError(t, r2.Apply(first))
+	assert.Equal(t, "root b2", snapshot(t, r1)["b"])
+	assert.Equal(t, snapshot(t, r1), snapshot(t, r2))
+}
+
+func TestConcurrentDeleteAndAdd(t *testing.T) {
+	r1, r2 := newReplica(t, "r1"), newReplica(t, "r2")
+	op, err := r1.Add("a", "root", "A")
+	require.NoError(t, err)
+	require.NoError(t, r2.Apply(op))
+
+	del, err := r1.Delete("a")
+	require.NoError(t, err)
+	add, err := r2.Add("a1", "a", "A1")
+	require.NoError(t, err)
+
+	require.NoError(t, r1.Apply(add))
+	require.NoError(t, r2.Apply(del))
+
+	// the node added under the deleted one is deleted too
+	expected := map[string]string{"root": "<root> Root"}
+	assert.Equal(t, expected, snapshot(t, r1))
+	assert.Equal(t, expected, snapshot(t, r2))
+}
+
+func TestRandomConvergence(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	replicas := []*Replica[string]{newReplica(t, "r1"), newReplica(t, "r2"), newReplica(t, "r3")}
+	var ops []Op[string]
+
+	for step := 0; step < 600; step++ {
+		replica := replicas[random.Intn(len(replicas))]
+		ids := replica.Tree().IDs()
+		id := ids[random.Intn(len(ids))]
+		other := ids[random.Intn(len(ids))]
+
+		var (
+			op  Op[string]
+			err error
+		)
+		switch random.Intn(4) {
+		case 0, 1:
+			op, err = replica.Add(fmt.Sprintf("n%d", step), id, fmt.Sprint(step))
+		case 2:
+			op, err = replica.Move(id, other)
+		default:
+			if random.Intn(2) == 0 {
+				op, err = replica.Delete(id)
+			} else {
+				op, err = replica.Update(id, fmt.Sprint(step))
+			}
+		}
+		if err != nil {
+			continue
+		}
+		ops = append(ops, op)
+
+		// deliver some of the ops, out of order
+		if random.Intn(5) == 0 {
+			peer := replicas[random.Intn(len(replicas))]
+			require.NoError(t, peer.Apply(ops[random.Intn(len(ops))]))
+		}
+	}
+
+	for _, replica := range replicas {
+		shuffled := append([]Op[string](nil), ops...)
+		random.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+		require.NoError(t, replica.Apply(shuffled...))
+	}
+
+	expected := snapshot(t, replicas[0])
+	assert.Greater(t, len(expected), 1)
+	for _, replica := range replicas[1:] {
+		assert.Equal(t, expected, snapshot(t, replica))
+	}
+}
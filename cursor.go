@@ -0,0 +1,283 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import "encoding/base64"
+
+// Cursor is a stateful iterator over a subtree of the Tree.
+//
+// Unlike callback-based traversals, a Cursor is driven by the caller, one step at a time,
+// which allows walking a Tree incrementally and pausing/resuming the walk at will (e.g. in
+// parsers or UI components). A Cursor is positioned on a Node of the subtree it was created
+// for and offers two ways of moving:
+//   - Next walks the subtree in depth-first pre-order, children in insertion order.
+//   - Parent, FirstChild and NextSibling navigate explicitly, one edge at a time. Next
+//     resumes from wherever the Cursor has been moved to.
+//
+// A Cursor never leaves the subtree it was created for. It resolves Nodes by ID at every
+// step, hence it remains safe to use while the Tree is modified concurrently: moving from
+// a Node that has been deleted in the meantime simply fails.
+//
+// The children resolved on demand with WithChildResolver are materialized as the Cursor descends.
+//
+// A Cursor is not safe for concurrent use by multiple goroutines.
+type Cursor[T any] struct {
+	tree    *Tree[T]
+	start   string
+	current string
+	started bool
+	done    bool
+}
+
+// Cursor creates a Cursor over the subtree rooted at the given Node.
+//
+// Parameters:
+//   - start: The Node[T] at the root of the subtree to walk.
+//
+// Returns:
+//   - cursor: The Cursor positioned before the start Node. The first call to Next returns
+//     the start Node.
+//   - ok: A boolean indicating whether the start Node exists in the Tree.
+//
+// Example usage:
+//
+//	cursor, _ := tree.Cursor(tree.Root())
+//	for node, ok := cursor.Next(); ok; node, ok = cursor.Next() {
+//	    fmt.Println("Visiting:", node.ID())
+//	}
+func (x *Tree[T]) Cursor(start Node[T]) (cursor *Cursor[T], ok bool) {
+	if _, ok := x.getNode(start.ID()); !ok {
+		return nil, false
+	}
+	return &Cursor[T]{
+		tree:    x,
+		start:   start.ID(),
+		current: start.ID(),
+	}, true
+}
+
+// Current returns the Node the Cursor is positioned on.
+// It returns false when the Node is no longer part of the Tree.
+func (c *Cursor[T]) Current() (Node[T], bool) {
+	node, ok := c.tree.getNode(c.current)
+	if !ok {
+		return nil, false
+	}
+	return node.GetValue(), true
+}
+
+// Next moves the Cursor to the next Node of the subtree in depth-first pre-order and returns it.
+// It returns false once the whole subtree has been walked.
+func (c *Cursor[T]) Next() (Node[T], bool) {
+	if c.done {
+		return nil, false
+	}
+
+	if !c.started {
+		c.started = true
+		return c.Current()
+	}
+
+	node, ok := c.tree.getNode(c.current)
+	if !ok {
+		c.done = true
+		return nil, false
+	}
+
+	// descend first
+	c.tree.expandQuietly(node.ID)
+	if child, ok := c.firstChild(node); ok {
+		return c.moveTo(child), true
+	}
+
+	// then look for the next sibling of the node or of its closest ancestor
+	for node.ID != c.start {
+		parent, ok := c.tree.parentNode(node.ID)
+		if !ok {
+			break
+		}
+		if sibling, ok := parent.Descendants.Next(node.ID); ok {
+			return c.moveTo(sibling), true
+		}
+		node = parent
+	}
+
+	c.done = true
+	return nil, false
+}
+
+// Parent moves the Cursor to the parent of the current Node and returns it.
+// It returns false, without moving, when the current Node is the start of the Cursor.
+func (c *Cursor[T]) Parent() (Node[T], bool) {
+	if c.current == c.start {
+		return nil, false
+	}
+
+	parent, ok := c.tree.parentNode(c.current)
+	if !ok {
+		return nil, false
+	}
+	return c.moveTo(parent), true
+}
+
+// FirstChild moves the Cursor to the first child of the current Node and returns it.
+// It returns false, without moving, when the current Node has no children.
+func (c *Cursor[T]) FirstChild() (Node[T], bool) {
+	node, ok := c.tree.getNode(c.current)
+	if !ok {
+		return nil, false
+	}
+
+	c.tree.expandQuietly(node.ID)
+	child, ok := c.firstChild(node)
+	if !ok {
+		return nil, false
+	}
+	return c.moveTo(child), true
+}
+
+// firstChild returns the first child of the given node. A child being one of the ancestors of the
+// node, which only happens when the hierarchy is corrupted, is reported and the node is treated as a leaf
+func (c *Cursor[T]) firstChild(node *treeNode[T]) (*treeNode[T], bool) {
+	child, ok := node.Descendants.First()
+	if !ok {
+		return nil, false
+	}
+
+	// only a child held by another parent may close a cycle
+	if parent, ok := c.tree.parentNode(child.ID); ok && parent.ID == node.ID {
+		return child, true
+	}
+	if !c.tree.newPathGuard(node).visit(node, child) {
+		return nil, false
+	}
+	return child, true
+}
+
+// NextSibling moves the Cursor to the next sibling of the current Node and returns it.
+// It returns false, without moving, when the current Node is the last child of its parent
+// or the start of the Cursor.
+func (c *Cursor[T]) NextSibling() (Node[T], bool) {
+	if c.current == c.start {
+		return nil, false
+	}
+
+	parent, ok := c.tree.parentNode(c.current)
+	if !ok {
+		return nil, false
+	}
+
+	sibling, ok := parent.Descendants.Next(c.current)
+	if !ok {
+		return nil, false
+	}
+	return c.moveTo(sibling), true
+}
+
+// moveTo positions the Cursor on the given treeNode and returns its value
+func (c *Cursor[T]) moveTo(node *treeNode[T]) Node[T] {
+	c.current = node.ID
+	c.started = true
+	c.done = false
+	return node.GetValue()
+}
+
+// DescendantsPage returns a page of the descendants of the given Node.
+//
+// The descendants are paged in depth-first pre-order, children in insertion order, so that APIs
+// built on top of the Tree can walk huge subtrees page by page without loading them entirely.
+// Every page resumes right after the last Node of the previous one, hence the order remains stable
+// while the Tree is modified: the Nodes added after the cursor are met on the next pages.
+//
+// Parameters:
+//   - node: The Node[T] whose descendants are paged.
+//   - cursor: The cursor returned along with the previous page, empty for the first page.
+//   - limit: The maximum number of Nodes of the page.
+//
+// Returns:
+//   - page: The descendants of the page. It is empty when limit is not positive.
+//   - nextCursor: The opaque cursor of the next page, empty once the last page is reached.
+//   - ok: false when the Node is not part of the Tree, or the cursor is malformed or designates
+//     a Node that has since been deleted or moved out of the subtree.
+//
+// Example usage:
+//
+//	var cursor string
+//	for {
+//	    page, next, ok := tree.DescendantsPage(department, cursor, 100)
+//	    if !ok {
+//	        break
+//	    }
+//	    process(page)
+//	    if next == "" {
+//	        break
+//	    }
+//	    cursor = next
+//	}
+func (x *Tree[T]) DescendantsPage(node Node[T], cursor string, limit int) (page []Node[T], nextCursor string, ok bool) {
+	start, ok := x.getNode(node.ID())
+	if !ok {
+		return nil, "", false
+	}
+
+	walker := &Cursor[T]{tree: x, start: start.ID, current: start.ID, started: true}
+	if cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, "", false
+		}
+
+		// the cursor must designate a descendant of the Node
+		last, ok := x.getNode(string(decoded))
+		if !ok || last.ID == start.ID {
+			return nil, "", false
+		}
+
+		link, ok := last.GetPath().At(last.GetPath().Depth() - start.GetPath().Depth())
+		if !ok || link.id != start.ID {
+			return nil, "", false
+		}
+		walker.current = last.ID
+	}
+
+	if limit <= 0 {
+		return nil, cursor, true
+	}
+
+	for len(page) < limit {
+		descendant, ok := walker.Next()
+		if !ok {
+			return page, "", true
+		}
+		page = append(page, descendant)
+	}
+
+	// announce a next page only when there is one
+	last := page[len(page)-1].ID()
+	if _, ok := walker.Next(); !ok {
+		return page, "", true
+	}
+	return page, base64.RawURLEncoding.EncodeToString([]byte(last)), true
+}
@@ -0,0 +1,202 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	// root -> (a -> (a1, a2), b -> (b1))
+	tree := NewTree[string]()
+	root := NewNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	a := NewNode("a", "a")
+	require.NoError(t, tree.Add(a, root))
+	require.NoError(t, tree.Add(NewNode("a1", "a1"), a))
+	require.NoError(t, tree.Add(NewNode("a2", "a2"), a))
+	b := NewNode("b", "b")
+	require.NoError(t, tree.Add(b, root))
+	require.NoError(t, tree.Add(NewNode("b1", "b1"), b))
+
+	t.Run("next walks in pre-order", func(t *testing.T) {
+		cursor, ok := tree.Cursor(root)
+		require.True(t, ok)
+
+		var visited []string
+		for node, ok := cursor.Next(); ok; node, ok = cursor.Next() {
+			visited = append(visited, node.ID())
+		}
+		assert.Equal(t, []string{"root", "a", "a1", "a2", "b", "b1"}, visited)
+		_, ok = cursor.Next()
+		assert.False(t, ok)
+	})
+	t.Run("next stays within the subtree", func(t *testing.T) {
+		cursor, ok := tree.Cursor(a)
+		require.True(t, ok)
+
+		var visited []string
+		for node, ok := cursor.Next(); ok; node, ok = cursor.Next() {
+			visited = append(visited, node.ID())
+		}
+		assert.Equal(t, []string{"a", "a1", "a2"}, visited)
+	})
+	t.Run("explicit navigation", func(t *testing.T) {
+		cursor, ok := tree.Cursor(root)
+		require.True(t, ok)
+
+		_, ok = cursor.Parent()
+		assert.False(t, ok)
+		_, ok = cursor.NextSibling()
+		assert.False(t, ok)
+
+		node, ok := cursor.FirstChild()
+		require.True(t, ok)
+		assert.Equal(t, "a", node.ID())
+
+		node, ok = cursor.NextSibling()
+		require.True(t, ok)
+		assert.Equal(t, "b", node.ID())
+		_, ok = cursor.NextSibling()
+		assert.False(t, ok)
+
+		node, ok = cursor.FirstChild()
+		require.True(t, ok)
+		assert.Equal(t, "b1", node.ID())
+		_, ok = cursor.FirstChild()
+		assert.False(t, ok)
+
+		node, ok = cursor.Parent()
+		require.True(t, ok)
+		assert.Equal(t, "b", node.ID())
+
+		current, ok := cursor.Current()
+		require.True(t, ok)
+		assert.Equal(t, "b", current.ID())
+
+		// next resumes from the current position
+		node, ok = cursor.Next()
+		require.True(t, ok)
+		assert.Equal(t, "b1", node.ID())
+		_, ok = cursor.Next()
+		assert.False(t, ok)
+	})
+	t.Run("unknown start", func(t *testing.T) {
+		_, ok := tree.Cursor(NewNode("rogue", "rogue"))
+		assert.False(t, ok)
+	})
+	t.Run("deleted current node", func(t *testing.T) {
+		cursor, ok := tree.Cursor(b)
+		require.True(t, ok)
+		_, ok = cursor.Next()
+		require.True(t, ok)
+
+		require.NoError(t, tree.Delete(b))
+		_, ok = cursor.Current()
+		assert.False(t, ok)
+		_, ok = cursor.Next()
+		assert.False(t, ok)
+	})
+}
+
+func TestDescendantsPage(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("a1", ""), "a"))
+	require.NoError(t, tree.AddByID(NewNode("a2", ""), "a"))
+	require.NoError(t, tree.AddByID(NewNode("b1", ""), "b"))
+
+	pageIDs := func(page []Node[string]) []string {
+		ids := make([]string, len(page))
+		for i, node := range page {
+			ids[i] = node.ID()
+		}
+		return ids
+	}
+
+	t.Run("pages through the descendants", func(t *testing.T) {
+		var (
+			pages  [][]string
+			cursor string
+		)
+		for {
+			page, next, ok := tree.DescendantsPage(NewNode("root", ""), cursor, 2)
+			require.True(t, ok)
+			pages = append(pages, pageIDs(page))
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		assert.Equal(t, [][]string{{"a", "a1"}, {"a2", "b"}, {"b1"}}, pages)
+	})
+
+	t.Run("resumes after concurrent changes", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", ""), "a"))
+
+		page, next, ok := tree.DescendantsPage(NewNode("root", ""), "", 2)
+		require.True(t, ok)
+		assert.Equal(t, []string{"a", "a1"}, pageIDs(page))
+
+		require.NoError(t, tree.AddByID(NewNode("a2", ""), "a"))
+		page, next, ok = tree.DescendantsPage(NewNode("root", ""), next, 5)
+		require.True(t, ok)
+		assert.Equal(t, []string{"a2", "b"}, pageIDs(page))
+		assert.Empty(t, next)
+	})
+
+	t.Run("pages a subtree", func(t *testing.T) {
+		page, next, ok := tree.DescendantsPage(NewNode("a", ""), "", 10)
+		require.True(t, ok)
+		assert.Equal(t, []string{"a1", "a2"}, pageIDs(page))
+		assert.Empty(t, next)
+
+		page, _, ok = tree.DescendantsPage(NewNode("b1", ""), "", 10)
+		require.True(t, ok)
+		assert.Empty(t, page)
+	})
+
+	t.Run("rejects invalid cursors", func(t *testing.T) {
+		_, next, ok := tree.DescendantsPage(NewNode("root", ""), "", 1)
+		require.True(t, ok)
+
+		// the cursor designates a node outside of the subtree
+		_, _, ok = tree.DescendantsPage(NewNode("b", ""), next, 1)
+		assert.False(t, ok)
+		_, _, ok = tree.DescendantsPage(NewNode("root", ""), "%%%", 1)
+		assert.False(t, ok)
+		_, _, ok = tree.DescendantsPage(NewNode("missing", ""), "", 1)
+		assert.False(t, ok)
+	})
+}
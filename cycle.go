@@ -0,0 +1,87 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import "fmt"
+
+// errCycle is the cause of the operations rejected because they would make a Node its own ancestor
+var errCycle = fmt.Errorf("%w: %w", ErrInvalidOperation, ErrCycleDetected)
+
+// cycleGuard keeps a walk of the Tree from looping forever over a corrupted hierarchy in which
+// a Node is its own descendant.
+//
+// An exact guard remembers every visited Node so that none is visited twice, which the walks
+// removing the Nodes require. Otherwise, since a walk of a consistent Tree
+// visits each Node once, the guard lets as many Nodes as the Tree holds go through for free and only
+// starts remembering the visited Nodes past that budget, which keeps the read walks allocation free
+// at the cost of a few Nodes visited twice when a cycle exists.
+type cycleGuard[T any] struct {
+	tree   *Tree[T]
+	budget int64
+	seen   map[*treeNode[T]]struct{}
+}
+
+// newCycleGuard creates a cycleGuard for a walk starting at the given treeNode
+func (x *Tree[T]) newCycleGuard(exact bool, start *treeNode[T]) *cycleGuard[T] {
+	guard := &cycleGuard[T]{tree: x}
+	if !exact {
+		guard.budget = x.size.Load() + 1
+		return guard
+	}
+	guard.seen = map[*treeNode[T]]struct{}{start: {}}
+	return guard
+}
+
+// newPathGuard creates an exact cycleGuard for a step down from the given treeNode, the treeNode
+// and its ancestors being already visited, so that a child being one of them is reported as a cycle.
+// It suits the walks taking one step at a time, such as the Cursor, which go up and down the Tree
+func (x *Tree[T]) newPathGuard(node *treeNode[T]) *cycleGuard[T] {
+	guard := x.newCycleGuard(true, node)
+	for link := node.GetPath(); link != nil; link = link.parent {
+		if ancestor, ok := x.getNode(link.id); ok {
+			guard.seen[ancestor] = struct{}{}
+		}
+	}
+	return guard
+}
+
+// visit states whether the walk can go down to the given child of the given parent.
+// It reports the cycle as an invariant violation when the child has already been visited
+func (g *cycleGuard[T]) visit(parent, child *treeNode[T]) bool {
+	if g.budget > 0 {
+		g.budget--
+		return true
+	}
+
+	if g.seen == nil {
+		g.seen = make(map[*treeNode[T]]struct{})
+	}
+	if _, ok := g.seen[child]; ok {
+		g.tree.invariant("%w: node %q is reachable more than once under %q", ErrCycleDetected, child.ID, parent.ID)
+		return false
+	}
+	g.seen[child] = struct{}{}
+	return true
+}
@@ -0,0 +1,120 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCycleGuard(t *testing.T) {
+	t.Run("rejects the moves and links creating a cycle", func(t *testing.T) {
+		tree := NewTree[string](WithMultiParent())
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", ""), "a"))
+
+		err := tree.MoveByID("a", "b")
+		assert.ErrorIs(t, err, ErrCycleDetected)
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+
+		a, _ := tree.Find("a")
+		b, _ := tree.Find("b")
+		err = tree.Link(a, b)
+		assert.ErrorIs(t, err, ErrCycleDetected)
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+	})
+
+	t.Run("stops the walks of a corrupted tree", func(t *testing.T) {
+		tree := newValidateTestTree(t)
+		node2, _ := tree.getNode("node2")
+		node2.Descendants.Append(tree.rootNode.Load())
+
+		root := tree.Root()
+		_, ok := tree.Descendants(root)
+		assert.True(t, ok)
+		assert.True(t, tree.Accept(root, &printer{}))
+		assert.NotEmpty(t, tree.Snapshot().Records)
+
+		require.NoError(t, tree.DeleteByID("node1"))
+		assert.Zero(t, tree.Size())
+	})
+
+	t.Run("stops the dumps, queries and traversals of a corrupted tree", func(t *testing.T) {
+		tree := newValidateTestTree(t)
+		node2, _ := tree.getNode("node2")
+		node2.Descendants.Append(tree.rootNode.Load())
+		size := int(tree.Size())
+
+		assert.NotEmpty(t, tree.Dump())
+		assert.NotEmpty(t, tree.Query().Run())
+		assert.NotEmpty(t, tree.Glob("/**"))
+		count, ok := Aggregate(tree, tree.Root(), 0, func(count int, _ Node[string]) int { return count + 1 })
+		assert.True(t, ok)
+		assert.GreaterOrEqual(t, count, size)
+
+		cursor, ok := tree.Cursor(tree.Root())
+		require.True(t, ok)
+		visited := 0
+		for _, ok := cursor.Next(); ok; _, ok = cursor.Next() {
+			visited++
+			require.LessOrEqual(t, visited, size)
+		}
+		assert.Equal(t, size, visited)
+	})
+
+	t.Run("panics on the dumps and queries of a corrupted tree in strict mode", func(t *testing.T) {
+		tree := NewTree[string](WithMode(StrictMode))
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		a, _ := tree.getNode("a")
+		a.Descendants.Append(tree.rootNode.Load())
+
+		assert.Panics(t, func() { tree.Dump() })
+		assert.Panics(t, func() { tree.Query().Run() })
+
+		cursor, ok := tree.Cursor(tree.Root())
+		require.True(t, ok)
+		_, _ = cursor.Next()
+		_, _ = cursor.FirstChild()
+		assert.PanicsWithError(t, `tree invariant violated: cycle detected: node "root" is reachable more than once under "a"`, func() {
+			cursor.FirstChild()
+		})
+	})
+
+	t.Run("panics in strict mode", func(t *testing.T) {
+		tree := NewTree[string](WithMode(StrictMode))
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		a, _ := tree.getNode("a")
+		a.Descendants.Append(tree.rootNode.Load())
+
+		assert.PanicsWithError(t, `tree invariant violated: cycle detected: node "root" is reachable more than once under "a"`, func() {
+			tree.Descendants(tree.Root())
+		})
+	})
+}
@@ -0,0 +1,279 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Delta holds the Nodes changed between two sequence numbers of a Tree. Applied to a Tree restored
+// from the snapshot taken at From, or brought up to date with the previous deltas, it yields the
+// Tree as of Seq.
+type Delta[T any] struct {
+	// From is the sequence number the delta follows
+	From uint64
+	// Seq is the sequence number of the last change captured by the delta
+	Seq uint64
+	// Records holds the Nodes added, updated, moved or annotated since From, with their current value,
+	// parent, edge, metadata, tags and links. A parent is always recorded before its children.
+	Records []Record[T]
+	// Deleted holds the identifiers of the Nodes deleted since From
+	Deleted []string
+}
+
+// Snapshot captures every Node of the Tree along with the sequence number of the last change applied.
+//
+// It is the base on which the deltas returned by SnapshotSince are applied.
+//
+// Returns:
+//   - Snapshot[T]: The Nodes of the Tree in depth-first order.
+//
+// Notes:
+//   - Snapshot blocks the changes of the Tree while the Nodes are captured.
+//
+// Example usage:
+//
+//	base := tree.Snapshot()
+//	// ...
+//	delta, err := tree.SnapshotSince(base.Seq)
+func (x *Tree[T]) Snapshot() Snapshot[T] {
+	return x.snapshot()
+}
+
+// Restore replaces the Nodes of the Tree with the ones of the given snapshot.
+//
+// Parameters:
+//   - snapshot: The snapshot, as returned by Snapshot or loaded from a Storage.
+//
+// Returns:
+//   - err: An error raised while adding the Nodes, in which case the Tree holds the Nodes added so far.
+//
+// Notes:
+//   - The Tree must not be changed while it is restored.
+//   - The hooks, the watchers and the Storage are notified of the restored Nodes.
+//
+// Example usage:
+//
+//	replica := NewTree[string]()
+//	if err := replica.Restore(base); err != nil {
+//	    log.Fatal("Cannot restore the tree:", err)
+//	}
+func (x *Tree[T]) Restore(snapshot Snapshot[T]) error {
+	err := x.requireContext(opLoad, "", "")
+	if err == nil {
+		err = x.restore(context.Background(), snapshot.Records)
+	}
+	if err != nil {
+		return fmt.Errorf("gotree: cannot restore snapshot %d: %w", snapshot.Seq, err)
+	}
+	return nil
+}
+
+// SnapshotSince captures the Nodes changed after the given sequence number.
+//
+// Instead of every Node, the delta only holds the Nodes added, updated or moved since the sequence
+// number, along with the identifiers of the deleted ones, which makes frequent snapshots of large
+// Trees affordable: save a full Snapshot from time to time and the deltas in between.
+//
+// It relies on the change feed, enabled with WithChangeFeed, whose size bounds how far back a
+// delta can go.
+//
+// Parameters:
+//   - seq: The sequence number of the base snapshot or of the previous delta.
+//
+// Returns:
+//   - delta: The Nodes changed after the sequence number.
+//   - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The delta was captured.
+//   - ErrSequenceExpired: Some of the changes following the sequence number are no longer
+//     retained, a full Snapshot is required.
+//   - ErrInvalidOperation: The change feed is not enabled.
+//
+// Notes:
+//   - SnapshotSince blocks the changes of the Tree while the Nodes are captured.
+//   - The children added or moved by the delta are appended to their parent, hence the order
+//     of the children may differ once the delta is applied.
+//
+// Example usage:
+//
+//	tree := NewTree[string](WithChangeFeed(1_000_000))
+//	base := tree.Snapshot()
+//	// ...
+//	delta, err := tree.SnapshotSince(base.Seq)
+//	if errors.Is(err, ErrSequenceExpired) {
+//	    base = tree.Snapshot()
+//	}
+func (x *Tree[T]) SnapshotSince(seq uint64) (delta Delta[T], err error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	events, err := x.ChangesSince(seq)
+	if err != nil {
+		return Delta[T]{}, err
+	}
+
+	delta = Delta[T]{From: seq, Seq: seq}
+	changed := make(map[string]struct{})
+	deleted := make(map[string]struct{})
+	var ids []string
+	for _, event := range events {
+		delta.Seq = event.Seq
+		id := event.Node.ID()
+		if _, ok := changed[id]; !ok {
+			if _, ok := deleted[id]; !ok {
+				ids = append(ids, id)
+			}
+		}
+
+		if event.Type == EventDelete {
+			deleted[id] = struct{}{}
+		} else {
+			changed[id] = struct{}{}
+		}
+	}
+
+	depths := make(map[string]int, len(changed))
+	for _, id := range ids {
+		value, ok := x.nodes.Load(id)
+		if !ok {
+			if _, ok := deleted[id]; ok {
+				delta.Deleted = append(delta.Deleted, id)
+			}
+			continue
+		}
+
+		node := value
+		if _, ok := changed[id]; !ok {
+			continue
+		}
+
+		var parentID string
+		if parent, ok := x.parentNode(id); ok {
+			parentID = parent.ID
+		}
+		depths[id] = node.GetPath().Depth()
+		delta.Records = append(delta.Records, x.record(node, parentID))
+	}
+
+	// the parents are recorded before their children
+	slices.SortStableFunc(delta.Records, func(a, b Record[T]) int {
+		return depths[a.ID] - depths[b.ID]
+	})
+	return delta, nil
+}
+
+// ApplyDelta brings the Tree up to date with the given delta, returned by SnapshotSince.
+//
+// The Nodes of the delta are added, moved under their recorded parent and updated as needed,
+// and the deleted Nodes are then removed along with their descendants.
+//
+// Parameters:
+//   - delta: The delta following the snapshot the Tree was restored from, or the previous delta.
+//
+// Returns:
+//   - err: An error raised while applying the delta, in which case the Tree holds the
+//     changes applied so far.
+//
+// Notes:
+//   - The deltas must be applied in order, starting from the snapshot the Tree was restored from.
+//     Applying a delta twice is harmless.
+//   - The hooks, the watchers and the Storage are notified of the applied changes.
+//
+// Example usage:
+//
+//	replica := NewTree[string]()
+//	_ = replica.Restore(base)
+//	for _, delta := range deltas {
+//	    if err := replica.ApplyDelta(delta); err != nil {
+//	        log.Fatal("Cannot apply the delta:", err)
+//	    }
+//	}
+func (x *Tree[T]) ApplyDelta(delta Delta[T]) error {
+	if err := x.requireContext(opLoad, "", ""); err != nil {
+		return fmt.Errorf("gotree: cannot apply delta %d: %w", delta.Seq, err)
+	}
+
+	for _, record := range delta.Records {
+		if err := x.applyRecord(record); err != nil {
+			return fmt.Errorf("gotree: cannot apply delta %d: %w", delta.Seq, err)
+		}
+	}
+
+	// the linked parents may be recorded after the Nodes linked under them
+	for _, record := range delta.Records {
+		if err := x.relink(record); err != nil {
+			return fmt.Errorf("gotree: cannot apply delta %d: %w", delta.Seq, err)
+		}
+	}
+
+	for _, id := range delta.Deleted {
+		// the descendants of a deleted Node are already gone
+		if err := x.deleteContext(context.Background(), id); err != nil && !errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("gotree: cannot apply delta %d: %w", delta.Seq, err)
+		}
+	}
+	return nil
+}
+
+// applyRecord adds, moves and updates the Node of the given record
+func (x *Tree[T]) applyRecord(record Record[T]) error {
+	// a new root means that the former Tree has been deleted
+	if root := x.rootNode.Load(); record.ParentID == "" && root != nil && root.ID != record.ID {
+		if err := x.resetContext(context.Background(), newResetConfig()); err != nil {
+			return err
+		}
+	}
+
+	node, ok := x.nodes.Load(record.ID)
+	if !ok {
+		var edge *EdgeData
+		if record.Edge != (EdgeData{}) {
+			edge = &record.Edge
+		}
+		if err := x.addByIDContext(context.Background(), NewNode(record.ID, record.Value), record.ParentID, edge); err != nil {
+			return err
+		}
+		return x.annotate(record)
+	}
+
+	if parent, ok := x.parentNode(record.ID); ok && parent.ID != record.ParentID {
+		if err := x.moveContext(context.Background(), record.ID, record.ParentID); err != nil {
+			return err
+		}
+	}
+	if err := x.updateContext(context.Background(), NewNode(record.ID, record.Value)); err != nil {
+		return err
+	}
+
+	if record.ParentID != "" && node.GetEdge() != record.Edge {
+		if err := x.setEdge(record.ID, record.Edge); err != nil {
+			return err
+		}
+	}
+	return x.annotate(record)
+}
@@ -0,0 +1,174 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotSince(t *testing.T) {
+	// state returns the parent and the value of every Node of the given Tree
+	state := func(tree *Tree[string]) map[string][2]string {
+		nodes := make(map[string][2]string)
+		for _, record := range tree.Snapshot().Records {
+			nodes[record.ID] = [2]string{record.ParentID, record.Value}
+		}
+		return nodes
+	}
+
+	newTree := func(t *testing.T) *Tree[string] {
+		t.Helper()
+		tree := NewTree[string](WithChangeFeed(100))
+		require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "A"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "B"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", "A1"), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a2", "A2"), "a"))
+		require.NoError(t, tree.AddByID(NewNode("b1", "B1"), "b"))
+		return tree
+	}
+
+	t.Run("captures only the changed nodes", func(t *testing.T) {
+		tree := newTree(t)
+		base := tree.Snapshot()
+		assert.EqualValues(t, 6, base.Seq)
+		assert.Len(t, base.Records, 6)
+
+		require.NoError(t, tree.Update(NewNode("b1", "B1'")))
+		require.NoError(t, tree.AddByID(NewNode("c", "C"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("c1", "C1"), "c"))
+		require.NoError(t, tree.MoveByID("a1", "c1"))
+		require.NoError(t, tree.DeleteByID("a"))
+
+		delta, err := tree.SnapshotSince(base.Seq)
+		require.NoError(t, err)
+		assert.EqualValues(t, 6, delta.From)
+		assert.Equal(t, tree.LastSeq(), delta.Seq)
+		assert.Equal(t, []Record[string]{
+			{ID: "c", ParentID: "root", Value: "C"},
+			{ID: "b1", ParentID: "b", Value: "B1'"},
+			{ID: "c1", ParentID: "c", Value: "C1"},
+			{ID: "a1", ParentID: "c1", Value: "A1"},
+		}, delta.Records)
+		assert.ElementsMatch(t, []string{"a", "a2"}, delta.Deleted)
+
+		replica := NewTree[string]()
+		require.NoError(t, replica.Restore(base))
+		require.NoError(t, replica.ApplyDelta(delta))
+		assert.Equal(t, state(tree), state(replica))
+		assert.Empty(t, replica.Validate())
+
+		// applying a delta twice is harmless
+		require.NoError(t, replica.ApplyDelta(delta))
+		assert.Equal(t, state(tree), state(replica))
+	})
+
+	t.Run("applies the deltas in sequence", func(t *testing.T) {
+		tree := newTree(t)
+		base := tree.Snapshot()
+		replica := NewTree[string]()
+		require.NoError(t, replica.Restore(base))
+
+		seq := base.Seq
+		steps := []func(){
+			func() { require.NoError(t, tree.MoveByID("b", "a1")) },
+			func() {
+				require.NoError(t, tree.DeleteByID("a1"))
+				require.NoError(t, tree.AddByID(NewNode("a1", "New A1"), "root"))
+			},
+			func() {},
+		}
+		for _, step := range steps {
+			step()
+			delta, err := tree.SnapshotSince(seq)
+			require.NoError(t, err)
+			require.NoError(t, replica.ApplyDelta(delta))
+			assert.Equal(t, state(tree), state(replica))
+			seq = delta.Seq
+		}
+	})
+
+	t.Run("carries the edges, metadata, tags and links", func(t *testing.T) {
+		tree := NewTree[string](WithChangeFeed(100), WithMultiParent())
+		require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "A"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "B"), "root"))
+		require.NoError(t, tree.SetMeta("a", "owner", "alice"))
+		base := tree.Snapshot()
+
+		replica := NewTree[string](WithMultiParent())
+		require.NoError(t, replica.Restore(base))
+		assert.Equal(t, base.Records, replica.Snapshot().Records)
+
+		a, _ := tree.Find("a")
+		require.NoError(t, tree.AddWithEdge(NewNode("a1", "A1"), a, EdgeData{Label: "first"}))
+		require.NoError(t, tree.DeleteMeta("a", "owner"))
+		require.NoError(t, tree.Tag("b", "critical"))
+		require.NoError(t, tree.Link(NewNode("a1", ""), NewNode("b", "")))
+		delta, err := tree.SnapshotSince(base.Seq)
+		require.NoError(t, err)
+		require.NoError(t, replica.ApplyDelta(delta))
+		assert.Equal(t, tree.Snapshot().Records, replica.Snapshot().Records)
+
+		require.NoError(t, tree.Unlink(NewNode("a1", ""), NewNode("b", "")))
+		require.NoError(t, tree.SetEdge(NewNode("a1", ""), EdgeData{Label: "second"}))
+		next, err := tree.SnapshotSince(delta.Seq)
+		require.NoError(t, err)
+		require.NoError(t, replica.ApplyDelta(next))
+		assert.Equal(t, tree.Snapshot().Records, replica.Snapshot().Records)
+	})
+
+	t.Run("replaces the root", func(t *testing.T) {
+		tree := newTree(t)
+		base := tree.Snapshot()
+		tree.Reset()
+		require.NoError(t, tree.AddByID(NewNode("a", "New Root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("x", "X"), "a"))
+
+		delta, err := tree.SnapshotSince(base.Seq)
+		require.NoError(t, err)
+
+		replica := NewTree[string]()
+		require.NoError(t, replica.Restore(base))
+		require.NoError(t, replica.ApplyDelta(delta))
+		assert.Equal(t, state(tree), state(replica))
+	})
+
+	t.Run("returns an error when the changes are no longer retained", func(t *testing.T) {
+		tree := NewTree[string](WithChangeFeed(2))
+		require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "A"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "B"), "root"))
+
+		_, err := tree.SnapshotSince(0)
+		assert.ErrorIs(t, err, ErrSequenceExpired)
+
+		_, err = NewTree[string]().SnapshotSince(0)
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+	})
+}
@@ -0,0 +1,139 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import "slices"
+
+// Diameter returns the number of edges of the longest path between two Nodes of the Tree.
+//
+// The longest path does not necessarily go through the root: it links the two Nodes that are
+// the farthest apart, whatever their lowest common ancestor.
+//
+// Returns:
+//   - int: The number of edges of the longest path. It is zero for an empty Tree or a single Node.
+//
+// Notes:
+//   - Diameter blocks the structural changes of the Tree while it walks the Nodes.
+//
+// Example usage:
+//
+//	fmt.Println("Longest path:", tree.Diameter())
+func (x *Tree[T]) Diameter() int {
+	_, eccentricities := x.eccentricities()
+	if len(eccentricities) == 0 {
+		return 0
+	}
+	// the eccentricity of the ends of the longest path is the diameter
+	return slices.Max(eccentricities)
+}
+
+// Center returns the Nodes minimizing the distance to the farthest Node of the Tree.
+//
+// The center of a Tree is made of either one Node or two adjacent Nodes, lying in the middle of
+// every longest path. It is the best place for a Node to reach all the others in as few hops as possible.
+//
+// Returns:
+//   - []Node[T]: The Nodes of the center in depth-first order, the parent first. It is empty for an empty Tree.
+//
+// Notes:
+//   - Center blocks the structural changes of the Tree while it walks the Nodes.
+//
+// Example usage:
+//
+//	for _, node := range tree.Center() {
+//	    fmt.Println("Center:", node.ID())
+//	}
+func (x *Tree[T]) Center() []Node[T] {
+	records, eccentricities := x.eccentricities()
+	if len(eccentricities) == 0 {
+		return nil
+	}
+
+	radius := slices.Min(eccentricities)
+	var center []Node[T]
+	for i, eccentricity := range eccentricities {
+		if eccentricity != radius {
+			continue
+		}
+		if node, ok := x.Find(records[i].ID); ok {
+			center = append(center, node)
+		}
+	}
+	return center
+}
+
+// eccentricities returns the records of the Tree in depth-first order along with the distance
+// from every Node to the farthest one
+func (x *Tree[T]) eccentricities() ([]Record[T], []int) {
+	records := x.snapshot().Records
+	positions := make(map[string]int, len(records))
+	parents := make([]int, len(records))
+	for i, record := range records {
+		positions[record.ID] = i
+		parents[i] = -1
+		if parent, ok := positions[record.ParentID]; ok {
+			parents[i] = parent
+		}
+	}
+
+	// compute the two longest downward paths of every Node from the leaves up:
+	// the children follow their parent in depth-first order
+	longest := make([]int, len(records))
+	second := make([]int, len(records))
+	via := make([]int, len(records))
+	for i := range via {
+		via[i] = -1
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		parent := parents[i]
+		if parent < 0 {
+			continue
+		}
+
+		switch height := longest[i] + 1; {
+		case height > longest[parent]:
+			second[parent] = longest[parent]
+			longest[parent], via[parent] = height, i
+		case height > second[parent]:
+			second[parent] = height
+		}
+	}
+
+	// compute the longest upward path of every Node from the root down, which either climbs further
+	// or goes down another branch of the parent
+	upward := make([]int, len(records))
+	eccentricities := make([]int, len(records))
+	for i := range records {
+		if parent := parents[i]; parent >= 0 {
+			sibling := longest[parent]
+			if via[parent] == i {
+				sibling = second[parent]
+			}
+			upward[i] = max(upward[parent], sibling) + 1
+		}
+		eccentricities[i] = max(longest[i], upward[i])
+	}
+	return records, eccentricities
+}
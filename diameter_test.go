@@ -0,0 +1,86 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiameter(t *testing.T) {
+	t.Run("measures an empty tree", func(t *testing.T) {
+		tree := NewTree[string]()
+		assert.Zero(t, tree.Diameter())
+		assert.Empty(t, tree.Center())
+	})
+
+	t.Run("measures a single node", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		assert.Zero(t, tree.Diameter())
+		assert.Equal(t, []string{"root"}, centerIDs(tree))
+	})
+
+	t.Run("finds a path away from the root", func(t *testing.T) {
+		// root - a - a1 - a11 - a111
+		//          \ a2 - a21 - a211
+		//   \ b
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", ""), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a2", ""), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a11", ""), "a1"))
+		require.NoError(t, tree.AddByID(NewNode("a111", ""), "a11"))
+		require.NoError(t, tree.AddByID(NewNode("a21", ""), "a2"))
+		require.NoError(t, tree.AddByID(NewNode("a211", ""), "a21"))
+
+		assert.Equal(t, 6, tree.Diameter())
+		assert.Equal(t, []string{"a"}, centerIDs(tree))
+	})
+
+	t.Run("finds two adjacent centers", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", ""), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a11", ""), "a1"))
+
+		assert.Equal(t, 3, tree.Diameter())
+		assert.Equal(t, []string{"a", "a1"}, centerIDs(tree))
+	})
+}
+
+// centerIDs returns the identifiers of the center of the given tree
+func centerIDs(tree *Tree[string]) []string {
+	var ids []string
+	for _, node := range tree.Center() {
+		ids = append(ids, node.ID())
+	}
+	return ids
+}
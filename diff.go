@@ -0,0 +1,269 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ChangeKind defines the kinds of change of a Node between two Trees. The kinds are flags:
+// a Node can be both moved and updated.
+type ChangeKind uint8
+
+const (
+	// ChangeAdded describes a Node missing from the base Tree
+	ChangeAdded ChangeKind = 1 << iota
+	// ChangeRemoved describes a Node missing from the target Tree
+	ChangeRemoved
+	// ChangeMoved describes a Node whose parent differs
+	ChangeMoved
+	// ChangeUpdated describes a Node whose value differs
+	ChangeUpdated
+)
+
+// Has states whether the ChangeKind includes the given kind
+func (k ChangeKind) Has(kind ChangeKind) bool {
+	return k&kind != 0
+}
+
+// String returns the names of the kinds, joined with a plus sign
+func (k ChangeKind) String() string {
+	var names []string
+	for _, kind := range []struct {
+		kind ChangeKind
+		name string
+	}{{ChangeAdded, "added"}, {ChangeRemoved, "removed"}, {ChangeMoved, "moved"}, {ChangeUpdated, "updated"}} {
+		if k.Has(kind.kind) {
+			names = append(names, kind.name)
+		}
+	}
+	if len(names) == 0 {
+		return "unchanged"
+	}
+	return strings.Join(names, "+")
+}
+
+// NodeChange describes the change of a Node between two Trees
+type NodeChange[T any] struct {
+	// Kind is the kind of change
+	Kind ChangeKind
+	// ID is the identifier of the Node
+	ID string
+	// Path holds the identifiers of the Nodes from the root down to the Node, in the target
+	// Tree or, for a removed Node, in the base Tree
+	Path []string
+	// OldParent is the identifier of the parent in the base Tree, empty for the root or an added Node
+	OldParent string
+	// NewParent is the identifier of the parent in the target Tree, empty for the root or a removed Node
+	NewParent string
+	// OldValue is the value in the base Tree, the zero value for an added Node
+	OldValue T
+	// NewValue is the value in the target Tree, the zero value for a removed Node
+	NewValue T
+}
+
+// Changeset holds the changes turning a base Tree into a target Tree
+type Changeset[T any] struct {
+	// Changes holds the changed Nodes in hierarchical order: a Node is listed after its ancestors
+	Changes []NodeChange[T]
+}
+
+// Diff compares the given Trees and returns the changes turning the base Tree into the target Tree.
+//
+// The Nodes are matched by identifier: a Node is added or removed when it is missing from one
+// of the Trees, moved when its parent differs and updated when its value differs.
+//
+// Parameters:
+//   - base: The Tree before the changes.
+//   - target: The Tree after the changes.
+//   - equal: The function comparing the values. A nil function compares them with reflect.DeepEqual.
+//
+// Returns:
+//   - Changeset[T]: The changes, empty when the Trees hold the same hierarchy and values.
+//
+// Notes:
+//   - The order of the children is not compared.
+//
+// Example usage:
+//
+//	changes := Diff(before, after, nil)
+//	_ = RenderDiff(os.Stdout, changes)
+func Diff[T any](base, target *Tree[T], equal func(a, b T) bool) Changeset[T] {
+	if equal == nil {
+		equal = func(a, b T) bool { return reflect.DeepEqual(a, b) }
+	}
+
+	before, after := indexRecords(base.snapshot()), indexRecords(target.snapshot())
+	var changes []NodeChange[T]
+	for id, record := range after {
+		change := NodeChange[T]{ID: id, Path: recordPath(after, id), NewParent: record.ParentID, NewValue: record.Value}
+		old, ok := before[id]
+		if !ok {
+			change.Kind = ChangeAdded
+			changes = append(changes, change)
+			continue
+		}
+
+		change.OldParent, change.OldValue = old.ParentID, old.Value
+		if old.ParentID != record.ParentID {
+			change.Kind |= ChangeMoved
+		}
+		if !equal(old.Value, record.Value) {
+			change.Kind |= ChangeUpdated
+		}
+		if change.Kind != 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	for id, record := range before {
+		if _, ok := after[id]; !ok {
+			changes = append(changes, NodeChange[T]{Kind: ChangeRemoved, ID: id, Path: recordPath(before, id), OldParent: record.ParentID, OldValue: record.Value})
+		}
+	}
+
+	sortChanges(changes)
+	return Changeset[T]{Changes: changes}
+}
+
+// RenderDiff writes a textual report of the given changes, meant for code-review-style inspection.
+//
+// The report starts with a summary of the changes, followed by one line per changed Node, indented
+// by its depth and prefixed like a unified diff: "+" for an added Node, "-" for a removed one, "~" for
+// an updated one and ">" for a moved one. The unchanged ancestors of the changed Nodes are rendered as
+// context lines, prefixed with a space.
+//
+// Parameters:
+//   - w: The writer receiving the report.
+//   - cs: The changes, as returned by Diff.
+//
+// Returns:
+//   - err: The error of the writer, if any.
+//
+// Example output:
+//
+//	@@ 1 added, 2 removed, 1 moved, 1 updated @@
+//	  root
+//	~   a: A -> A'
+//	-     a2: A2
+//	>     b1: B1 (moved from b)
+//	-   b: B
+//	+   c: C
+func RenderDiff[T any](w io.Writer, cs Changeset[T]) error {
+	changes := slices.Clone(cs.Changes)
+	sortChanges(changes)
+
+	counts := make(map[ChangeKind]int)
+	for _, change := range changes {
+		for _, kind := range []ChangeKind{ChangeAdded, ChangeRemoved, ChangeMoved, ChangeUpdated} {
+			if change.Kind.Has(kind) {
+				counts[kind]++
+			}
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "@@ %d added, %d removed, %d moved, %d updated @@\n",
+		counts[ChangeAdded], counts[ChangeRemoved], counts[ChangeMoved], counts[ChangeUpdated])
+
+	var printed []string
+	for _, change := range changes {
+		path := change.Path
+		if len(path) == 0 {
+			path = []string{change.ID}
+		}
+
+		// the ancestors that have not been rendered yet are rendered as context
+		common := 0
+		for common < len(printed) && common < len(path)-1 && printed[common] == path[common] {
+			common++
+		}
+		for depth := common; depth < len(path)-1; depth++ {
+			fmt.Fprintf(out, "  %s%s\n", strings.Repeat("  ", depth), path[depth])
+		}
+
+		indent := strings.Repeat("  ", len(path)-1)
+		switch {
+		case change.Kind.Has(ChangeAdded):
+			fmt.Fprintf(out, "+ %s%s: %v\n", indent, change.ID, change.NewValue)
+		case change.Kind.Has(ChangeRemoved):
+			fmt.Fprintf(out, "- %s%s: %v\n", indent, change.ID, change.OldValue)
+		default:
+			prefix, line := "~", change.ID
+			if change.Kind.Has(ChangeUpdated) {
+				line += fmt.Sprintf(": %v -> %v", change.OldValue, change.NewValue)
+			} else {
+				line += fmt.Sprintf(": %v", change.NewValue)
+			}
+			if change.Kind.Has(ChangeMoved) {
+				prefix = ">"
+				line += fmt.Sprintf(" (moved from %s)", parentLabel(change.OldParent))
+			}
+			fmt.Fprintf(out, "%s %s%s\n", prefix, indent, line)
+		}
+		printed = path
+	}
+	return out.Flush()
+}
+
+// indexRecords returns the given snapshot records keyed by identifier
+func indexRecords[T any](snapshot Snapshot[T]) map[string]Record[T] {
+	records := make(map[string]Record[T], len(snapshot.Records))
+	for _, record := range snapshot.Records {
+		records[record.ID] = record
+	}
+	return records
+}
+
+// recordPath returns the identifiers of the Nodes from the root down to the given Node
+func recordPath[T any](records map[string]Record[T], id string) []string {
+	var path []string
+	for current, ok := id, true; ok; current = records[current].ParentID {
+		path = append(path, current)
+		_, ok = records[records[current].ParentID]
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// sortChanges sorts the given changes in hierarchical order
+func sortChanges[T any](changes []NodeChange[T]) {
+	slices.SortStableFunc(changes, func(a, b NodeChange[T]) int {
+		return slices.Compare(a.Path, b.Path)
+	})
+}
+
+// parentLabel returns the given parent identifier, or a placeholder for the root
+func parentLabel(parent string) string {
+	if parent == "" {
+		return "<root>"
+	}
+	return parent
+}
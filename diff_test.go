@@ -0,0 +1,107 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	newTree := func(t *testing.T) *Tree[string] {
+		t.Helper()
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "A"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "B"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", "A1"), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a2", "A2"), "a"))
+		require.NoError(t, tree.AddByID(NewNode("b1", "B1"), "b"))
+		return tree
+	}
+
+	base := newTree(t)
+	target := newTree(t)
+	require.NoError(t, target.Update(NewNode("a", "A'")))
+	require.NoError(t, target.MoveByID("b1", "a"))
+	require.NoError(t, target.DeleteByID("b"))
+	require.NoError(t, target.DeleteByID("a2"))
+	require.NoError(t, target.AddByID(NewNode("c", "C"), "root"))
+	require.NoError(t, target.AddByID(NewNode("c1", "C1"), "c"))
+
+	t.Run("computes the changes", func(t *testing.T) {
+		changes := Diff(base, target, nil)
+		require.Len(t, changes.Changes, 6)
+		assert.Equal(t, NodeChange[string]{Kind: ChangeUpdated, ID: "a", Path: []string{"root", "a"}, OldParent: "root", NewParent: "root", OldValue: "A", NewValue: "A'"}, changes.Changes[0])
+		assert.Equal(t, NodeChange[string]{Kind: ChangeMoved, ID: "b1", Path: []string{"root", "a", "b1"}, OldParent: "b", NewParent: "a", OldValue: "B1", NewValue: "B1"}, changes.Changes[2])
+		assert.Equal(t, ChangeRemoved, changes.Changes[3].Kind)
+		assert.Equal(t, "b", changes.Changes[3].ID)
+
+		assert.Empty(t, Diff(base, newTree(t), nil).Changes)
+	})
+
+	t.Run("uses the given equality", func(t *testing.T) {
+		changes := Diff(base, target, func(a, b string) bool {
+			return strings.TrimSuffix(a, "'") == strings.TrimSuffix(b, "'")
+		})
+		for _, change := range changes.Changes {
+			assert.False(t, change.Kind.Has(ChangeUpdated))
+		}
+	})
+
+	t.Run("renders the report", func(t *testing.T) {
+		var report strings.Builder
+		require.NoError(t, RenderDiff(&report, Diff(base, target, nil)))
+		assert.Equal(t, `@@ 2 added, 2 removed, 1 moved, 1 updated @@
+  root
+~   a: A -> A'
+-     a2: A2
+>     b1: B1 (moved from b)
+-   b: B
++   c: C
++     c1: C1
+`, report.String())
+	})
+
+	t.Run("returns the error of the writer", func(t *testing.T) {
+		assert.Error(t, RenderDiff(failingWriter{}, Diff(base, target, nil)))
+	})
+
+	t.Run("names the kinds", func(t *testing.T) {
+		assert.Equal(t, "moved+updated", (ChangeMoved | ChangeUpdated).String())
+		assert.Equal(t, "unchanged", ChangeKind(0).String())
+	})
+}
+
+// failingWriter is an io.Writer always failing
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
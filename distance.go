@@ -0,0 +1,110 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+// LowestCommonAncestor returns the deepest Node that is an ancestor of both given Nodes.
+//
+// A Node is considered an ancestor of itself: the lowest common ancestor of a Node and one of its
+// descendants is the Node itself.
+//
+// Parameters:
+//   - a: The first Node.
+//   - b: The second Node.
+//
+// Returns:
+//   - ancestor: The lowest common ancestor of the Nodes.
+//   - ok: false when one of the Nodes is not part of the Tree.
+//
+// Notes:
+//   - The lookup walks the ancestors of the Nodes, hence it is linear in their depth. It is
+//     logarithmic when the Tree is created with WithAncestorIndex.
+//
+// Example usage:
+//
+//	ancestor, ok := tree.LowestCommonAncestor(alice, bob)
+//	if ok {
+//	    fmt.Println("Closest common manager:", ancestor.ID())
+//	}
+func (x *Tree[T]) LowestCommonAncestor(a, b Node[T]) (ancestor Node[T], ok bool) {
+	_, _, link, ok := x.commonAncestor(a, b)
+	if !ok {
+		return nil, false
+	}
+
+	node, ok := x.getNode(link.id)
+	if !ok {
+		return nil, false
+	}
+	return node.GetValue(), true
+}
+
+// Distance returns the number of edges on the path linking the given Nodes, which goes through
+// their lowest common ancestor.
+//
+// It scores how far apart two entities are in the hierarchy, such as the organizational distance
+// between two employees.
+//
+// Parameters:
+//   - a: The first Node.
+//   - b: The second Node.
+//
+// Returns:
+//   - distance: The number of edges between the Nodes, zero when they are the same Node.
+//   - ok: false when one of the Nodes is not part of the Tree.
+//
+// Notes:
+//   - The lookup walks the ancestors of the Nodes, hence it is linear in their depth. It is
+//     logarithmic when the Tree is created with WithAncestorIndex.
+//
+// Example usage:
+//
+//	distance, ok := tree.Distance(alice, bob)
+//	if ok {
+//	    fmt.Println("Organizational distance:", distance)
+//	}
+func (x *Tree[T]) Distance(a, b Node[T]) (distance int, ok bool) {
+	first, second, common, ok := x.commonAncestor(a, b)
+	if !ok {
+		return 0, false
+	}
+	return first.Depth() + second.Depth() - 2*common.Depth(), true
+}
+
+// commonAncestor returns the ancestry links of the given Nodes along with the one of their lowest common ancestor
+func (x *Tree[T]) commonAncestor(a, b Node[T]) (first, second, common *ancestry, ok bool) {
+	nodeA, ok := x.getNode(a.ID())
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	nodeB, ok := x.getNode(b.ID())
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	first, second = nodeA.GetPath(), nodeB.GetPath()
+	common = first.Common(second)
+	return first, second, common, common != nil
+}
@@ -0,0 +1,96 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistance(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("a1", ""), "a"))
+	require.NoError(t, tree.AddByID(NewNode("a2", ""), "a"))
+	require.NoError(t, tree.AddByID(NewNode("a11", ""), "a1"))
+	require.NoError(t, tree.AddByID(NewNode("b1", ""), "b"))
+
+	t.Run("finds the lowest common ancestor", func(t *testing.T) {
+		ancestor, ok := tree.LowestCommonAncestor(NewNode("a11", ""), NewNode("a2", ""))
+		require.True(t, ok)
+		assert.Equal(t, "a", ancestor.ID())
+
+		ancestor, ok = tree.LowestCommonAncestor(NewNode("a11", ""), NewNode("b1", ""))
+		require.True(t, ok)
+		assert.Equal(t, "root", ancestor.ID())
+
+		ancestor, ok = tree.LowestCommonAncestor(NewNode("a", ""), NewNode("a11", ""))
+		require.True(t, ok)
+		assert.Equal(t, "a", ancestor.ID())
+	})
+
+	t.Run("counts the edges between nodes", func(t *testing.T) {
+		cases := []struct {
+			a, b     string
+			distance int
+		}{
+			{"a11", "a11", 0},
+			{"a11", "a1", 1},
+			{"a11", "a2", 3},
+			{"a11", "b1", 5},
+			{"root", "a11", 3},
+			{"b1", "a11", 5},
+		}
+		for _, c := range cases {
+			distance, ok := tree.Distance(NewNode(c.a, ""), NewNode(c.b, ""))
+			require.True(t, ok)
+			assert.Equal(t, c.distance, distance, "%s-%s", c.a, c.b)
+		}
+	})
+
+	t.Run("follows moves", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", ""), "a"))
+		require.NoError(t, tree.Move(NewNode("a", ""), NewNode("b", "")))
+
+		distance, ok := tree.Distance(NewNode("a1", ""), NewNode("b", ""))
+		require.True(t, ok)
+		assert.Equal(t, 2, distance)
+	})
+
+	t.Run("fails for missing nodes", func(t *testing.T) {
+		_, ok := tree.Distance(NewNode("a1", ""), NewNode("missing", ""))
+		assert.False(t, ok)
+		_, ok = tree.LowestCommonAncestor(NewNode("missing", ""), NewNode("a1", ""))
+		assert.False(t, ok)
+	})
+}
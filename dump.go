@@ -0,0 +1,108 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String returns a compact one-line summary of the Tree, implementing fmt.Stringer.
+//
+// The summary holds the size of the Tree, its depth (the root being at depth zero)
+// and the ID of the root Node, which keeps log lines readable.
+//
+// Example output:
+//
+//	Tree[size=3 depth=2 root="root"]
+//	Tree[size=0 depth=0 root=<nil>]
+func (x *Tree[T]) String() string {
+	root := x.rootNode.Load()
+	if root == nil {
+		return fmt.Sprintf("Tree[size=%d depth=0 root=<nil>]", x.Size())
+	}
+	return fmt.Sprintf("Tree[size=%d depth=%d root=%q]", x.Size(), x.depth(), root.ID)
+}
+
+// Dump returns a multi-line representation of the Tree meant for debugging.
+//
+// Every Node is rendered on its own line with its ID and value, indented by its
+// position in the hierarchy. Children are listed in insertion order. The data of
+// the edges linking a Node to its parent is rendered when set.
+//
+// Example output:
+//
+//	root: Root Node
+//	├── node1: First
+//	│   └── node2 [yes 0.8]: Second
+//	└── node3: Third
+func (x *Tree[T]) Dump() string {
+	root := x.rootNode.Load()
+	if root == nil {
+		return ""
+	}
+
+	var builder strings.Builder
+	var dump func(node *treeNode[T], prefix, indent string)
+	guard := x.newCycleGuard(false, root)
+	dump = func(node *treeNode[T], prefix, indent string) {
+		builder.WriteString(prefix)
+		builder.WriteString(node.ID)
+		if edge := node.GetEdge(); edge != (EdgeData{}) {
+			fmt.Fprintf(&builder, " [%s %g]", edge.Label, edge.Weight)
+		}
+		if value := node.GetValue(); value != nil {
+			fmt.Fprintf(&builder, ": %v", value.Value())
+		}
+		builder.WriteString("\n")
+
+		children := node.Descendants.Items()
+		for i, child := range children {
+			if !guard.visit(node, child) {
+				continue
+			}
+			if i == len(children)-1 {
+				dump(child, indent+"└── ", indent+"    ")
+				continue
+			}
+			dump(child, indent+"├── ", indent+"│   ")
+		}
+	}
+
+	dump(root, "", "")
+	return builder.String()
+}
+
+// depth returns the depth of the deepest Node of the Tree, the root being at depth zero
+func (x *Tree[T]) depth() int {
+	depth := 0
+	x.nodes.Range(func(_ string, value *treeNode[T]) bool {
+		if d := value.GetPath().Depth(); d > depth {
+			depth = d
+		}
+		return true
+	})
+	return depth
+}
@@ -0,0 +1,56 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringAndDump(t *testing.T) {
+	tree := NewTree[string]()
+	assert.Equal(t, "Tree[size=0 depth=0 root=<nil>]", tree.String())
+	assert.Empty(t, tree.Dump())
+
+	root := NewNode("root", "Root Node")
+	require.NoError(t, tree.Add(root, nil))
+	node1 := NewNode("node1", "First")
+	require.NoError(t, tree.Add(node1, root))
+	require.NoError(t, tree.AddWithEdge(NewNode("node2", "Second"), node1, EdgeData{Label: "yes", Weight: 0.8}))
+	require.NoError(t, tree.Add(NewNode("node3", "Third"), root))
+
+	assert.Equal(t, `Tree[size=4 depth=2 root="root"]`, tree.String())
+	assert.Equal(t, `Tree[size=4 depth=2 root="root"]`, fmt.Sprint(tree))
+
+	expected := `root: Root Node
+├── node1: First
+│   └── node2 [yes 0.8]: Second
+└── node3: Third
+`
+	assert.Equal(t, expected, tree.Dump())
+}
@@ -0,0 +1,138 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+)
+
+// FindDuplicateSubtrees groups the subtrees that are identical in structure and values.
+//
+// Every subtree is given a hash combining the hash of its root value with the hashes of its child
+// subtrees, so that the duplicates are found in a single pass whatever the size of the Tree. As for
+// Isomorphic, the identifiers of the Nodes and the order of the children are ignored. It supports the
+// deduplication and the compression of template-heavy hierarchies.
+//
+// Parameters:
+//   - hash: The function hashing the values. Two values are considered identical when their hashes are.
+//
+// Returns:
+//   - [][]Node[T]: The groups of roots of identical subtrees, each holding at least two Nodes in
+//     depth-first order. The groups of the largest subtrees come first.
+//
+// Notes:
+//   - Only the subtrees of at least two Nodes are considered: identical leaves are not reported.
+//   - A group is omitted when its subtrees all belong to the subtrees of other groups, hence only the
+//     outermost duplicates are reported.
+//   - FindDuplicateSubtrees blocks the structural changes of the Tree while it walks the Nodes.
+//
+// Example usage:
+//
+//	groups := tree.FindDuplicateSubtrees(func(value string) []byte { return []byte(value) })
+//	for _, group := range groups {
+//	    fmt.Println("Identical subtrees:", len(group), "rooted at", group[0].ID())
+//	}
+func (x *Tree[T]) FindDuplicateSubtrees(hash func(T) []byte) [][]Node[T] {
+	records := x.snapshot().Records
+	positions := make(map[string]int, len(records))
+	parents := make([]int, len(records))
+	children := make([][][sha256.Size]byte, len(records))
+	sizes := make([]int, len(records))
+	for i, record := range records {
+		positions[record.ID] = i
+		parents[i] = -1
+		if parent, ok := positions[record.ParentID]; ok {
+			parents[i] = parent
+		}
+	}
+
+	// hash the subtrees from the leaves up: the children follow their parent in depth-first order
+	hashes := make([][sha256.Size]byte, len(records))
+	groups := make(map[[sha256.Size]byte][]int)
+	for i := len(records) - 1; i >= 0; i-- {
+		sizes[i]++
+		slices.SortFunc(children[i], func(a, b [sha256.Size]byte) int { return bytes.Compare(a[:], b[:]) })
+
+		digest := sha256.New()
+		value := hash(records[i].Value)
+		digest.Write(binary.BigEndian.AppendUint64(nil, uint64(len(value))))
+		digest.Write(value)
+		for _, child := range children[i] {
+			digest.Write(child[:])
+		}
+		digest.Sum(hashes[i][:0])
+
+		if parent := parents[i]; parent >= 0 {
+			children[parent] = append(children[parent], hashes[i])
+			sizes[parent] += sizes[i]
+		}
+		children[i] = nil
+
+		if sizes[i] > 1 {
+			groups[hashes[i]] = append(groups[hashes[i]], i)
+		}
+	}
+
+	// duplicated states whether the subtree at the given position has an identical counterpart
+	duplicated := func(i int) bool {
+		return i >= 0 && len(groups[hashes[i]]) > 1
+	}
+
+	var found [][]int
+	for _, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+
+		nested := true
+		for _, member := range members {
+			nested = nested && duplicated(parents[member])
+		}
+		if !nested {
+			slices.Sort(members)
+			found = append(found, members)
+		}
+	}
+
+	slices.SortFunc(found, func(a, b []int) int {
+		return cmp.Or(cmp.Compare(sizes[b[0]], sizes[a[0]]), cmp.Compare(a[0], b[0]))
+	})
+
+	result := make([][]Node[T], 0, len(found))
+	for _, members := range found {
+		group := make([]Node[T], 0, len(members))
+		for _, member := range members {
+			if node, ok := x.Find(records[member].ID); ok {
+				group = append(group, node)
+			}
+		}
+		result = append(result, group)
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicateSubtrees(t *testing.T) {
+	hash := func(value string) []byte { return []byte(value) }
+	ids := func(groups [][]Node[string]) [][]string {
+		result := make([][]string, len(groups))
+		for i, group := range groups {
+			for _, node := range group {
+				result[i] = append(result[i], node.ID())
+			}
+		}
+		return result
+	}
+
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", "Org"), ""))
+	// two identical team templates, whose children are listed in different orders
+	require.NoError(t, tree.AddByID(NewNode("team1", "Team"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("lead1", "Lead"), "team1"))
+	require.NoError(t, tree.AddByID(NewNode("dev1", "Dev"), "team1"))
+	require.NoError(t, tree.AddByID(NewNode("team2", "Team"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("dev2", "Dev"), "team2"))
+	require.NoError(t, tree.AddByID(NewNode("lead2", "Lead"), "team2"))
+	// a team with a different value
+	require.NoError(t, tree.AddByID(NewNode("team3", "Team"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("lead3", "Lead"), "team3"))
+	require.NoError(t, tree.AddByID(NewNode("ops3", "Ops"), "team3"))
+	// identical chains, one of them nested in a unique subtree
+	require.NoError(t, tree.AddByID(NewNode("chain1", "Link"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("end1", "End"), "chain1"))
+	require.NoError(t, tree.AddByID(NewNode("unique", "Unique"), "team3"))
+	require.NoError(t, tree.AddByID(NewNode("chain2", "Link"), "unique"))
+	require.NoError(t, tree.AddByID(NewNode("end2", "End"), "chain2"))
+
+	t.Run("groups the identical subtrees", func(t *testing.T) {
+		assert.Equal(t, [][]string{{"team1", "team2"}, {"chain2", "chain1"}}, ids(tree.FindDuplicateSubtrees(hash)))
+	})
+
+	t.Run("reports the nested duplicates when they also occur elsewhere", func(t *testing.T) {
+		require.NoError(t, tree.AddByID(NewNode("chain3", "Link"), "team1"))
+		require.NoError(t, tree.AddByID(NewNode("end3", "End"), "chain3"))
+		t.Cleanup(func() { require.NoError(t, tree.DeleteByID("chain3")) })
+
+		// team1 and team2 now differ
+		assert.Equal(t, [][]string{{"chain3", "chain2", "chain1"}}, ids(tree.FindDuplicateSubtrees(hash)))
+	})
+
+	t.Run("returns no group without duplicates", func(t *testing.T) {
+		assert.Empty(t, NewTree[string]().FindDuplicateSubtrees(hash))
+		single := NewTree[string]()
+		require.NoError(t, single.AddByID(NewNode("root", "Root"), ""))
+		require.NoError(t, single.AddByID(NewNode("a", "Leaf"), "root"))
+		require.NoError(t, single.AddByID(NewNode("b", "Leaf"), "root"))
+		assert.Empty(t, single.FindDuplicateSubtrees(hash))
+	})
+}
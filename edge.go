@@ -0,0 +1,148 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+)
+
+// EdgeData holds the data attached to the edge linking a Node to its parent.
+//
+// Edges allow modeling weighted or labeled hierarchies such as decision trees,
+// where the label describes the decision leading to a child, or cost hierarchies,
+// where the weight holds the cost of traversing the edge.
+type EdgeData struct {
+	// Weight is the weight (or cost) of the edge
+	Weight float64
+	// Label is the label of the edge
+	Label string
+}
+
+// AddWithEdge inserts a given Node into the Tree under the specified parent Node
+// and attaches the given data to the edge linking them.
+//
+// It behaves exactly like Add. Nodes added with Add have a zero EdgeData.
+//
+// Parameters:
+//   - node: The Node[T] to be added to the Tree.
+//   - parent: The Node[T] under which the `node` will be added as a child. If `parent` is nil,
+//     the `node` is set as the root Node of the Tree and the edge data is ignored since the
+//     root has no parent.
+//   - edge: The data attached to the parent→child edge.
+//
+// Returns:
+//   - err: The same errors as Add.
+//
+// Example usage:
+//
+//	tree := NewTree[string]()
+//	root := NewNode("income", "Income > 50k?")
+//	_ = tree.Add(root, nil)
+//	_ = tree.AddWithEdge(NewNode("approve", "Approve"), root, EdgeData{Label: "yes", Weight: 0.8})
+//	_ = tree.AddWithEdge(NewNode("reject", "Reject"), root, EdgeData{Label: "no", Weight: 0.2})
+func (x *Tree[T]) AddWithEdge(node, parent Node[T], edge EdgeData) (err error) {
+	if err := x.requireContext(opAdd, node.ID(), parentID(parent)); err != nil {
+		return err
+	}
+	return x.addContext(context.Background(), node, parent, &edge)
+}
+
+// SetEdge replaces the data attached to the edge linking the given Node to its parent
+// and increments the Node version.
+//
+// Parameters:
+//   - node: The Node[T] whose parent edge is updated.
+//   - edge: The new edge data.
+//
+// Returns:
+//   - err: nil on success, ErrNotFound when the Node does not exist in the Tree,
+//     ErrInvalidOperation when the Node is the root, which has no parent edge, or
+//     ErrNotPersisted when the Storage fails to append the change.
+func (x *Tree[T]) SetEdge(node Node[T], edge EdgeData) (err error) {
+	if err := x.requireContext(opSetEdge, node.ID(), ""); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opSetEdge, node.ID(), ""); err != nil {
+		return err
+	}
+	return x.setEdge(node.ID(), edge)
+}
+
+// setEdge replaces the data of the edge linking the Node with the given ID to its parent once written ahead
+func (x *Tree[T]) setEdge(id string, edge EdgeData) (err error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	treeNode, ok := x.getNode(id)
+	if !ok {
+		return newNodeError(opSetEdge, id, "", ErrNotFound)
+	}
+
+	parent, ok := x.parentNode(id)
+	if !ok {
+		return newNodeError(opSetEdge, id, "", ErrInvalidOperation)
+	}
+
+	write, commit := x.feed.begin()
+	defer func() {
+		commit(err == nil)
+	}()
+
+	if err := write(Event[T]{Type: EventEdge, Node: treeNode.GetValue(), Parent: parent.GetValue(), Edge: edge}); err != nil {
+		return newNodeError(opSetEdge, id, parent.ID, err)
+	}
+
+	treeNode.Edge.Store(&edge)
+	treeNode.BumpVersion()
+	return nil
+}
+
+// Edge returns the data attached to the edge linking the given Node to its parent.
+//
+// Parameters:
+//   - node: The Node[T] whose parent edge is requested.
+//
+// Returns:
+//   - edge: The edge data. It is a zero EdgeData when the Node was added without edge data.
+//   - ok: A boolean indicating whether the Node exists in the Tree and has a parent.
+//
+// Example usage:
+//
+//	for _, child := range children {
+//	    if edge, ok := tree.Edge(child); ok {
+//	        fmt.Printf("%s --%s (%.2f)--> %s\n", root.ID(), edge.Label, edge.Weight, child.ID())
+//	    }
+//	}
+func (x *Tree[T]) Edge(node Node[T]) (edge EdgeData, ok bool) {
+	treeNode, ok := x.getNode(node.ID())
+	if !ok {
+		return EdgeData{}, false
+	}
+
+	if _, ok := x.parents.Load(node.ID()); !ok {
+		return EdgeData{}, false
+	}
+	return treeNode.GetEdge(), true
+}
@@ -0,0 +1,90 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEdges(t *testing.T) {
+	tree := NewTree[string]()
+	root := NewNode("income", "Income > 50k?")
+	require.NoError(t, tree.AddWithEdge(root, nil, EdgeData{Label: "ignored"}))
+	approve := NewNode("approve", "Approve")
+	require.NoError(t, tree.AddWithEdge(approve, root, EdgeData{Label: "yes", Weight: 0.8}))
+	reject := NewNode("reject", "Reject")
+	require.NoError(t, tree.Add(reject, root))
+
+	// the root has no parent edge
+	_, ok := tree.Edge(root)
+	assert.False(t, ok)
+	assert.ErrorIs(t, tree.SetEdge(root, EdgeData{}), ErrInvalidOperation)
+
+	edge, ok := tree.Edge(approve)
+	require.True(t, ok)
+	assert.Equal(t, EdgeData{Label: "yes", Weight: 0.8}, edge)
+
+	// nodes added without edge data have a zero edge
+	edge, ok = tree.Edge(reject)
+	require.True(t, ok)
+	assert.Zero(t, edge)
+
+	require.NoError(t, tree.SetEdge(reject, EdgeData{Label: "no", Weight: 0.2}))
+	edge, _ = tree.Edge(reject)
+	assert.Equal(t, EdgeData{Label: "no", Weight: 0.2}, edge)
+	_, version, _ := tree.FindWithVersion("reject")
+	assert.EqualValues(t, 2, version)
+
+	rogue := NewNode("rogue", "rogue")
+	_, ok = tree.Edge(rogue)
+	assert.False(t, ok)
+	assert.ErrorIs(t, tree.SetEdge(rogue, EdgeData{}), ErrNotFound)
+	assert.ErrorIs(t, tree.AddWithEdge(NewNode("child", "child"), rogue, EdgeData{}), ErrParentNodeNotFound)
+}
+
+func TestAddWithEdgeNotPersisted(t *testing.T) {
+	storage := &failingStorage[string]{MemoryStorage: NewMemoryStorage[string]()}
+	tree := NewTree[string](WithStorage[string](storage))
+	root := NewNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+
+	var added []string
+	tree.OnAdd(func(node, _ Node[string]) {
+		added = append(added, node.ID())
+	})
+
+	storage.broken = true
+	err := tree.AddWithEdge(NewNode("child", "child"), root, EdgeData{Label: "yes"})
+	require.ErrorIs(t, err, ErrNotPersisted)
+
+	// the node is neither added nor notified
+	_, ok := tree.Edge(NewNode("child", ""))
+	assert.False(t, ok)
+	assert.EqualValues(t, 1, tree.Size())
+	assert.Empty(t, added)
+}
@@ -24,7 +24,10 @@
 
 package gotree
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrParentNodeNotFound is returned when attempting to add a Node to the Tree,
@@ -68,4 +71,247 @@ var (
 	//       fmt.Println("Cannot add a second root node:", ErrInvalidOperation)
 	//   }
 	ErrInvalidOperation = errors.New("invalid operation")
+
+	// ErrDuplicateID is returned when attempting to add a Node to the Tree
+	// whose identifier is already used by another Node of the Tree.
+	//
+	// Node identifiers must be unique within a Tree. Use AddOrReplace when the
+	// existing Node is meant to be overwritten.
+	//
+	// Example usage:
+	//   err := tree.Add(node, parent)
+	//   if errors.Is(err, ErrDuplicateID) {
+	//       fmt.Println("A node with the same ID already exists:", ErrDuplicateID)
+	//   }
+	ErrDuplicateID = errors.New("duplicate node ID")
+
+	// ErrInvariantViolation is returned by Validate for every structural
+	// inconsistency found in the Tree.
+	//
+	// Each returned error wraps ErrInvariantViolation and describes the offending
+	// Node so that the inconsistency can be investigated. Recoverable inconsistencies
+	// can be fixed with Repair.
+	//
+	// Example usage:
+	//   for _, err := range tree.Validate() {
+	//       if errors.Is(err, ErrInvariantViolation) {
+	//           fmt.Println("Tree is inconsistent:", err)
+	//       }
+	//   }
+	ErrInvariantViolation = errors.New("tree invariant violated")
+
+	// ErrMaxDepthExceeded is returned when adding a Node would make the Tree deeper
+	// than the limit set with WithMaxDepth.
+	//
+	// Example usage:
+	//   err := tree.Add(child, parent)
+	//   if errors.Is(err, ErrMaxDepthExceeded) {
+	//       fmt.Println("The tree is too deep:", ErrMaxDepthExceeded)
+	//   }
+	ErrMaxDepthExceeded = errors.New("max depth exceeded")
+
+	// ErrMaxChildrenExceeded is returned when adding a Node under a parent Node that
+	// already holds the number of children set with WithMaxChildren.
+	//
+	// Example usage:
+	//   err := tree.Add(child, parent)
+	//   if errors.Is(err, ErrMaxChildrenExceeded) {
+	//       fmt.Println("The parent has too many children:", ErrMaxChildrenExceeded)
+	//   }
+	ErrMaxChildrenExceeded = errors.New("max children exceeded")
+
+	// ErrMaxSizeExceeded is returned when adding a Node to a Tree that already holds
+	// the number of Nodes set with WithMaxSize.
+	//
+	// Example usage:
+	//   err := tree.Add(child, parent)
+	//   if errors.Is(err, ErrMaxSizeExceeded) {
+	//       fmt.Println("The tree is full:", ErrMaxSizeExceeded)
+	//   }
+	ErrMaxSizeExceeded = errors.New("max size exceeded")
+
+	// ErrInvalidID is returned when adding a Node whose identifier is rejected by
+	// the validator set with WithIDValidator.
+	//
+	// The returned error also wraps the error of the validator.
+	//
+	// Example usage:
+	//   err := tree.Add(child, parent)
+	//   if errors.Is(err, ErrInvalidID) {
+	//       fmt.Println("The node ID is malformed:", err)
+	//   }
+	ErrInvalidID = errors.New("invalid node ID")
+
+	// ErrVersionMismatch is returned by UpdateIf when the version of the Node
+	// differs from the expected one, meaning that the Node has been changed
+	// concurrently since it was read.
+	//
+	// Example usage:
+	//   err := tree.UpdateIf(id, version, newValue)
+	//   if errors.Is(err, ErrVersionMismatch) {
+	//       fmt.Println("The node has been updated concurrently:", ErrVersionMismatch)
+	//   }
+	ErrVersionMismatch = errors.New("node version mismatch")
+
+	// ErrIndexExists is returned by CreateIndex when an index with the same name
+	// already exists in the Tree.
+	//
+	// Example usage:
+	//   err := tree.CreateIndex("kind", keyFunc)
+	//   if errors.Is(err, ErrIndexExists) {
+	//       fmt.Println("The index already exists:", ErrIndexExists)
+	//   }
+	ErrIndexExists = errors.New("index already exists")
+
+	// ErrSequenceExpired is returned by ChangesSince when some of the changes following
+	// the given sequence number are no longer retained by the change feed.
+	//
+	// The consumer missed changes and has to resynchronize from the current state of
+	// the Tree. A bigger WithChangeFeed size makes it less likely.
+	//
+	// Example usage:
+	//   events, err := tree.ChangesSince(seq)
+	//   if errors.Is(err, ErrSequenceExpired) {
+	//       fmt.Println("Changes missed, resynchronizing:", ErrSequenceExpired)
+	//   }
+	ErrSequenceExpired = errors.New("sequence number expired")
+
+	// ErrNotPersisted is returned by the mutations of a Tree created with WithStorage when
+	// the Storage fails to append the change.
+	//
+	// The change is appended before it is applied, hence it is rejected: the Tree is left
+	// unchanged and neither the hooks nor the watchers are notified.
+	//
+	// Example usage:
+	//   err := tree.Add(child, parent)
+	//   if errors.Is(err, ErrNotPersisted) {
+	//       fmt.Println("The node was not added:", err)
+	//   }
+	ErrNotPersisted = errors.New("change not persisted")
+
+	// ErrCorruptedFile is returned by LoadFile when the file is not a Tree file written by
+	// SaveFile or when its content does not match its checksum.
+	//
+	// Example usage:
+	//   err := tree.LoadFile("tree.gotree")
+	//   if errors.Is(err, ErrCorruptedFile) {
+	//       fmt.Println("The file is damaged:", err)
+	//   }
+	ErrCorruptedFile = errors.New("corrupted tree file")
+
+	// ErrUnsupportedVersion is returned by LoadFile when the file was written with a version
+	// of the format that is newer than the ones known by this version of the library.
+	//
+	// Example usage:
+	//   err := tree.LoadFile("tree.gotree")
+	//   if errors.Is(err, ErrUnsupportedVersion) {
+	//       fmt.Println("Upgrade the library to load the file:", err)
+	//   }
+	ErrUnsupportedVersion = errors.New("unsupported file format version")
+
+	// ErrQuotaExceeded is returned when adding or moving Nodes into a subtree would exceed
+	// the quota set on it with SetQuota.
+	//
+	// Example usage:
+	//   err := tree.Add(child, tenant)
+	//   if errors.Is(err, ErrQuotaExceeded) {
+	//       fmt.Println("The tenant is full:", err)
+	//   }
+	ErrQuotaExceeded = errors.New("quota exceeded")
+
+	// ErrPermissionDenied is returned by the context-aware methods when the authorizer set with
+	// WithAuthorizer rejects the operation. The error of the authorizer is wrapped along with it.
+	// It is also returned by the methods changing such a Tree without context.
+	//
+	// Example usage:
+	//   err := tree.AddContext(ctx, child, parent)
+	//   if errors.Is(err, ErrPermissionDenied) {
+	//       fmt.Println("Not allowed:", err)
+	//   }
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// ErrThrottled is returned when the mutation limiter set with WithMutationLimiter does not let a
+	// mutation through, typically because the context is done before its turn comes. The error of the
+	// limiter is wrapped along with it.
+	//
+	// Example usage:
+	//   err := tree.AddContext(ctx, child, parent)
+	//   if errors.Is(err, ErrThrottled) {
+	//       fmt.Println("Too many writes, retry later:", err)
+	//   }
+	ErrThrottled = errors.New("mutation throttled")
+
+	// ErrCycleDetected is returned when an operation would make a Node its own ancestor, such as
+	// moving or linking a Node under one of its descendants. It is wrapped along with
+	// ErrInvalidOperation by those operations, and along with ErrInvariantViolation by Validate
+	// and the walks meeting a corrupted hierarchy.
+	//
+	// Example usage:
+	//   err := tree.Move(parent, child)
+	//   if errors.Is(err, ErrCycleDetected) {
+	//       fmt.Println("A node cannot be moved under its own subtree:", err)
+	//   }
+	ErrCycleDetected = errors.New("cycle detected")
 )
+
+// NodeError describes an error that occurred while operating on a given Node of the Tree.
+//
+// It carries the identifiers of the Nodes involved so that callers can report which
+// Node failed without parsing the error message. A NodeError wraps one of the sentinel
+// errors of this package, hence errors.Is keeps working against them.
+//
+// Example usage:
+//
+//	err := tree.Add(child, parent)
+//	var nodeErr *NodeError
+//	if errors.As(err, &nodeErr) && errors.Is(err, ErrParentNodeNotFound) {
+//	    fmt.Printf("cannot add %q: parent %q does not exist\n", nodeErr.NodeID(), nodeErr.ParentID())
+//	}
+type NodeError struct {
+	op       string
+	nodeID   string
+	parentID string
+	err      error
+}
+
+// enforce compilation error
+var _ error = (*NodeError)(nil)
+
+// newNodeError creates a NodeError for the given operation and Nodes wrapping the given error
+func newNodeError(op, nodeID, parentID string, err error) *NodeError {
+	return &NodeError{
+		op:       op,
+		nodeID:   nodeID,
+		parentID: parentID,
+		err:      err,
+	}
+}
+
+// Op returns the name of the operation that failed (e.g. "add", "delete")
+func (e *NodeError) Op() string {
+	return e.op
+}
+
+// NodeID returns the identifier of the Node the operation failed for
+func (e *NodeError) NodeID() string {
+	return e.nodeID
+}
+
+// ParentID returns the identifier of the parent Node involved in the operation.
+// It is empty when no parent Node is involved
+func (e *NodeError) ParentID() string {
+	return e.parentID
+}
+
+// Error returns the error message
+func (e *NodeError) Error() string {
+	if e.parentID != "" {
+		return fmt.Sprintf("%s node %q under parent %q: %v", e.op, e.nodeID, e.parentID, e.err)
+	}
+	return fmt.Sprintf("%s node %q: %v", e.op, e.nodeID, e.err)
+}
+
+// Unwrap returns the underlying error
+func (e *NodeError) Unwrap() error {
+	return e.err
+}
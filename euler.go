@@ -0,0 +1,125 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+// EulerTour is the sequence of the Nodes met while walking a Tree depth-first, a Node being
+// recorded when it is entered and again every time the walk comes back to it from one of its children.
+//
+// The tour flattens the Tree into arrays: the lowest common ancestor of two Nodes is the shallowest
+// Node of the tour between their first occurrences, and a subtree spans the range of the tour between
+// the first and the last occurrences of its root, which suits sparse tables and segment trees.
+type EulerTour[T any] struct {
+	// Nodes holds the Nodes of the tour. It holds 2n-1 Nodes for a Tree of n Nodes
+	Nodes []Node[T]
+	// Depths holds the depth of every Node of the tour, the root being at depth zero
+	Depths []int
+	// First holds the index of the first occurrence of every Node in the tour, keyed by identifier
+	First map[string]int
+	// Last holds the index of the last occurrence of every Node in the tour, keyed by identifier
+	Last map[string]int
+}
+
+// EulerTour computes the Euler tour of the Tree.
+//
+// The tour starts at the root and visits the children in their order. It enables the classic flat
+// index techniques on top of the Tree, such as sparse table lowest common ancestor queries or segment
+// tree aggregations over subtrees.
+//
+// Returns:
+//   - EulerTour[T]: The Euler tour of the Tree. It is empty for an empty Tree.
+//
+// Notes:
+//   - EulerTour blocks the structural changes of the Tree while it walks the Nodes.
+//   - The tour is a copy: it does not reflect the changes applied to the Tree afterward.
+//
+// Example usage:
+//
+//	tour := tree.EulerTour()
+//	// the subtree of the node spans tour.Nodes[tour.First[id] : tour.Last[id]+1]
+//	for _, node := range tour.Nodes[tour.First[id] : tour.Last[id]+1] {
+//	    fmt.Println(node.ID())
+//	}
+func (x *Tree[T]) EulerTour() EulerTour[T] {
+	records := x.snapshot().Records
+	tour := EulerTour[T]{
+		First: make(map[string]int, len(records)),
+		Last:  make(map[string]int, len(records)),
+	}
+	if len(records) == 0 {
+		return tour
+	}
+
+	// the records are in depth-first order: the parents come before their children
+	positions := make(map[string]int, len(records))
+	children := make([][]int, len(records))
+	nodes := make([]Node[T], len(records))
+	for i, record := range records {
+		positions[record.ID] = i
+		if parent, ok := positions[record.ParentID]; ok {
+			children[parent] = append(children[parent], i)
+		}
+		if node, ok := x.Find(record.ID); ok {
+			nodes[i] = node
+		}
+	}
+
+	tour.Nodes = make([]Node[T], 0, 2*len(records)-1)
+	tour.Depths = make([]int, 0, 2*len(records)-1)
+	visit := func(i, depth int) {
+		if _, ok := tour.First[records[i].ID]; !ok {
+			tour.First[records[i].ID] = len(tour.Nodes)
+		}
+		tour.Last[records[i].ID] = len(tour.Nodes)
+		tour.Nodes = append(tour.Nodes, nodes[i])
+		tour.Depths = append(tour.Depths, depth)
+	}
+
+	// walk iteratively so that deep Trees do not exhaust the stack
+	type frame struct {
+		node  int
+		next  int
+		depth int
+	}
+
+	stack := []frame{{node: 0}}
+	visit(0, 0)
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.next == len(children[top.node]) {
+			stack = stack[:len(stack)-1]
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				visit(parent.node, parent.depth)
+			}
+			continue
+		}
+
+		child := children[top.node][top.next]
+		top.next++
+		stack = append(stack, frame{node: child, depth: top.depth + 1})
+		visit(child, top.depth+1)
+	}
+	return tour
+}
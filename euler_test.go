@@ -0,0 +1,69 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEulerTour(t *testing.T) {
+	t.Run("is empty for an empty tree", func(t *testing.T) {
+		tour := NewTree[string]().EulerTour()
+		assert.Empty(t, tour.Nodes)
+		assert.Empty(t, tour.First)
+	})
+
+	t.Run("walks the tree depth-first", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", ""), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a2", ""), "a"))
+
+		tour := tree.EulerTour()
+		ids := make([]string, len(tour.Nodes))
+		for i, node := range tour.Nodes {
+			ids[i] = node.ID()
+		}
+		assert.Equal(t, []string{"root", "a", "a1", "a", "a2", "a", "root", "b", "root"}, ids)
+		assert.Equal(t, []int{0, 1, 2, 1, 2, 1, 0, 1, 0}, tour.Depths)
+		assert.Equal(t, map[string]int{"root": 0, "a": 1, "a1": 2, "a2": 4, "b": 7}, tour.First)
+		assert.Equal(t, map[string]int{"root": 8, "a": 5, "a1": 2, "a2": 4, "b": 7}, tour.Last)
+
+		// the shallowest node between the first occurrences is the lowest common ancestor
+		from, to := tour.First["a2"], tour.First["b"]
+		shallowest := from
+		for i := from; i <= to; i++ {
+			if tour.Depths[i] < tour.Depths[shallowest] {
+				shallowest = i
+			}
+		}
+		assert.Equal(t, "root", tour.Nodes[shallowest].ID())
+	})
+}
@@ -0,0 +1,620 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression defines the algorithm compressing the content of an encoded Tree
+type Compression uint8
+
+const (
+	// NoCompression leaves the content uncompressed
+	NoCompression Compression = iota
+	// Gzip compresses the content with gzip
+	Gzip
+	// Zstd compresses the content with Zstandard
+	Zstd
+)
+
+// String returns the name of the Compression
+func (c Compression) String() string {
+	switch c {
+	case NoCompression:
+		return "none"
+	case Gzip:
+		return "gzip"
+	case Zstd:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// fileMagic identifies the encoded Trees
+	fileMagic = "GOTREE"
+	// fileVersion is the version of the format written by EncodeCompressed and SaveFile
+	fileVersion uint16 = 2
+	// fileVersionEncrypted is the version of the format written by EncodeEncrypted
+	fileVersionEncrypted uint16 = 3
+	// fileChecksumSize is the size of the checksum of the payload
+	fileChecksumSize = 4
+)
+
+// The versions of the format:
+//   - 1: magic | version | checksum | payload
+//   - 2: magic | version | compression | compressed(payload | checksum)
+//   - 3: magic | version | compression | nonce | sealed(compressed(payload | checksum)), the header
+//     being authenticated as additional data
+//
+// The payload is the JSON encoding of a filePayloadV1 and the checksum its CRC-32C.
+
+// fileRecordV1 is a Node of the payload of the version 1 of the file format
+type fileRecordV1[T any] struct {
+	ID     string `json:"id"`
+	Parent string `json:"parent,omitempty"`
+	Value  T      `json:"value"`
+}
+
+// filePayloadV1 is the payload of the version 1 of the file format: the Nodes in depth-first order
+// and the schema version of their values, zero when no Migrations are set
+type filePayloadV1[T any] struct {
+	Schema  int               `json:"schema,omitempty"`
+	Records []fileRecordV1[T] `json:"records"`
+}
+
+// EncodeCompressed writes the Nodes of the Tree to the given writer, compressed with the given algorithm.
+//
+// The content starts with a header made of a magic string, the version of the format and the
+// compression algorithm, so that Decode detects the compression by itself, and ends with a
+// checksum of the Nodes detecting corrupted content.
+//
+// Parameters:
+//   - w: The writer receiving the encoded Tree.
+//   - compression: The compression algorithm. Serialized Trees holding string-heavy identifiers
+//     typically shrink tenfold with Gzip or Zstd.
+//
+// Returns:
+//   - err: An error when the values cannot be encoded, the compression is unknown or the writer fails.
+//
+// Notes:
+//   - The values are encoded with encoding/json and must round-trip through it.
+//   - The metadata, edges and tags of the Nodes are not encoded.
+//
+// Example usage:
+//
+//	var buf bytes.Buffer
+//	if err := tree.EncodeCompressed(&buf, Zstd); err != nil {
+//	    log.Println("Cannot encode the tree:", err)
+//	}
+func (x *Tree[T]) EncodeCompressed(w io.Writer, compression Compression) error {
+	if _, err := x.encode(w, compression, nil); err != nil {
+		return fmt.Errorf("gotree: cannot encode tree: %w", err)
+	}
+	return nil
+}
+
+// Decode replaces the Nodes of the Tree with the ones read from the given reader, written by
+// EncodeCompressed or SaveFile.
+//
+// The compression is read from the header. The whole content is read and checked before the
+// Tree is reset, hence a corrupted content leaves the Tree untouched.
+//
+// Parameters:
+//   - r: The reader providing the encoded Tree.
+//
+// Returns:
+//   - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The Tree was decoded.
+//   - ErrCorruptedFile: The content was not written by EncodeCompressed or is damaged.
+//   - ErrInvalidOperation: The content is encrypted, see DecodeEncrypted.
+//   - ErrUnsupportedVersion: The content was written by a newer version of the library.
+//   - Any error raised while reading the content or adding its Nodes, in which case the Tree
+//     holds the Nodes added so far.
+//
+// Notes:
+//   - The Tree must not be changed while it is decoded.
+//   - The hooks, the watchers and the Storage are notified of the decoded Nodes.
+//
+// Example usage:
+//
+//	tree := NewTree[string]()
+//	if err := tree.Decode(resp.Body); err != nil {
+//	    log.Println("Cannot decode the tree:", err)
+//	}
+func (x *Tree[T]) Decode(r io.Reader) error {
+	if _, err := x.decode(r, nil); err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+	return nil
+}
+
+// EncodeEncrypted writes the Nodes of the Tree to the given writer as done by EncodeCompressed,
+// encrypted and authenticated with the given AEAD.
+//
+// It protects the snapshots of sensitive hierarchies stored on shared storage: the content can
+// only be read, and any tampering is detected, with the key of the AEAD. The header is left in
+// clear so that DecodeEncrypted detects the format, but it is authenticated along with the content.
+//
+// Parameters:
+//   - w: The writer receiving the encrypted Tree.
+//   - aead: The AEAD built from the key of the caller, e.g. AES-GCM. A random nonce is
+//     generated for every call.
+//   - compression: The compression algorithm, applied before the encryption.
+//
+// Returns:
+//   - err: An error when the values cannot be encoded, the compression is unknown or the writer fails.
+//
+// Notes:
+//   - The encrypted content is buffered in memory before being written.
+//   - The metadata, edges and tags of the Nodes are not encoded.
+//
+// Example usage:
+//
+//	block, _ := aes.NewCipher(key) // 32-byte key for AES-256
+//	aead, _ := cipher.NewGCM(block)
+//	if err := tree.EncodeEncrypted(w, aead, Zstd); err != nil {
+//	    log.Println("Cannot encode the tree:", err)
+//	}
+func (x *Tree[T]) EncodeEncrypted(w io.Writer, aead cipher.AEAD, compression Compression) error {
+	if aead == nil {
+		return fmt.Errorf("%w: nil AEAD", ErrInvalidOperation)
+	}
+
+	if _, err := x.encode(w, compression, aead); err != nil {
+		return fmt.Errorf("gotree: cannot encode tree: %w", err)
+	}
+	return nil
+}
+
+// DecodeEncrypted replaces the Nodes of the Tree with the ones read from the given reader, written by
+// EncodeEncrypted with the same key.
+//
+// The whole content is read, authenticated and checked before the Tree is reset, hence a tampered
+// content leaves the Tree untouched.
+//
+// Parameters:
+//   - r: The reader providing the encrypted Tree.
+//   - aead: The AEAD built from the key the content was encrypted with.
+//
+// Returns:
+//   - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The Tree was decoded.
+//   - ErrCorruptedFile: The content cannot be authenticated, either because it was tampered
+//     with or because the key differs, or is damaged.
+//   - ErrInvalidOperation: The content is not encrypted.
+//   - ErrUnsupportedVersion: The content was written by a newer version of the library.
+//   - Any error raised while reading the content or adding its Nodes, in which case the Tree
+//     holds the Nodes added so far.
+//
+// Notes:
+//   - The Tree must not be changed while it is decoded.
+//   - The hooks, the watchers and the Storage are notified of the decoded Nodes.
+//
+// Example usage:
+//
+//	tree := NewTree[string]()
+//	if err := tree.DecodeEncrypted(r, aead); err != nil {
+//	    log.Println("Cannot decode the tree:", err)
+//	}
+func (x *Tree[T]) DecodeEncrypted(r io.Reader, aead cipher.AEAD) error {
+	if aead == nil {
+		return fmt.Errorf("%w: nil AEAD", ErrInvalidOperation)
+	}
+
+	if _, err := x.decode(r, aead); err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+	return nil
+}
+
+// SaveFile writes the Nodes of the Tree to the given file.
+//
+// The file is encoded as done by EncodeCompressed without compression: its header holds a magic
+// string and the version of the format, and a checksum follows the Nodes, so that LoadFile detects
+// corrupted files and keeps loading the files written by older versions of the library. The file
+// is replaced atomically and synced to disk.
+//
+// Parameters:
+//   - path: The path of the file, which is created or replaced.
+//
+// Returns:
+//   - err: An error when the values cannot be encoded or the file cannot be written.
+//
+// Notes:
+//   - The values are encoded with encoding/json and must round-trip through it.
+//   - The metadata, edges and tags of the Nodes are not saved.
+//
+// Example usage:
+//
+//	if err := tree.SaveFile("tree.gotree"); err != nil {
+//	    log.Println("Cannot save the tree:", err)
+//	}
+func (x *Tree[T]) SaveFile(path string) error {
+	var count int
+	if err := replaceFile(path, func(file *os.File) error {
+		buffered := bufio.NewWriter(file)
+		n, err := x.encode(buffered, NoCompression, nil)
+		if err != nil {
+			return err
+		}
+		count = n
+		return buffered.Flush()
+	}); err != nil {
+		return fmt.Errorf("gotree: cannot write %s: %w", path, err)
+	}
+
+	x.log(slog.LevelDebug, "tree saved", slog.String("path", path), slog.Int("count", count))
+	return nil
+}
+
+// LoadFile replaces the Nodes of the Tree with the ones of the given file, written by SaveFile
+// or EncodeCompressed.
+//
+// The whole file is read and checked before the Tree is reset, hence a corrupted file leaves the
+// Tree untouched.
+//
+// Parameters:
+//   - path: The path of the file.
+//
+// Returns:
+//   - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The Tree was loaded.
+//   - ErrCorruptedFile: The file was not written by SaveFile or is damaged.
+//   - ErrUnsupportedVersion: The file was written by a newer version of the library.
+//   - Any error raised while reading the file or adding its Nodes, in which case the Tree
+//     holds the Nodes added so far.
+//
+// Notes:
+//   - The Tree must not be changed while it is loaded.
+//   - The hooks, the watchers and the Storage are notified of the loaded Nodes.
+//
+// Example usage:
+//
+//	tree := NewTree[string]()
+//	if err := tree.LoadFile("tree.gotree"); err != nil {
+//	    log.Fatal("Cannot load the tree:", err)
+//	}
+func (x *Tree[T]) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("gotree: cannot read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	count, err := x.decode(bufio.NewReader(file), nil)
+	if err != nil {
+		return fmt.Errorf("gotree: cannot load %s: %w", path, err)
+	}
+
+	x.log(slog.LevelDebug, "tree loaded", slog.String("path", path), slog.Int("count", count))
+	return nil
+}
+
+// encode writes the Nodes of the Tree to the given writer, sealed with the given AEAD when
+// not nil, and returns the number of Nodes written
+func (x *Tree[T]) encode(w io.Writer, compression Compression, aead cipher.AEAD) (int, error) {
+	snapshot := x.snapshot()
+	payload := filePayloadV1[T]{Schema: x.cfg.migrations.Version(), Records: make([]fileRecordV1[T], len(snapshot.Records))}
+	for i, record := range snapshot.Records {
+		payload.Records[i] = fileRecordV1[T]{ID: record.ID, Parent: record.ParentID, Value: record.Value}
+	}
+
+	version := fileVersion
+	if aead != nil {
+		version = fileVersionEncrypted
+	}
+
+	header := make([]byte, len(fileMagic)+3)
+	copy(header, fileMagic)
+	binary.BigEndian.PutUint16(header[len(fileMagic):], version)
+	header[len(fileMagic)+2] = byte(compression)
+
+	if compression > Zstd {
+		return 0, fmt.Errorf("unknown compression %d", compression)
+	}
+
+	// the encrypted content is sealed at once, hence it is buffered
+	out := w
+	var sealed bytes.Buffer
+	if aead != nil {
+		out = &sealed
+	} else if _, err := w.Write(header); err != nil {
+		return 0, err
+	}
+
+	body, err := compressor(out, compression)
+	if err != nil {
+		return 0, err
+	}
+
+	checksum := crc32.New(crcTable)
+	if err := json.NewEncoder(io.MultiWriter(body, checksum)).Encode(payload); err != nil {
+		return 0, err
+	}
+
+	if _, err := body.Write(binary.BigEndian.AppendUint32(nil, checksum.Sum32())); err != nil {
+		return 0, err
+	}
+
+	if err := body.Close(); err != nil {
+		return 0, err
+	}
+
+	if aead != nil {
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return 0, err
+		}
+
+		// the header is authenticated along with the content
+		content := append(append(header, nonce...), aead.Seal(nil, nonce, sealed.Bytes(), header)...)
+		if _, err := w.Write(content); err != nil {
+			return 0, err
+		}
+	}
+	return len(payload.Records), nil
+}
+
+// decode reads the Nodes from the given reader, opened with the given AEAD when not nil, checks
+// them and restores them into the Tree. It returns the number of Nodes restored
+func (x *Tree[T]) decode(r io.Reader, aead cipher.AEAD) (int, error) {
+	if err := x.requireContext(opLoad, "", ""); err != nil {
+		return 0, err
+	}
+
+	records, err := decodeFile[T](r, aead, x.cfg.migrations)
+	if err != nil {
+		return 0, err
+	}
+	return len(records), x.restore(context.Background(), records)
+}
+
+// decodeFile checks the header of the given content and decodes its payload according to its version.
+// The encrypted content is opened with the given AEAD, which must be nil for the plain content, and
+// the values written with an older schema are upgraded with the given migrations
+func decodeFile[T any](r io.Reader, aead cipher.AEAD, migrations *Migrations) ([]Record[T], error) {
+	_, payload, err := readFile(r, aead)
+	if err != nil {
+		return nil, err
+	}
+
+	var content filePayloadV1[json.RawMessage]
+	if err := json.Unmarshal(payload, &content); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptedFile, err)
+	}
+
+	if content.Schema > migrations.Version() {
+		return nil, fmt.Errorf("%w: schema %d", ErrUnsupportedVersion, content.Schema)
+	}
+
+	records := make([]Record[T], len(content.Records))
+	for i, record := range content.Records {
+		value := record.Value
+		if content.Schema < migrations.Version() {
+			var err error
+			if value, err = migrations.migrate(value, content.Schema); err != nil {
+				return nil, fmt.Errorf("cannot upgrade the value of node %q: %w", record.ID, err)
+			}
+		}
+
+		records[i] = Record[T]{ID: record.ID, ParentID: record.Parent}
+		if err := json.Unmarshal(value, &records[i].Value); err != nil {
+			return nil, fmt.Errorf("cannot decode the value of node %q: %w", record.ID, err)
+		}
+	}
+	return records, nil
+}
+
+// fileHeader describes the content of an encoded Tree
+type fileHeader struct {
+	version     uint16
+	compression Compression
+	checksum    uint32
+}
+
+// readFile checks the header of the given content and returns it along with the payload, once
+// decompressed, opened with the given AEAD when the content is encrypted and checked against its checksum
+func readFile(r io.Reader, aead cipher.AEAD) (fileHeader, []byte, error) {
+	header, version, err := readFileHeader(r)
+	if err != nil {
+		return fileHeader{}, nil, err
+	}
+
+	switch encrypted := version == fileVersionEncrypted; {
+	case encrypted && aead == nil:
+		return fileHeader{}, nil, fmt.Errorf("%w: content is encrypted", ErrInvalidOperation)
+	case !encrypted && aead != nil:
+		return fileHeader{}, nil, fmt.Errorf("%w: content is not encrypted", ErrInvalidOperation)
+	}
+
+	var payload []byte
+	var checksum uint32
+	info := fileHeader{version: version}
+	switch version {
+	case 1:
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return fileHeader{}, nil, err
+		}
+		if len(data) < fileChecksumSize {
+			return fileHeader{}, nil, fmt.Errorf("%w: missing checksum", ErrCorruptedFile)
+		}
+		checksum, payload = binary.BigEndian.Uint32(data), data[fileChecksumSize:]
+	case 2, 3:
+		compression := make([]byte, 1)
+		if _, err := io.ReadFull(r, compression); err != nil {
+			return fileHeader{}, nil, fmt.Errorf("%w: missing header", ErrCorruptedFile)
+		}
+		info.compression = Compression(compression[0])
+
+		if version == fileVersionEncrypted {
+			opened, err := open(r, aead, append(header, compression...))
+			if err != nil {
+				return fileHeader{}, nil, err
+			}
+			r = bytes.NewReader(opened)
+		}
+
+		data, err := decompress(r, info.compression)
+		if err != nil {
+			return fileHeader{}, nil, err
+		}
+		if len(data) < fileChecksumSize {
+			return fileHeader{}, nil, fmt.Errorf("%w: missing checksum", ErrCorruptedFile)
+		}
+		payload = data[:len(data)-fileChecksumSize]
+		checksum = binary.BigEndian.Uint32(data[len(payload):])
+	default:
+		return fileHeader{}, nil, fmt.Errorf("%w: version %d", ErrUnsupportedVersion, version)
+	}
+
+	if crc32.Checksum(payload, crcTable) != checksum {
+		return fileHeader{}, nil, fmt.Errorf("%w: checksum mismatch", ErrCorruptedFile)
+	}
+	info.checksum = checksum
+	return info, payload, nil
+}
+
+// readFileHeader reads the magic string and the version of the format starting the given content.
+// It returns the bytes read along with the version
+func readFileHeader(r io.Reader) ([]byte, uint16, error) {
+	header := make([]byte, len(fileMagic)+2)
+	if _, err := io.ReadFull(r, header); err != nil || string(header[:len(fileMagic)]) != fileMagic {
+		return nil, 0, fmt.Errorf("%w: missing header", ErrCorruptedFile)
+	}
+
+	version := binary.BigEndian.Uint16(header[len(fileMagic):])
+	if version > fileVersionEncrypted {
+		return nil, 0, fmt.Errorf("%w: version %d", ErrUnsupportedVersion, version)
+	}
+	return header, version, nil
+}
+
+// nopCloser turns a writer into a io.WriteCloser whose Close does nothing
+type nopCloser struct {
+	io.Writer
+}
+
+// Close does nothing
+func (nopCloser) Close() error {
+	return nil
+}
+
+// compressor returns the writer compressing the content written to w with the given algorithm.
+// Closing it flushes the compressed content without closing w
+func compressor(w io.Writer, compression Compression) (io.WriteCloser, error) {
+	switch compression {
+	case NoCompression:
+		return nopCloser{w}, nil
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unknown compression %d", compression)
+	}
+}
+
+// decompress reads the whole content of r decompressed with the given algorithm
+func decompress(r io.Reader, compression Compression) ([]byte, error) {
+	reader, release, err := decompressor(r, compression)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if compression == NoCompression {
+		return io.ReadAll(reader)
+	}
+	return readCompressed(reader)
+}
+
+// decompressor returns a reader decompressing the content of the given reader with the given algorithm,
+// along with the function releasing it
+func decompressor(r io.Reader, compression Compression) (io.Reader, func(), error) {
+	switch compression {
+	case NoCompression:
+		return r, func() {}, nil
+	case Gzip:
+		reader, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrCorruptedFile, err)
+		}
+		return reader, func() { _ = reader.Close() }, nil
+	case Zstd:
+		reader, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return reader, reader.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: compression %d", ErrUnsupportedVersion, compression)
+	}
+}
+
+// readCompressed reads the whole content of the given decompressing reader, reporting the invalid
+// compressed content as corrupted
+func readCompressed(r io.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptedFile, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// open reads the nonce and the sealed content following it from r and opens the content with the
+// given AEAD, authenticating the given header
+func open(r io.Reader, aead cipher.AEAD, header []byte) ([]byte, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: missing nonce", ErrCorruptedFile)
+	}
+
+	opened, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptedFile, err)
+	}
+	return opened, nil
+}
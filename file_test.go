@@ -0,0 +1,231 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveFile(t *testing.T) {
+	newTree := func(t *testing.T) *Tree[string] {
+		t.Helper()
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "A"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "B"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", "A1"), "a"))
+		return tree
+	}
+
+	t.Run("round-trips the tree", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tree.gotree")
+		tree := newTree(t)
+		require.NoError(t, tree.SaveFile(path))
+
+		loaded := NewTree[string]()
+		require.NoError(t, loaded.AddByID(NewNode("stale", "Stale"), ""))
+		require.NoError(t, loaded.LoadFile(path))
+		assert.Equal(t, tree.Dump(), loaded.Dump())
+		assert.Empty(t, loaded.Validate())
+	})
+
+	t.Run("writes a versioned header", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tree.gotree")
+		require.NoError(t, newTree(t).SaveFile(path))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "GOTREE", string(data[:6]))
+		assert.EqualValues(t, 2, binary.BigEndian.Uint16(data[6:8]))
+		assert.EqualValues(t, NoCompression, data[8])
+	})
+
+	t.Run("loads a version 1 file", func(t *testing.T) {
+		payload := []byte(`{"records":[{"id":"root","value":"Root"},{"id":"a","parent":"root","value":"A"}]}`)
+		header := make([]byte, 12)
+		copy(header, "GOTREE")
+		binary.BigEndian.PutUint16(header[6:], 1)
+		binary.BigEndian.PutUint32(header[8:], crc32.Checksum(payload, crc32.MakeTable(crc32.Castagnoli)))
+
+		path := filepath.Join(t.TempDir(), "tree.gotree")
+		require.NoError(t, os.WriteFile(path, append(header, payload...), 0o600))
+
+		tree := NewTree[string]()
+		require.NoError(t, tree.LoadFile(path))
+		node, ok := tree.Find("a")
+		require.True(t, ok)
+		assert.Equal(t, "A", node.Value())
+		parent, ok := tree.ParentAt(node, 0)
+		require.True(t, ok)
+		assert.Equal(t, "root", parent.ID())
+	})
+
+	t.Run("detects the corrupted files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tree.gotree")
+		require.NoError(t, newTree(t).SaveFile(path))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		corrupted := append([]byte(nil), data...)
+		corrupted[len(corrupted)-3] ^= 0xff
+		require.NoError(t, os.WriteFile(path, corrupted, 0o600))
+
+		tree := newTree(t)
+		dump := tree.Dump()
+		assert.ErrorIs(t, tree.LoadFile(path), ErrCorruptedFile)
+		assert.Equal(t, dump, tree.Dump())
+
+		require.NoError(t, os.WriteFile(path, []byte("{}"), 0o600))
+		assert.ErrorIs(t, tree.LoadFile(path), ErrCorruptedFile)
+	})
+
+	t.Run("rejects the newer versions", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tree.gotree")
+		require.NoError(t, newTree(t).SaveFile(path))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		binary.BigEndian.PutUint16(data[6:], 4)
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		assert.ErrorIs(t, NewTree[string]().LoadFile(path), ErrUnsupportedVersion)
+
+		binary.BigEndian.PutUint16(data[6:], 2)
+		data[8] = 9
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		assert.ErrorIs(t, NewTree[string]().LoadFile(path), ErrUnsupportedVersion)
+	})
+
+	t.Run("returns an error when the file does not exist", func(t *testing.T) {
+		err := NewTree[string]().LoadFile(filepath.Join(t.TempDir(), "missing"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestEncodeCompressed(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+	for i := range 200 {
+		id := "organization/department/team/member-" + strings.Repeat("x", i%7) + string(rune('a'+i%26)) + string(rune('a'+i/26))
+		require.NoError(t, tree.AddByID(NewNode(id, "Value"), "root"))
+	}
+
+	var plain bytes.Buffer
+	require.NoError(t, tree.EncodeCompressed(&plain, NoCompression))
+
+	for _, compression := range []Compression{NoCompression, Gzip, Zstd} {
+		t.Run(compression.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tree.EncodeCompressed(&buf, compression))
+			assert.EqualValues(t, compression, buf.Bytes()[8])
+			if compression != NoCompression {
+				assert.Less(t, buf.Len(), plain.Len()/4)
+			}
+
+			decoded := NewTree[string]()
+			require.NoError(t, decoded.Decode(&buf))
+			assert.Equal(t, tree.Dump(), decoded.Dump())
+		})
+	}
+
+	t.Run("detects the corrupted content", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, tree.EncodeCompressed(&buf, Gzip))
+		data := buf.Bytes()
+		data[len(data)/2] ^= 0xff
+		assert.ErrorIs(t, NewTree[string]().Decode(bytes.NewReader(data)), ErrCorruptedFile)
+	})
+
+	t.Run("rejects an unknown compression", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.Error(t, tree.EncodeCompressed(&buf, Compression(9)))
+		assert.Zero(t, buf.Len())
+	})
+}
+
+func TestEncodeEncrypted(t *testing.T) {
+	newAEAD := func(t *testing.T, key string) cipher.AEAD {
+		t.Helper()
+		block, err := aes.NewCipher([]byte(key))
+		require.NoError(t, err)
+		aead, err := cipher.NewGCM(block)
+		require.NoError(t, err)
+		return aead
+	}
+
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("ceo", "Alice"), ""))
+	require.NoError(t, tree.AddByID(NewNode("cto", "Bob"), "ceo"))
+	require.NoError(t, tree.AddByID(NewNode("engineer", "Carol"), "cto"))
+	aead := newAEAD(t, "0123456789abcdef0123456789abcdef")
+
+	for _, compression := range []Compression{NoCompression, Zstd} {
+		t.Run(compression.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tree.EncodeEncrypted(&buf, aead, compression))
+			assert.Equal(t, "GOTREE", buf.String()[:6])
+			assert.NotContains(t, buf.String(), "Alice")
+
+			decoded := NewTree[string]()
+			require.NoError(t, decoded.DecodeEncrypted(&buf, aead))
+			assert.Equal(t, tree.Dump(), decoded.Dump())
+		})
+	}
+
+	t.Run("detects a wrong key", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, tree.EncodeEncrypted(&buf, aead, Gzip))
+		other := newAEAD(t, "fedcba9876543210fedcba9876543210")
+		assert.ErrorIs(t, NewTree[string]().DecodeEncrypted(&buf, other), ErrCorruptedFile)
+	})
+
+	t.Run("detects a tampered header", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, tree.EncodeEncrypted(&buf, aead, NoCompression))
+		data := buf.Bytes()
+		data[8] = byte(Gzip)
+		assert.ErrorIs(t, NewTree[string]().DecodeEncrypted(bytes.NewReader(data), aead), ErrCorruptedFile)
+	})
+
+	t.Run("rejects the mismatching modes", func(t *testing.T) {
+		var encrypted, plain bytes.Buffer
+		require.NoError(t, tree.EncodeEncrypted(&encrypted, aead, NoCompression))
+		require.NoError(t, tree.EncodeCompressed(&plain, NoCompression))
+
+		assert.ErrorIs(t, NewTree[string]().Decode(&encrypted), ErrInvalidOperation)
+		assert.ErrorIs(t, NewTree[string]().DecodeEncrypted(&plain, aead), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.EncodeEncrypted(&plain, nil, NoCompression), ErrInvalidOperation)
+	})
+}
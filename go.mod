@@ -2,7 +2,10 @@ module github.com/tochemey/gotree
 
 go 1.22.0
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/klauspost/compress v1.18.0
+	github.com/stretchr/testify v1.10.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
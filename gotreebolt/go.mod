@@ -0,0 +1,17 @@
+module github.com/tochemey/gotree/gotreebolt
+
+go 1.22.0
+
+require (
+	github.com/stretchr/testify v1.10.0
+	github.com/tochemey/gotree v0.0.0-20261016184310-55f523293600
+	go.etcd.io/bbolt v1.3.11
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
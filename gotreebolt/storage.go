@@ -0,0 +1,339 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotreebolt provides a gotree.Storage persisting a Tree to a bbolt database file,
+// so that the Tree survives process restarts with no external infrastructure.
+//
+// The snapshot and the changes are kept in their own buckets and every operation is a
+// bbolt transaction, hence the Storage is crash-safe: a change is durable once appended.
+// The edges, metadata, tags and links of the Nodes are persisted along with them, the
+// metadata being encoded with encoding/json.
+//
+//	storage, err := gotreebolt.Open("tree.db", gotree.JSONCodec[string]())
+//	if err != nil {
+//	    return err
+//	}
+//	defer storage.Close()
+//
+//	tree := gotree.NewTree[string](gotree.WithStorage[string](storage))
+//	if err := tree.Recover(); err != nil {
+//	    return err
+//	}
+package gotreebolt
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/tochemey/gotree"
+)
+
+var (
+	// snapshotBucket holds the records of the snapshot keyed by their position
+	snapshotBucket = []byte("gotree.snapshot")
+	// changesBucket holds the changes keyed by their sequence number
+	changesBucket = []byte("gotree.changes")
+	// metaBucket holds the sequence number of the snapshot
+	metaBucket = []byte("gotree.meta")
+	// seqKey is the key of the sequence number of the snapshot
+	seqKey = []byte("seq")
+)
+
+// record is the persisted form of a snapshot record
+type record struct {
+	ID       string           `json:"id"`
+	ParentID string           `json:"parent,omitempty"`
+	Value    json.RawMessage  `json:"value"`
+	Edge     *gotree.EdgeData `json:"edge,omitempty"`
+	Meta     map[string]any   `json:"meta,omitempty"`
+	Tags     []string         `json:"tags,omitempty"`
+	Links    []string         `json:"links,omitempty"`
+}
+
+// change is the persisted form of a change
+type change struct {
+	Type     gotree.EventType `json:"type"`
+	ID       string           `json:"id"`
+	ParentID string           `json:"parent,omitempty"`
+	Value    json.RawMessage  `json:"value,omitempty"`
+	Edge     *gotree.EdgeData `json:"edge,omitempty"`
+	Meta     map[string]any   `json:"meta,omitempty"`
+	Tags     []string         `json:"tags,omitempty"`
+}
+
+// Storage is a gotree.Storage backed by a bbolt database
+type Storage[T any] struct {
+	db    *bolt.DB
+	codec gotree.Codec[T]
+	owned bool
+}
+
+// enforce compilation error
+var _ gotree.Storage[any] = (*Storage[any])(nil)
+
+// Open opens, or creates, the bbolt database at the given path and returns a Storage
+// persisting the values with the given Codec. Close releases the database.
+func Open[T any](path string, codec gotree.Codec[T]) (*Storage[T], error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("gotreebolt: cannot open %s: %w", path, err)
+	}
+
+	storage, err := New(db, codec)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	storage.owned = true
+	return storage, nil
+}
+
+// New returns a Storage persisting the values with the given Codec to the given bbolt database,
+// which can be shared with the application as long as its gotree.* buckets are left untouched.
+// The database remains owned by the caller.
+func New[T any](db *bolt.DB, codec gotree.Codec[T]) (*Storage[T], error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{snapshotBucket, changesBucket, metaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gotreebolt: cannot create the buckets: %w", err)
+	}
+	return &Storage[T]{db: db, codec: codec}, nil
+}
+
+// Close closes the database opened by Open. It is a no-op for the Storage created with New.
+func (s *Storage[T]) Close() error {
+	if !s.owned {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// SaveSnapshot replaces the snapshot and deletes the changes it covers in a single transaction
+func (s *Storage[T]) SaveSnapshot(snapshot gotree.Snapshot[T]) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(snapshotBucket); err != nil {
+			return err
+		}
+
+		records, err := tx.CreateBucket(snapshotBucket)
+		if err != nil {
+			return err
+		}
+
+		for i, r := range snapshot.Records {
+			value, err := s.codec.Marshal(r.Value)
+			if err != nil {
+				return fmt.Errorf("cannot encode the value of %q: %w", r.ID, err)
+			}
+
+			data, err := json.Marshal(record{
+				ID:       r.ID,
+				ParentID: r.ParentID,
+				Value:    value,
+				Edge:     edgeOf(r.Edge),
+				Meta:     r.Meta,
+				Tags:     r.Tags,
+				Links:    r.Links,
+			})
+			if err != nil {
+				return fmt.Errorf("cannot encode %q: %w", r.ID, err)
+			}
+
+			if err := records.Put(encodeKey(uint64(i)), data); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Bucket(metaBucket).Put(seqKey, encodeKey(snapshot.Seq)); err != nil {
+			return err
+		}
+
+		// the keys are ordered by sequence number
+		cursor := tx.Bucket(changesBucket).Cursor()
+		for key, _ := cursor.First(); key != nil && decodeKey(key) <= snapshot.Seq; key, _ = cursor.First() {
+			if err := cursor.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LoadSnapshot returns the persisted snapshot, an empty one when none has been saved
+func (s *Storage[T]) LoadSnapshot() (snapshot gotree.Snapshot[T], err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		if seq := tx.Bucket(metaBucket).Get(seqKey); seq != nil {
+			snapshot.Seq = decodeKey(seq)
+		}
+
+		records := tx.Bucket(snapshotBucket)
+		snapshot.Records = make([]gotree.Record[T], 0, records.Stats().KeyN)
+		return records.ForEach(func(_, data []byte) error {
+			var r record
+			if err := json.Unmarshal(data, &r); err != nil {
+				return fmt.Errorf("cannot decode a record: %w", err)
+			}
+
+			value, err := s.codec.Unmarshal(r.Value)
+			if err != nil {
+				return fmt.Errorf("cannot decode the value of %q: %w", r.ID, err)
+			}
+
+			snapshot.Records = append(snapshot.Records, gotree.Record[T]{
+				ID:       r.ID,
+				ParentID: r.ParentID,
+				Value:    value,
+				Edge:     edgeData(r.Edge),
+				Meta:     r.Meta,
+				Tags:     r.Tags,
+				Links:    r.Links,
+			})
+			return nil
+		})
+	})
+	return snapshot, err
+}
+
+// AppendChanges persists the events of a change in a single transaction
+func (s *Storage[T]) AppendChanges(events []gotree.Event[T]) error {
+	data := make([][]byte, len(events))
+	for i, event := range events {
+		c := change{Type: event.Type, ID: event.Node.ID(), Edge: edgeOf(event.Edge), Meta: event.Meta, Tags: event.Tags}
+		if event.Parent != nil {
+			c.ParentID = event.Parent.ID()
+		}
+
+		if event.Type == gotree.EventAdd || event.Type == gotree.EventUpdate {
+			value, err := s.codec.Marshal(event.Node.Value())
+			if err != nil {
+				return fmt.Errorf("cannot encode the value of %q: %w", c.ID, err)
+			}
+			c.Value = value
+		}
+
+		var err error
+		if data[i], err = json.Marshal(c); err != nil {
+			return fmt.Errorf("cannot encode the change of %q: %w", c.ID, err)
+		}
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(changesBucket)
+		for i, event := range events {
+			if err := bucket.Put(encodeKey(event.Seq), data[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReplayChanges calls apply with the persisted changes following the given sequence number
+func (s *Storage[T]) ReplayChanges(after uint64, apply func(change gotree.Event[T]) error) error {
+	// the changes are decoded in a read transaction and applied outside of it,
+	// since applying them appends to the database when the Tree is not recovering
+	var events []gotree.Event[T]
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(changesBucket).Cursor()
+		for key, data := cursor.Seek(encodeKey(after + 1)); key != nil; key, data = cursor.Next() {
+			event, err := s.decodeChange(decodeKey(key), data)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := apply(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeChange decodes the persisted change with the given sequence number
+func (s *Storage[T]) decodeChange(seq uint64, data []byte) (gotree.Event[T], error) {
+	var c change
+	if err := json.Unmarshal(data, &c); err != nil {
+		return gotree.Event[T]{}, fmt.Errorf("cannot decode change %d: %w", seq, err)
+	}
+
+	var value T
+	if len(c.Value) > 0 {
+		var err error
+		if value, err = s.codec.Unmarshal(c.Value); err != nil {
+			return gotree.Event[T]{}, fmt.Errorf("cannot decode the value of change %d: %w", seq, err)
+		}
+	}
+
+	event := gotree.Event[T]{Seq: seq, Type: c.Type, Node: gotree.NewNode(c.ID, value), Edge: edgeData(c.Edge), Meta: c.Meta, Tags: c.Tags}
+	if c.ParentID != "" {
+		// only the identifier of the parent is needed to replay the change
+		event.Parent = gotree.NewNode(c.ParentID, *new(T))
+	}
+	return event, nil
+}
+
+// edgeOf returns the given edge data, nil when it is zero
+func edgeOf(edge gotree.EdgeData) *gotree.EdgeData {
+	if edge == (gotree.EdgeData{}) {
+		return nil
+	}
+	return &edge
+}
+
+// edgeData returns the given edge data, a zero one when nil
+func edgeData(edge *gotree.EdgeData) gotree.EdgeData {
+	if edge == nil {
+		return gotree.EdgeData{}
+	}
+	return *edge
+}
+
+// encodeKey encodes the given number as a big-endian key so that the keys sort numerically
+func encodeKey(n uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, n)
+	return key
+}
+
+// decodeKey decodes a key encoded with encodeKey
+func decodeKey(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key)
+}
@@ -0,0 +1,161 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreebolt
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/tochemey/gotree"
+)
+
+type item struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestStorage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+
+	open := func(t *testing.T) (*Storage[item], *gotree.Tree[item]) {
+		t.Helper()
+		storage, err := Open(path, gotree.JSONCodec[item]())
+		require.NoError(t, err)
+		tree := gotree.NewTree[item](gotree.WithStorage[item](storage))
+		require.NoError(t, tree.Recover())
+		return storage, tree
+	}
+
+	storage, tree := open(t)
+	assert.Zero(t, tree.Size())
+	require.NoError(t, tree.AddByID(gotree.NewNode("root", item{Name: "root"}), ""))
+	require.NoError(t, tree.AddByID(gotree.NewNode("a", item{Name: "a", Count: 1}), "root"))
+	require.NoError(t, tree.AddByID(gotree.NewNode("b", item{Name: "b", Count: 2}), "root"))
+	require.NoError(t, tree.AddByID(gotree.NewNode("a1", item{Name: "a1"}), "a"))
+	require.NoError(t, tree.Update(gotree.NewNode("a", item{Name: "a", Count: 10})))
+	require.NoError(t, tree.MoveByID("a1", "b"))
+	dump := tree.Dump()
+	require.NoError(t, storage.Close())
+
+	t.Run("survives a restart", func(t *testing.T) {
+		storage, recovered := open(t)
+		defer storage.Close()
+
+		assert.Equal(t, dump, recovered.Dump())
+		assert.EqualValues(t, 6, recovered.LastSeq())
+		node, ok := recovered.Find("a")
+		require.True(t, ok)
+		assert.Equal(t, item{Name: "a", Count: 10}, node.Value())
+	})
+
+	t.Run("compacts the changes covered by a snapshot", func(t *testing.T) {
+		storage, tree := open(t)
+		require.NoError(t, tree.Persist())
+		require.NoError(t, tree.DeleteByID("b"))
+		require.NoError(t, tree.AddByID(gotree.NewNode("c", item{Name: "c"}), "root"))
+
+		var seqs []uint64
+		require.NoError(t, storage.ReplayChanges(0, func(change gotree.Event[item]) error {
+			seqs = append(seqs, change.Seq)
+			return nil
+		}))
+		assert.Equal(t, []uint64{7, 8, 9}, seqs)
+
+		dump := tree.Dump()
+		require.NoError(t, storage.Close())
+
+		storage, recovered := open(t)
+		defer storage.Close()
+		assert.Equal(t, dump, recovered.Dump())
+		assert.Empty(t, recovered.Validate())
+
+		snapshot, err := storage.LoadSnapshot()
+		require.NoError(t, err)
+		assert.EqualValues(t, 6, snapshot.Seq)
+		assert.Equal(t, []gotree.Record[item]{
+			{ID: "root", Value: item{Name: "root"}},
+			{ID: "a", ParentID: "root", Value: item{Name: "a", Count: 10}},
+			{ID: "b", ParentID: "root", Value: item{Name: "b", Count: 2}},
+			{ID: "a1", ParentID: "b", Value: item{Name: "a1"}},
+		}, snapshot.Records)
+	})
+
+	t.Run("keeps the edges, metadata, tags and links", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "annotated.db")
+		open := func(t *testing.T) (*Storage[item], *gotree.Tree[item]) {
+			t.Helper()
+			storage, err := Open(path, gotree.JSONCodec[item]())
+			require.NoError(t, err)
+			tree := gotree.NewTree[item](gotree.WithStorage[item](storage), gotree.WithMultiParent())
+			require.NoError(t, tree.Recover())
+			return storage, tree
+		}
+
+		storage, tree := open(t)
+		root := gotree.NewNode("root", item{Name: "root"})
+		require.NoError(t, tree.AddByID(root, ""))
+		require.NoError(t, tree.AddWithEdge(gotree.NewNode("a", item{Name: "a"}), root, gotree.EdgeData{Label: "left", Weight: 1}))
+		require.NoError(t, tree.AddByID(gotree.NewNode("b", item{Name: "b"}), "root"))
+		require.NoError(t, tree.SetMeta("a", "owner", "alice"))
+		require.NoError(t, tree.Persist())
+
+		require.NoError(t, tree.AddByID(gotree.NewNode("b1", item{Name: "b1"}), "b"))
+		require.NoError(t, tree.Tag("b1", "critical"))
+		require.NoError(t, tree.Link(gotree.NewNode("b1", item{}), gotree.NewNode("a", item{})))
+		require.NoError(t, tree.SetEdge(gotree.NewNode("b", item{}), gotree.EdgeData{Label: "right"}))
+		snapshot := tree.Snapshot()
+		require.NoError(t, storage.Close())
+
+		storage, recovered := open(t)
+		defer storage.Close()
+		assert.Equal(t, snapshot, recovered.Snapshot())
+
+		// the snapshot captures them as well
+		require.NoError(t, recovered.Persist())
+		persisted, err := storage.LoadSnapshot()
+		require.NoError(t, err)
+		assert.Equal(t, snapshot.Records, persisted.Records)
+	})
+
+	t.Run("shares a database", func(t *testing.T) {
+		db, err := bolt.Open(filepath.Join(t.TempDir(), "shared.db"), 0o600, nil)
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage, err := New(db, gotree.JSONCodec[item]())
+		require.NoError(t, err)
+		require.NoError(t, storage.AppendChanges([]gotree.Event[item]{{Seq: 1, Type: gotree.EventAdd, Node: gotree.NewNode("root", item{Name: "root"})}}))
+		require.NoError(t, storage.Close())
+
+		// the database remains usable once the storage is closed
+		tree := gotree.NewTree[item](gotree.WithStorage[item](storage))
+		require.NoError(t, tree.Recover())
+		assert.EqualValues(t, 1, tree.Size())
+	})
+}
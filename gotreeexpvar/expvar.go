@@ -0,0 +1,137 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotreeexpvar publishes the metrics of a gotree.Tree as expvar variables.
+//
+// The variables are exposed by the expvar HTTP handler, under /debug/vars when the
+// default ServeMux is used:
+//
+//	metrics := gotreeexpvar.New("inventory")
+//	tree := gotree.NewTree[string](gotree.WithMetrics(metrics))
+//	metrics.Observe(tree)
+package gotreeexpvar
+
+import (
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/tochemey/gotree"
+)
+
+// buckets are the upper bounds of the latency histogram buckets
+var buckets = []struct {
+	name  string
+	bound time.Duration
+}{
+	{name: "1us", bound: time.Microsecond},
+	{name: "10us", bound: 10 * time.Microsecond},
+	{name: "100us", bound: 100 * time.Microsecond},
+	{name: "1ms", bound: time.Millisecond},
+	{name: "10ms", bound: 10 * time.Millisecond},
+	{name: "100ms", bound: 100 * time.Millisecond},
+	{name: "1s", bound: time.Second},
+}
+
+// overflow is the name of the bucket of the latencies above the last bound
+const overflow = "+Inf"
+
+// Metrics implements gotree.Metrics by publishing an expvar.Map holding, for every operation:
+//   - "<operation>_total": the number of operations.
+//   - "<operation>_errors_total": the number of failed operations.
+//   - "<operation>_duration_ns_total": the cumulated duration of the operations in nanoseconds.
+//   - "<operation>_duration": the histogram of the durations, counting the operations by
+//     latency upper bound ("1us", "10us", ..., "1s" and "+Inf").
+//
+// Observe adds the "size" and "depth" gauges of a Tree.
+type Metrics struct {
+	vars       *expvar.Map
+	mu         sync.Mutex
+	histograms sync.Map
+}
+
+var _ gotree.Metrics = (*Metrics)(nil)
+
+// New creates a Metrics publishing its variables under the given name.
+// It panics when the name is already published, like expvar.Publish.
+func New(name string) *Metrics {
+	return &Metrics{
+		vars: expvar.NewMap(name),
+	}
+}
+
+// Vars returns the expvar.Map holding the variables
+func (m *Metrics) Vars() *expvar.Map {
+	return m.vars
+}
+
+// ObserveOperation implements gotree.Metrics
+func (m *Metrics) ObserveOperation(op gotree.Operation, duration time.Duration, err error) {
+	operation := string(op)
+	m.vars.Add(operation+"_total", 1)
+	if err != nil {
+		m.vars.Add(operation+"_errors_total", 1)
+	}
+	m.vars.Add(operation+"_duration_ns_total", duration.Nanoseconds())
+	m.histogram(operation).Add(bucket(duration), 1)
+}
+
+// Observe publishes the "size" and "depth" gauges of the given Tree.
+// The depth is computed on every read of the variables by walking the Tree.
+func (m *Metrics) Observe(tree gotree.Gauges) {
+	m.vars.Set("size", expvar.Func(func() any {
+		return tree.Size()
+	}))
+	m.vars.Set("depth", expvar.Func(func() any {
+		return tree.Depth()
+	}))
+}
+
+// histogram returns the histogram of the given operation, creating it when missing
+func (m *Metrics) histogram(operation string) *expvar.Map {
+	if histogram, ok := m.histograms.Load(operation); ok {
+		return histogram.(*expvar.Map)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if histogram, ok := m.histograms.Load(operation); ok {
+		return histogram.(*expvar.Map)
+	}
+
+	histogram := new(expvar.Map).Init()
+	m.vars.Set(operation+"_duration", histogram)
+	m.histograms.Store(operation, histogram)
+	return histogram
+}
+
+// bucket returns the name of the histogram bucket of the given duration
+func bucket(duration time.Duration) string {
+	for _, b := range buckets {
+		if duration <= b.bound {
+			return b.name
+		}
+	}
+	return overflow
+}
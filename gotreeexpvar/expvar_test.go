@@ -0,0 +1,78 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreeexpvar
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tochemey/gotree"
+)
+
+func TestMetrics(t *testing.T) {
+	metrics := New("gotree_test")
+	tree := gotree.NewTree[string](gotree.WithMetrics(metrics))
+	metrics.Observe(tree)
+
+	root := gotree.NewNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.Add(gotree.NewNode("a", "a"), root))
+	require.Error(t, tree.Add(gotree.NewNode("a", "a"), root))
+	_, _ = tree.Find("x")
+
+	var vars map[string]any
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("gotree_test").String()), &vars))
+
+	assert.EqualValues(t, 3, vars["add_total"])
+	assert.EqualValues(t, 1, vars["add_errors_total"])
+	assert.EqualValues(t, 1, vars["find_total"])
+	assert.EqualValues(t, 1, vars["find_errors_total"])
+	assert.Contains(t, vars, "add_duration_ns_total")
+	assert.EqualValues(t, 2, vars["size"])
+	assert.EqualValues(t, 1, vars["depth"])
+
+	var count float64
+	for _, value := range vars["add_duration"].(map[string]any) {
+		count += value.(float64)
+	}
+	assert.EqualValues(t, 3, count)
+}
+
+func TestBucket(t *testing.T) {
+	assert.Equal(t, "1us", bucket(500*time.Nanosecond))
+	assert.Equal(t, "1ms", bucket(time.Millisecond))
+	assert.Equal(t, "1s", bucket(800*time.Millisecond))
+	assert.Equal(t, overflow, bucket(2*time.Second))
+
+	metrics := New("gotree_bucket_test")
+	metrics.ObserveOperation(gotree.OperationDelete, 2*time.Second, errors.New("boom"))
+	assert.Equal(t, "1", metrics.Vars().Get("delete_duration").(*expvar.Map).Get(overflow).String())
+}
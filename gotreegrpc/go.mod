@@ -0,0 +1,21 @@
+module github.com/tochemey/gotree/gotreegrpc
+
+go 1.22.0
+
+require (
+	github.com/stretchr/testify v1.10.0
+	github.com/tochemey/gotree v0.0.0-20261016184310-55f523293600
+	google.golang.org/grpc v1.67.3
+	google.golang.org/protobuf v1.36.5
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240814211410-ddb44dafa142 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,246 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotreegrpc gives remote access to a gotree.Tree over gRPC, so that the hierarchy
+// can be owned by one process and consumed by many.
+//
+// The service is defined in treepb/tree.proto; the generated client lives in the treepb
+// package. The values of the Nodes travel as bytes encoded by a Codec:
+//
+//	server := grpc.NewServer()
+//	treepb.RegisterTreeServiceServer(server, gotreegrpc.NewServer(tree, gotree.JSONCodec[string]()))
+package gotreegrpc
+
+//go:generate buf generate
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tochemey/gotree"
+	"github.com/tochemey/gotree/gotreegrpc/treepb"
+)
+
+// Server implements treepb.TreeServiceServer on top of a Tree.
+//
+// The errors of the Tree are mapped to gRPC status codes:
+//   - gotree.ErrNotFound and gotree.ErrParentNodeNotFound: codes.NotFound.
+//   - gotree.ErrDuplicateID: codes.AlreadyExists.
+//   - gotree.ErrInvalidOperation and gotree.ErrInvalidID: codes.FailedPrecondition.
+//   - gotree.ErrMaxDepthExceeded, gotree.ErrMaxChildrenExceeded and gotree.ErrMaxSizeExceeded:
+//     codes.ResourceExhausted.
+type Server[T any] struct {
+	treepb.UnimplementedTreeServiceServer
+
+	tree  *gotree.Tree[T]
+	codec gotree.Codec[T]
+}
+
+var _ treepb.TreeServiceServer = (*Server[any])(nil)
+
+// NewServer creates a Server serving the given Tree and encoding its values with the given Codec
+func NewServer[T any](tree *gotree.Tree[T], codec gotree.Codec[T]) *Server[T] {
+	return &Server[T]{
+		tree:  tree,
+		codec: codec,
+	}
+}
+
+// Get returns a Node along with its parent, its children and its version
+func (s *Server[T]) Get(_ context.Context, request *treepb.GetRequest) (*treepb.GetResponse, error) {
+	node, version, ok := s.tree.FindWithVersion(request.GetId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "node %q not found", request.GetId())
+	}
+
+	message, err := s.toNode(node)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &treepb.GetResponse{
+		Node:    message,
+		Version: version,
+	}
+
+	if parent, ok := s.tree.ParentAt(node, 0); ok {
+		response.ParentId = parent.ID()
+	}
+
+	if cursor, ok := s.tree.Cursor(node); ok {
+		for child, ok := cursor.FirstChild(); ok; child, ok = cursor.NextSibling() {
+			response.ChildrenIds = append(response.ChildrenIds, child.ID())
+		}
+	}
+	return response, nil
+}
+
+// Add adds a Node under its parent, or as the root when no parent is given
+func (s *Server[T]) Add(_ context.Context, request *treepb.AddRequest) (*treepb.AddResponse, error) {
+	if request.GetNode().GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "node id is required")
+	}
+
+	value, err := s.codec.Unmarshal(request.GetNode().GetValue())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot decode the value of node %q: %v", request.GetNode().GetId(), err)
+	}
+
+	if err := s.tree.AddByID(gotree.NewNode(request.GetNode().GetId(), value), request.GetParentId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &treepb.AddResponse{}, nil
+}
+
+// Delete deletes a Node along with its descendants
+func (s *Server[T]) Delete(_ context.Context, request *treepb.DeleteRequest) (*treepb.DeleteResponse, error) {
+	removed, err := s.tree.DeleteAndCollect(gotree.NewNode(request.GetId(), *new(T)))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &treepb.DeleteResponse{RemovedIds: make([]string, len(removed))}
+	for i, node := range removed {
+		response.RemovedIds[i] = node.ID()
+	}
+	return response, nil
+}
+
+// Move moves a Node along with its descendants under another parent
+func (s *Server[T]) Move(_ context.Context, request *treepb.MoveRequest) (*treepb.MoveResponse, error) {
+	if err := s.tree.MoveByID(request.GetId(), request.GetParentId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &treepb.MoveResponse{}, nil
+}
+
+// Watch streams the changes of the Tree until the client cancels the call.
+// Only the additions, updates, deletions and moves are streamed: the changes of the edges,
+// metadata, tags and links of the Nodes have no message.
+// The call fails with codes.ResourceExhausted when the client does not keep up with the changes.
+func (s *Server[T]) Watch(request *treepb.WatchRequest, stream grpc.ServerStreamingServer[treepb.Event]) error {
+	filter := func(event gotree.Event[T]) bool {
+		return toEventType(event.Type) != treepb.EventType_EVENT_TYPE_UNSPECIFIED
+	}
+	if len(request.GetTypes()) > 0 {
+		types := make(map[treepb.EventType]struct{}, len(request.GetTypes()))
+		for _, eventType := range request.GetTypes() {
+			types[eventType] = struct{}{}
+		}
+		filter = func(event gotree.Event[T]) bool {
+			eventType := toEventType(event.Type)
+			_, ok := types[eventType]
+			return ok && eventType != treepb.EventType_EVENT_TYPE_UNSPECIFIED
+		}
+	}
+
+	var opts []gotree.WatchOption
+	if size := request.GetBufferSize(); size > 0 {
+		opts = append(opts, gotree.WithBufferSize(int(size)))
+	}
+
+	ctx := stream.Context()
+	for event := range s.tree.Watch(ctx, filter, opts...) {
+		node, err := s.toNode(event.Node)
+		if err != nil {
+			return err
+		}
+
+		message := &treepb.Event{
+			Seq:  event.Seq,
+			Type: toEventType(event.Type),
+			Node: node,
+		}
+		if event.Parent != nil {
+			message.ParentId = event.Parent.ID()
+		}
+
+		if err := stream.Send(message); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.ResourceExhausted, "watcher disconnected: the changes were not received fast enough")
+}
+
+// toNode converts the given Node to its message
+func (s *Server[T]) toNode(node gotree.Node[T]) (*treepb.Node, error) {
+	value, err := s.codec.Marshal(node.Value())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot encode the value of node %q: %v", node.ID(), err)
+	}
+	return &treepb.Node{Id: node.ID(), Value: value}, nil
+}
+
+// toEventType converts the given EventType to its message
+func toEventType(eventType gotree.EventType) treepb.EventType {
+	switch eventType {
+	case gotree.EventAdd:
+		return treepb.EventType_EVENT_TYPE_ADD
+	case gotree.EventUpdate:
+		return treepb.EventType_EVENT_TYPE_UPDATE
+	case gotree.EventDelete:
+		return treepb.EventType_EVENT_TYPE_DELETE
+	case gotree.EventMove:
+		return treepb.EventType_EVENT_TYPE_MOVE
+	default:
+		return treepb.EventType_EVENT_TYPE_UNSPECIFIED
+	}
+}
+
+// toStatus converts the given error of the Tree to a gRPC status error.
+//
+// A change the Storage failed to persist has been rejected, leaving the Tree unchanged: it is reported
+// as Unavailable so that the clients retry it
+func toStatus(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, gotree.ErrNotPersisted):
+		code = codes.Unavailable
+	case errors.Is(err, gotree.ErrThrottled), errors.Is(err, gotree.ErrQuotaExceeded):
+		code = codes.ResourceExhausted
+	case errors.Is(err, gotree.ErrPermissionDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, gotree.ErrVersionMismatch):
+		code = codes.Aborted
+	case errors.Is(err, gotree.ErrNotFound), errors.Is(err, gotree.ErrParentNodeNotFound):
+		code = codes.NotFound
+	case errors.Is(err, gotree.ErrDuplicateID):
+		code = codes.AlreadyExists
+	case errors.Is(err, gotree.ErrInvalidOperation), errors.Is(err, gotree.ErrInvalidID):
+		code = codes.FailedPrecondition
+	case errors.Is(err, gotree.ErrMaxDepthExceeded),
+		errors.Is(err, gotree.ErrMaxChildrenExceeded),
+		errors.Is(err, gotree.ErrMaxSizeExceeded):
+		code = codes.ResourceExhausted
+	}
+	return status.Error(code, err.Error())
+}
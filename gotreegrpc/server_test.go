@@ -0,0 +1,255 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreegrpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tochemey/gotree"
+	"github.com/tochemey/gotree/gotreegrpc/treepb"
+)
+
+// serve serves the given Tree in memory and returns a client
+func serve(t *testing.T, tree *gotree.Tree[string]) treepb.TreeServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	treepb.RegisterTreeServiceServer(server, NewServer(tree, gotree.JSONCodec[string]()))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return treepb.NewTreeServiceClient(conn)
+}
+
+func node(id, value string) *treepb.Node {
+	return &treepb.Node{Id: id, Value: []byte(`"` + value + `"`)}
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	tree := gotree.NewTree[string]()
+	client := serve(t, tree)
+
+	_, err := client.Add(ctx, &treepb.AddRequest{Node: node("root", "Root")})
+	require.NoError(t, err)
+	for _, request := range []*treepb.AddRequest{
+		{Node: node("a", "A"), ParentId: "root"},
+		{Node: node("a1", "A1"), ParentId: "a"},
+		{Node: node("b", "B"), ParentId: "root"},
+	} {
+		_, err := client.Add(ctx, request)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 4, tree.Size())
+
+	t.Run("get", func(t *testing.T) {
+		response, err := client.Get(ctx, &treepb.GetRequest{Id: "a"})
+		require.NoError(t, err)
+		assert.Equal(t, "a", response.GetNode().GetId())
+		assert.Equal(t, `"A"`, string(response.GetNode().GetValue()))
+		assert.Equal(t, "root", response.GetParentId())
+		assert.Equal(t, []string{"a1"}, response.GetChildrenIds())
+		assert.EqualValues(t, 2, response.GetVersion())
+
+		response, err = client.Get(ctx, &treepb.GetRequest{Id: "root"})
+		require.NoError(t, err)
+		assert.Empty(t, response.GetParentId())
+		assert.Equal(t, []string{"a", "b"}, response.GetChildrenIds())
+	})
+
+	t.Run("move", func(t *testing.T) {
+		_, err := client.Move(ctx, &treepb.MoveRequest{Id: "a1", ParentId: "b"})
+		require.NoError(t, err)
+
+		response, err := client.Get(ctx, &treepb.GetRequest{Id: "a1"})
+		require.NoError(t, err)
+		assert.Equal(t, "b", response.GetParentId())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		response, err := client.Delete(ctx, &treepb.DeleteRequest{Id: "b"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b", "a1"}, response.GetRemovedIds())
+		assert.EqualValues(t, 2, tree.Size())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			call func() error
+			code codes.Code
+		}{
+			{name: "unknown node", call: func() error {
+				_, err := client.Get(ctx, &treepb.GetRequest{Id: "missing"})
+				return err
+			}, code: codes.NotFound},
+			{name: "unknown parent", call: func() error {
+				_, err := client.Add(ctx, &treepb.AddRequest{Node: node("c", "C"), ParentId: "missing"})
+				return err
+			}, code: codes.NotFound},
+			{name: "duplicate", call: func() error {
+				_, err := client.Add(ctx, &treepb.AddRequest{Node: node("a", "A"), ParentId: "root"})
+				return err
+			}, code: codes.AlreadyExists},
+			{name: "second root", call: func() error {
+				_, err := client.Add(ctx, &treepb.AddRequest{Node: node("c", "C")})
+				return err
+			}, code: codes.FailedPrecondition},
+			{name: "missing id", call: func() error {
+				_, err := client.Add(ctx, &treepb.AddRequest{Node: node("", "C"), ParentId: "root"})
+				return err
+			}, code: codes.InvalidArgument},
+			{name: "invalid value", call: func() error {
+				_, err := client.Add(ctx, &treepb.AddRequest{Node: &treepb.Node{Id: "c", Value: []byte("{")}, ParentId: "root"})
+				return err
+			}, code: codes.InvalidArgument},
+			{name: "move root", call: func() error {
+				_, err := client.Move(ctx, &treepb.MoveRequest{Id: "root", ParentId: "a"})
+				return err
+			}, code: codes.FailedPrecondition},
+			{name: "delete unknown node", call: func() error {
+				_, err := client.Delete(ctx, &treepb.DeleteRequest{Id: "missing"})
+				return err
+			}, code: codes.NotFound},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				assert.Equal(t, tc.code, status.Code(tc.call()))
+			})
+		}
+	})
+}
+
+func TestToStatus(t *testing.T) {
+	testCases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{err: gotree.ErrNotFound, code: codes.NotFound},
+		{err: gotree.ErrParentNodeNotFound, code: codes.NotFound},
+		{err: gotree.ErrDuplicateID, code: codes.AlreadyExists},
+		{err: gotree.ErrInvalidOperation, code: codes.FailedPrecondition},
+		{err: gotree.ErrMaxSizeExceeded, code: codes.ResourceExhausted},
+		{err: gotree.ErrQuotaExceeded, code: codes.ResourceExhausted},
+		{err: fmt.Errorf("%w: %w", gotree.ErrThrottled, context.DeadlineExceeded), code: codes.ResourceExhausted},
+		{err: gotree.ErrPermissionDenied, code: codes.PermissionDenied},
+		{err: gotree.ErrVersionMismatch, code: codes.Aborted},
+		{err: fmt.Errorf("%w: change 3: %w", gotree.ErrNotPersisted, gotree.ErrNotFound), code: codes.Unavailable},
+		{err: gotree.ErrCorruptedFile, code: codes.Internal},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			err := toStatus(fmt.Errorf("gotree: %w", tc.err))
+			assert.Equal(t, tc.code, status.Code(err))
+			assert.Contains(t, status.Convert(err).Message(), tc.err.Error())
+		})
+	}
+}
+
+func TestServerWatch(t *testing.T) {
+	tree := gotree.NewTree[string]()
+	client := serve(t, tree)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &treepb.WatchRequest{Types: []treepb.EventType{
+		treepb.EventType_EVENT_TYPE_ADD,
+		treepb.EventType_EVENT_TYPE_MOVE,
+	}})
+	require.NoError(t, err)
+
+	events := make(chan *treepb.Event, 16)
+	go func() {
+		defer close(events)
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			events <- event
+		}
+	}()
+
+	// the subscription is registered asynchronously: add probes until one is received
+	require.NoError(t, tree.AddByID(gotree.NewNode("root", "Root"), ""))
+	probes := 0
+	require.Eventually(t, func() bool {
+		probes++
+		require.NoError(t, tree.AddByID(gotree.NewNode(fmt.Sprintf("probe%d", probes), "probe"), "root"))
+		select {
+		case <-events:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, 2*time.Second, time.Millisecond)
+
+	require.NoError(t, tree.AddByID(gotree.NewNode("a", "A"), "root"))
+	require.NoError(t, tree.AddByID(gotree.NewNode("b", "B"), "root"))
+	require.NoError(t, tree.Update(gotree.NewNode("a", "A2")))
+	require.NoError(t, tree.MoveByID("a", "b"))
+
+	var received []string
+	for event := range events {
+		if strings.HasPrefix(event.GetNode().GetId(), "probe") {
+			continue
+		}
+		received = append(received, event.GetType().String()+" "+event.GetNode().GetId()+" "+event.GetParentId())
+		if len(received) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"EVENT_TYPE_ADD a root", "EVENT_TYPE_ADD b root", "EVENT_TYPE_MOVE a b"}, received)
+
+	cancel()
+	for range events {
+	}
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
@@ -0,0 +1,251 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotreehttp exposes a gotree.Tree over HTTP for debugging and inspection.
+//
+// The Handler serves the following read-only endpoints:
+//   - GET /: the Tree rendered as an HTML page.
+//   - GET /tree: the Tree rendered as nested JSON.
+//   - GET /nodes/{id}: a Node along with its parent, depth and children IDs.
+//   - GET /nodes/{id}/children: the children of a Node in insertion order.
+//   - GET /nodes/{id}/descendants: the descendants of a Node.
+//
+// The values of the Nodes are encoded with encoding/json. Mount the Handler under a
+// prefix with http.StripPrefix:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/debug/tree/", http.StripPrefix("/debug/tree", gotreehttp.New(tree)))
+package gotreehttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/tochemey/gotree"
+)
+
+// Node is the JSON representation of a Node
+type Node[T any] struct {
+	ID    string `json:"id"`
+	Value T      `json:"value"`
+}
+
+// NodeInfo is the JSON representation of a Node along with its position in the Tree
+type NodeInfo[T any] struct {
+	ID       string   `json:"id"`
+	Value    T        `json:"value"`
+	Parent   string   `json:"parent,omitempty"`
+	Depth    int      `json:"depth"`
+	Children []string `json:"children"`
+}
+
+// Subtree is the nested JSON representation of a Node and its descendants
+type Subtree[T any] struct {
+	ID       string        `json:"id"`
+	Value    T             `json:"value"`
+	Children []*Subtree[T] `json:"children,omitempty"`
+}
+
+// Handler is an http.Handler serving a read-only view of a Tree
+type Handler[T any] struct {
+	tree *gotree.Tree[T]
+	mux  *http.ServeMux
+}
+
+var _ http.Handler = (*Handler[any])(nil)
+
+// New creates a Handler serving the given Tree
+func New[T any](tree *gotree.Tree[T]) *Handler[T] {
+	h := &Handler[T]{
+		tree: tree,
+		mux:  http.NewServeMux(),
+	}
+
+	h.mux.HandleFunc("GET /{$}", h.page)
+	h.mux.HandleFunc("GET /tree", h.subtree)
+	h.mux.HandleFunc("GET /nodes/{id}", h.node)
+	h.mux.HandleFunc("GET /nodes/{id}/children", h.children)
+	h.mux.HandleFunc("GET /nodes/{id}/descendants", h.descendants)
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// node serves a Node along with its position in the Tree
+func (h *Handler[T]) node(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	node, ok := h.tree.Find(id)
+	if !ok {
+		notFound(w, id)
+		return
+	}
+
+	ancestors, _ := h.tree.AncestorsByID(id)
+	info := NodeInfo[T]{
+		ID:       node.ID(),
+		Value:    node.Value(),
+		Depth:    len(ancestors),
+		Children: []string{},
+	}
+
+	if parent, ok := h.tree.ParentAt(node, 0); ok {
+		info.Parent = parent.ID()
+	}
+
+	for _, child := range h.childrenOf(node) {
+		info.Children = append(info.Children, child.ID())
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+// children serves the children of a Node
+func (h *Handler[T]) children(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	node, ok := h.tree.Find(id)
+	if !ok {
+		notFound(w, id)
+		return
+	}
+	writeJSON(w, http.StatusOK, toNodes(h.childrenOf(node)))
+}
+
+// descendants serves the descendants of a Node
+func (h *Handler[T]) descendants(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	descendants, ok := h.tree.DescendantsByID(id)
+	if !ok {
+		notFound(w, id)
+		return
+	}
+	writeJSON(w, http.StatusOK, toNodes(descendants))
+}
+
+// subtree serves the whole Tree as nested JSON, null when the Tree is empty
+func (h *Handler[T]) subtree(w http.ResponseWriter, _ *http.Request) {
+	root, ok := h.tree.RootOK()
+	if !ok {
+		writeJSON(w, http.StatusOK, nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.build(root))
+}
+
+// page serves the whole Tree as an HTML page
+func (h *Handler[T]) page(w http.ResponseWriter, _ *http.Request) {
+	data := struct {
+		Size int64
+		Root *Subtree[T]
+	}{Size: h.tree.Size()}
+
+	if root, ok := h.tree.RootOK(); ok {
+		data.Root = h.build(root)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := pageTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// build returns the Subtree rooted at the given Node
+func (h *Handler[T]) build(node gotree.Node[T]) *Subtree[T] {
+	subtree := &Subtree[T]{ID: node.ID(), Value: node.Value()}
+	for _, child := range h.childrenOf(node) {
+		subtree.Children = append(subtree.Children, h.build(child))
+	}
+	return subtree
+}
+
+// childrenOf returns the children of the given Node in insertion order
+func (h *Handler[T]) childrenOf(node gotree.Node[T]) []gotree.Node[T] {
+	cursor, ok := h.tree.Cursor(node)
+	if !ok {
+		return nil
+	}
+
+	var children []gotree.Node[T]
+	for child, ok := cursor.FirstChild(); ok; child, ok = cursor.NextSibling() {
+		children = append(children, child)
+	}
+	return children
+}
+
+// toNodes converts the given Nodes to their JSON representation
+func toNodes[T any](nodes []gotree.Node[T]) []Node[T] {
+	items := make([]Node[T], 0, len(nodes))
+	for _, node := range nodes {
+		items = append(items, Node[T]{ID: node.ID(), Value: node.Value()})
+	}
+	return items
+}
+
+// notFound writes the error of an unknown Node
+func notFound(w http.ResponseWriter, id string) {
+	writeJSON(w, http.StatusNotFound, map[string]string{
+		"error": fmt.Sprintf("node %q not found", id),
+	})
+}
+
+// writeJSON writes the given payload as JSON with the given status code
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(body, '\n'))
+}
+
+// pageTemplate renders the Tree as nested lists linking to the Node endpoints
+var pageTemplate = template.Must(template.New("page").
+	Funcs(template.FuncMap{"pathEscape": url.PathEscape}).
+	Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>gotree</title>
+<style>
+body { font-family: monospace; }
+ul { list-style: none; border-left: 1px solid #ccc; margin: 0; padding-left: 1.5em; }
+.value { color: #555; }
+</style>
+</head>
+<body>
+<h1>Tree</h1>
+<p>{{.Size}} nodes. <a href="tree">JSON</a></p>
+{{if .Root}}<ul>{{template "node" .Root}}</ul>{{else}}<p>The tree is empty.</p>{{end}}
+</body>
+</html>
+{{define "node"}}<li><a href="nodes/{{pathEscape .ID}}">{{.ID}}</a> <span class="value">{{printf "%v" .Value}}</span>{{if .Children}}
+<ul>{{range .Children}}{{template "node" .}}{{end}}</ul>{{end}}</li>
+{{end}}`))
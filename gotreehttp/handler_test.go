@@ -0,0 +1,152 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreehttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tochemey/gotree"
+)
+
+func newTree(t *testing.T) *gotree.Tree[string] {
+	tree := gotree.NewTree[string]()
+	root := gotree.NewNode("root", "Root")
+	a := gotree.NewNode("a", "A")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.Add(a, root))
+	require.NoError(t, tree.Add(gotree.NewNode("a/1", "<A1>"), a))
+	require.NoError(t, tree.Add(gotree.NewNode("b", "B"), root))
+	return tree
+}
+
+func get(t *testing.T, handler http.Handler, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	return recorder
+}
+
+func decode[V any](t *testing.T, recorder *httptest.ResponseRecorder) V {
+	var payload V
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &payload))
+	return payload
+}
+
+func TestHandler(t *testing.T) {
+	handler := New(newTree(t))
+
+	t.Run("node", func(t *testing.T) {
+		recorder := get(t, handler, "/nodes/a")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, NodeInfo[string]{ID: "a", Value: "A", Parent: "root", Depth: 1, Children: []string{"a/1"}}, decode[NodeInfo[string]](t, recorder))
+
+		recorder = get(t, handler, "/nodes/root")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, NodeInfo[string]{ID: "root", Value: "Root", Children: []string{"a", "b"}}, decode[NodeInfo[string]](t, recorder))
+
+		recorder = get(t, handler, "/nodes/a%2F1")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, NodeInfo[string]{ID: "a/1", Value: "<A1>", Parent: "a", Depth: 2, Children: []string{}}, decode[NodeInfo[string]](t, recorder))
+	})
+
+	t.Run("children", func(t *testing.T) {
+		recorder := get(t, handler, "/nodes/root/children")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, []Node[string]{{ID: "a", Value: "A"}, {ID: "b", Value: "B"}}, decode[[]Node[string]](t, recorder))
+
+		recorder = get(t, handler, "/nodes/b/children")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, decode[[]Node[string]](t, recorder))
+	})
+
+	t.Run("descendants", func(t *testing.T) {
+		recorder := get(t, handler, "/nodes/root/descendants")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.ElementsMatch(t, []Node[string]{{ID: "a", Value: "A"}, {ID: "a/1", Value: "<A1>"}, {ID: "b", Value: "B"}}, decode[[]Node[string]](t, recorder))
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		recorder := get(t, handler, "/tree")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		expected := &Subtree[string]{ID: "root", Value: "Root", Children: []*Subtree[string]{
+			{ID: "a", Value: "A", Children: []*Subtree[string]{{ID: "a/1", Value: "<A1>"}}},
+			{ID: "b", Value: "B"},
+		}}
+		assert.Equal(t, expected, decode[*Subtree[string]](t, recorder))
+	})
+
+	t.Run("page", func(t *testing.T) {
+		recorder := get(t, handler, "/")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+		body := recorder.Body.String()
+		assert.Contains(t, body, "4 nodes.")
+		assert.Contains(t, body, `<a href="nodes/a%2F1">a/1</a>`)
+		assert.Contains(t, body, "&lt;A1&gt;")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		for _, target := range []string{"/nodes/missing", "/nodes/missing/children", "/nodes/missing/descendants"} {
+			recorder := get(t, handler, target)
+			require.Equal(t, http.StatusNotFound, recorder.Code, target)
+			assert.Equal(t, map[string]string{"error": `node "missing" not found`}, decode[map[string]string](t, recorder))
+		}
+		assert.Equal(t, http.StatusNotFound, get(t, handler, "/unknown").Code)
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/nodes/a", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
+
+func TestHandlerEmptyTree(t *testing.T) {
+	handler := New(gotree.NewTree[int]())
+
+	recorder := get(t, handler, "/tree")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Nil(t, decode[*Subtree[int]](t, recorder))
+
+	recorder = get(t, handler, "/")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The tree is empty.")
+}
+
+func TestHandlerPrefix(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/tree/", http.StripPrefix("/debug/tree", New(newTree(t))))
+
+	recorder := get(t, mux, "/debug/tree/nodes/b")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "b", decode[NodeInfo[string]](t, recorder).ID)
+	assert.Equal(t, http.StatusOK, get(t, mux, "/debug/tree/").Code)
+}
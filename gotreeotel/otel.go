@@ -0,0 +1,82 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotreeotel traces the expensive operations of a gotree.Tree with OpenTelemetry.
+//
+//	tree := gotree.NewTree[string](gotreeotel.WithTracer(otel.Tracer("inventory")))
+//
+// Every traced operation creates a span named "gotree.<operation>" (e.g. "gotree.delete")
+// carrying the number of Nodes it processed in the "gotree.nodes" attribute. Since the Tree
+// methods do not take a context, the spans are root spans.
+package gotreeotel
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tochemey/gotree"
+)
+
+const (
+	// SpanPrefix prefixes the name of the spans
+	SpanPrefix = "gotree."
+	// NodesKey is the attribute holding the number of Nodes processed by an operation
+	NodesKey = attribute.Key("gotree.nodes")
+)
+
+// Tracer implements gotree.Tracer on top of an OpenTelemetry trace.Tracer
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ gotree.Tracer = (*Tracer)(nil)
+
+// NewTracer creates a Tracer starting its spans with the given OpenTelemetry tracer
+func NewTracer(tracer trace.Tracer) *Tracer {
+	return &Tracer{tracer: tracer}
+}
+
+// WithTracer returns the gotree.Option tracing the expensive operations of the Tree
+// with the given OpenTelemetry tracer.
+func WithTracer(tracer trace.Tracer) gotree.Option {
+	return gotree.WithTracer(NewTracer(tracer))
+}
+
+// StartSpan implements gotree.Tracer
+func (t *Tracer) StartSpan(operation gotree.Operation) (end func(nodes int, err error)) {
+	name := SpanPrefix + strings.ReplaceAll(string(operation), " ", "_")
+	_, span := t.tracer.Start(context.Background(), name, trace.WithSpanKind(trace.SpanKindInternal))
+	return func(nodes int, err error) {
+		span.SetAttributes(NodesKey.Int(nodes))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
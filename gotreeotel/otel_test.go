@@ -0,0 +1,81 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreeotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/tochemey/gotree"
+)
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tree := gotree.NewTree[string](WithTracer(provider.Tracer("test")))
+
+	root := gotree.NewNode("root", "root")
+	a := gotree.NewNode("a", "a")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.Add(a, root))
+	require.NoError(t, tree.Add(gotree.NewNode("b", "b"), a))
+
+	descendants, ok := tree.Descendants(root)
+	require.True(t, ok)
+	require.Len(t, descendants, 2)
+	require.NoError(t, tree.CreateIndex("value", func(value string) string { return value }))
+	require.NoError(t, tree.Delete(a))
+	require.Error(t, tree.Delete(a))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+
+	expected := []struct {
+		name  string
+		nodes int64
+		err   bool
+	}{
+		{name: "gotree.descendants", nodes: 2},
+		{name: "gotree.create_index", nodes: 3},
+		{name: "gotree.delete", nodes: 2},
+		{name: "gotree.delete", nodes: 0, err: true},
+	}
+	for i, span := range spans {
+		assert.Equal(t, expected[i].name, span.Name())
+		require.Len(t, span.Attributes(), 1)
+		assert.Equal(t, NodesKey, span.Attributes()[0].Key)
+		assert.Equal(t, expected[i].nodes, span.Attributes()[0].Value.AsInt64())
+		if expected[i].err {
+			assert.Equal(t, codes.Error, span.Status().Code)
+		} else {
+			assert.Equal(t, codes.Unset, span.Status().Code)
+		}
+	}
+}
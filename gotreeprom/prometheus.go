@@ -0,0 +1,177 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotreeprom exposes the metrics of a gotree.Tree to Prometheus.
+//
+//	metrics, err := gotreeprom.New(prometheus.DefaultRegisterer, gotreeprom.WithConstLabels(prometheus.Labels{"tree": "inventory"}))
+//	if err != nil {
+//	    return err
+//	}
+//	tree := gotree.NewTree[string](gotree.WithMetrics(metrics))
+//	if err := metrics.Observe(tree); err != nil {
+//	    return err
+//	}
+package gotreeprom
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/tochemey/gotree"
+)
+
+// DefaultNamespace is the default namespace of the metrics
+const DefaultNamespace = "gotree"
+
+// the values of the outcome label
+const (
+	outcomeSuccess = "success"
+	outcomeError   = "error"
+)
+
+// Option configures the Metrics
+type Option func(cfg *config)
+
+// config holds the Metrics settings
+type config struct {
+	namespace   string
+	constLabels prometheus.Labels
+	buckets     []float64
+}
+
+// WithNamespace sets the namespace prefixing the name of the metrics. It defaults to DefaultNamespace.
+func WithNamespace(namespace string) Option {
+	return func(cfg *config) {
+		cfg.namespace = namespace
+	}
+}
+
+// WithConstLabels sets the labels attached to all the metrics, e.g. to tell several Trees apart.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(cfg *config) {
+		cfg.constLabels = labels
+	}
+}
+
+// WithBuckets sets the buckets of the latency histogram, in seconds. It defaults to
+// exponential buckets from 1µs to about 1s.
+func WithBuckets(buckets []float64) Option {
+	return func(cfg *config) {
+		cfg.buckets = buckets
+	}
+}
+
+// Metrics implements gotree.Metrics with the following Prometheus metrics:
+//   - <namespace>_operations_total: a counter of the operations by operation and outcome
+//     ("success" or "error").
+//   - <namespace>_operation_duration_seconds: a histogram of the latency of the operations
+//     by operation.
+//
+// Observe adds the <namespace>_size and <namespace>_depth gauges of a Tree.
+type Metrics struct {
+	cfg        *config
+	registerer prometheus.Registerer
+	operations *prometheus.CounterVec
+	durations  *prometheus.HistogramVec
+}
+
+var _ gotree.Metrics = (*Metrics)(nil)
+
+// New creates a Metrics and registers its collectors with the given registerer.
+// It returns the registration error, e.g. when the metrics are already registered.
+func New(registerer prometheus.Registerer, opts ...Option) (*Metrics, error) {
+	cfg := &config{
+		namespace: DefaultNamespace,
+		buckets:   prometheus.ExponentialBuckets(0.000001, 4, 11),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	metrics := &Metrics{
+		cfg:        cfg,
+		registerer: registerer,
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   cfg.namespace,
+			Name:        "operations_total",
+			Help:        "Number of tree operations by operation and outcome.",
+			ConstLabels: cfg.constLabels,
+		}, []string{"operation", "outcome"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   cfg.namespace,
+			Name:        "operation_duration_seconds",
+			Help:        "Latency of the tree operations.",
+			ConstLabels: cfg.constLabels,
+			Buckets:     cfg.buckets,
+		}, []string{"operation"}),
+	}
+
+	for _, collector := range []prometheus.Collector{metrics.operations, metrics.durations} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return metrics, nil
+}
+
+// ObserveOperation implements gotree.Metrics
+func (m *Metrics) ObserveOperation(operation gotree.Operation, duration time.Duration, err error) {
+	outcome := outcomeSuccess
+	if err != nil {
+		outcome = outcomeError
+	}
+	m.operations.WithLabelValues(string(operation), outcome).Inc()
+	m.durations.WithLabelValues(string(operation)).Observe(duration.Seconds())
+}
+
+// Observe registers the <namespace>_size and <namespace>_depth gauges of the given Tree.
+// The depth is computed on every scrape by walking the Tree.
+func (m *Metrics) Observe(tree gotree.Gauges) error {
+	size := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   m.cfg.namespace,
+		Name:        "size",
+		Help:        "Number of nodes of the tree.",
+		ConstLabels: m.cfg.constLabels,
+	}, func() float64 {
+		return float64(tree.Size())
+	})
+	depth := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   m.cfg.namespace,
+		Name:        "depth",
+		Help:        "Depth of the deepest node of the tree.",
+		ConstLabels: m.cfg.constLabels,
+	}, func() float64 {
+		return float64(tree.Depth())
+	})
+
+	if err := m.registerer.Register(size); err != nil {
+		return err
+	}
+	if err := m.registerer.Register(depth); err != nil {
+		m.registerer.Unregister(size)
+		return err
+	}
+	return nil
+}
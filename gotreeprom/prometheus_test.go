@@ -0,0 +1,75 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreeprom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tochemey/gotree"
+)
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := New(registry, WithConstLabels(prometheus.Labels{"tree": "test"}))
+	require.NoError(t, err)
+
+	tree := gotree.NewTree[string](gotree.WithMetrics(metrics))
+	require.NoError(t, metrics.Observe(tree))
+
+	root := gotree.NewNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.Add(gotree.NewNode("a", "a"), root))
+	require.Error(t, tree.Add(gotree.NewNode("a", "a"), root))
+
+	expected := `
+# HELP gotree_operations_total Number of tree operations by operation and outcome.
+# TYPE gotree_operations_total counter
+gotree_operations_total{operation="add",outcome="error",tree="test"} 1
+gotree_operations_total{operation="add",outcome="success",tree="test"} 2
+# HELP gotree_size Number of nodes of the tree.
+# TYPE gotree_size gauge
+gotree_size{tree="test"} 2
+# HELP gotree_depth Depth of the deepest node of the tree.
+# TYPE gotree_depth gauge
+gotree_depth{tree="test"} 1
+`
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected), "gotree_operations_total", "gotree_size", "gotree_depth")
+	require.NoError(t, err)
+
+	count, err := testutil.GatherAndCount(registry, "gotree_operation_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// the collectors cannot be registered twice
+	_, err = New(registry)
+	require.Error(t, err)
+	require.Error(t, metrics.Observe(tree))
+}
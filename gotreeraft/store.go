@@ -0,0 +1,274 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotreeraft replicates a gotree.Tree through a Raft log, turning gotree into a small
+// strongly consistent hierarchical store.
+//
+// The mutations are proposed to the Raft leader and applied in the same order to the Tree of
+// every member of the cluster by an FSM implementing raft.FSM. The consensus is delegated to
+// github.com/hashicorp/raft, whose Transport, log store and snapshot store are pluggable:
+//
+//	tree := gotree.NewTree[string]()
+//	fsm := gotreeraft.NewFSM(tree)
+//	r, err := raft.NewRaft(config, fsm, logStore, stableStore, snapshotStore, transport)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	store := gotreeraft.NewStore(r, fsm)
+//	err = store.Add(ctx, gotree.NewNode("root", "Root"), "")
+//
+// The values of the Nodes are encoded with encoding/json and must round-trip through it.
+// The Tree must only be read: mutating it directly breaks the replication.
+package gotreeraft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hashicorp/raft"
+
+	"github.com/tochemey/gotree"
+)
+
+// the operations carried by the commands
+const (
+	opAdd    = "add"
+	opUpdate = "update"
+	opDelete = "delete"
+	opMove   = "move"
+)
+
+// command is a mutation of the Tree replicated through the Raft log
+type command[T any] struct {
+	Op     string `json:"op"`
+	ID     string `json:"id"`
+	Parent string `json:"parent,omitempty"`
+	Value  T      `json:"value"`
+}
+
+// record is a Node of a snapshot
+type record[T any] struct {
+	ID     string `json:"id"`
+	Parent string `json:"parent,omitempty"`
+	Value  T      `json:"value"`
+}
+
+// FSM applies the commands committed to the Raft log to a Tree. It implements raft.FSM.
+type FSM[T any] struct {
+	tree *gotree.Tree[T]
+}
+
+var _ raft.FSM = (*FSM[any])(nil)
+
+// NewFSM creates an FSM applying the commands to the given Tree
+func NewFSM[T any](tree *gotree.Tree[T]) *FSM[T] {
+	return &FSM[T]{tree: tree}
+}
+
+// Tree returns the Tree maintained by the FSM
+func (f *FSM[T]) Tree() *gotree.Tree[T] {
+	return f.tree
+}
+
+// Apply applies a committed command to the Tree and returns the resulting error, if any.
+// The errors are deterministic: every member of the cluster returns the same one.
+func (f *FSM[T]) Apply(log *raft.Log) any {
+	var cmd command[T]
+	if err := json.Unmarshal(log.Data, &cmd); err != nil {
+		return fmt.Errorf("gotreeraft: invalid command at index %d: %w", log.Index, err)
+	}
+
+	switch cmd.Op {
+	case opAdd:
+		return f.tree.AddByID(gotree.NewNode(cmd.ID, cmd.Value), cmd.Parent)
+	case opUpdate:
+		return f.tree.Update(gotree.NewNode(cmd.ID, cmd.Value))
+	case opDelete:
+		return f.tree.DeleteByID(cmd.ID)
+	case opMove:
+		return f.tree.MoveByID(cmd.ID, cmd.Parent)
+	default:
+		return fmt.Errorf("gotreeraft: unknown operation %q at index %d", cmd.Op, log.Index)
+	}
+}
+
+// Snapshot captures the Nodes of the Tree in pre-order.
+// The capture walks the whole Tree while the commands are not applied.
+func (f *FSM[T]) Snapshot() (raft.FSMSnapshot, error) {
+	root, ok := f.tree.RootOK()
+	if !ok {
+		return &snapshot[T]{}, nil
+	}
+
+	cursor, ok := f.tree.Cursor(root)
+	if !ok {
+		return &snapshot[T]{}, nil
+	}
+
+	records := make([]record[T], 0, f.tree.Size())
+	for node, ok := cursor.Next(); ok; node, ok = cursor.Next() {
+		rec := record[T]{ID: node.ID(), Value: node.Value()}
+		if parent, ok := f.tree.ParentAt(node, 0); ok {
+			rec.Parent = parent.ID()
+		}
+		records = append(records, rec)
+	}
+	return &snapshot[T]{records: records}, nil
+}
+
+// Restore replaces the content of the Tree with the given snapshot
+func (f *FSM[T]) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+
+	var records []record[T]
+	if err := json.NewDecoder(rc).Decode(&records); err != nil {
+		return fmt.Errorf("gotreeraft: invalid snapshot: %w", err)
+	}
+
+	f.tree.Reset()
+	for _, rec := range records {
+		if err := f.tree.AddByID(gotree.NewNode(rec.ID, rec.Value), rec.Parent); err != nil {
+			return fmt.Errorf("gotreeraft: invalid snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// snapshot is a point-in-time copy of the Nodes of a Tree. It implements raft.FSMSnapshot
+type snapshot[T any] struct {
+	records []record[T]
+}
+
+// Persist writes the snapshot to the given sink
+func (s *snapshot[T]) Persist(sink raft.SnapshotSink) error {
+	records := s.records
+	if records == nil {
+		records = []record[T]{}
+	}
+
+	if err := json.NewEncoder(sink).Encode(records); err != nil {
+		return errors.Join(err, sink.Cancel())
+	}
+	return sink.Close()
+}
+
+// Release releases the snapshot
+func (s *snapshot[T]) Release() {}
+
+// Store proposes the mutations of a replicated Tree to the Raft cluster.
+//
+// The mutations must be proposed to the leader; the other members return raft.ErrNotLeader
+// and the caller should retry against raft.Raft.LeaderWithID. A mutation returns once it has
+// been committed and applied to the Tree of the leader, with the error of the Tree operation
+// (e.g. gotree.ErrDuplicateID).
+type Store[T any] struct {
+	raft *raft.Raft
+	fsm  *FSM[T]
+}
+
+// NewStore creates a Store proposing the mutations to the given Raft node, whose FSM is the given one
+func NewStore[T any](r *raft.Raft, fsm *FSM[T]) *Store[T] {
+	return &Store[T]{
+		raft: r,
+		fsm:  fsm,
+	}
+}
+
+// Tree returns the local replica of the Tree. Reads on a follower can be stale; call
+// Barrier on the leader to read every committed mutation.
+func (s *Store[T]) Tree() *gotree.Tree[T] {
+	return s.fsm.tree
+}
+
+// Add adds the given Node under the Node with the given parent ID, as the root when empty
+func (s *Store[T]) Add(ctx context.Context, node gotree.Node[T], parentID string) error {
+	return s.propose(ctx, command[T]{Op: opAdd, ID: node.ID(), Parent: parentID, Value: node.Value()})
+}
+
+// Update replaces the value of the given Node
+func (s *Store[T]) Update(ctx context.Context, node gotree.Node[T]) error {
+	return s.propose(ctx, command[T]{Op: opUpdate, ID: node.ID(), Value: node.Value()})
+}
+
+// Delete deletes the Node with the given ID along with its descendants
+func (s *Store[T]) Delete(ctx context.Context, id string) error {
+	return s.propose(ctx, command[T]{Op: opDelete, ID: id})
+}
+
+// Move moves the Node with the given ID along with its descendants under the Node with the given parent ID
+func (s *Store[T]) Move(ctx context.Context, id, parentID string) error {
+	return s.propose(ctx, command[T]{Op: opMove, ID: id, Parent: parentID})
+}
+
+// Barrier waits until every mutation committed before the call has been applied to the local Tree.
+// It must be called on the leader.
+func (s *Store[T]) Barrier(ctx context.Context) error {
+	return wait(ctx, s.raft.Barrier(timeout(ctx)))
+}
+
+// propose appends the given command to the Raft log and waits for its application
+func (s *Store[T]) propose(ctx context.Context, cmd command[T]) error {
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return fmt.Errorf("gotreeraft: cannot encode the %s of node %q: %w", cmd.Op, cmd.ID, err)
+	}
+
+	future := s.raft.Apply(data, timeout(ctx))
+	if err := wait(ctx, future); err != nil {
+		return err
+	}
+
+	if err, ok := future.Response().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// wait waits for the given future until the context is done
+func wait(ctx context.Context, future raft.Future) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- future.Error()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// timeout returns the time left before the deadline of the given context, zero meaning no timeout
+func timeout(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return max(time.Until(deadline), time.Nanosecond)
+	}
+	return 0
+}
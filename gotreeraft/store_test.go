@@ -0,0 +1,233 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreeraft
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tochemey/gotree"
+)
+
+// member is a member of an in-memory Raft cluster
+type member struct {
+	raft  *raft.Raft
+	store *Store[string]
+}
+
+// newCluster starts a cluster of the given size connected by in-memory transports
+func newCluster(t *testing.T, size int) []*member {
+	var (
+		members    []*member
+		transports []*raft.InmemTransport
+		servers    []raft.Server
+	)
+
+	for i := 0; i < size; i++ {
+		id := raft.ServerID(fmt.Sprintf("node%d", i))
+		_, transport := raft.NewInmemTransport(raft.ServerAddress(id))
+		transports = append(transports, transport)
+		servers = append(servers, raft.Server{ID: id, Address: transport.LocalAddr()})
+	}
+
+	for i, transport := range transports {
+		for j, peer := range transports {
+			if i != j {
+				transport.Connect(peer.LocalAddr(), peer)
+			}
+		}
+	}
+
+	for i, transport := range transports {
+		config := raft.DefaultConfig()
+		config.LocalID = servers[i].ID
+		config.HeartbeatTimeout = 50 * time.Millisecond
+		config.ElectionTimeout = 50 * time.Millisecond
+		config.LeaderLeaseTimeout = 50 * time.Millisecond
+		config.CommitTimeout = 5 * time.Millisecond
+		config.Logger = hclog.NewNullLogger()
+
+		logs := raft.NewInmemStore()
+		snapshots := raft.NewInmemSnapshotStore()
+		require.NoError(t, raft.BootstrapCluster(config, logs, logs, snapshots, transport, raft.Configuration{Servers: servers}))
+
+		fsm := NewFSM(gotree.NewTree[string]())
+		r, err := raft.NewRaft(config, fsm, logs, logs, snapshots, transport)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = r.Shutdown().Error()
+		})
+		members = append(members, &member{raft: r, store: NewStore(r, fsm)})
+	}
+	return members
+}
+
+// leader waits for the cluster to elect a leader and returns it
+func leader(t *testing.T, members []*member) *member {
+	var elected *member
+	require.Eventually(t, func() bool {
+		for _, m := range members {
+			if m.raft.State() == raft.Leader {
+				elected = m
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+	return elected
+}
+
+func TestStore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	members := newCluster(t, 3)
+	store := leader(t, members).store
+
+	require.NoError(t, store.Add(ctx, gotree.NewNode("root", "Root"), ""))
+	require.NoError(t, store.Add(ctx, gotree.NewNode("a", "A"), "root"))
+	require.NoError(t, store.Add(ctx, gotree.NewNode("b", "B"), "root"))
+	require.NoError(t, store.Add(ctx, gotree.NewNode("a1", "A1"), "a"))
+	require.NoError(t, store.Update(ctx, gotree.NewNode("a", "A'")))
+	require.NoError(t, store.Move(ctx, "a1", "b"))
+	require.NoError(t, store.Delete(ctx, "a"))
+
+	// the errors of the tree are returned to the proposer
+	assert.ErrorIs(t, store.Add(ctx, gotree.NewNode("b", "B"), "root"), gotree.ErrDuplicateID)
+	assert.ErrorIs(t, store.Move(ctx, "root", "b"), gotree.ErrInvalidOperation)
+	assert.ErrorIs(t, store.Delete(ctx, "a"), gotree.ErrNotFound)
+
+	require.NoError(t, store.Barrier(ctx))
+	expected := "root: Root\n└── b: B\n    └── a1: A1\n"
+	assert.Equal(t, expected, store.Tree().Dump())
+
+	// every member converges
+	for _, m := range members {
+		assert.Eventually(t, func() bool {
+			return m.store.Tree().Dump() == expected
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	// the followers reject the mutations
+	for _, m := range members {
+		if m.store != store {
+			assert.ErrorIs(t, m.store.Add(ctx, gotree.NewNode("c", "C"), "root"), raft.ErrNotLeader)
+		}
+	}
+
+	done, stop := context.WithCancel(ctx)
+	stop()
+	assert.ErrorIs(t, store.Add(done, gotree.NewNode("c", "C"), "root"), context.Canceled)
+}
+
+// sink is an in-memory raft.SnapshotSink
+type sink struct {
+	bytes.Buffer
+	cancelled bool
+}
+
+func (s *sink) ID() string    { return "sink" }
+func (s *sink) Cancel() error { s.cancelled = true; return nil }
+func (s *sink) Close() error  { return nil }
+
+func TestFSMSnapshot(t *testing.T) {
+	tree := gotree.NewTree[string]()
+	require.NoError(t, tree.AddByID(gotree.NewNode("root", "Root"), ""))
+	require.NoError(t, tree.AddByID(gotree.NewNode("a", "A"), "root"))
+	require.NoError(t, tree.AddByID(gotree.NewNode("a1", "A1"), "a"))
+	require.NoError(t, tree.AddByID(gotree.NewNode("b", "B"), "root"))
+
+	snap, err := NewFSM(tree).Snapshot()
+	require.NoError(t, err)
+
+	// the snapshot is not affected by the later mutations
+	require.NoError(t, tree.DeleteByID("a"))
+
+	out := new(sink)
+	require.NoError(t, snap.Persist(out))
+	snap.Release()
+
+	restored := gotree.NewTree[string]()
+	require.NoError(t, restored.AddByID(gotree.NewNode("stale", ""), ""))
+	fsm := NewFSM(restored)
+	require.NoError(t, fsm.Restore(io.NopCloser(&out.Buffer)))
+	assert.Equal(t, "root: Root\n├── a: A\n│   └── a1: A1\n└── b: B\n", fsm.Tree().Dump())
+
+	t.Run("empty tree", func(t *testing.T) {
+		snap, err := NewFSM(gotree.NewTree[string]()).Snapshot()
+		require.NoError(t, err)
+		out := new(sink)
+		require.NoError(t, snap.Persist(out))
+
+		fsm := NewFSM(restored)
+		require.NoError(t, fsm.Restore(io.NopCloser(&out.Buffer)))
+		assert.Zero(t, fsm.Tree().Size())
+	})
+
+	t.Run("invalid snapshot", func(t *testing.T) {
+		fsm := NewFSM(gotree.NewTree[string]())
+		assert.Error(t, fsm.Restore(io.NopCloser(bytes.NewBufferString("{"))))
+		assert.Error(t, fsm.Restore(io.NopCloser(bytes.NewBufferString(`[{"id":"a","parent":"missing"}]`))))
+	})
+}
+
+func TestFSMInvalidCommands(t *testing.T) {
+	fsm := NewFSM(gotree.NewTree[string]())
+	assert.ErrorContains(t, fsm.Apply(&raft.Log{Index: 3, Data: []byte("{")}).(error), "invalid command at index 3")
+	assert.ErrorContains(t, fsm.Apply(&raft.Log{Index: 4, Data: []byte(`{"op":"bogus"}`)}).(error), `unknown operation "bogus" at index 4`)
+}
+
+func TestSnapshotReplication(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	members := newCluster(t, 1)
+	m := leader(t, members)
+	require.NoError(t, m.store.Add(ctx, gotree.NewNode("root", "Root"), ""))
+	require.NoError(t, m.store.Add(ctx, gotree.NewNode("a", "A"), "root"))
+
+	// snapshot through raft then restore the persisted snapshot
+	require.NoError(t, m.raft.Snapshot().Error())
+	require.NoError(t, m.store.Add(ctx, gotree.NewNode("b", "B"), "root"))
+
+	future := m.raft.Snapshot()
+	require.NoError(t, future.Error())
+	meta, reader, err := future.Open()
+	require.NoError(t, err)
+	assert.Positive(t, meta.Index)
+
+	fsm := NewFSM(gotree.NewTree[string]())
+	require.NoError(t, fsm.Restore(reader))
+	assert.Equal(t, "root: Root\n├── a: A\n└── b: B\n", fsm.Tree().Dump())
+}
@@ -0,0 +1,213 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreeredis
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/tochemey/gotree"
+)
+
+// Follow keeps the given Tree in line with the persisted state, typically in another process
+// than the Tree writing it, until the context is done. The changes applied to the followed
+// Tree reach its watchers, which turns the Redis keyspace notifications into gotree Events.
+//
+// The Tree is first loaded from the persisted state then updated on every keyspace notification
+// of a Node hash. The notifications must be enabled on the Redis server for the keyspace, hash
+// and generic commands, for instance with:
+//
+//	CONFIG SET notify-keyspace-events Khg
+//
+// The followed Tree must not persist to the same Storage, and must be created with
+// gotree.WithMultiParent when the followed one is. Follow returns the error that stopped it,
+// the error of the context once done.
+func (s *Storage[T]) Follow(ctx context.Context, tree *gotree.Tree[T]) error {
+	pubsub := s.client.PSubscribe(ctx, "__keyspace@*__:"+s.nodeKey("*"))
+	defer pubsub.Close()
+
+	// wait for the subscription so that no change is missed between loading and following
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("gotreeredis: cannot subscribe to the keyspace notifications: %w", err)
+	}
+
+	snapshot, err := s.load(ctx)
+	if err != nil {
+		return fmt.Errorf("gotreeredis: cannot load the tree: %w", err)
+	}
+
+	if err := tree.Restore(snapshot); err != nil {
+		return fmt.Errorf("gotreeredis: cannot load the tree: %w", err)
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case message, ok := <-messages:
+			if !ok {
+				return errors.New("gotreeredis: keyspace notifications closed")
+			}
+
+			_, key, found := strings.Cut(message.Channel, "__:")
+			if !found || !strings.HasPrefix(key, s.nodeKey("")) {
+				continue
+			}
+
+			if err := s.reconcile(ctx, tree, strings.TrimPrefix(key, s.nodeKey(""))); err != nil {
+				return fmt.Errorf("gotreeredis: cannot follow %q: %w", key, err)
+			}
+		}
+	}
+}
+
+// reconcile applies the persisted state of the Node with the given ID to the given Tree
+func (s *Storage[T]) reconcile(ctx context.Context, tree *gotree.Tree[T], id string) error {
+	fields, err := s.client.HGetAll(ctx, s.nodeKey(id)).Result()
+	if err != nil {
+		return err
+	}
+
+	// a deleted Node is deleted along with its descendants, which may already be gone
+	if len(fields) == 0 {
+		if err := tree.DeleteByID(id); err != nil && !errors.Is(err, gotree.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+
+	record, err := s.decode(id, fields)
+	if err != nil {
+		return err
+	}
+
+	current, ok := tree.Find(id)
+	if !ok {
+		// the parent may have been deleted since
+		err := tree.AddByID(gotree.NewNode(id, record.Value), record.ParentID)
+		if errors.Is(err, gotree.ErrParentNodeNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return annotate(tree, record)
+	}
+
+	if parent, ok := tree.ParentAt(current, 0); ok && parent.ID() != record.ParentID {
+		if err := tree.MoveByID(id, record.ParentID); err != nil && !errors.Is(err, gotree.ErrParentNodeNotFound) {
+			return err
+		}
+	}
+
+	// the value is only updated when it changed, the notification may stem from a move or an annotation
+	data, err := s.codec.Marshal(current.Value())
+	if err != nil {
+		return fmt.Errorf("cannot encode the value: %w", err)
+	}
+	if !bytes.Equal(data, []byte(fields[valueField])) {
+		if err := tree.Update(gotree.NewNode(id, record.Value)); err != nil {
+			return err
+		}
+	}
+	return annotate(tree, record)
+}
+
+// annotate applies the edge, metadata, tags and links of the given record to its Node in the given Tree
+// when they changed. The links under the Nodes that are not followed yet are skipped.
+func annotate[T any](tree *gotree.Tree[T], record gotree.Record[T]) error {
+	node := gotree.NewNode(record.ID, record.Value)
+	if edge, ok := tree.Edge(node); ok && edge != record.Edge {
+		if err := tree.SetEdge(node, record.Edge); err != nil {
+			return err
+		}
+	}
+
+	meta, _ := tree.Meta(record.ID)
+	for key := range meta {
+		if _, ok := record.Meta[key]; !ok {
+			if err := tree.DeleteMeta(record.ID, key); err != nil {
+				return err
+			}
+		}
+	}
+	for key, value := range record.Meta {
+		if current, ok := meta[key]; !ok || !reflect.DeepEqual(current, value) {
+			if err := tree.SetMeta(record.ID, key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	tags, _ := tree.Tags(record.ID)
+	if removed := difference(tags, record.Tags); len(removed) > 0 {
+		if err := tree.Untag(record.ID, removed...); err != nil {
+			return err
+		}
+	}
+	if added := difference(record.Tags, tags); len(added) > 0 {
+		if err := tree.Tag(record.ID, added...); err != nil {
+			return err
+		}
+	}
+
+	parents, _ := tree.Parents(node)
+	var links []string
+	for i, parent := range parents {
+		// the first parent is the primary one
+		if i > 0 {
+			links = append(links, parent.ID())
+		}
+	}
+	for _, parentID := range difference(links, record.Links) {
+		if err := tree.Unlink(node, gotree.NewNode(parentID, *new(T))); err != nil {
+			return err
+		}
+	}
+	for _, parentID := range difference(record.Links, links) {
+		err := tree.Link(node, gotree.NewNode(parentID, *new(T)))
+		if err != nil && !errors.Is(err, gotree.ErrParentNodeNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// difference returns the elements of a missing from b
+func difference(a, b []string) []string {
+	var missing []string
+	for _, element := range a {
+		if !slices.Contains(b, element) {
+			missing = append(missing, element)
+		}
+	}
+	return missing
+}
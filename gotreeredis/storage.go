@@ -0,0 +1,544 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotreeredis provides a gotree.Storage persisting a Tree to Redis, for the teams
+// already running Redis that want to share hierarchical state.
+//
+// Every Node is stored as a hash holding its encoded value, the ID of its parent and its
+// annotations, and the children of every Node as a sorted set ordered by insertion. The keys of
+// a Tree share the "{name}:" prefix, whose hash tag keeps them in the same slot of a Redis Cluster
+// so that every change is applied in a single transaction:
+//
+//	{name}:seq            the sequence number of the last persisted change
+//	{name}:root           the ID of the root
+//	{name}:node:<id>      the hash of the Node: "value" and "parent", along with the JSON encoded
+//	                      "edge", "meta", "tags" and "links" (its linked parents) when set
+//	{name}:children:<id>  the sorted set of the children of the Node
+//	{name}:linked:<id>    the set of the Nodes linked under the Node in the multi-parent mode
+//
+// A single Tree writes to a given name while any number of processes follow it with Follow.
+//
+//	storage := gotreeredis.New(redis.NewClient(&redis.Options{Addr: "localhost:6379"}), "config", gotree.JSONCodec[string]())
+//	tree := gotree.NewTree[string](gotree.WithStorage[string](storage))
+//	if err := tree.Recover(); err != nil {
+//	    return err
+//	}
+package gotreeredis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/tochemey/gotree"
+)
+
+// defaultTimeout bounds the Redis operations of the Storage, whose interface carries no context
+const defaultTimeout = 10 * time.Second
+
+// Hash fields of the Nodes
+const (
+	valueField  = "value"
+	parentField = "parent"
+	edgeField   = "edge"
+	metaField   = "meta"
+	tagsField   = "tags"
+	linksField  = "links"
+)
+
+// Storage is a gotree.Storage backed by Redis.
+//
+// The Storage holds the current state of the Tree rather than a log of its changes: every
+// change is applied to the state as it is appended. The snapshots therefore always reflect
+// the latest change and there is no change to replay.
+type Storage[T any] struct {
+	client  redis.UniversalClient
+	prefix  string
+	codec   gotree.Codec[T]
+	timeout time.Duration
+}
+
+// enforce compilation error
+var _ gotree.Storage[any] = (*Storage[any])(nil)
+
+// New returns a Storage persisting the Tree with the given name to Redis,
+// encoding the values with the given Codec. The client remains owned by the caller.
+func New[T any](client redis.UniversalClient, name string, codec gotree.Codec[T]) *Storage[T] {
+	return &Storage[T]{
+		client:  client,
+		prefix:  "{" + name + "}:",
+		codec:   codec,
+		timeout: defaultTimeout,
+	}
+}
+
+// SaveSnapshot replaces the persisted state with the given snapshot in a single transaction
+func (s *Storage[T]) SaveSnapshot(snapshot gotree.Snapshot[T]) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	var stale []string
+	iter := s.client.Scan(ctx, 0, s.prefix+"*", 1000).Iterator()
+	for iter.Next(ctx) {
+		stale = append(stale, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("cannot list the keys: %w", err)
+	}
+
+	values := make([][]byte, len(snapshot.Records))
+	annotations := make([][]any, len(snapshot.Records))
+	for i, record := range snapshot.Records {
+		value, err := s.codec.Marshal(record.Value)
+		if err != nil {
+			return fmt.Errorf("cannot encode the value of %q: %w", record.ID, err)
+		}
+		values[i] = value
+
+		if annotations[i], err = encodeAnnotations(record); err != nil {
+			return fmt.Errorf("cannot encode the annotations of %q: %w", record.ID, err)
+		}
+	}
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(stale) > 0 {
+			pipe.Del(ctx, stale...)
+		}
+
+		for i, record := range snapshot.Records {
+			fields := append([]any{valueField, values[i]}, annotations[i]...)
+			if record.ParentID == "" {
+				pipe.Set(ctx, s.rootKey(), record.ID, 0)
+				pipe.HSet(ctx, s.nodeKey(record.ID), fields...)
+				continue
+			}
+
+			// the children appended afterward are scored with their positive sequence number
+			pipe.HSet(ctx, s.nodeKey(record.ID), append(fields, parentField, record.ParentID)...)
+			pipe.ZAdd(ctx, s.childrenKey(record.ParentID), redis.Z{Score: float64(i - len(snapshot.Records)), Member: record.ID})
+			for _, parentID := range record.Links {
+				pipe.SAdd(ctx, s.linkedKey(parentID), record.ID)
+			}
+		}
+
+		pipe.Set(ctx, s.seqKey(), snapshot.Seq, 0)
+		return nil
+	})
+	return err
+}
+
+// LoadSnapshot returns the persisted state of the Tree
+func (s *Storage[T]) LoadSnapshot() (gotree.Snapshot[T], error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	return s.load(ctx)
+}
+
+// AppendChanges applies the events of a change to the persisted state in a single transaction
+func (s *Storage[T]) AppendChanges(changes []gotree.Event[T]) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	// the persisted state is read before the transaction, hence every write is prepared against the
+	// state preceding the change: the descendants of a deleted Node are deleted along with it
+	writes := make([]func(pipe redis.Pipeliner), len(changes))
+	removed := make(map[string]struct{})
+	for i, change := range changes {
+		var err error
+		if writes[i], err = s.prepare(ctx, change, removed); err != nil {
+			return err
+		}
+	}
+
+	return s.commit(ctx, changes[len(changes)-1].Seq, func(pipe redis.Pipeliner) {
+		for _, write := range writes {
+			write(pipe)
+		}
+	})
+}
+
+// prepare returns the writes applying the given event to the persisted state. The given set holds the
+// Nodes removed by the deletions prepared so far for the same change, whose descendants follow them
+func (s *Storage[T]) prepare(ctx context.Context, change gotree.Event[T], removed map[string]struct{}) (func(pipe redis.Pipeliner), error) {
+	id := change.Node.ID()
+	switch change.Type {
+	case gotree.EventAdd, gotree.EventUpdate:
+		value, err := s.codec.Marshal(change.Node.Value())
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode the value of %q: %w", id, err)
+		}
+
+		return func(pipe redis.Pipeliner) {
+			if change.Type == gotree.EventUpdate {
+				pipe.HSet(ctx, s.nodeKey(id), valueField, value)
+				return
+			}
+
+			if change.Parent == nil {
+				pipe.Set(ctx, s.rootKey(), id, 0)
+				pipe.HSet(ctx, s.nodeKey(id), valueField, value)
+				return
+			}
+
+			fields := []any{valueField, value, parentField, change.Parent.ID()}
+			if change.Edge != (gotree.EdgeData{}) {
+				edge, _ := json.Marshal(change.Edge)
+				fields = append(fields, edgeField, edge)
+			}
+			pipe.HSet(ctx, s.nodeKey(id), fields...)
+			pipe.ZAdd(ctx, s.childrenKey(change.Parent.ID()), redis.Z{Score: float64(change.Seq), Member: id})
+		}, nil
+	case gotree.EventMove:
+		from, err := s.client.HGet(ctx, s.nodeKey(id), parentField).Result()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the parent of %q: %w", id, err)
+		}
+
+		links, err := s.links(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		// the new parent holds the Node as primary parent from now on, with no edge data
+		to := change.Parent.ID()
+		return func(pipe redis.Pipeliner) {
+			pipe.ZRem(ctx, s.childrenKey(from), id)
+			pipe.ZAdd(ctx, s.childrenKey(to), redis.Z{Score: float64(change.Seq), Member: id})
+			pipe.HSet(ctx, s.nodeKey(id), parentField, to)
+			pipe.HDel(ctx, s.nodeKey(id), edgeField)
+			if slices.Contains(links, to) {
+				s.writeLinks(ctx, pipe, id, slices.DeleteFunc(links, func(parentID string) bool { return parentID == to }))
+				pipe.SRem(ctx, s.linkedKey(to), id)
+			}
+		}, nil
+	case gotree.EventDelete:
+		if _, ok := removed[id]; ok {
+			return func(redis.Pipeliner) {}, nil
+		}
+		return s.prepareDelete(ctx, change, removed)
+	case gotree.EventMeta:
+		return s.prepareField(ctx, id, metaField, change.Meta, len(change.Meta) == 0)
+	case gotree.EventTag:
+		return s.prepareField(ctx, id, tagsField, change.Tags, len(change.Tags) == 0)
+	case gotree.EventEdge:
+		return s.prepareField(ctx, id, edgeField, change.Edge, change.Edge == (gotree.EdgeData{}))
+	case gotree.EventLink, gotree.EventUnlink:
+		links, err := s.links(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		parentID := change.Parent.ID()
+		links = slices.DeleteFunc(links, func(linked string) bool { return linked == parentID })
+		return func(pipe redis.Pipeliner) {
+			if change.Type == gotree.EventUnlink {
+				s.writeLinks(ctx, pipe, id, links)
+				pipe.SRem(ctx, s.linkedKey(parentID), id)
+				return
+			}
+
+			s.writeLinks(ctx, pipe, id, append(links, parentID))
+			pipe.SAdd(ctx, s.linkedKey(parentID), id)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown change type %d", change.Type)
+	}
+}
+
+// prepareDelete returns the writes removing the Node of the given deletion along with its descendants,
+// and their links, and adds them to the given set of removed Nodes
+func (s *Storage[T]) prepareDelete(ctx context.Context, change gotree.Event[T], removed map[string]struct{}) (func(pipe redis.Pipeliner), error) {
+	id := change.Node.ID()
+	subtree, err := s.subtree(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range subtree {
+		removed[node] = struct{}{}
+	}
+
+	// the Nodes linked under the removed ones, outside of the subtree, lose these links
+	relinked := make(map[string][]string)
+	parents := make(map[string][]string)
+	for _, node := range subtree {
+		if parents[node], err = s.links(ctx, node); err != nil {
+			return nil, err
+		}
+
+		linked, err := s.client.SMembers(ctx, s.linkedKey(node)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the nodes linked under %q: %w", node, err)
+		}
+		for _, child := range linked {
+			if _, ok := removed[child]; ok {
+				continue
+			}
+			if _, ok := relinked[child]; !ok {
+				if relinked[child], err = s.links(ctx, child); err != nil {
+					return nil, err
+				}
+			}
+			relinked[child] = slices.DeleteFunc(relinked[child], func(parentID string) bool { return parentID == node })
+		}
+	}
+
+	return func(pipe redis.Pipeliner) {
+		if len(subtree) == 0 {
+			return
+		}
+
+		keys := make([]string, 0, 3*len(subtree))
+		for _, node := range subtree {
+			keys = append(keys, s.nodeKey(node), s.childrenKey(node), s.linkedKey(node))
+			for _, parentID := range parents[node] {
+				pipe.SRem(ctx, s.linkedKey(parentID), node)
+			}
+		}
+		pipe.Del(ctx, keys...)
+
+		for child, links := range relinked {
+			s.writeLinks(ctx, pipe, child, links)
+		}
+
+		if change.Parent != nil {
+			pipe.ZRem(ctx, s.childrenKey(change.Parent.ID()), id)
+		} else {
+			pipe.Del(ctx, s.rootKey())
+		}
+	}, nil
+}
+
+// prepareField returns the writes setting the given hash field of the Node with the given ID to the
+// JSON encoding of the given value, or removing it when empty
+func (s *Storage[T]) prepareField(ctx context.Context, id, field string, value any, empty bool) (func(pipe redis.Pipeliner), error) {
+	if empty {
+		return func(pipe redis.Pipeliner) {
+			pipe.HDel(ctx, s.nodeKey(id), field)
+		}, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode the %s of %q: %w", field, id, err)
+	}
+	return func(pipe redis.Pipeliner) {
+		pipe.HSet(ctx, s.nodeKey(id), field, data)
+	}, nil
+}
+
+// links returns the persisted linked parents of the Node with the given ID
+func (s *Storage[T]) links(ctx context.Context, id string) ([]string, error) {
+	data, err := s.client.HGet(ctx, s.nodeKey(id), linksField).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the links of %q: %w", id, err)
+	}
+
+	var links []string
+	if err := json.Unmarshal(data, &links); err != nil {
+		return nil, fmt.Errorf("cannot decode the links of %q: %w", id, err)
+	}
+	return links, nil
+}
+
+// writeLinks writes the given linked parents of the Node with the given ID
+func (s *Storage[T]) writeLinks(ctx context.Context, pipe redis.Pipeliner, id string, links []string) {
+	if len(links) == 0 {
+		pipe.HDel(ctx, s.nodeKey(id), linksField)
+		return
+	}
+	data, _ := json.Marshal(links)
+	pipe.HSet(ctx, s.nodeKey(id), linksField, data)
+}
+
+// ReplayChanges is a no-op: the changes are applied to the persisted state as they are appended
+func (s *Storage[T]) ReplayChanges(uint64, func(change gotree.Event[T]) error) error {
+	return nil
+}
+
+// commit applies the given writes along with the sequence number in a single transaction
+func (s *Storage[T]) commit(ctx context.Context, seq uint64, writes func(pipe redis.Pipeliner)) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		writes(pipe)
+		pipe.Set(ctx, s.seqKey(), seq, 0)
+		return nil
+	})
+	return err
+}
+
+// subtree returns the IDs of the persisted Node with the given ID and its descendants,
+// none when the Node is not persisted
+func (s *Storage[T]) subtree(ctx context.Context, id string) ([]string, error) {
+	exists, err := s.client.Exists(ctx, s.nodeKey(id)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %q: %w", id, err)
+	}
+	if exists == 0 {
+		return nil, nil
+	}
+
+	subtree := []string{id}
+	for i := 0; i < len(subtree); i++ {
+		children, err := s.client.ZRange(ctx, s.childrenKey(subtree[i]), 0, -1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the children of %q: %w", subtree[i], err)
+		}
+		subtree = append(subtree, children...)
+	}
+	return subtree, nil
+}
+
+// load reads the persisted state level by level and returns it in depth-first order
+func (s *Storage[T]) load(ctx context.Context) (snapshot gotree.Snapshot[T], err error) {
+	seq, err := s.client.Get(ctx, s.seqKey()).Result()
+	switch {
+	case errors.Is(err, redis.Nil):
+	case err != nil:
+		return snapshot, fmt.Errorf("cannot read the sequence number: %w", err)
+	default:
+		if snapshot.Seq, err = strconv.ParseUint(seq, 10, 64); err != nil {
+			return snapshot, fmt.Errorf("invalid sequence number %q: %w", seq, err)
+		}
+	}
+
+	root, err := s.client.Get(ctx, s.rootKey()).Result()
+	if errors.Is(err, redis.Nil) {
+		return snapshot, nil
+	}
+	if err != nil {
+		return snapshot, fmt.Errorf("cannot read the root: %w", err)
+	}
+
+	records := make(map[string]gotree.Record[T])
+	children := make(map[string][]string)
+	for level := []string{root}; len(level) > 0; {
+		pipe := s.client.Pipeline()
+		fieldsCmds := make([]*redis.MapStringStringCmd, len(level))
+		childrenCmds := make([]*redis.StringSliceCmd, len(level))
+		for i, id := range level {
+			fieldsCmds[i] = pipe.HGetAll(ctx, s.nodeKey(id))
+			childrenCmds[i] = pipe.ZRange(ctx, s.childrenKey(id), 0, -1)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return snapshot, fmt.Errorf("cannot read the nodes: %w", err)
+		}
+
+		var next []string
+		for i, id := range level {
+			if records[id], err = s.decode(id, fieldsCmds[i].Val()); err != nil {
+				return snapshot, err
+			}
+			children[id] = childrenCmds[i].Val()
+			next = append(next, children[id]...)
+		}
+		level = next
+	}
+
+	var walk func(id string)
+	walk = func(id string) {
+		snapshot.Records = append(snapshot.Records, records[id])
+		for _, child := range children[id] {
+			walk(child)
+		}
+	}
+	walk(root)
+	return snapshot, nil
+}
+
+// decode decodes the hash fields of the Node with the given ID
+func (s *Storage[T]) decode(id string, fields map[string]string) (record gotree.Record[T], err error) {
+	record.ID, record.ParentID = id, fields[parentField]
+	if record.Value, err = s.codec.Unmarshal([]byte(fields[valueField])); err != nil {
+		return record, fmt.Errorf("cannot decode the value of %q: %w", id, err)
+	}
+
+	for field, target := range map[string]any{edgeField: &record.Edge, metaField: &record.Meta, tagsField: &record.Tags, linksField: &record.Links} {
+		if data, ok := fields[field]; ok {
+			if err := json.Unmarshal([]byte(data), target); err != nil {
+				return record, fmt.Errorf("cannot decode the %s of %q: %w", field, id, err)
+			}
+		}
+	}
+	return record, nil
+}
+
+// encodeAnnotations returns the hash fields holding the JSON encoded edge, metadata, tags and links
+// of the given record, when set
+func encodeAnnotations[T any](record gotree.Record[T]) ([]any, error) {
+	var fields []any
+	for _, annotation := range []struct {
+		field string
+		value any
+		set   bool
+	}{
+		{edgeField, record.Edge, record.ParentID != "" && record.Edge != (gotree.EdgeData{})},
+		{metaField, record.Meta, len(record.Meta) > 0},
+		{tagsField, record.Tags, len(record.Tags) > 0},
+		{linksField, record.Links, len(record.Links) > 0},
+	} {
+		if !annotation.set {
+			continue
+		}
+
+		data, err := json.Marshal(annotation.value)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, annotation.field, data)
+	}
+	return fields, nil
+}
+
+// seqKey returns the key of the sequence number
+func (s *Storage[T]) seqKey() string {
+	return s.prefix + "seq"
+}
+
+// rootKey returns the key of the root ID
+func (s *Storage[T]) rootKey() string {
+	return s.prefix + "root"
+}
+
+// nodeKey returns the key of the hash of the Node with the given ID
+func (s *Storage[T]) nodeKey(id string) string {
+	return s.prefix + "node:" + id
+}
+
+// childrenKey returns the key of the children of the Node with the given ID
+func (s *Storage[T]) childrenKey(id string) string {
+	return s.prefix + "children:" + id
+}
+
+// linkedKey returns the key of the Nodes linked under the Node with the given ID
+func (s *Storage[T]) linkedKey(id string) string {
+	return s.prefix + "linked:" + id
+}
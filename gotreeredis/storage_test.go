@@ -0,0 +1,236 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreeredis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tochemey/gotree"
+)
+
+func newStorage(t *testing.T) (*miniredis.Miniredis, *Storage[string]) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return server, New(client, "tree", gotree.JSONCodec[string]())
+}
+
+func populate(t *testing.T, tree *gotree.Tree[string]) {
+	t.Helper()
+	require.NoError(t, tree.AddByID(gotree.NewNode("root", "Root"), ""))
+	require.NoError(t, tree.AddByID(gotree.NewNode("a", "A"), "root"))
+	require.NoError(t, tree.AddByID(gotree.NewNode("b", "B"), "root"))
+	require.NoError(t, tree.AddByID(gotree.NewNode("a1", "A1"), "a"))
+	require.NoError(t, tree.AddByID(gotree.NewNode("a2", "A2"), "a"))
+}
+
+func TestStorage(t *testing.T) {
+	t.Run("persists the nodes as hashes and the children as sorted sets", func(t *testing.T) {
+		server, storage := newStorage(t)
+		tree := gotree.NewTree[string](gotree.WithStorage[string](storage))
+		populate(t, tree)
+		require.NoError(t, tree.Update(gotree.NewNode("a1", "A1'")))
+		require.NoError(t, tree.MoveByID("a2", "b"))
+
+		root, err := server.Get("{tree}:root")
+		require.NoError(t, err)
+		assert.Equal(t, "root", root)
+		assert.Equal(t, `"A1'"`, server.HGet("{tree}:node:a1", "value"))
+		assert.Equal(t, "b", server.HGet("{tree}:node:a2", "parent"))
+		children, err := server.ZMembers("{tree}:children:root")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, children)
+		seq, err := server.Get("{tree}:seq")
+		require.NoError(t, err)
+		assert.Equal(t, "7", seq)
+
+		require.NoError(t, tree.DeleteByID("a"))
+		assert.False(t, server.Exists("{tree}:node:a"))
+		assert.False(t, server.Exists("{tree}:node:a1"))
+		assert.True(t, server.Exists("{tree}:node:a2"))
+		children, err = server.ZMembers("{tree}:children:root")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b"}, children)
+
+		recovered := gotree.NewTree[string](gotree.WithStorage[string](storage))
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, tree.Dump(), recovered.Dump())
+		assert.Equal(t, tree.LastSeq(), recovered.LastSeq())
+	})
+
+	t.Run("replaces the state with a snapshot", func(t *testing.T) {
+		server, storage := newStorage(t)
+		require.NoError(t, server.Set("{tree}:node:stale", "stale"))
+
+		// a Tree persisting from a populated state brings the storage in line with a snapshot
+		persisted := gotree.NewTree[string](gotree.WithStorage[string](storage))
+		populate(t, persisted)
+		require.NoError(t, persisted.Persist())
+		assert.False(t, server.Exists("{tree}:node:stale"))
+
+		// the children appended after the snapshot follow the snapshot ones
+		require.NoError(t, persisted.AddByID(gotree.NewNode("c", "C"), "root"))
+		recovered := gotree.NewTree[string](gotree.WithStorage[string](storage))
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, persisted.Dump(), recovered.Dump())
+
+		snapshot, err := storage.LoadSnapshot()
+		require.NoError(t, err)
+		assert.EqualValues(t, 6, snapshot.Seq)
+		ids := make([]string, len(snapshot.Records))
+		for i, record := range snapshot.Records {
+			ids[i] = record.ID
+		}
+		assert.Equal(t, []string{"root", "a", "a1", "a2", "b", "c"}, ids)
+	})
+
+	t.Run("keeps the edges, metadata, tags and links", func(t *testing.T) {
+		server, storage := newStorage(t)
+		tree := gotree.NewTree[string](gotree.WithStorage[string](storage), gotree.WithMultiParent())
+		populate(t, tree)
+		a, _ := tree.Find("a")
+		require.NoError(t, tree.AddWithEdge(gotree.NewNode("a3", "A3"), a, gotree.EdgeData{Label: "third"}))
+		require.NoError(t, tree.SetMeta("a", "owner", "alice"))
+		require.NoError(t, tree.Tag("a1", "critical", "billing"))
+		require.NoError(t, tree.Untag("a1", "billing"))
+		require.NoError(t, tree.Link(gotree.NewNode("a1", ""), gotree.NewNode("b", "")))
+		require.NoError(t, tree.Link(gotree.NewNode("a2", ""), gotree.NewNode("b", "")))
+		require.NoError(t, tree.Unlink(gotree.NewNode("a2", ""), gotree.NewNode("b", "")))
+		require.NoError(t, tree.SetEdge(gotree.NewNode("b", ""), gotree.EdgeData{Label: "right"}))
+
+		assert.Equal(t, `{"owner":"alice"}`, server.HGet("{tree}:node:a", "meta"))
+		assert.Equal(t, `["critical"]`, server.HGet("{tree}:node:a1", "tags"))
+		assert.Equal(t, `["b"]`, server.HGet("{tree}:node:a1", "links"))
+		linked, err := server.SMembers("{tree}:linked:b")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a1"}, linked)
+
+		recovered := gotree.NewTree[string](gotree.WithStorage[string](storage), gotree.WithMultiParent())
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, tree.Snapshot().Records, recovered.Snapshot().Records)
+
+		// moving a Node under a linked parent drops the link and the edge data
+		require.NoError(t, tree.MoveByID("a3", "b"))
+		assert.Empty(t, server.HGet("{tree}:node:a3", "edge"))
+		require.NoError(t, tree.MoveByID("a1", "b"))
+		assert.Empty(t, server.HGet("{tree}:node:a1", "links"))
+		assert.False(t, server.Exists("{tree}:linked:b"))
+
+		// deleting a linked parent drops the links under it
+		require.NoError(t, tree.Link(gotree.NewNode("a2", ""), gotree.NewNode("b", "")))
+		require.NoError(t, tree.DeleteByID("b"))
+		assert.Empty(t, server.HGet("{tree}:node:a2", "links"))
+		assert.False(t, server.Exists("{tree}:linked:b"))
+
+		// the snapshot captures them as well
+		require.NoError(t, tree.Tag("a2", "draft"))
+		require.NoError(t, tree.Link(gotree.NewNode("a2", ""), gotree.NewNode("root", "")))
+		require.NoError(t, tree.Persist())
+		recovered = gotree.NewTree[string](gotree.WithStorage[string](storage), gotree.WithMultiParent())
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, tree.Snapshot().Records, recovered.Snapshot().Records)
+	})
+
+	t.Run("loads an empty state", func(t *testing.T) {
+		_, storage := newStorage(t)
+		snapshot, err := storage.LoadSnapshot()
+		require.NoError(t, err)
+		assert.Zero(t, snapshot.Seq)
+		assert.Empty(t, snapshot.Records)
+	})
+}
+
+func TestFollow(t *testing.T) {
+	server, storage := newStorage(t)
+	writer := gotree.NewTree[string](gotree.WithStorage[string](storage))
+	populate(t, writer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	follower := gotree.NewTree[string]()
+	events := follower.Watch(ctx, nil)
+	done := make(chan error, 1)
+	go func() {
+		done <- storage.Follow(ctx, follower)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+
+	require.Eventually(t, func() bool {
+		return follower.Size() == writer.Size()
+	}, 5*time.Second, 10*time.Millisecond)
+	for range writer.Size() {
+		<-events
+	}
+
+	// miniredis does not emit the keyspace notifications, hence they are published as Redis would
+	notify := func(id, command string) {
+		server.Publish("__keyspace@0__:{tree}:node:"+id, command)
+	}
+
+	require.NoError(t, writer.AddByID(gotree.NewNode("c", "C"), "b"))
+	notify("c", "hset")
+	event := <-events
+	assert.Equal(t, gotree.EventAdd, event.Type)
+	assert.Equal(t, "c", event.Node.ID())
+	assert.Equal(t, "b", event.Parent.ID())
+
+	require.NoError(t, writer.Update(gotree.NewNode("c", "C'")))
+	notify("c", "hset")
+	event = <-events
+	assert.Equal(t, gotree.EventUpdate, event.Type)
+	assert.Equal(t, "C'", event.Node.Value())
+
+	require.NoError(t, writer.MoveByID("c", "a1"))
+	notify("c", "hset")
+	event = <-events
+	assert.Equal(t, gotree.EventMove, event.Type)
+	assert.Equal(t, "a1", event.Parent.ID())
+
+	require.NoError(t, writer.DeleteByID("a"))
+	for _, id := range []string{"a", "a1", "c", "a2"} {
+		notify(id, "del")
+	}
+	for _, id := range []string{"a", "a1", "c", "a2"} {
+		event = <-events
+		assert.Equal(t, gotree.EventDelete, event.Type)
+		assert.Equal(t, id, event.Node.ID())
+	}
+
+	require.Eventually(t, func() bool {
+		return follower.Dump() == writer.Dump()
+	}, 5*time.Second, 10*time.Millisecond)
+}
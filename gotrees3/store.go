@@ -0,0 +1,277 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotrees3 implements gotree.BlobStore for the S3-compatible object storages (AWS S3,
+// MinIO, Ceph, ...), so that Tree.SaveTo and Tree.LoadFrom checkpoint a Tree to a bucket.
+//
+// It talks to the S3 REST API directly, signing the requests with AWS Signature Version 4.
+// Large snapshots are streamed with multipart uploads.
+package gotrees3
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tochemey/gotree"
+)
+
+const (
+	// DefaultPartSize is the default size of the parts of the multipart uploads
+	DefaultPartSize = 16 << 20
+	// MinPartSize is the minimum size of the parts of the multipart uploads accepted by S3
+	MinPartSize = 5 << 20
+)
+
+// Credentials are the access keys signing the requests
+type Credentials struct {
+	// AccessKeyID is the identifier of the access key
+	AccessKeyID string
+	// SecretAccessKey is the secret of the access key
+	SecretAccessKey string
+	// SessionToken is the token of temporary credentials, empty otherwise
+	SessionToken string
+}
+
+// Option configures a Store
+type Option func(store *Store)
+
+// WithRegion sets the region of the bucket, us-east-1 by default
+func WithRegion(region string) Option {
+	return func(store *Store) {
+		store.region = region
+	}
+}
+
+// WithPartSize sets the size of the parts of the multipart uploads, DefaultPartSize by default.
+// The content smaller than a part is uploaded with a single request. Sizes below MinPartSize are raised to it.
+func WithPartSize(size int64) Option {
+	return func(store *Store) {
+		store.partSize = max(size, MinPartSize)
+	}
+}
+
+// WithHTTPClient sets the HTTP client of the Store, for instance to configure TLS or timeouts
+func WithHTTPClient(client *http.Client) Option {
+	return func(store *Store) {
+		if client != nil {
+			store.client = client
+		}
+	}
+}
+
+// Store is a gotree.BlobStore keeping the objects in a bucket of an S3-compatible object storage.
+// The bucket is addressed in path style: <endpoint>/<bucket>/<key>.
+type Store struct {
+	endpoint    *url.URL
+	bucket      string
+	credentials Credentials
+	region      string
+	partSize    int64
+	client      *http.Client
+	// now returns the signing time
+	now func() time.Time
+}
+
+// enforce compilation error
+var _ gotree.BlobStore = (*Store)(nil)
+
+// New creates a Store keeping the objects in the given bucket.
+//
+// The endpoint is the base URL of the object storage, e.g. https://s3.eu-west-1.amazonaws.com
+// or http://localhost:9000 for MinIO.
+func New(endpoint, bucket string, credentials Credentials, opts ...Option) (*Store, error) {
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("gotrees3: invalid endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme == "" || u.Host == "" || bucket == "" {
+		return nil, fmt.Errorf("gotrees3: invalid endpoint %q or bucket %q", endpoint, bucket)
+	}
+
+	store := &Store{
+		endpoint:    u,
+		bucket:      bucket,
+		credentials: credentials,
+		region:      "us-east-1",
+		partSize:    DefaultPartSize,
+		client:      http.DefaultClient,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(store)
+	}
+	return store, nil
+}
+
+// Put uploads the content read from r under the given key. The content fitting in a part is
+// uploaded with a single request, the bigger one with a multipart upload holding a single part
+// in memory at a time. A failed multipart upload is aborted.
+func (s *Store) Put(ctx context.Context, key string, r io.Reader) error {
+	part := make([]byte, s.partSize)
+	n, err := io.ReadFull(r, part)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		resp, err := s.do(ctx, http.MethodPut, key, nil, part[:n])
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	uploadID, err := s.createUpload(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if err := s.uploadParts(ctx, key, uploadID, part, r); err != nil {
+		// the parts uploaded so far are discarded, whether or not the context is done
+		if resp, aerr := s.do(context.WithoutCancel(ctx), http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil); aerr == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+	return nil
+}
+
+// Get downloads the content stored under the given key. The error wraps fs.ErrNotExist when
+// the object does not exist.
+func (s *Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// completedPart is a part of a completed multipart upload
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// createUpload starts a multipart upload and returns its identifier
+func (s *Store) createUpload(ctx context.Context, key string) (string, error) {
+	resp, err := s.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil || result.UploadID == "" {
+		return "", fmt.Errorf("gotrees3: cannot start the upload of %s: %v", key, err)
+	}
+	return result.UploadID, nil
+}
+
+// uploadParts uploads the given full part followed by the rest of r as the parts of the given upload,
+// and completes it
+func (s *Store) uploadParts(ctx context.Context, key, uploadID string, part []byte, r io.Reader) error {
+	var parts []completedPart
+	for n := len(part); n > 0; {
+		number := len(parts) + 1
+		query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}}
+		resp, err := s.do(ctx, http.MethodPut, key, query, part[:n])
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		parts = append(parts, completedPart{PartNumber: number, ETag: resp.Header.Get("ETag")})
+
+		n, err = io.ReadFull(r, part)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+	}
+
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// the completion may fail after the response status has been sent
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if bytes.Contains(content, []byte("<Error>")) {
+		return fmt.Errorf("gotrees3: cannot complete the upload of %s: %s", key, content)
+	}
+	return nil
+}
+
+// do sends the signed request of the given object and returns the successful response.
+// The body of the failed responses is read into the returned error
+func (s *Store) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	u := *s.endpoint
+	u.Path = s.endpoint.Path + "/" + s.bucket + "/" + key
+	u.RawPath = uriEncode(s.endpoint.Path, false) + "/" + uriEncode(s.bucket, true) + "/" + uriEncode(key, false)
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	s.sign(req, body)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		err := fmt.Errorf("gotrees3: %s %s: %s: %s", method, key, resp.Status, bytes.TrimSpace(content))
+		if resp.StatusCode == http.StatusNotFound {
+			err = fmt.Errorf("%w: %w", fs.ErrNotExist, err)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,196 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreesync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// consulWait is the duration of the blocking queries watching the keys
+const consulWait = 5 * time.Minute
+
+// consulSource lists and watches the keys of the Consul KV store
+type consulSource struct {
+	address string
+	cfg     *sourceConfig
+
+	mu       sync.Mutex
+	listings map[string]consulListing
+}
+
+// enforce compilation error
+var _ Source = (*consulSource)(nil)
+
+// consulListing is the listing of the keys under a prefix at a given index
+type consulListing struct {
+	index  uint64
+	values map[string][]byte
+}
+
+// consulKeyValue is an entry of the KV store
+type consulKeyValue struct {
+	Key   string `json:"Key"`
+	Value []byte `json:"Value"`
+}
+
+// NewConsulSource creates a Source reading the keys of the Consul KV store of the agent at the
+// given address, such as "http://localhost:8500". The changes are watched with blocking queries.
+// WithToken sets the ACL token of the requests.
+func NewConsulSource(address string, opts ...SourceOption) Source {
+	return &consulSource{
+		address:  strings.TrimSuffix(address, "/"),
+		cfg:      newSourceConfig(opts),
+		listings: make(map[string]consulListing),
+	}
+}
+
+// List returns the keys under the given prefix and the index of the store
+func (s *consulSource) List(ctx context.Context, prefix string) ([]Change, uint64, error) {
+	listing, err := s.list(ctx, prefix, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.mu.Lock()
+	s.listings[prefix] = listing
+	s.mu.Unlock()
+
+	keys := make([]Change, 0, len(listing.values))
+	for key, value := range listing.values {
+		keys = append(keys, Change{Key: key, Value: value})
+	}
+	return keys, listing.index, nil
+}
+
+// Watch blocks on the keys under the given prefix and applies the difference between the
+// consecutive listings, starting from the listing returned by List at the given index
+func (s *consulSource) Watch(ctx context.Context, prefix string, revision uint64, apply func(changes []Change)) error {
+	s.mu.Lock()
+	previous, ok := s.listings[prefix]
+	s.mu.Unlock()
+	if !ok || previous.index != revision {
+		return fmt.Errorf("index %d of %q has not been listed", revision, prefix)
+	}
+
+	for {
+		listing, err := s.list(ctx, prefix, previous.index)
+		if err != nil {
+			return err
+		}
+
+		// the index going backward means the store has been reset
+		if listing.index < previous.index {
+			listing.index = 0
+		}
+
+		if changes := consulDiff(previous.values, listing.values); len(changes) > 0 {
+			apply(changes)
+		}
+
+		s.mu.Lock()
+		s.listings[prefix] = listing
+		s.mu.Unlock()
+		previous = listing
+	}
+}
+
+// list lists the keys under the given prefix, blocking until the index of the store
+// is past the given one when it is not zero
+func (s *consulSource) list(ctx context.Context, prefix string, index uint64) (consulListing, error) {
+	query := url.Values{"recurse": []string{"true"}}
+	if index > 0 {
+		query.Set("index", strconv.FormatUint(index, 10))
+		query.Set("wait", consulWait.String())
+	}
+
+	endpoint := s.address + "/v1/kv/" + (&url.URL{Path: strings.TrimPrefix(prefix, "/")}).EscapedPath() + "?" + query.Encode()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return consulListing{}, err
+	}
+	if s.cfg.token != "" {
+		request.Header.Set("X-Consul-Token", s.cfg.token)
+	}
+
+	response, err := s.cfg.client.Do(request)
+	if err != nil {
+		return consulListing{}, err
+	}
+	defer response.Body.Close()
+
+	listing := consulListing{values: make(map[string][]byte)}
+	if header := response.Header.Get("X-Consul-Index"); header != "" {
+		if listing.index, err = strconv.ParseUint(header, 10, 64); err != nil {
+			return consulListing{}, fmt.Errorf("invalid index %q: %w", header, err)
+		}
+	}
+
+	switch response.StatusCode {
+	case http.StatusNotFound:
+		// no key under the prefix
+		return listing, nil
+	case http.StatusOK:
+	default:
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return consulListing{}, fmt.Errorf("/v1/kv returned %s: %s", response.Status, bytes.TrimSpace(message))
+	}
+
+	var entries []consulKeyValue
+	if err := json.NewDecoder(response.Body).Decode(&entries); err != nil {
+		return consulListing{}, fmt.Errorf("cannot decode the keys: %w", err)
+	}
+
+	for _, entry := range entries {
+		listing.values[entry.Key] = entry.Value
+	}
+	return listing, nil
+}
+
+// consulDiff returns the changes turning the previous listing into the current one
+func consulDiff(previous, current map[string][]byte) []Change {
+	var changes []Change
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			changes = append(changes, Change{Key: key, Deleted: true})
+		}
+	}
+
+	for key, value := range current {
+		if old, ok := previous[key]; !ok || !bytes.Equal(old, value) {
+			changes = append(changes, Change{Key: key, Value: value})
+		}
+	}
+	return changes
+}
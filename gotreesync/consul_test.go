@@ -0,0 +1,178 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreesync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConsul serves the KV endpoint of Consul with blocking queries
+type fakeConsul struct {
+	mu      sync.Mutex
+	index   uint64
+	keys    map[string]string
+	changed chan struct{}
+}
+
+func newFakeConsul(keys map[string]string) *fakeConsul {
+	return &fakeConsul{index: 5, keys: keys, changed: make(chan struct{})}
+}
+
+func (c *fakeConsul) set(key, value string, deleted bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if deleted {
+		delete(c.keys, key)
+	} else {
+		c.keys[key] = value
+	}
+	c.index++
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+func (c *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Consul-Token") != "token" {
+		http.Error(w, "ACL not found", http.StatusForbidden)
+		return
+	}
+
+	prefix := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+	if r.URL.Query().Get("recurse") != "true" {
+		http.Error(w, "recurse expected", http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	if index, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64); index >= c.index {
+		changed := c.changed
+		c.mu.Unlock()
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+		c.mu.Lock()
+	}
+	defer c.mu.Unlock()
+
+	var entries []consulKeyValue
+	for key, value := range c.keys {
+		if strings.HasPrefix(key, prefix) {
+			entries = append(entries, consulKeyValue{Key: key, Value: []byte(value)})
+		}
+	}
+
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(c.index, 10))
+	if len(entries) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(entries)
+}
+
+func TestConsulSource(t *testing.T) {
+	t.Run("lists the keys under the prefix", func(t *testing.T) {
+		server := httptest.NewServer(newFakeConsul(map[string]string{"config/a": "1", "config/b/c": "2", "other": "3"}))
+		t.Cleanup(server.Close)
+
+		source := NewConsulSource(server.URL, WithToken("token"))
+		keys, index, err := source.List(context.Background(), "config/")
+		require.NoError(t, err)
+		assert.EqualValues(t, 5, index)
+		assert.ElementsMatch(t, []Change{
+			{Key: "config/a", Value: []byte("1")},
+			{Key: "config/b/c", Value: []byte("2")},
+		}, keys)
+
+		keys, _, err = source.List(context.Background(), "missing/")
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+
+		_, _, err = NewConsulSource(server.URL).List(context.Background(), "config/")
+		assert.ErrorContains(t, err, "403")
+	})
+
+	t.Run("watches the changes", func(t *testing.T) {
+		consul := newFakeConsul(map[string]string{"config/a": "1", "config/b": "2"})
+		server := httptest.NewServer(consul)
+		t.Cleanup(server.Close)
+
+		source := NewConsulSource(server.URL, WithToken("token"))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		assert.ErrorContains(t, source.Watch(ctx, "config/", 5, func([]Change) {}), "has not been listed")
+
+		_, index, err := source.List(ctx, "config/")
+		require.NoError(t, err)
+
+		changes := make(chan []Change, 1)
+		done := make(chan error, 1)
+		go func() {
+			done <- source.Watch(ctx, "config/", index, func(received []Change) {
+				changes <- received
+			})
+		}()
+
+		consul.set("config/a", "10", false)
+		assert.Equal(t, []Change{{Key: "config/a", Value: []byte("10")}}, <-changes)
+
+		consul.set("config/b", "", true)
+		assert.Equal(t, []Change{{Key: "config/b", Deleted: true}}, <-changes)
+
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+
+	t.Run("feeds a Mirror", func(t *testing.T) {
+		consul := newFakeConsul(map[string]string{"config/app/name": "app"})
+		server := httptest.NewServer(consul)
+		t.Cleanup(server.Close)
+
+		mirror := NewMirror(NewConsulSource(server.URL, WithToken("token")), "config/", decodeString)
+		runMirror(t, mirror)
+		name, ok := value(mirror.Tree(), "config/app/name")
+		require.True(t, ok)
+		assert.Equal(t, "app", name)
+
+		consul.set("config/app/port", "8080", false)
+		require.Eventually(t, func() bool {
+			port, _ := value(mirror.Tree(), "config/app/port")
+			return port == "8080"
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
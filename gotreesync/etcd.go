@@ -0,0 +1,228 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreesync
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// etcdSource lists and watches the keys of etcd through its v3 JSON gateway
+type etcdSource struct {
+	endpoint string
+	cfg      *sourceConfig
+}
+
+// enforce compilation error
+var _ Source = (*etcdSource)(nil)
+
+// NewEtcdSource creates a Source reading the keys of the etcd cluster at the given endpoint,
+// such as "http://localhost:2379", through the v3 JSON gateway.
+// WithToken sets the authentication token returned by /v3/auth/authenticate.
+func NewEtcdSource(endpoint string, opts ...SourceOption) Source {
+	return &etcdSource{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		cfg:      newSourceConfig(opts),
+	}
+}
+
+// etcdKeyValue is a key-value pair of the v3 JSON gateway
+type etcdKeyValue struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// etcdHeader is the response header of the v3 JSON gateway
+type etcdHeader struct {
+	Revision string `json:"revision"`
+}
+
+// etcdRangeRequest is the body of /v3/kv/range
+type etcdRangeRequest struct {
+	Key      []byte `json:"key"`
+	RangeEnd []byte `json:"range_end"`
+}
+
+// etcdRangeResponse is the response of /v3/kv/range
+type etcdRangeResponse struct {
+	Header etcdHeader     `json:"header"`
+	KVs    []etcdKeyValue `json:"kvs"`
+}
+
+// etcdWatchRequest is the body of /v3/watch
+type etcdWatchRequest struct {
+	CreateRequest etcdWatchCreateRequest `json:"create_request"`
+}
+
+// etcdWatchCreateRequest creates a watch
+type etcdWatchCreateRequest struct {
+	Key           []byte `json:"key"`
+	RangeEnd      []byte `json:"range_end"`
+	StartRevision string `json:"start_revision"`
+}
+
+// etcdWatchResponse is a message streamed by /v3/watch
+type etcdWatchResponse struct {
+	Result *struct {
+		Canceled        bool   `json:"canceled"`
+		CompactRevision string `json:"compact_revision"`
+		CancelReason    string `json:"cancel_reason"`
+		Events          []struct {
+			Type string       `json:"type"`
+			KV   etcdKeyValue `json:"kv"`
+		} `json:"events"`
+	} `json:"result"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// List returns the keys under the given prefix and the revision of the store
+func (s *etcdSource) List(ctx context.Context, prefix string) ([]Change, uint64, error) {
+	body, err := s.post(ctx, "/v3/kv/range", etcdRangeRequest{Key: []byte(prefix), RangeEnd: etcdRangeEnd(prefix)})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer body.Close()
+
+	var response etcdRangeResponse
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("cannot decode the range response: %w", err)
+	}
+
+	revision, err := strconv.ParseUint(response.Header.Revision, 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid revision %q: %w", response.Header.Revision, err)
+	}
+
+	keys := make([]Change, 0, len(response.KVs))
+	for _, kv := range response.KVs {
+		keys = append(keys, Change{Key: string(kv.Key), Value: kv.Value})
+	}
+	return keys, revision, nil
+}
+
+// Watch streams the changes of the keys under the given prefix applied after the given revision
+func (s *etcdSource) Watch(ctx context.Context, prefix string, revision uint64, apply func(changes []Change)) error {
+	body, err := s.post(ctx, "/v3/watch", etcdWatchRequest{CreateRequest: etcdWatchCreateRequest{
+		Key:           []byte(prefix),
+		RangeEnd:      etcdRangeEnd(prefix),
+		StartRevision: strconv.FormatUint(revision+1, 10),
+	}})
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(body))
+	for {
+		var response etcdWatchResponse
+		if err := decoder.Decode(&response); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, io.EOF) {
+				return errors.New("watch closed by the store")
+			}
+			return fmt.Errorf("cannot decode the watch response: %w", err)
+		}
+
+		switch {
+		case response.Error != nil:
+			return fmt.Errorf("watch failed: %s", response.Error.Message)
+		case response.Result == nil:
+			continue
+		case response.Result.Canceled:
+			if response.Result.CompactRevision != "" && response.Result.CompactRevision != "0" {
+				return fmt.Errorf("revision %d has been compacted", revision)
+			}
+			return fmt.Errorf("watch canceled: %s", response.Result.CancelReason)
+		}
+
+		if len(response.Result.Events) == 0 {
+			continue
+		}
+
+		changes := make([]Change, 0, len(response.Result.Events))
+		for _, event := range response.Result.Events {
+			if event.Type == "DELETE" {
+				changes = append(changes, Change{Key: string(event.KV.Key), Deleted: true})
+				continue
+			}
+			changes = append(changes, Change{Key: string(event.KV.Key), Value: event.KV.Value})
+		}
+		apply(changes)
+	}
+}
+
+// post sends the given request to the gateway and returns the body of the response
+func (s *etcdSource) post(ctx context.Context, path string, payload any) (io.ReadCloser, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if s.cfg.token != "" {
+		request.Header.Set("Authorization", s.cfg.token)
+	}
+
+	response, err := s.cfg.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return nil, fmt.Errorf("%s returned %s: %s", path, response.Status, bytes.TrimSpace(message))
+	}
+	return response.Body, nil
+}
+
+// etcdRangeEnd returns the end of the range of the keys with the given prefix.
+// The range of the empty prefix covers every key.
+func etcdRangeEnd(prefix string) []byte {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return []byte{0}
+}
@@ -0,0 +1,170 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreesync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEtcd serves the range and watch endpoints of the etcd v3 JSON gateway
+type fakeEtcd struct {
+	mu       sync.Mutex
+	revision int64
+	keys     map[string]string
+	events   chan string
+	token    string
+}
+
+func newFakeEtcd(keys map[string]string) *fakeEtcd {
+	return &fakeEtcd{revision: 10, keys: keys, events: make(chan string)}
+}
+
+func (e *fakeEtcd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if e.token != "" && r.Header.Get("Authorization") != e.token {
+		http.Error(w, `{"error":"invalid auth token"}`, http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/v3/kv/range":
+		var request etcdRangeRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		response := etcdRangeResponse{Header: etcdHeader{Revision: "10"}}
+		for key, value := range e.keys {
+			if bytes.Compare([]byte(key), request.Key) >= 0 && bytes.Compare([]byte(key), request.RangeEnd) < 0 {
+				response.KVs = append(response.KVs, etcdKeyValue{Key: []byte(key), Value: []byte(value)})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	case "/v3/watch":
+		var request etcdWatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.CreateRequest.StartRevision != "11" {
+			http.Error(w, "unexpected watch request", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"result":{"header":{"revision":"10"},"created":true}}` + "\n"))
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case event, ok := <-e.events:
+				if !ok {
+					return
+				}
+				_, _ = w.Write([]byte(event + "\n"))
+				w.(http.Flusher).Flush()
+			}
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestEtcdSource(t *testing.T) {
+	t.Run("lists the keys under the prefix", func(t *testing.T) {
+		etcd := newFakeEtcd(map[string]string{"/config/a": "1", "/config/b/c": "2", "/configs": "3"})
+		etcd.token = "secret"
+		server := httptest.NewServer(etcd)
+		t.Cleanup(server.Close)
+
+		source := NewEtcdSource(server.URL, WithToken("secret"))
+		keys, revision, err := source.List(context.Background(), "/config/")
+		require.NoError(t, err)
+		assert.EqualValues(t, 10, revision)
+		assert.ElementsMatch(t, []Change{
+			{Key: "/config/a", Value: []byte("1")},
+			{Key: "/config/b/c", Value: []byte("2")},
+		}, keys)
+
+		_, _, err = NewEtcdSource(server.URL).List(context.Background(), "/config/")
+		assert.ErrorContains(t, err, "401")
+	})
+
+	t.Run("watches the changes", func(t *testing.T) {
+		etcd := newFakeEtcd(map[string]string{})
+		server := httptest.NewServer(etcd)
+		t.Cleanup(server.Close)
+
+		changes := make(chan []Change, 1)
+		done := make(chan error, 1)
+		go func() {
+			done <- NewEtcdSource(server.URL).Watch(context.Background(), "/config/", 10, func(received []Change) {
+				changes <- received
+			})
+		}()
+
+		etcd.events <- `{"result":{"header":{"revision":"12"},"events":[{"kv":{"key":"L2NvbmZpZy9h","value":"MQ=="}},{"type":"DELETE","kv":{"key":"L2NvbmZpZy9i"}}]}}`
+		assert.Equal(t, []Change{
+			{Key: "/config/a", Value: []byte("1")},
+			{Key: "/config/b", Deleted: true},
+		}, <-changes)
+
+		etcd.events <- `{"result":{"header":{"revision":"12"},"canceled":true,"compact_revision":"11"}}`
+		assert.ErrorContains(t, <-done, "compacted")
+	})
+
+	t.Run("feeds a Mirror", func(t *testing.T) {
+		etcd := newFakeEtcd(map[string]string{"/config/app/name": "app"})
+		server := httptest.NewServer(etcd)
+		t.Cleanup(server.Close)
+
+		mirror := NewMirror(NewEtcdSource(server.URL), "/config/", decodeString)
+		runMirror(t, mirror)
+		name, ok := value(mirror.Tree(), "/config/app/name")
+		require.True(t, ok)
+		assert.Equal(t, "app", name)
+
+		etcd.events <- `{"result":{"events":[{"kv":{"key":"L2NvbmZpZy9hcHAvbmFtZQ==","value":"YXBpCg=="}}]}}`
+		require.Eventually(t, func() bool {
+			name, _ := value(mirror.Tree(), "/config/app/name")
+			return name == "api\n"
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestEtcdRangeEnd(t *testing.T) {
+	assert.Equal(t, []byte("/config0"), etcdRangeEnd("/config/"))
+	assert.Equal(t, []byte{'a', 0x01}, etcdRangeEnd("a\x00"))
+	assert.Equal(t, []byte{'b'}, etcdRangeEnd("a\xff"))
+	assert.Equal(t, []byte{0}, etcdRangeEnd(""))
+}
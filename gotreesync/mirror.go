@@ -0,0 +1,358 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotreesync mirrors a key prefix of a hierarchical key-value store, such as etcd or
+// Consul, into a gotree.Tree and keeps it up to date by watching the store, making the Tree
+// an in-memory index over external hierarchical configuration.
+//
+// The keys are split on "/" and every segment becomes a Node identified by the key prefix
+// it stands for: the key "/config/app/db/host" mirrored from the prefix "/config/" becomes
+// the Node "/config/app/db/host" under "/config/app/db", "/config/app" and the root "/config".
+// The Nodes created for the intermediate segments, and the keys without value such as the
+// folders of Consul, hold the zero value.
+//
+//	mirror := gotreesync.NewMirror(gotreesync.NewEtcdSource("http://localhost:2379"), "/config/",
+//	    func(value []byte) (string, error) { return string(value), nil })
+//	go mirror.Run(ctx)
+//	<-mirror.Ready()
+//
+//	node, ok := mirror.Tree().Find("/config/app/db/host")
+package gotreesync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tochemey/gotree"
+)
+
+// separator separates the segments of the keys
+const separator = "/"
+
+// defaultRetryInterval is the default delay before listing the keys again after a failure
+const defaultRetryInterval = time.Second
+
+// Change describes a key of the store
+type Change struct {
+	// Key is the full key
+	Key string
+	// Value is the value of the key. It is nil for deletions
+	Value []byte
+	// Deleted is true when the key has been deleted
+	Deleted bool
+}
+
+// Source is a hierarchical key-value store mirrored by a Mirror.
+// NewEtcdSource and NewConsulSource return the sources of etcd and Consul.
+type Source interface {
+	// List returns the keys under the given prefix along with the revision of the store
+	// to watch from.
+	List(ctx context.Context, prefix string) (keys []Change, revision uint64, err error)
+	// Watch calls apply with the changes of the keys under the given prefix applied after
+	// the given revision, in order, until the context is done or the watch fails. It
+	// returns the error that stopped the watch; the Mirror then lists the keys again.
+	Watch(ctx context.Context, prefix string, revision uint64, apply func(changes []Change)) error
+}
+
+// MirrorOption configures a Mirror
+type MirrorOption func(config *mirrorConfig)
+
+// mirrorConfig holds the Mirror settings
+type mirrorConfig struct {
+	retryInterval time.Duration
+	onError       func(err error)
+}
+
+// WithRetryInterval sets the delay before listing the keys again after the Source failed.
+// The default interval is one second.
+func WithRetryInterval(interval time.Duration) MirrorOption {
+	return func(config *mirrorConfig) {
+		if interval > 0 {
+			config.retryInterval = interval
+		}
+	}
+}
+
+// WithErrorHandler sets the function receiving the failures of the Source and the values
+// that cannot be decoded. The failures are ignored by default.
+func WithErrorHandler(handler func(err error)) MirrorOption {
+	return func(config *mirrorConfig) {
+		config.onError = handler
+	}
+}
+
+// Mirror mirrors the keys of a Source under a prefix into a Tree
+type Mirror[T any] struct {
+	source Source
+	prefix string
+	root   string
+	decode func(value []byte) (T, error)
+	cfg    *mirrorConfig
+	tree   *gotree.Tree[T]
+
+	mu     sync.Mutex
+	values map[string][]byte
+	ready  chan struct{}
+	once   sync.Once
+}
+
+// NewMirror creates a Mirror of the keys of the given Source under the given prefix,
+// decoding their values with the given function
+func NewMirror[T any](source Source, prefix string, decode func(value []byte) (T, error), opts ...MirrorOption) *Mirror[T] {
+	cfg := &mirrorConfig{
+		retryInterval: defaultRetryInterval,
+		onError:       func(error) {},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	root := strings.TrimSuffix(prefix, separator)
+	if root == "" {
+		root = separator
+	}
+
+	m := &Mirror[T]{
+		source: source,
+		prefix: prefix,
+		root:   root,
+		decode: decode,
+		cfg:    cfg,
+		tree:   gotree.NewTree[T](),
+		values: make(map[string][]byte),
+		ready:  make(chan struct{}),
+	}
+	_ = m.tree.AddByID(gotree.NewNode(root, *new(T)), "")
+	return m
+}
+
+// Tree returns the mirrored Tree. It must only be read: the Mirror overwrites the changes.
+func (m *Mirror[T]) Tree() *gotree.Tree[T] {
+	return m.tree
+}
+
+// Ready returns a channel closed once the keys have been listed for the first time
+func (m *Mirror[T]) Ready() <-chan struct{} {
+	return m.ready
+}
+
+// Run lists the keys then applies their changes until the given context is done.
+// The keys are listed again, and the Tree resynchronized, whenever the Source fails.
+// It returns the error of the context.
+func (m *Mirror[T]) Run(ctx context.Context) error {
+	for {
+		if err := m.run(ctx); err != nil && ctx.Err() == nil {
+			m.cfg.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(m.cfg.retryInterval):
+		}
+	}
+}
+
+// run lists the keys and watches their changes until the watch fails
+func (m *Mirror[T]) run(ctx context.Context) error {
+	keys, revision, err := m.source.List(ctx, m.prefix)
+	if err != nil {
+		return fmt.Errorf("gotreesync: cannot list %q: %w", m.prefix, err)
+	}
+
+	m.resync(keys)
+	m.once.Do(func() {
+		close(m.ready)
+	})
+
+	if err := m.source.Watch(ctx, m.prefix, revision, m.onChanges); err != nil {
+		return fmt.Errorf("gotreesync: cannot watch %q: %w", m.prefix, err)
+	}
+	return nil
+}
+
+// resync applies the given listing, deleting the keys that are no longer listed
+func (m *Mirror[T]) resync(keys []Change) {
+	listed := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if id, ok := m.id(key.Key); ok {
+			listed[id] = struct{}{}
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id := range m.values {
+		if _, ok := listed[id]; !ok {
+			m.delete(id)
+		}
+	}
+	m.apply(keys)
+}
+
+// onChanges applies the changes received from the Source
+func (m *Mirror[T]) onChanges(changes []Change) {
+	m.mu.Lock()
+	m.apply(changes)
+	m.mu.Unlock()
+}
+
+// apply applies the given changes to the Tree.
+// It must be called with the lock held
+func (m *Mirror[T]) apply(changes []Change) {
+	for _, change := range changes {
+		id, ok := m.id(change.Key)
+		if !ok {
+			continue
+		}
+
+		if change.Deleted {
+			m.delete(id)
+			continue
+		}
+
+		if err := m.set(id, change.Value); err != nil {
+			m.cfg.onError(fmt.Errorf("gotreesync: cannot mirror %q: %w", change.Key, err))
+		}
+	}
+}
+
+// set sets the value of the Node with the given ID, creating the missing Nodes of its path
+func (m *Mirror[T]) set(id string, raw []byte) error {
+	if current, ok := m.values[id]; ok && bytes.Equal(current, raw) {
+		return nil
+	}
+
+	var value T
+	if len(raw) > 0 {
+		decoded, err := m.decode(raw)
+		if err != nil {
+			return err
+		}
+		value = decoded
+	}
+
+	if _, ok := m.tree.Find(id); ok {
+		if err := m.tree.Update(gotree.NewNode(id, value)); err != nil {
+			return err
+		}
+	} else {
+		parent := m.root
+		for _, ancestor := range m.ancestors(id) {
+			if _, ok := m.tree.Find(ancestor); !ok {
+				if err := m.tree.AddByID(gotree.NewNode(ancestor, *new(T)), parent); err != nil {
+					return err
+				}
+			}
+			parent = ancestor
+		}
+
+		if err := m.tree.AddByID(gotree.NewNode(id, value), parent); err != nil {
+			return err
+		}
+	}
+
+	m.values[id] = raw
+	return nil
+}
+
+// delete deletes the value of the Node with the given ID. The Node is reset to the zero value
+// when it has children, otherwise it is deleted along with its ancestors left without value nor children.
+func (m *Mirror[T]) delete(id string) {
+	if _, ok := m.values[id]; !ok {
+		return
+	}
+	delete(m.values, id)
+
+	node, ok := m.tree.Find(id)
+	if !ok {
+		return
+	}
+
+	if id == m.root || m.hasChildren(node) {
+		_ = m.tree.Update(gotree.NewNode(id, *new(T)))
+		return
+	}
+
+	for id != m.root {
+		if _, set := m.values[id]; set || m.hasChildren(node) {
+			return
+		}
+
+		parent, ok := m.tree.ParentAt(node, 0)
+		_ = m.tree.DeleteByID(id)
+		if !ok {
+			return
+		}
+		node, id = parent, parent.ID()
+	}
+}
+
+// id returns the ID of the Node mirroring the given key.
+// The keys differing only by their empty segments, such as "a/b" and "a/b/", share the same Node.
+func (m *Mirror[T]) id(key string) (string, bool) {
+	if !strings.HasPrefix(key, m.prefix) && key != m.root {
+		return "", false
+	}
+
+	id := m.root
+	for _, segment := range strings.Split(strings.TrimPrefix(key, m.prefix), separator) {
+		if segment == "" {
+			continue
+		}
+		if !strings.HasSuffix(id, separator) {
+			id += separator
+		}
+		id += segment
+	}
+	return id, true
+}
+
+// ancestors returns the IDs of the Nodes between the root and the Node with the given ID, root first
+func (m *Mirror[T]) ancestors(id string) []string {
+	var ancestors []string
+	for index := len(m.root); ; {
+		next := strings.Index(id[index+1:], separator)
+		if next < 0 {
+			return ancestors
+		}
+		index += next + 1
+		ancestors = append(ancestors, id[:index])
+	}
+}
+
+// hasChildren reports whether the given Node has children
+func (m *Mirror[T]) hasChildren(node gotree.Node[T]) bool {
+	cursor, ok := m.tree.Cursor(node)
+	if !ok {
+		return false
+	}
+	_, ok = cursor.FirstChild()
+	return ok
+}
@@ -0,0 +1,273 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreesync
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tochemey/gotree"
+)
+
+// fakeSource is an in-memory Source
+type fakeSource struct {
+	mu      sync.Mutex
+	keys    map[string]string
+	lists   int
+	changes chan []Change
+	fail    chan error
+}
+
+func newFakeSource(keys map[string]string) *fakeSource {
+	return &fakeSource{keys: keys, changes: make(chan []Change), fail: make(chan error)}
+}
+
+func (s *fakeSource) List(_ context.Context, _ string) ([]Change, uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lists++
+	var keys []Change
+	for key, value := range s.keys {
+		keys = append(keys, Change{Key: key, Value: []byte(value)})
+	}
+	return keys, uint64(s.lists), nil
+}
+
+func (s *fakeSource) Watch(ctx context.Context, _ string, _ uint64, apply func(changes []Change)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-s.fail:
+			return err
+		case changes := <-s.changes:
+			apply(changes)
+		}
+	}
+}
+
+func (s *fakeSource) set(keys map[string]string) {
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+}
+
+func decodeString(value []byte) (string, error) {
+	return string(value), nil
+}
+
+func runMirror[T any](t *testing.T, mirror *Mirror[T]) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- mirror.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+
+	select {
+	case <-mirror.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("mirror is not ready")
+	}
+}
+
+func value[T any](tree *gotree.Tree[T], id string) (T, bool) {
+	node, ok := tree.Find(id)
+	if !ok {
+		return *new(T), false
+	}
+	return node.Value(), true
+}
+
+func parentID[T any](t *testing.T, tree *gotree.Tree[T], id string) string {
+	t.Helper()
+	node, ok := tree.Find(id)
+	require.True(t, ok, id)
+	parent, ok := tree.ParentAt(node, 0)
+	require.True(t, ok, id)
+	return parent.ID()
+}
+
+func TestMirror(t *testing.T) {
+	t.Run("mirrors the keys as a hierarchy", func(t *testing.T) {
+		source := newFakeSource(map[string]string{
+			"/config/app/db/host": "localhost",
+			"/config/app/name":    "app",
+			"/other/key":          "ignored",
+		})
+		mirror := NewMirror(source, "/config/", decodeString)
+		runMirror(t, mirror)
+		tree := mirror.Tree()
+
+		root, ok := tree.RootOK()
+		require.True(t, ok)
+		assert.Equal(t, "/config", root.ID())
+		assert.EqualValues(t, 5, tree.Size())
+
+		host, ok := value(tree, "/config/app/db/host")
+		require.True(t, ok)
+		assert.Equal(t, "localhost", host)
+		assert.Equal(t, "/config/app/db", parentID(t, tree, "/config/app/db/host"))
+		assert.Equal(t, "/config/app", parentID(t, tree, "/config/app/db"))
+		assert.Equal(t, "/config", parentID(t, tree, "/config/app"))
+		assert.Equal(t, "/config/app", parentID(t, tree, "/config/app/name"))
+
+		db, ok := value(tree, "/config/app/db")
+		require.True(t, ok)
+		assert.Empty(t, db)
+		_, ok = tree.Find("/other/key")
+		assert.False(t, ok)
+	})
+
+	t.Run("applies the changes", func(t *testing.T) {
+		source := newFakeSource(map[string]string{
+			"/config/app/db/host": "localhost",
+			"/config/app/name":    "app",
+		})
+		mirror := NewMirror(source, "/config/", decodeString)
+		runMirror(t, mirror)
+		tree := mirror.Tree()
+
+		source.changes <- []Change{
+			{Key: "/config/app/db/host", Value: []byte("db.local")},
+			{Key: "/config/app/db/port", Value: []byte("5432")},
+			{Key: "/config/app/name", Deleted: true},
+		}
+		source.changes <- nil
+
+		host, _ := value(tree, "/config/app/db/host")
+		assert.Equal(t, "db.local", host)
+		port, _ := value(tree, "/config/app/db/port")
+		assert.Equal(t, "5432", port)
+		_, ok := tree.Find("/config/app/name")
+		assert.False(t, ok)
+
+		// deleting the last keys prunes the empty intermediate nodes
+		source.changes <- []Change{
+			{Key: "/config/app/db/host", Deleted: true},
+			{Key: "/config/app/db/port", Deleted: true},
+		}
+		source.changes <- nil
+		assert.EqualValues(t, 1, tree.Size())
+		assert.Empty(t, tree.Validate())
+	})
+
+	t.Run("keeps the nodes holding children or values", func(t *testing.T) {
+		source := newFakeSource(map[string]string{
+			"/config/app":      "parent",
+			"/config/app/name": "app",
+			"/config/app/port": "80",
+		})
+		mirror := NewMirror(source, "/config/", decodeString)
+		runMirror(t, mirror)
+		tree := mirror.Tree()
+
+		source.changes <- []Change{{Key: "/config/app/name", Deleted: true}, {Key: "/config/app/port", Deleted: true}}
+		source.changes <- nil
+		app, ok := value(tree, "/config/app")
+		require.True(t, ok)
+		assert.Equal(t, "parent", app)
+
+		source.changes <- []Change{{Key: "/config/app/name", Value: []byte("app")}, {Key: "/config/app", Deleted: true}}
+		source.changes <- nil
+		app, ok = value(tree, "/config/app")
+		require.True(t, ok)
+		assert.Empty(t, app)
+		_, ok = tree.Find("/config/app/name")
+		assert.True(t, ok)
+	})
+
+	t.Run("resynchronizes after a failure", func(t *testing.T) {
+		source := newFakeSource(map[string]string{
+			"/config/a": "1",
+			"/config/b": "2",
+		})
+		var mu sync.Mutex
+		var errs []error
+		mirror := NewMirror(source, "/config/", decodeString,
+			WithRetryInterval(10*time.Millisecond),
+			WithErrorHandler(func(err error) {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}))
+		runMirror(t, mirror)
+		tree := mirror.Tree()
+
+		source.set(map[string]string{"/config/b": "3", "/config/c/d": "4"})
+		source.fail <- errors.New("connection lost")
+
+		require.Eventually(t, func() bool {
+			_, found := tree.Find("/config/c/d")
+			return found
+		}, 5*time.Second, 10*time.Millisecond)
+		_, ok := tree.Find("/config/a")
+		assert.False(t, ok)
+		b, _ := value(tree, "/config/b")
+		assert.Equal(t, "3", b)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "connection lost")
+	})
+
+	t.Run("reports the values that cannot be decoded", func(t *testing.T) {
+		source := newFakeSource(map[string]string{
+			"a/port": "80",
+			"a/host": "localhost",
+		})
+		var mu sync.Mutex
+		var errs []error
+		mirror := NewMirror(source, "", func(value []byte) (int, error) { return strconv.Atoi(string(value)) }, WithErrorHandler(func(err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}))
+		runMirror(t, mirror)
+		tree := mirror.Tree()
+
+		port, ok := value(tree, "/a/port")
+		require.True(t, ok)
+		assert.Equal(t, 80, port)
+		_, ok = tree.Find("/a/host")
+		assert.False(t, ok)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], `cannot mirror "a/host"`)
+	})
+}
@@ -0,0 +1,62 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreesync
+
+import "net/http"
+
+// SourceOption configures the sources of etcd and Consul
+type SourceOption func(config *sourceConfig)
+
+// sourceConfig holds the settings of the sources
+type sourceConfig struct {
+	client *http.Client
+	token  string
+}
+
+// newSourceConfig creates the settings of a source from the given options
+func newSourceConfig(opts []SourceOption) *sourceConfig {
+	cfg := &sourceConfig{client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithHTTPClient sets the HTTP client of the source, for instance to configure TLS.
+// The watches are long-lived requests: the client must not set a short timeout.
+func WithHTTPClient(client *http.Client) SourceOption {
+	return func(config *sourceConfig) {
+		if client != nil {
+			config.client = client
+		}
+	}
+}
+
+// WithToken sets the token authenticating the requests of the source
+func WithToken(token string) SourceOption {
+	return func(config *sourceConfig) {
+		config.token = token
+	}
+}
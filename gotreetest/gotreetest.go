@@ -0,0 +1,160 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotreetest provides the helpers to write property tests against gotree.
+//
+// The generators build random Trees from a seed, hence a failing case is reproduced by running the
+// generator again with the same seed, and the assertions compare Trees without going through every
+// Node by hand:
+//
+//	tree := gotreetest.GenTree(seed, 1000, 8, 4)
+//	restored := gotree.NewTree[int]()
+//	require.NoError(t, restored.Restore(tree.Snapshot()))
+//	gotreetest.AssertSameStructure(t, tree, restored)
+package gotreetest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+
+	"github.com/tochemey/gotree"
+)
+
+// TestingT is the subset of testing.TB used by the assertions
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// GenTree generates a random Tree from the given seed. The Nodes are identified by "n0", "n1", ... in
+// the order they are added, n0 being the root, and hold their position in that order as value.
+//
+// Every Node is added under a parent drawn uniformly among the Nodes whose depth is below maxDepth and
+// which have less than branching children, a non positive limit meaning no limit. The Tree holds less
+// Nodes than requested when the limits are reached first. The same seed always generates the same Tree.
+func GenTree(seed int64, nodes, maxDepth, branching int, opts ...gotree.Option) *gotree.Tree[int] {
+	return GenTreeFunc(seed, nodes, maxDepth, branching, func(_ *rand.Rand, i int) int { return i }, opts...)
+}
+
+// GenTreeFunc behaves like GenTree, the value of every Node being computed by the given function
+// from the random source of the generator and the position of the Node.
+func GenTreeFunc[T any](seed int64, nodes, maxDepth, branching int, value func(r *rand.Rand, i int) T, opts ...gotree.Option) *gotree.Tree[T] {
+	tree := gotree.NewTree[T](opts...)
+	if nodes <= 0 {
+		return tree
+	}
+
+	r := rand.New(rand.NewPCG(uint64(seed), uint64(seed)>>32))
+	if err := tree.Add(gotree.NewNode(nodeID(0), value(r, 0)), nil); err != nil {
+		panic(fmt.Sprintf("gotreetest: cannot add the root: %v", err))
+	}
+	root, _ := tree.Ref(nodeID(0))
+
+	// open holds the Nodes which can still receive children
+	type candidate struct {
+		ref      gotree.NodeRef[T]
+		depth    int
+		children int
+	}
+
+	open := []*candidate{{ref: root}}
+
+	for i := 1; i < nodes && len(open) > 0; i++ {
+		pick := r.IntN(len(open))
+		parent := open[pick]
+
+		ref, err := tree.AddAt(parent.ref, gotree.NewNode(nodeID(i), value(r, i)))
+		if err != nil {
+			panic(fmt.Sprintf("gotreetest: cannot add node %q: %v", nodeID(i), err))
+		}
+
+		parent.children++
+		if branching > 0 && parent.children >= branching {
+			open[pick] = open[len(open)-1]
+			open = open[:len(open)-1]
+		}
+		if maxDepth <= 0 || parent.depth+1 < maxDepth {
+			open = append(open, &candidate{ref: ref, depth: parent.depth + 1})
+		}
+	}
+	return tree
+}
+
+// nodeID returns the identifier of the Node generated at the given position
+func nodeID(i int) string {
+	return fmt.Sprintf("n%d", i)
+}
+
+// AssertSameStructure asserts that both Trees hold the same Nodes under the same parents,
+// the children being in the same order. The values are not compared.
+// It reports the first difference found and returns whether the Trees match.
+func AssertSameStructure[T any](t TestingT, expected, actual *gotree.Tree[T]) bool {
+	t.Helper()
+	return compare(t, expected, actual, false)
+}
+
+// AssertSameTree behaves like AssertSameStructure and also asserts that the Nodes hold
+// deeply equal values.
+func AssertSameTree[T any](t TestingT, expected, actual *gotree.Tree[T]) bool {
+	t.Helper()
+	return compare(t, expected, actual, true)
+}
+
+// AssertValid asserts that the Tree passes its consistency checks, reporting every violation found
+func AssertValid[T any](t TestingT, tree *gotree.Tree[T]) bool {
+	t.Helper()
+	violations := tree.Validate()
+	for _, violation := range violations {
+		t.Errorf("gotreetest: invalid tree: %v", violation)
+	}
+	return len(violations) == 0
+}
+
+// compare compares the snapshots of both Trees: their records list the Nodes
+// depth-first, the children following the order of their parent
+func compare[T any](t TestingT, expected, actual *gotree.Tree[T], values bool) bool {
+	t.Helper()
+	want, got := expected.Snapshot().Records, actual.Snapshot().Records
+	for i := range min(len(want), len(got)) {
+		switch {
+		case want[i].ID != got[i].ID:
+			t.Errorf("gotreetest: node #%d differs: expected %q under %q, got %q under %q",
+				i, want[i].ID, want[i].ParentID, got[i].ID, got[i].ParentID)
+			return false
+		case want[i].ParentID != got[i].ParentID:
+			t.Errorf("gotreetest: node %q has another parent: expected %q, got %q", want[i].ID, want[i].ParentID, got[i].ParentID)
+			return false
+		case values && !reflect.DeepEqual(want[i].Value, got[i].Value):
+			t.Errorf("gotreetest: node %q has another value: expected %v, got %v", want[i].ID, want[i].Value, got[i].Value)
+			return false
+		}
+	}
+
+	if len(want) != len(got) {
+		t.Errorf("gotreetest: trees differ in size: expected %d nodes, got %d", len(want), len(got))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreetest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tochemey/gotree"
+)
+
+// recorder is a TestingT recording the reported failures
+type recorder struct {
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestGenTree(t *testing.T) {
+	t.Run("is deterministic", func(t *testing.T) {
+		tree := GenTree(42, 500, 6, 3)
+		AssertSameTree(t, tree, GenTree(42, 500, 6, 3))
+		AssertValid(t, tree)
+		assert.EqualValues(t, 500, tree.Size())
+
+		other := &recorder{}
+		assert.False(t, AssertSameStructure(other, tree, GenTree(43, 500, 6, 3)))
+		assert.Len(t, other.failures, 1)
+	})
+
+	t.Run("honors the limits", func(t *testing.T) {
+		tree := GenTree(7, 1000, 4, 3)
+		assert.LessOrEqual(t, tree.Depth(), 4)
+		// a complete ternary tree of depth 4 holds 121 nodes
+		assert.EqualValues(t, 121, tree.Size())
+		for _, node := range tree.Nodes() {
+			ref, ok := tree.Ref(node.ID())
+			require.True(t, ok)
+			children, ok := tree.ChildrenOf(ref)
+			require.True(t, ok)
+			assert.LessOrEqual(t, len(children), 3)
+		}
+
+		chain := GenTree(1, 50, 0, 1)
+		assert.EqualValues(t, 50, chain.Size())
+		assert.Equal(t, 49, chain.Depth())
+
+		assert.Zero(t, GenTree(1, 0, 3, 3).Size())
+	})
+
+	t.Run("generates custom values", func(t *testing.T) {
+		tree := GenTreeFunc(3, 20, 0, 0, func(r *rand.Rand, i int) string {
+			return fmt.Sprintf("%d-%d", i, r.IntN(10))
+		}, gotree.WithAncestorIndex())
+		AssertSameTree(t, tree, GenTreeFunc(3, 20, 0, 0, func(r *rand.Rand, i int) string {
+			return fmt.Sprintf("%d-%d", i, r.IntN(10))
+		}))
+		root, ok := tree.Find("n0")
+		require.True(t, ok)
+		assert.Regexp(t, `^0-\d$`, root.Value())
+	})
+}
+
+func TestAssertions(t *testing.T) {
+	expected := gotree.NewTree[string]()
+	require.NoError(t, expected.AddByID(gotree.NewNode("root", "root"), ""))
+	require.NoError(t, expected.AddByID(gotree.NewNode("a", "a"), "root"))
+	require.NoError(t, expected.AddByID(gotree.NewNode("b", "b"), "root"))
+
+	t.Run("reports a different value", func(t *testing.T) {
+		actual := gotree.NewTree[string]()
+		require.NoError(t, actual.AddByID(gotree.NewNode("root", "root"), ""))
+		require.NoError(t, actual.AddByID(gotree.NewNode("a", "a"), "root"))
+		require.NoError(t, actual.AddByID(gotree.NewNode("b", "other"), "root"))
+
+		assert.True(t, AssertSameStructure(t, expected, actual))
+		r := &recorder{}
+		assert.False(t, AssertSameTree(r, expected, actual))
+		assert.Equal(t, []string{`gotreetest: node "b" has another value: expected b, got other`}, r.failures)
+	})
+
+	t.Run("reports a different order or parent", func(t *testing.T) {
+		actual := gotree.NewTree[string]()
+		require.NoError(t, actual.AddByID(gotree.NewNode("root", "root"), ""))
+		require.NoError(t, actual.AddByID(gotree.NewNode("b", "b"), "root"))
+		require.NoError(t, actual.AddByID(gotree.NewNode("a", "a"), "root"))
+
+		r := &recorder{}
+		assert.False(t, AssertSameStructure(r, expected, actual))
+		assert.Len(t, r.failures, 1)
+
+		require.NoError(t, actual.MoveByID("a", "b"))
+		r = &recorder{}
+		assert.False(t, AssertSameStructure(r, expected, actual))
+		assert.Len(t, r.failures, 1)
+	})
+
+	t.Run("reports a different size", func(t *testing.T) {
+		actual := gotree.NewTree[string]()
+		require.NoError(t, actual.AddByID(gotree.NewNode("root", "root"), ""))
+		require.NoError(t, actual.AddByID(gotree.NewNode("a", "a"), "root"))
+
+		r := &recorder{}
+		assert.False(t, AssertSameStructure(r, expected, actual))
+		assert.Equal(t, []string{"gotreetest: trees differ in size: expected 3 nodes, got 2"}, r.failures)
+	})
+}
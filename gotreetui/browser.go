@@ -0,0 +1,448 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package gotreetui provides an interactive terminal browser for a gotree.Tree.
+//
+// The browser lists the Nodes as an outline that can be expanded and collapsed, searches
+// the Nodes by ID and shows the details of the selected Node, its value being rendered as
+// indented JSON. The outline follows the changes of a live Tree.
+//
+//	if err := gotreetui.Run(ctx, tree); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Keys:
+//   - up/k, down/j, pgup, pgdown, home/g, end/G: move the selection.
+//   - right/l: expand the selected Node, or select its first child when already expanded.
+//   - left/h: collapse the selected Node, or select its parent when already collapsed.
+//   - enter/space: toggle the selected Node.
+//   - /: search a Node by ID; enter jumps to the exact match or to the first ID containing the text.
+//   - q, ctrl+c: quit.
+package gotreetui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tochemey/gotree"
+)
+
+// detailLines is the number of lines showing the details of the selected Node
+const detailLines = 8
+
+// row is a visible line of the outline
+type row[T any] struct {
+	node     gotree.Node[T]
+	depth    int
+	children int
+}
+
+// changedMsg reports that the Tree has changed
+type changedMsg struct{}
+
+// Model is the bubbletea model of the browser.
+// It can be embedded in another bubbletea program.
+type Model[T any] struct {
+	tree     *gotree.Tree[T]
+	events   <-chan gotree.Event[T]
+	expanded map[string]bool
+	rows     []row[T]
+	selected string
+	cursor   int
+	offset   int
+	width    int
+	height   int
+
+	searching bool
+	search    string
+	status    string
+}
+
+var _ tea.Model = (*Model[any])(nil)
+
+// NewModel creates a Model browsing the given Tree with the root expanded.
+// The Model follows the changes of the Tree until the given context is done.
+func NewModel[T any](ctx context.Context, tree *gotree.Tree[T]) *Model[T] {
+	m := &Model[T]{
+		tree:     tree,
+		expanded: make(map[string]bool),
+		events: tree.Watch(ctx, nil,
+			gotree.WithBufferSize(1),
+			gotree.WithBackpressure(gotree.BackpressureDropNewest)),
+	}
+
+	if root, ok := tree.RootOK(); ok {
+		m.expanded[root.ID()] = true
+		m.selected = root.ID()
+	}
+	m.refresh()
+	return m
+}
+
+// Run browses the given Tree until the user quits or the given context is done
+func Run[T any](ctx context.Context, tree *gotree.Tree[T], opts ...tea.ProgramOption) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	opts = append([]tea.ProgramOption{tea.WithAltScreen(), tea.WithContext(ctx)}, opts...)
+	_, err := tea.NewProgram(NewModel(ctx, tree), opts...).Run()
+	if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// Init implements tea.Model
+func (m *Model[T]) Init() tea.Cmd {
+	return m.wait()
+}
+
+// Update implements tea.Model
+func (m *Model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+	case changedMsg:
+		m.refresh()
+		return m, m.wait()
+	case tea.KeyMsg:
+		if m.searching {
+			m.updateSearch(msg)
+			return m, nil
+		}
+		return m, m.updateBrowse(msg)
+	}
+	return m, nil
+}
+
+// updateBrowse handles the keys of the outline
+func (m *Model[T]) updateBrowse(msg tea.KeyMsg) tea.Cmd {
+	m.status = ""
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return tea.Quit
+	case "up", "k":
+		m.move(m.cursor - 1)
+	case "down", "j":
+		m.move(m.cursor + 1)
+	case "pgup":
+		m.move(m.cursor - m.pageSize())
+	case "pgdown":
+		m.move(m.cursor + m.pageSize())
+	case "home", "g":
+		m.move(0)
+	case "end", "G":
+		m.move(len(m.rows) - 1)
+	case "right", "l":
+		m.expand()
+	case "left", "h":
+		m.collapse()
+	case "enter", " ":
+		if current, ok := m.current(); ok && current.children > 0 {
+			m.expanded[current.node.ID()] = !m.expanded[current.node.ID()]
+			m.refresh()
+		}
+	case "/":
+		m.searching = true
+		m.search = ""
+	}
+	return nil
+}
+
+// updateSearch handles the keys of the search prompt
+func (m *Model[T]) updateSearch(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.searching = false
+	case tea.KeyEnter:
+		m.searching = false
+		m.find(m.search)
+	case tea.KeyBackspace:
+		if runes := []rune(m.search); len(runes) > 0 {
+			m.search = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.search += string(msg.Runes)
+	}
+}
+
+// View implements tea.Model
+func (m *Model[T]) View() string {
+	var builder strings.Builder
+
+	if len(m.rows) == 0 {
+		builder.WriteString("The tree is empty.\n")
+	}
+
+	end := min(len(m.rows), m.offset+m.pageSize())
+	for i := m.offset; i < end; i++ {
+		line := m.line(m.rows[i])
+		if i == m.cursor {
+			line = "\x1b[7m" + line + "\x1b[0m"
+		}
+		builder.WriteString(line)
+		builder.WriteString("\n")
+	}
+
+	separator := 20
+	if m.width > 0 {
+		separator = m.width
+	}
+	builder.WriteString(strings.Repeat("─", separator))
+	builder.WriteString("\n")
+	for _, line := range m.details() {
+		builder.WriteString(m.truncate(line))
+		builder.WriteString("\n")
+	}
+
+	switch {
+	case m.searching:
+		builder.WriteString(m.truncate("/" + m.search))
+	case m.status != "":
+		builder.WriteString(m.truncate(m.status))
+	default:
+		builder.WriteString(m.truncate("↑/↓ move  ←/→ collapse/expand  / search  q quit"))
+	}
+	return builder.String()
+}
+
+// refresh rebuilds the visible rows, keeping the selected Node when it still exists
+func (m *Model[T]) refresh() {
+	m.rows = m.rows[:0]
+	root, ok := m.tree.RootOK()
+	if !ok {
+		m.cursor, m.offset = 0, 0
+		return
+	}
+
+	var visit func(node gotree.Node[T], depth int)
+	visit = func(node gotree.Node[T], depth int) {
+		children := m.children(node)
+		m.rows = append(m.rows, row[T]{node: node, depth: depth, children: len(children)})
+		if m.expanded[node.ID()] {
+			for _, child := range children {
+				visit(child, depth+1)
+			}
+		}
+	}
+	visit(root, 0)
+
+	m.cursor = min(m.cursor, len(m.rows)-1)
+	for i, r := range m.rows {
+		if r.node.ID() == m.selected {
+			m.cursor = i
+			break
+		}
+	}
+	m.selected = m.rows[m.cursor].node.ID()
+	m.scroll()
+}
+
+// children returns the children of the given Node in insertion order
+func (m *Model[T]) children(node gotree.Node[T]) []gotree.Node[T] {
+	cursor, ok := m.tree.Cursor(node)
+	if !ok {
+		return nil
+	}
+
+	var children []gotree.Node[T]
+	for child, ok := cursor.FirstChild(); ok; child, ok = cursor.NextSibling() {
+		children = append(children, child)
+	}
+	return children
+}
+
+// current returns the selected row
+func (m *Model[T]) current() (row[T], bool) {
+	if len(m.rows) == 0 {
+		return row[T]{}, false
+	}
+	return m.rows[m.cursor], true
+}
+
+// move selects the row at the given index
+func (m *Model[T]) move(index int) {
+	if len(m.rows) == 0 {
+		return
+	}
+	m.cursor = max(0, min(index, len(m.rows)-1))
+	m.selected = m.rows[m.cursor].node.ID()
+	m.scroll()
+}
+
+// expand expands the selected Node or selects its first child when already expanded
+func (m *Model[T]) expand() {
+	current, ok := m.current()
+	if !ok || current.children == 0 {
+		return
+	}
+
+	if m.expanded[current.node.ID()] {
+		m.move(m.cursor + 1)
+		return
+	}
+	m.expanded[current.node.ID()] = true
+	m.refresh()
+}
+
+// collapse collapses the selected Node or selects its parent when already collapsed
+func (m *Model[T]) collapse() {
+	current, ok := m.current()
+	if !ok {
+		return
+	}
+
+	if current.children > 0 && m.expanded[current.node.ID()] {
+		m.expanded[current.node.ID()] = false
+		m.refresh()
+		return
+	}
+
+	if parent, ok := m.tree.ParentAt(current.node, 0); ok {
+		m.selected = parent.ID()
+		m.refresh()
+	}
+}
+
+// find selects the Node with the given ID, or the first Node whose ID contains the given text,
+// expanding its ancestors
+func (m *Model[T]) find(text string) {
+	if text == "" {
+		return
+	}
+
+	node, ok := m.tree.Find(text)
+	if !ok {
+		ids := m.tree.IDs()
+		sort.Strings(ids)
+		for _, id := range ids {
+			if strings.Contains(id, text) {
+				node, ok = m.tree.Find(id)
+				break
+			}
+		}
+	}
+
+	if !ok {
+		m.status = fmt.Sprintf("no node matches %q", text)
+		return
+	}
+
+	ancestors, _ := m.tree.AncestorsByID(node.ID())
+	for _, ancestor := range ancestors {
+		m.expanded[ancestor.ID()] = true
+	}
+	m.selected = node.ID()
+	m.refresh()
+}
+
+// wait returns a command reporting the next change of the Tree
+func (m *Model[T]) wait() tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-m.events; !ok {
+			return nil
+		}
+		return changedMsg{}
+	}
+}
+
+// line renders the given row of the outline
+func (m *Model[T]) line(r row[T]) string {
+	marker := "  "
+	if r.children > 0 {
+		marker = "▸ "
+		if m.expanded[r.node.ID()] {
+			marker = "▾ "
+		}
+	}
+
+	value := strings.Join(strings.Fields(fmt.Sprint(r.node.Value())), " ")
+	return m.truncate(fmt.Sprintf("%s%s%s: %s", strings.Repeat("  ", r.depth), marker, r.node.ID(), value))
+}
+
+// details renders the details of the selected Node
+func (m *Model[T]) details() []string {
+	current, ok := m.current()
+	if !ok {
+		return nil
+	}
+
+	node, version, ok := m.tree.FindWithVersion(current.node.ID())
+	if !ok {
+		return []string{"node " + current.node.ID() + " has been deleted"}
+	}
+
+	path, _ := m.tree.PathOf(node)
+	lines := []string{
+		fmt.Sprintf("id: %s  depth: %d  children: %d  version: %d", node.ID(), current.depth, current.children, version),
+		"path: " + path,
+	}
+
+	value, err := json.MarshalIndent(node.Value(), "", "  ")
+	if err != nil {
+		value = []byte(fmt.Sprintf("%+v", node.Value()))
+	}
+
+	valueLines := strings.Split(string(value), "\n")
+	if limit := detailLines - len(lines); len(valueLines) > limit {
+		valueLines = append(valueLines[:limit-1], "…")
+	}
+	return append(lines, valueLines...)
+}
+
+// pageSize returns the number of visible rows
+func (m *Model[T]) pageSize() int {
+	if m.height == 0 {
+		return len(m.rows)
+	}
+	// the outline shares the screen with the separator, the details and the status line
+	return max(1, m.height-detailLines-2)
+}
+
+// scroll adjusts the first visible row so that the selected row is visible
+func (m *Model[T]) scroll() {
+	size := m.pageSize()
+	switch {
+	case m.cursor < m.offset:
+		m.offset = m.cursor
+	case m.cursor >= m.offset+size:
+		m.offset = m.cursor - size + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.rows)-size))
+}
+
+// truncate truncates the given line to the width of the screen
+func (m *Model[T]) truncate(line string) string {
+	if runes := []rune(line); m.width > 0 && len(runes) > m.width {
+		return string(runes[:m.width-1]) + "…"
+	}
+	return line
+}
@@ -0,0 +1,231 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotreetui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tochemey/gotree"
+)
+
+type service struct {
+	Name string `json:"name"`
+	Port int    `json:"port"`
+}
+
+func newTree(t *testing.T) *gotree.Tree[service] {
+	tree := gotree.NewTree[service]()
+	require.NoError(t, tree.AddByID(gotree.NewNode("root", service{Name: "root"}), ""))
+	require.NoError(t, tree.AddByID(gotree.NewNode("api", service{Name: "api", Port: 80}), "root"))
+	require.NoError(t, tree.AddByID(gotree.NewNode("auth", service{Name: "auth", Port: 81}), "api"))
+	require.NoError(t, tree.AddByID(gotree.NewNode("db", service{Name: "db", Port: 5432}), "root"))
+	return tree
+}
+
+// press sends the given keys to the model
+func press(m *Model[service], keys ...string) {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		m.Update(msg)
+	}
+}
+
+// outline returns the visible rows of the model
+func outline(m *Model[service]) []string {
+	var lines []string
+	for _, r := range m.rows {
+		lines = append(lines, strings.TrimRight(m.line(r), " "))
+	}
+	return lines
+}
+
+func TestModel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := NewModel(ctx, newTree(t))
+	assert.Equal(t, []string{
+		"▾ root: {root 0}",
+		"  ▸ api: {api 80}",
+		"    db: {db 5432}",
+	}, outline(m))
+
+	t.Run("expand and collapse", func(t *testing.T) {
+		press(m, "down", "right")
+		assert.Equal(t, []string{
+			"▾ root: {root 0}",
+			"  ▾ api: {api 80}",
+			"      auth: {auth 81}",
+			"    db: {db 5432}",
+		}, outline(m))
+
+		press(m, "right")
+		assert.Equal(t, "auth", m.selected)
+
+		// a leaf selects its parent, then the parent collapses
+		press(m, "left", "left")
+		assert.Equal(t, "api", m.selected)
+		assert.Len(t, m.rows, 3)
+
+		press(m, "enter")
+		assert.Len(t, m.rows, 4)
+		press(m, " ")
+		assert.Len(t, m.rows, 3)
+	})
+
+	t.Run("moves within bounds", func(t *testing.T) {
+		press(m, "g", "up")
+		assert.Equal(t, "root", m.selected)
+		press(m, "G", "down")
+		assert.Equal(t, "db", m.selected)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		press(m, "/", "a", "u", "x", "backspace", "enter")
+		assert.False(t, m.searching)
+		assert.Equal(t, "auth", m.selected)
+		assert.Len(t, m.rows, 4)
+
+		press(m, "/", "d", "b", "enter")
+		assert.Equal(t, "db", m.selected)
+
+		press(m, "/", "missing", "enter")
+		assert.Equal(t, "db", m.selected)
+		assert.Contains(t, m.View(), `no node matches "missing"`)
+
+		press(m, "/", "api", "esc")
+		assert.Equal(t, "db", m.selected)
+	})
+
+	t.Run("details", func(t *testing.T) {
+		press(m, "/", "auth", "enter")
+		view := m.View()
+		assert.Contains(t, view, "id: auth  depth: 2  children: 0  version: 1")
+		assert.Contains(t, view, "path: /root/api/auth")
+		assert.Contains(t, view, "\"port\": 81")
+	})
+
+	t.Run("quit", func(t *testing.T) {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+		require.NotNil(t, cmd)
+		assert.Equal(t, tea.Quit(), cmd())
+	})
+}
+
+func TestModelScrolls(t *testing.T) {
+	tree := gotree.NewTree[service]()
+	require.NoError(t, tree.AddByID(gotree.NewNode("root", service{}), ""))
+	for _, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		require.NoError(t, tree.AddByID(gotree.NewNode(id, service{}), "root"))
+	}
+
+	m := NewModel(context.Background(), tree)
+	// three rows are visible
+	m.Update(tea.WindowSizeMsg{Width: 30, Height: detailLines + 5})
+
+	press(m, "G")
+	assert.Equal(t, 4, m.offset)
+	view := m.View()
+	assert.NotContains(t, view, "root:")
+	assert.Contains(t, view, "f: {")
+
+	press(m, "g")
+	assert.Zero(t, m.offset)
+	for _, line := range strings.Split(m.View(), "\n") {
+		assert.LessOrEqual(t, len([]rune(strings.ReplaceAll(strings.ReplaceAll(line, "\x1b[7m", ""), "\x1b[0m", ""))), 30)
+	}
+}
+
+func TestModelFollowsTheTree(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tree := newTree(t)
+	m := NewModel(ctx, tree)
+	press(m, "down")
+	require.Equal(t, "api", m.selected)
+
+	cmd := m.Init()
+	require.NoError(t, tree.AddByID(gotree.NewNode("cache", service{Name: "cache"}), "root"))
+	require.NoError(t, tree.DeleteByID("api"))
+
+	msg := make(chan tea.Msg, 1)
+	go func() {
+		msg <- cmd()
+	}()
+
+	select {
+	case changed := <-msg:
+		_, next := m.Update(changed)
+		assert.NotNil(t, next)
+	case <-time.After(time.Second):
+		t.Fatal("the change has not been reported")
+	}
+
+	assert.Equal(t, []string{
+		"▾ root: {root 0}",
+		"    db: {db 5432}",
+		"    cache: {cache 0}",
+	}, outline(m))
+	// the selection falls back on the row at the same position
+	assert.Equal(t, "db", m.selected)
+
+	// the changes are no longer reported once the context is done
+	cancel()
+	assert.Eventually(t, func() bool {
+		return cmd() == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestModelEmptyTree(t *testing.T) {
+	m := NewModel(context.Background(), gotree.NewTree[service]())
+	press(m, "down", "right", "left", "enter")
+	assert.Contains(t, m.View(), "The tree is empty.")
+}
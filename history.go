@@ -0,0 +1,208 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// VersionedValue is a value held by a Node at some point in time
+type VersionedValue[T any] struct {
+	// Version is the version of the Node holding the value
+	Version uint64
+	// Value is the value of the Node. It is the zero value when Deleted is set
+	Value T
+	// Time is the time the value was set, or the Node deleted
+	Time time.Time
+	// Deleted states whether the entry records the deletion of the Node
+	Deleted bool
+}
+
+// History returns the values held over time by the Node with the given ID.
+//
+// The values are recorded when the value history is enabled with WithHistory: every addition,
+// update and replacement of a Node records its new value, and its deletion records a Deleted entry.
+// The history outlives the deletion of the Node, so that the past values of the deleted Nodes remain
+// available for auditing.
+//
+// Parameters:
+//   - id: The identifier of the Node.
+//
+// Returns:
+//   - []VersionedValue[T]: The values of the Node, oldest first. It is empty when the history is not
+//     enabled or nothing has been recorded for the Node.
+//
+// Notes:
+//   - The structural changes (moves, metadata, edges) are not recorded.
+//   - The values replaced concurrently may be recorded once, with the latest of them.
+//
+// Example usage:
+//
+//	for _, entry := range tree.History("timeout") {
+//	    fmt.Println(entry.Time, entry.Version, entry.Value)
+//	}
+func (x *Tree[T]) History(id string) []VersionedValue[T] {
+	return x.history.entries(id)
+}
+
+// ValueAt returns the value held at the given time by the Node with the given ID.
+//
+// Parameters:
+//   - id: The identifier of the Node.
+//   - at: The point in time.
+//
+// Returns:
+//   - value: The value of the Node at that time.
+//   - ok: false when the history is not enabled, the Node did not exist at that time, or its value at
+//     that time is no longer retained.
+//
+// Example usage:
+//
+//	tree := NewTree[string](WithHistory(100))
+//	// ...
+//	lastTuesday := time.Date(2025, time.March, 4, 12, 0, 0, 0, time.UTC)
+//	if value, ok := tree.ValueAt("timeout", lastTuesday); ok {
+//	    fmt.Println("The timeout was", value)
+//	}
+func (x *Tree[T]) ValueAt(id string, at time.Time) (value T, ok bool) {
+	return x.history.valueAt(id, at)
+}
+
+// valueHistory retains the values held by the Nodes over time
+type valueHistory[T any] struct {
+	mu sync.Mutex
+	// limit is the maximum number of entries retained per Node, unlimited when not positive
+	limit int
+	// values maps every Node ID to its entries, oldest first
+	values map[string][]VersionedValue[T]
+}
+
+// newValueHistory creates an empty valueHistory retaining the given number of entries per Node
+func newValueHistory[T any](limit int) *valueHistory[T] {
+	return &valueHistory[T]{
+		limit:  limit,
+		values: make(map[string][]VersionedValue[T]),
+	}
+}
+
+// record appends the current value of the given Node, which is either new or updated, to the history.
+// The value is read under the history lock so that the entries of a Node keep increasing versions.
+// It is nil safe
+func (h *valueHistory[T]) record(node *treeNode[T]) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	current := node.Value.Load()
+	if current == nil || current.data == nil {
+		return
+	}
+
+	entries := h.values[node.ID]
+	if n := len(entries); n > 0 && !entries[n-1].Deleted && entries[n-1].Version >= current.version {
+		return
+	}
+	h.append(node.ID, VersionedValue[T]{
+		Version: current.version,
+		Value:   current.data.Value(),
+		Time:    time.Now(),
+	})
+}
+
+// delete records the deletion of the given Nodes. It is nil safe
+func (h *valueHistory[T]) delete(ids ...string) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	for _, id := range ids {
+		var version uint64
+		if entries := h.values[id]; len(entries) > 0 {
+			version = entries[len(entries)-1].Version
+		}
+		h.append(id, VersionedValue[T]{Version: version, Time: now, Deleted: true})
+	}
+}
+
+// append adds the given entry to the history of the given Node, discarding the oldest entries
+// beyond the limit. It must be called with the history lock held
+func (h *valueHistory[T]) append(id string, entry VersionedValue[T]) {
+	entries := append(h.values[id], entry)
+	if h.limit > 0 && len(entries) > h.limit {
+		entries = slices.Delete(entries, 0, len(entries)-h.limit)
+	}
+	h.values[id] = entries
+}
+
+// entries returns a copy of the entries of the given Node. It is nil safe
+func (h *valueHistory[T]) entries(id string) []VersionedValue[T] {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.values[id])
+}
+
+// valueAt returns the value of the given Node at the given time. It is nil safe
+func (h *valueHistory[T]) valueAt(id string, at time.Time) (value T, ok bool) {
+	if h == nil {
+		return value, false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entries := h.values[id]
+	// the entries are sorted by time: find the last one set at or before the given time
+	i, _ := slices.BinarySearchFunc(entries, at, func(entry VersionedValue[T], at time.Time) int {
+		if entry.Time.After(at) {
+			return 1
+		}
+		return -1
+	})
+	if i == 0 || entries[i-1].Deleted {
+		return value, false
+	}
+	return entries[i-1].Value, true
+}
+
+// clear discards the whole history. It is nil safe
+func (h *valueHistory[T]) clear() {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	clear(h.values)
+}
@@ -0,0 +1,124 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	// checkpoint returns a time strictly between the changes made before and after it
+	checkpoint := func() time.Time {
+		time.Sleep(time.Millisecond)
+		defer time.Sleep(time.Millisecond)
+		return time.Now()
+	}
+
+	t.Run("records the values over time", func(t *testing.T) {
+		tree := NewTree[string](WithHistory(0))
+		before := checkpoint()
+		require.NoError(t, tree.AddByID(NewNode("root", "v1"), ""))
+		first := checkpoint()
+		require.NoError(t, tree.Update(NewNode("root", "v2")))
+		second := checkpoint()
+		require.NoError(t, tree.AddOrReplace(NewNode("root", "v3"), nil))
+		require.NoError(t, tree.AddByID(NewNode("child", "c1"), "root"))
+		third := checkpoint()
+		require.NoError(t, tree.DeleteByID("child"))
+		deleted := checkpoint()
+
+		history := tree.History("root")
+		require.Len(t, history, 3)
+		assert.Equal(t, []string{"v1", "v2", "v3"}, []string{history[0].Value, history[1].Value, history[2].Value})
+		assert.Equal(t, []uint64{1, 2, 3}, []uint64{history[0].Version, history[1].Version, history[2].Version})
+
+		_, ok := tree.ValueAt("root", before)
+		assert.False(t, ok)
+		value, ok := tree.ValueAt("root", first)
+		require.True(t, ok)
+		assert.Equal(t, "v1", value)
+		value, _ = tree.ValueAt("root", second)
+		assert.Equal(t, "v2", value)
+		value, _ = tree.ValueAt("root", deleted)
+		assert.Equal(t, "v3", value)
+
+		// the history outlives the deletion
+		history = tree.History("child")
+		require.Len(t, history, 2)
+		assert.True(t, history[1].Deleted)
+		value, ok = tree.ValueAt("child", third)
+		require.True(t, ok)
+		assert.Equal(t, "c1", value)
+		_, ok = tree.ValueAt("child", deleted)
+		assert.False(t, ok)
+	})
+
+	t.Run("ignores the structural changes", func(t *testing.T) {
+		tree := NewTree[string](WithHistory(0))
+		require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "b"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", "a1"), "a"))
+		require.NoError(t, tree.MoveByID("a1", "b"))
+		assert.Len(t, tree.History("root"), 1)
+		assert.Len(t, tree.History("a1"), 1)
+	})
+
+	t.Run("bounds the entries", func(t *testing.T) {
+		tree := NewTree[int](WithHistory(2))
+		require.NoError(t, tree.AddByID(NewNode("root", 1), ""))
+		expired := checkpoint()
+		require.NoError(t, tree.Update(NewNode("root", 2)))
+		require.NoError(t, tree.Update(NewNode("root", 3)))
+
+		history := tree.History("root")
+		require.Len(t, history, 2)
+		assert.Equal(t, 2, history[0].Value)
+		assert.Equal(t, 3, history[1].Value)
+		_, ok := tree.ValueAt("root", expired)
+		assert.False(t, ok)
+	})
+
+	t.Run("records the deletions of a reset", func(t *testing.T) {
+		tree := NewTree[string](WithHistory(0))
+		require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+		tree.Reset()
+		history := tree.History("root")
+		require.Len(t, history, 2)
+		assert.True(t, history[1].Deleted)
+	})
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+		assert.Empty(t, tree.History("root"))
+		_, ok := tree.ValueAt("root", time.Now())
+		assert.False(t, ok)
+	})
+}
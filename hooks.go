@@ -0,0 +1,170 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+// Hook is a function invoked after a mutation of the Tree with the affected Node
+// and its parent. The parent is nil for the root Node.
+type Hook[T any] func(node, parent Node[T])
+
+// MoveHook is a function invoked after a Node has been moved, with the moved Node
+// along with its former and its new parent.
+type MoveHook[T any] func(node, from, to Node[T])
+
+// hooks holds the registered hooks. It is copied on registration so that
+// the mutations read it without locking.
+type hooks[T any] struct {
+	onAdd    []Hook[T]
+	onUpdate []Hook[T]
+	onDelete []Hook[T]
+	onMove   []MoveHook[T]
+}
+
+// mutation describes a Node affected by a mutation along with its parent
+type mutation[T any] struct {
+	node   Node[T]
+	parent Node[T]
+}
+
+// OnAdd registers a hook invoked after a Node has been added to the Tree.
+//
+// Hooks are invoked synchronously by the goroutine performing the mutation, once the
+// mutation is complete and the Tree locks are released; they can therefore call the Tree.
+// Hooks registered for the same event are invoked in the registration order. Concurrent
+// mutations invoke their hooks concurrently, in no particular order.
+//
+// Parameters:
+//   - hook: The function to invoke with the added Node and its parent.
+//
+// Example usage:
+//
+//	tree.OnAdd(func(node, parent Node[string]) {
+//	    cache.Invalidate(parent.ID())
+//	})
+func (x *Tree[T]) OnAdd(hook Hook[T]) {
+	x.registerHook(func(h *hooks[T]) {
+		h.onAdd = append(h.onAdd, hook)
+	})
+}
+
+// OnUpdate registers a hook invoked after the value of a Node has been replaced in place,
+// with the Node holding the new value and its parent.
+//
+// See OnAdd for the invocation guarantees.
+func (x *Tree[T]) OnUpdate(hook Hook[T]) {
+	x.registerHook(func(h *hooks[T]) {
+		h.onUpdate = append(h.onUpdate, hook)
+	})
+}
+
+// OnDelete registers a hook invoked after a Node has been removed from the Tree.
+//
+// When a Node is deleted along with its descendants, the hook is invoked for every removed
+// Node in depth-first order, starting with the deleted Node. Reset invokes it for every Node
+// of the Tree.
+//
+// See OnAdd for the invocation guarantees.
+func (x *Tree[T]) OnDelete(hook Hook[T]) {
+	x.registerHook(func(h *hooks[T]) {
+		h.onDelete = append(h.onDelete, hook)
+	})
+}
+
+// OnMove registers a hook invoked after a Node has been moved under another parent
+// along with its descendants, with the Node, its former and its new parent.
+//
+// See OnAdd for the invocation guarantees.
+func (x *Tree[T]) OnMove(hook MoveHook[T]) {
+	x.registerHook(func(h *hooks[T]) {
+		h.onMove = append(h.onMove, hook)
+	})
+}
+
+// registerHook registers a hook by applying the given function on a copy of the current hooks
+func (x *Tree[T]) registerHook(register func(h *hooks[T])) {
+	x.hooksMu.Lock()
+	defer x.hooksMu.Unlock()
+
+	registered := new(hooks[T])
+	if current := x.hooks.Load(); current != nil {
+		*registered = hooks[T]{
+			onAdd:    append([]Hook[T](nil), current.onAdd...),
+			onUpdate: append([]Hook[T](nil), current.onUpdate...),
+			onDelete: append([]Hook[T](nil), current.onDelete...),
+			onMove:   append([]MoveHook[T](nil), current.onMove...),
+		}
+	}
+	register(registered)
+	x.hooks.Store(registered)
+}
+
+// hasUpdateHooks returns true when update hooks are registered
+func (x *Tree[T]) hasUpdateHooks() bool {
+	h := x.hooks.Load()
+	return h != nil && len(h.onUpdate) > 0
+}
+
+// hasDeleteHooks returns true when delete hooks are registered
+func (x *Tree[T]) hasDeleteHooks() bool {
+	h := x.hooks.Load()
+	return h != nil && len(h.onDelete) > 0
+}
+
+// notifyAdd invokes the add hooks
+func (x *Tree[T]) notifyAdd(node, parent Node[T]) {
+	if h := x.hooks.Load(); h != nil {
+		for _, hook := range h.onAdd {
+			hook(node, parent)
+		}
+	}
+}
+
+// notifyUpdate invokes the update hooks
+func (x *Tree[T]) notifyUpdate(node, parent Node[T]) {
+	if h := x.hooks.Load(); h != nil {
+		for _, hook := range h.onUpdate {
+			hook(node, parent)
+		}
+	}
+}
+
+// notifyMove invokes the move hooks
+func (x *Tree[T]) notifyMove(node, from, to Node[T]) {
+	if h := x.hooks.Load(); h != nil {
+		for _, hook := range h.onMove {
+			hook(node, from, to)
+		}
+	}
+}
+
+// notifyDelete invokes the delete hooks for every removed Node
+func (x *Tree[T]) notifyDelete(removed []mutation[T]) {
+	if h := x.hooks.Load(); h != nil {
+		for _, m := range removed {
+			for _, hook := range h.onDelete {
+				hook(m.node, m.parent)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooks(t *testing.T) {
+	var events []string
+	record := func(kind string) Hook[string] {
+		return func(node, parent Node[string]) {
+			parentID := "<nil>"
+			if parent != nil {
+				parentID = parent.ID()
+			}
+			events = append(events, fmt.Sprintf("%s %s=%s under %s", kind, node.ID(), node.Value(), parentID))
+		}
+	}
+
+	tree := NewTree[string]()
+	tree.OnAdd(record("add"))
+	tree.OnUpdate(record("update"))
+	tree.OnDelete(record("delete"))
+
+	root := NewNode("root", "r")
+	a := NewNode("a", "a")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.AddByID(a, "root"))
+	require.NoError(t, tree.AddWithEdge(NewNode("b", "b"), a, EdgeData{Weight: 1}))
+	require.NoError(t, tree.Add(NewNode("c", "c"), root))
+	assert.Equal(t, []string{
+		"add root=r under <nil>",
+		"add a=a under root",
+		"add b=b under a",
+		"add c=c under root",
+	}, events)
+
+	// failed mutations do not invoke the hooks
+	events = nil
+	require.Error(t, tree.Add(NewNode("a", "a"), root))
+	require.Error(t, tree.Update(NewNode("x", "x")))
+	require.Error(t, tree.UpdateIf("a", 42, NewNode("a", "a2")))
+	require.Error(t, tree.DeleteByID("x"))
+	assert.Empty(t, events)
+
+	require.NoError(t, tree.Update(NewNode("b", "b2")))
+	_, version, _ := tree.FindWithVersion("root")
+	require.NoError(t, tree.UpdateIf("root", version, NewNode("root", "r2")))
+	require.NoError(t, tree.AddOrReplace(NewNode("c", "c2"), root))
+	assert.Equal(t, []string{
+		"update b=b2 under a",
+		"update root=r2 under <nil>",
+		"update c=c2 under root",
+	}, events)
+
+	// relocating a node removes its subtree first
+	events = nil
+	require.NoError(t, tree.AddOrReplace(NewNode("a", "a2"), NewNode("c", "")))
+	assert.Equal(t, []string{
+		"delete a=a under root",
+		"delete b=b2 under a",
+		"add a=a2 under c",
+	}, events)
+
+	events = nil
+	require.NoError(t, tree.AddByID(NewNode("d", "d"), "a"))
+	events = nil
+	removed, err := tree.DeleteAndCollect(NewNode("a", ""))
+	require.NoError(t, err)
+	assert.Len(t, removed, 2)
+	assert.Equal(t, []string{
+		"delete a=a2 under c",
+		"delete d=d under a",
+	}, events)
+
+	events = nil
+	tree.Reset()
+	assert.Equal(t, []string{
+		"delete root=r2 under <nil>",
+		"delete c=c2 under root",
+	}, events)
+}
+
+func TestHooksCanCallTheTree(t *testing.T) {
+	tree := NewTree[string]()
+	tree.OnAdd(func(node, parent Node[string]) {
+		// mirror every child of the root as a leaf under itself
+		if parent != nil && parent.ID() == "root" {
+			require.NoError(t, tree.Add(NewNode(node.ID()+"-mirror", node.Value()), node))
+		}
+	})
+	tree.OnDelete(func(node, _ Node[string]) {
+		_, ok := tree.Find(node.ID())
+		assert.False(t, ok)
+	})
+
+	root := NewNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.Add(NewNode("a", "a"), root))
+
+	_, ok := tree.Find("a-mirror")
+	assert.True(t, ok)
+
+	require.NoError(t, tree.Delete(root))
+	assert.Zero(t, tree.Size())
+}
+
+func TestHooksOrder(t *testing.T) {
+	var calls []int
+	tree := NewTree[string]()
+	for i := 0; i < 3; i++ {
+		tree.OnAdd(func(Node[string], Node[string]) {
+			calls = append(calls, i)
+		})
+	}
+
+	require.NoError(t, tree.Add(NewNode("root", "root"), nil))
+	assert.Equal(t, []int{0, 1, 2}, calls)
+}
@@ -0,0 +1,256 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FromNestedMap builds a Tree from nested maps, such as a JSON document decoded into a map[string]any.
+//
+// The map must hold a single entry, the root of the Tree. Every entry becomes a Node: the entries
+// holding a map[string]any become Nodes holding a nil value whose children are the entries of that
+// map, and the other entries become leaves holding the value of the entry. Since the same keys often
+// repeat across the subtrees of a document, every Node is identified by its path, made of the keys from
+// the root down to the entry escaped and separated like the segments rendered by PathOf, e.g.
+// "/config/db/host". The children are added in the order of their keys.
+//
+// Parameters:
+//   - nested: The nested maps to import.
+//   - opts: The options of the created Tree.
+//
+// Returns:
+//   - *Tree[any]: The created Tree.
+//   - error: ErrInvalidOperation when the map does not hold exactly one entry, or the error
+//     of the first Node that could not be added.
+//
+// Example usage:
+//
+//	var document map[string]any
+//	_ = json.Unmarshal([]byte(`{"config": {"db": {"host": "localhost", "port": 5432}}}`), &document)
+//	tree, err := FromNestedMap(document)
+//	if err == nil {
+//	    host, _ := tree.Find("/config/db/host")
+//	    fmt.Println(host.Value()) // Output: localhost
+//	}
+func FromNestedMap(nested map[string]any, opts ...Option) (*Tree[any], error) {
+	if len(nested) != 1 {
+		return nil, fmt.Errorf("%w: the nested map holds %d root entries instead of one", ErrInvalidOperation, len(nested))
+	}
+
+	tree := NewTree[any](opts...)
+	var add func(parent NodeRef[any], path, key string, value any) error
+	add = func(parent NodeRef[any], path, key string, value any) error {
+		id := path + tree.cfg.pathSeparator + tree.cfg.pathEscape(key)
+		children, ok := value.(map[string]any)
+		if ok {
+			value = nil
+		}
+
+		var (
+			ref NodeRef[any]
+			err error
+		)
+		if path == "" {
+			if err = tree.Add(NewNode(id, value), nil); err == nil {
+				ref, _ = tree.Ref(id)
+			}
+		} else {
+			ref, err = tree.AddAt(parent, NewNode(id, value))
+		}
+		if err != nil {
+			return err
+		}
+
+		keys := make([]string, 0, len(children))
+		for key := range children {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if err := add(ref, id, key, children[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for key, value := range nested {
+		if err := add(NodeRef[any]{}, "", key, value); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+// FromChildrenMap builds a Tree from an adjacency list mapping the ID of every parent to the IDs of its
+// children, in order, along with the values of the Nodes.
+//
+// The root is the only Node which is not the child of another Node. The Nodes missing from the values
+// hold the zero value, and the values of unknown Nodes are ignored.
+//
+// Parameters:
+//   - children: The IDs of the children of every parent Node.
+//   - values: The values of the Nodes keyed by ID.
+//   - opts: The options of the created Tree.
+//
+// Returns:
+//   - *Tree[T]: The created Tree.
+//   - error: ErrInvalidOperation when the adjacency list does not have exactly one root,
+//     ErrCycleDetected when some Nodes cannot be reached from the root, or the error
+//     of the first Node that could not be added, such as ErrDuplicateID for a Node listed twice.
+//
+// Example usage:
+//
+//	tree, err := FromChildrenMap(map[string][]string{
+//	    "root": {"a", "b"},
+//	    "a":    {"a1"},
+//	}, map[string]string{"root": "Root", "a": "A", "b": "B", "a1": "A1"})
+func FromChildrenMap[T any](children map[string][]string, values map[string]T, opts ...Option) (*Tree[T], error) {
+	tree := NewTree[T](opts...)
+	if len(children) == 0 {
+		return tree, nil
+	}
+
+	// the root is the only parent which is nobody's child
+	isChild := make(map[string]struct{})
+	for _, ids := range children {
+		for _, id := range ids {
+			isChild[id] = struct{}{}
+		}
+	}
+
+	var roots []string
+	for id := range children {
+		if _, ok := isChild[id]; !ok {
+			roots = append(roots, id)
+		}
+	}
+	if len(roots) != 1 {
+		slices.Sort(roots)
+		return nil, fmt.Errorf("%w: the children map has %d roots %v instead of one", ErrInvalidOperation, len(roots), roots)
+	}
+
+	root := roots[0]
+	if err := tree.Add(NewNode(root, values[root]), nil); err != nil {
+		return nil, err
+	}
+
+	// add the Nodes breadth-first from the root
+	ref, _ := tree.Ref(root)
+	pending := []NodeRef[T]{ref}
+	for len(pending) > 0 {
+		parent := pending[0]
+		pending = pending[1:]
+		for _, id := range children[parent.ID()] {
+			child, err := tree.AddAt(parent, NewNode(id, values[id]))
+			if err != nil {
+				return nil, err
+			}
+			pending = append(pending, child)
+		}
+	}
+
+	if size := tree.Size(); size != int64(len(isChild)+1) {
+		return nil, fmt.Errorf("%w: %d nodes cannot be reached from the root %q", ErrCycleDetected, int64(len(isChild)+1)-size, root)
+	}
+	return tree, nil
+}
+
+// ToNestedMap exports the Tree as nested maps, the mirror of FromNestedMap.
+//
+// Every Node becomes an entry of the map of its parent, the root being the only entry of the returned
+// map. The Nodes having children hold the map of their children, and the leaves hold their value. The
+// entries are keyed by the IDs of the Nodes, unless every Node is identified by the path of its keys as
+// done by FromNestedMap, in which case they are keyed by the last segment of their path, so that the
+// nested maps imported by FromNestedMap are exported back as they were.
+//
+// Returns:
+//   - map[string]any: The nested maps of the Tree. It is empty for an empty Tree.
+//
+// Notes:
+//   - The values of the Nodes having children are not exported.
+//   - ToNestedMap blocks the structural changes of the Tree while it captures the Nodes.
+//
+// Example usage:
+//
+//	nested := tree.ToNestedMap()
+//	_ = template.Must(template.New("config").Parse(`{{ .root.db.host }}`)).Execute(os.Stdout, nested)
+func (x *Tree[T]) ToNestedMap() map[string]any {
+	records := x.snapshot().Records
+
+	// key the entries by the last segment of their path when all the IDs are paths
+	keys := make([]string, len(records))
+	paths := true
+	for i, record := range records {
+		if keys[i], paths = x.pathSegment(record.ID, record.ParentID); !paths {
+			break
+		}
+	}
+	if !paths {
+		for i, record := range records {
+			keys[i] = record.ID
+		}
+	}
+
+	type entry struct {
+		container map[string]any
+		key       string
+		children  map[string]any
+	}
+
+	// the records are in depth-first order: the parents come before their children
+	nested := make(map[string]any, 1)
+	entries := make(map[string]*entry, len(records))
+	for i, record := range records {
+		current := &entry{container: nested, key: keys[i]}
+		if parent, ok := entries[record.ParentID]; ok {
+			if parent.children == nil {
+				parent.children = make(map[string]any)
+				parent.container[parent.key] = parent.children
+			}
+			current.container = parent.children
+		}
+		current.container[current.key] = record.Value
+		entries[record.ID] = current
+	}
+	return nested
+}
+
+// pathSegment returns the unescaped last segment of the given ID when it is the path generated
+// by FromNestedMap under the parent with the given ID, empty for the root
+func (x *Tree[T]) pathSegment(id, parentID string) (string, bool) {
+	segment, ok := strings.CutPrefix(id, parentID+x.cfg.pathSeparator)
+	if !ok || segment == "" || strings.Contains(segment, x.cfg.pathSeparator) {
+		return "", false
+	}
+	key, err := x.cfg.pathUnescape(segment)
+	if err != nil || x.cfg.pathEscape(key) != segment {
+		return "", false
+	}
+	return key, true
+}
@@ -0,0 +1,177 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromNestedMap(t *testing.T) {
+	t.Run("imports a decoded document", func(t *testing.T) {
+		var document map[string]any
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"config": {
+				"db": {"port": 5432, "host": "localhost"},
+				"cache": {"host": "redis", "ttl": "1m"},
+				"hosts": ["a", "b"]
+			}
+		}`), &document))
+
+		tree, err := FromNestedMap(document)
+		require.NoError(t, err)
+		assert.EqualValues(t, 8, tree.Size())
+		assert.Empty(t, tree.Validate())
+
+		host, ok := tree.Find("/config/db/host")
+		require.True(t, ok)
+		assert.Equal(t, "/config/db/host", host.ID())
+		assert.Equal(t, "localhost", host.Value())
+
+		db, ok := tree.Find("/config/db")
+		require.True(t, ok)
+		assert.Nil(t, db.Value())
+
+		hosts, ok := tree.Find("/config/hosts")
+		require.True(t, ok)
+		assert.Equal(t, []any{"a", "b"}, hosts.Value())
+
+		entries, ok := tree.DescendantsTree(tree.Root())
+		require.True(t, ok)
+		var ids []string
+		for _, entry := range entries {
+			ids = append(ids, entry.Node.ID())
+		}
+		assert.Equal(t, []string{
+			"/config/cache", "/config/cache/host", "/config/cache/ttl",
+			"/config/db", "/config/db/host", "/config/db/port",
+			"/config/hosts",
+		}, ids)
+	})
+
+	t.Run("escapes the keys", func(t *testing.T) {
+		tree, err := FromNestedMap(map[string]any{"root": map[string]any{"a/b": 1}})
+		require.NoError(t, err)
+		node, ok := tree.Find("/root/a%2Fb")
+		require.True(t, ok)
+		assert.Equal(t, 1, node.Value())
+	})
+
+	t.Run("requires a single root", func(t *testing.T) {
+		_, err := FromNestedMap(map[string]any{"a": 1, "b": 2})
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+		_, err = FromNestedMap(nil)
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+	})
+
+	t.Run("applies the options", func(t *testing.T) {
+		_, err := FromNestedMap(map[string]any{"root": map[string]any{"a": 1, "b": 2}}, WithMaxChildren(1))
+		assert.ErrorIs(t, err, ErrMaxChildrenExceeded)
+	})
+}
+
+func TestFromChildrenMap(t *testing.T) {
+	t.Run("imports an adjacency list", func(t *testing.T) {
+		tree, err := FromChildrenMap(map[string][]string{
+			"root": {"b", "a"},
+			"a":    {"a1", "a2"},
+			"a2":   {},
+		}, map[string]string{"root": "Root", "a": "A", "b": "B", "a1": "A1", "unknown": "?"})
+		require.NoError(t, err)
+		assert.EqualValues(t, 5, tree.Size())
+		assert.Equal(t, "root", tree.Root().ID())
+
+		root, _ := tree.RootOK()
+		entries, ok := tree.DescendantsTree(root)
+		require.True(t, ok)
+		var ids []string
+		for _, entry := range entries {
+			ids = append(ids, entry.Node.ID())
+		}
+		assert.Equal(t, []string{"b", "a", "a1", "a2"}, ids)
+
+		a2, ok := tree.Find("a2")
+		require.True(t, ok)
+		assert.Empty(t, a2.Value())
+		_, ok = tree.Find("unknown")
+		assert.False(t, ok)
+
+		empty, err := FromChildrenMap[string](nil, nil)
+		require.NoError(t, err)
+		assert.Zero(t, empty.Size())
+	})
+
+	t.Run("requires a single root", func(t *testing.T) {
+		_, err := FromChildrenMap[int](map[string][]string{"a": {"a1"}, "b": {"b1"}}, nil)
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+	})
+
+	t.Run("rejects the unreachable nodes and the duplicates", func(t *testing.T) {
+		_, err := FromChildrenMap[int](map[string][]string{"root": {"a"}, "x": {"y"}, "y": {"x"}}, nil)
+		assert.ErrorIs(t, err, ErrCycleDetected)
+
+		_, err = FromChildrenMap[int](map[string][]string{"root": {"a", "b"}, "b": {"a"}}, nil)
+		assert.ErrorIs(t, err, ErrDuplicateID)
+	})
+}
+
+func TestToNestedMap(t *testing.T) {
+	t.Run("exports the imported maps back", func(t *testing.T) {
+		document := map[string]any{
+			"config": map[string]any{
+				"db":    map[string]any{"host": "localhost", "port": 5432.0},
+				"a/b":   true,
+				"hosts": []any{"a", "b"},
+			},
+		}
+		tree, err := FromNestedMap(document)
+		require.NoError(t, err)
+		assert.Equal(t, document, tree.ToNestedMap())
+	})
+
+	t.Run("keys the nodes by ID", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", "r"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "b"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", "a1"), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a/x", "ax"), "a"))
+
+		assert.Equal(t, map[string]any{
+			"root": map[string]any{
+				"a": map[string]any{"a1": "a1", "a/x": "ax"},
+				"b": "b",
+			},
+		}, tree.ToNestedMap())
+		assert.Empty(t, NewTree[string]().ToNestedMap())
+
+		leaf := NewTree[int]()
+		require.NoError(t, leaf.AddByID(NewNode("root", 1), ""))
+		assert.Equal(t, map[string]any{"root": 1}, leaf.ToNestedMap())
+	})
+}
@@ -0,0 +1,257 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"sync"
+)
+
+// CreateIndex creates a secondary index over the values of the Nodes of the Tree.
+//
+// The index maps the key computed from every Node value to the Nodes sharing it, allowing
+// Lookup to retrieve them without scanning the Tree. It is built from the existing Nodes
+// and maintained by every subsequent mutation (Add, AddOrReplace, Update, UpdateIf, Delete,
+// Reset, ...).
+//
+// Parameters:
+//   - name: The unique name of the index.
+//   - key: The function computing the index key of a Node value. Values whose key is empty
+//     are not indexed. It must be cheap and must not call the Tree.
+//
+// Returns:
+//   - err: ErrIndexExists when an index with the same name exists, nil otherwise.
+//
+// Example usage:
+//
+//	err := tree.CreateIndex("kind", func(value Resource) string {
+//	    return value.Kind
+//	})
+//	pods, _ := tree.Lookup("kind", "pod")
+func (x *Tree[T]) CreateIndex(name string, key func(value T) string) (err error) {
+	end := x.trace(OperationCreateIndex)
+	indexed := 0
+	defer func() {
+		end(indexed, err)
+	}()
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	current := x.indexMap()
+	if _, ok := current[name]; ok {
+		return fmt.Errorf("%w: %q", ErrIndexExists, name)
+	}
+
+	index := newValueIndex(key)
+	x.nodes.Range(func(_ string, value *treeNode[T]) bool {
+		node := value
+		if data := node.GetValue(); data != nil {
+			index.put(node.ID, data.Value())
+		}
+		return true
+	})
+
+	// indexes are copied on write so that reads never lock
+	indexes := make(map[string]*valueIndex[T], len(current)+1)
+	for indexName, index := range current {
+		indexes[indexName] = index
+	}
+	indexes[name] = index
+	x.indexes.Store(&indexes)
+	indexed = len(index.keys)
+	return nil
+}
+
+// DropIndex removes the secondary index with the given name.
+// It returns false when no such index exists.
+func (x *Tree[T]) DropIndex(name string) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	current := x.indexMap()
+	if _, ok := current[name]; !ok {
+		return false
+	}
+
+	indexes := make(map[string]*valueIndex[T], len(current)-1)
+	for indexName, index := range current {
+		if indexName != name {
+			indexes[indexName] = index
+		}
+	}
+	x.indexes.Store(&indexes)
+	return true
+}
+
+// Lookup returns the Nodes whose value is indexed under the given key by the named index.
+//
+// The Nodes are resolved in constant time per matching Node, in an unspecified order.
+//
+// Parameters:
+//   - name: The name of the index created with CreateIndex.
+//   - key: The key to look up.
+//
+// Returns:
+//   - nodes: The Nodes indexed under the key. It is empty when no Node matches.
+//   - ok: A boolean indicating whether the index exists.
+//
+// Example usage:
+//
+//	pods, ok := tree.Lookup("kind", "pod")
+//	if ok {
+//	    fmt.Println("Number of pods:", len(pods))
+//	}
+func (x *Tree[T]) Lookup(name, key string) (nodes []Node[T], ok bool) {
+	index, ok := x.indexMap()[name]
+	if !ok {
+		return nil, false
+	}
+
+	for _, id := range index.lookup(key) {
+		if node, ok := x.getNode(id); ok {
+			nodes = append(nodes, node.GetValue())
+		}
+	}
+	return nodes, true
+}
+
+// indexMap returns the current secondary indexes
+func (x *Tree[T]) indexMap() map[string]*valueIndex[T] {
+	if indexes := x.indexes.Load(); indexes != nil {
+		return *indexes
+	}
+	return nil
+}
+
+// reindex updates the secondary indexes with the current value of the given Node.
+// The value is read under the index lock so that concurrent updates of the same
+// Node leave the index with its latest value.
+func (x *Tree[T]) reindex(node *treeNode[T]) {
+	for _, index := range x.indexMap() {
+		index.mu.Lock()
+		if data := node.GetValue(); data != nil {
+			index.putLocked(node.ID, data.Value())
+		}
+		index.mu.Unlock()
+	}
+}
+
+// unindex removes the Nodes with the given IDs from the secondary indexes
+func (x *Tree[T]) unindex(ids ...string) {
+	for _, index := range x.indexMap() {
+		index.mu.Lock()
+		for _, id := range ids {
+			index.removeLocked(id)
+		}
+		index.mu.Unlock()
+	}
+}
+
+// clearIndexes removes all the entries of the secondary indexes, keeping their definitions
+func (x *Tree[T]) clearIndexes() {
+	for _, index := range x.indexMap() {
+		index.mu.Lock()
+		index.entries = make(map[string]map[string]struct{})
+		index.keys = make(map[string]string)
+		index.mu.Unlock()
+	}
+}
+
+// valueIndex maps the keys computed from the Node values to the IDs of the Nodes
+type valueIndex[T any] struct {
+	key func(value T) string
+	mu  sync.RWMutex
+	// entries maps every key to the set of Node IDs
+	entries map[string]map[string]struct{}
+	// keys maps every indexed Node ID to its key
+	keys map[string]string
+}
+
+// newValueIndex creates an empty valueIndex
+func newValueIndex[T any](key func(value T) string) *valueIndex[T] {
+	return &valueIndex[T]{
+		key:     key,
+		entries: make(map[string]map[string]struct{}),
+		keys:    make(map[string]string),
+	}
+}
+
+// put indexes the given Node value
+func (i *valueIndex[T]) put(id string, value T) {
+	i.mu.Lock()
+	i.putLocked(id, value)
+	i.mu.Unlock()
+}
+
+// putLocked indexes the given Node value. The caller must hold the index lock
+func (i *valueIndex[T]) putLocked(id string, value T) {
+	key := i.key(value)
+	if current, ok := i.keys[id]; ok {
+		if current == key {
+			return
+		}
+		i.removeLocked(id)
+	}
+
+	if key == "" {
+		return
+	}
+
+	ids, ok := i.entries[key]
+	if !ok {
+		ids = make(map[string]struct{})
+		i.entries[key] = ids
+	}
+	ids[id] = struct{}{}
+	i.keys[id] = key
+}
+
+// removeLocked removes the given Node from the index. The caller must hold the index lock
+func (i *valueIndex[T]) removeLocked(id string) {
+	key, ok := i.keys[id]
+	if !ok {
+		return
+	}
+
+	delete(i.keys, id)
+	ids := i.entries[key]
+	delete(ids, id)
+	if len(ids) == 0 {
+		delete(i.entries, key)
+	}
+}
+
+// lookup returns the IDs of the Nodes indexed under the given key
+func (i *valueIndex[T]) lookup(key string) []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	ids := make([]string, 0, len(i.entries[key]))
+	for id := range i.entries[key] {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,151 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex(t *testing.T) {
+	prefix := func(value string) string {
+		key, _, _ := strings.Cut(value, ":")
+		return key
+	}
+
+	lookup := func(t *testing.T, tree *Tree[string], key string) []string {
+		t.Helper()
+		nodes, ok := tree.Lookup("prefix", key)
+		require.True(t, ok)
+		return queryIDs(nodes)
+	}
+
+	t.Run("Built from the existing nodes", func(t *testing.T) {
+		tree := NewTree[string]()
+		root := NewNode("root", "")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(NewNode("a", "pod:a"), root))
+		require.NoError(t, tree.Add(NewNode("b", "pod:b"), root))
+		require.NoError(t, tree.Add(NewNode("c", "svc:c"), root))
+
+		require.NoError(t, tree.CreateIndex("prefix", prefix))
+		assert.ElementsMatch(t, []string{"a", "b"}, lookup(t, tree, "pod"))
+		assert.ElementsMatch(t, []string{"c"}, lookup(t, tree, "svc"))
+		// empty keys are not indexed
+		assert.Empty(t, lookup(t, tree, ""))
+		assert.Empty(t, lookup(t, tree, "deploy"))
+
+		err := tree.CreateIndex("prefix", prefix)
+		require.ErrorIs(t, err, ErrIndexExists)
+
+		_, ok := tree.Lookup("unknown", "pod")
+		assert.False(t, ok)
+	})
+	t.Run("Maintained by the mutations", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.CreateIndex("prefix", prefix))
+
+		root := NewNode("root", "")
+		a := NewNode("a", "pod:a")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(a, root))
+		require.NoError(t, tree.Add(NewNode("b", "pod:b"), a))
+		require.NoError(t, tree.Add(NewNode("c", "svc:c"), root))
+		assert.ElementsMatch(t, []string{"a", "b"}, lookup(t, tree, "pod"))
+
+		require.NoError(t, tree.Update(NewNode("b", "svc:b")))
+		assert.ElementsMatch(t, []string{"a"}, lookup(t, tree, "pod"))
+		assert.ElementsMatch(t, []string{"b", "c"}, lookup(t, tree, "svc"))
+
+		_, version, _ := tree.FindWithVersion("c")
+		require.NoError(t, tree.UpdateIf("c", version, NewNode("c", "job:c")))
+		assert.ElementsMatch(t, []string{"c"}, lookup(t, tree, "job"))
+
+		require.NoError(t, tree.AddOrReplace(NewNode("c", "pod:c"), root))
+		assert.ElementsMatch(t, []string{"a", "c"}, lookup(t, tree, "pod"))
+		assert.Empty(t, lookup(t, tree, "job"))
+
+		require.NoError(t, tree.Delete(a))
+		assert.ElementsMatch(t, []string{"c"}, lookup(t, tree, "pod"))
+		assert.Empty(t, lookup(t, tree, "svc"))
+
+		tree.Reset()
+		assert.Empty(t, lookup(t, tree, "pod"))
+
+		// the index definition survives the reset
+		require.NoError(t, tree.Add(NewNode("root", "pod:root"), nil))
+		assert.ElementsMatch(t, []string{"root"}, lookup(t, tree, "pod"))
+	})
+	t.Run("DropIndex", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.CreateIndex("prefix", prefix))
+		require.NoError(t, tree.CreateIndex("value", func(value string) string { return value }))
+		require.NoError(t, tree.Add(NewNode("root", "pod:root"), nil))
+
+		assert.True(t, tree.DropIndex("prefix"))
+		assert.False(t, tree.DropIndex("prefix"))
+		_, ok := tree.Lookup("prefix", "pod")
+		assert.False(t, ok)
+
+		nodes, ok := tree.Lookup("value", "pod:root")
+		require.True(t, ok)
+		assert.Len(t, nodes, 1)
+	})
+	t.Run("With concurrent updates", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.CreateIndex("prefix", prefix))
+		root := NewNode("root", "")
+		require.NoError(t, tree.Add(root, nil))
+		for i := 0; i < 10; i++ {
+			require.NoError(t, tree.Add(NewNode(fmt.Sprintf("node-%d", i), "pod:"), root))
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					kind := []string{"pod", "svc"}[(i+j)%2]
+					_ = tree.Update(NewNode(fmt.Sprintf("node-%d", j%10), kind+":"))
+					_, _ = tree.Lookup("prefix", kind)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		// the index reflects the latest value of every node
+		for i := 0; i < 10; i++ {
+			node, ok := tree.Find(fmt.Sprintf("node-%d", i))
+			require.True(t, ok)
+			assert.Contains(t, lookup(t, tree, prefix(node.Value())), node.ID())
+		}
+	})
+}
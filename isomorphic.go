@@ -0,0 +1,183 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Isomorphic states whether the given Trees have the same shape, ignoring the identifiers of their Nodes.
+//
+// Two Trees are isomorphic when their Nodes can be paired so that the parent of every Node is paired
+// with the parent of its counterpart. The order of the children is ignored. It detects duplicated
+// template structures whose Nodes have been given different identifiers.
+//
+// Parameters:
+//   - a: The first Tree.
+//   - b: The second Tree.
+//   - equal: An optional function comparing the values of the paired Nodes. When nil, only the
+//     shapes are compared.
+//
+// Returns:
+//   - bool: true when the Trees are isomorphic. Two empty Trees are isomorphic.
+//
+// Notes:
+//   - The shapes are compared in O(n log n) with canonical forms. The values are compared on the
+//     pairs of subtrees of the same shape only, hence equal is called sparingly.
+//
+// Example usage:
+//
+//	if Isomorphic(templateA, templateB, nil) {
+//	    fmt.Println("Same structure")
+//	}
+//
+//	sameContent := Isomorphic(templateA, templateB, func(x, y string) bool { return x == y })
+func Isomorphic[T any](a, b *Tree[T], equal func(x, y T) bool) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+
+	shapes := make(map[string]int)
+	left, right := newShapeTree(a.snapshot(), shapes), newShapeTree(b.snapshot(), shapes)
+	if len(left.nodes) == 0 || len(right.nodes) == 0 {
+		return len(left.nodes) == len(right.nodes)
+	}
+
+	if left.nodes[0].shape != right.nodes[0].shape {
+		return false
+	}
+
+	if equal == nil {
+		return true
+	}
+
+	matcher := &shapeMatcher[T]{left: left, right: right, equal: equal, memo: make(map[[2]int]bool)}
+	return matcher.match(0, 0)
+}
+
+// shapeNode is a Node of a shapeTree
+type shapeNode[T any] struct {
+	value    T
+	children []int
+	// shape is the canonical form of the subtree: two subtrees have the same shape
+	// if and only if they are isomorphic
+	shape int
+}
+
+// shapeTree holds the Nodes of a snapshot along with the canonical form of their subtrees
+type shapeTree[T any] struct {
+	// nodes holds the Nodes in depth-first order, the root first
+	nodes []shapeNode[T]
+}
+
+// newShapeTree builds the shapeTree of the given snapshot, interning the canonical forms in shapes
+func newShapeTree[T any](snapshot Snapshot[T], shapes map[string]int) *shapeTree[T] {
+	tree := &shapeTree[T]{nodes: make([]shapeNode[T], len(snapshot.Records))}
+	positions := make(map[string]int, len(snapshot.Records))
+	for i, record := range snapshot.Records {
+		positions[record.ID] = i
+		tree.nodes[i].value = record.Value
+		if parent, ok := positions[record.ParentID]; ok {
+			tree.nodes[parent].children = append(tree.nodes[parent].children, i)
+		}
+	}
+
+	// the children follow their parent in depth-first order, hence they are canonicalized first
+	// when walking the Nodes backward
+	var key strings.Builder
+	for i := len(tree.nodes) - 1; i >= 0; i-- {
+		node := &tree.nodes[i]
+		codes := make([]int, len(node.children))
+		for j, child := range node.children {
+			codes[j] = tree.nodes[child].shape
+		}
+		slices.Sort(codes)
+
+		key.Reset()
+		key.WriteByte('(')
+		for _, code := range codes {
+			key.WriteString(strconv.Itoa(code))
+			key.WriteByte(',')
+		}
+		key.WriteByte(')')
+
+		shape, ok := shapes[key.String()]
+		if !ok {
+			shape = len(shapes)
+			shapes[key.String()] = shape
+		}
+		node.shape = shape
+	}
+	return tree
+}
+
+// shapeMatcher pairs the Nodes of two shapeTrees of the same shape so that the values of the paired Nodes are equal
+type shapeMatcher[T any] struct {
+	left, right *shapeTree[T]
+	equal       func(x, y T) bool
+	// memo holds the outcome of the pairs of subtrees already compared
+	memo map[[2]int]bool
+}
+
+// match states whether the given subtrees of the same shape can be paired with equal values
+func (m *shapeMatcher[T]) match(l, r int) bool {
+	if matched, ok := m.memo[[2]int{l, r}]; ok {
+		return matched
+	}
+
+	left, right := m.left.nodes[l], m.right.nodes[r]
+	matched := m.equal(left.value, right.value) && m.matchChildren(left.children, right.children)
+	m.memo[[2]int{l, r}] = matched
+	return matched
+}
+
+// matchChildren states whether the given children can be paired one to one, finding a perfect
+// matching with augmenting paths among the children of the same shape
+func (m *shapeMatcher[T]) matchChildren(left, right []int) bool {
+	owner := make(map[int]int, len(right))
+	var augment func(l int, visited map[int]bool) bool
+	augment = func(l int, visited map[int]bool) bool {
+		for _, r := range right {
+			if visited[r] || m.left.nodes[l].shape != m.right.nodes[r].shape || !m.match(l, r) {
+				continue
+			}
+			visited[r] = true
+			if current, ok := owner[r]; !ok || augment(current, visited) {
+				owner[r] = l
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, l := range left {
+		if !augment(l, make(map[int]bool)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsomorphic(t *testing.T) {
+	// build creates a Tree from the given parent of every Node, listed parents first
+	build := func(t *testing.T, nodes ...[3]string) *Tree[string] {
+		t.Helper()
+		tree := NewTree[string]()
+		for _, node := range nodes {
+			require.NoError(t, tree.AddByID(NewNode(node[0], node[2]), node[1]))
+		}
+		return tree
+	}
+
+	equal := func(x, y string) bool { return x == y }
+
+	template := build(t,
+		[3]string{"root", "", "Team"},
+		[3]string{"lead", "root", "Lead"},
+		[3]string{"dev1", "lead", "Dev"},
+		[3]string{"dev2", "lead", "QA"},
+		[3]string{"ops", "root", "Ops"},
+	)
+
+	t.Run("ignores the identifiers and the order of the children", func(t *testing.T) {
+		copied := build(t,
+			[3]string{"t", "", "Team"},
+			[3]string{"o", "t", "Ops"},
+			[3]string{"l", "t", "Lead"},
+			[3]string{"q", "l", "QA"},
+			[3]string{"d", "l", "Dev"},
+		)
+		assert.True(t, Isomorphic(template, copied, nil))
+		assert.True(t, Isomorphic(template, copied, equal))
+	})
+
+	t.Run("detects the different shapes", func(t *testing.T) {
+		chain := build(t,
+			[3]string{"root", "", "Team"},
+			[3]string{"lead", "root", "Lead"},
+			[3]string{"dev1", "lead", "Dev"},
+			[3]string{"dev2", "dev1", "QA"},
+			[3]string{"ops", "root", "Ops"},
+		)
+		assert.False(t, Isomorphic(template, chain, nil))
+
+		smaller := build(t, [3]string{"root", "", "Team"})
+		assert.False(t, Isomorphic(template, smaller, nil))
+	})
+
+	t.Run("compares the values of the paired nodes", func(t *testing.T) {
+		swapped := build(t,
+			[3]string{"root", "", "Team"},
+			[3]string{"lead", "root", "Ops"},
+			[3]string{"dev1", "lead", "Dev"},
+			[3]string{"dev2", "lead", "QA"},
+			[3]string{"ops", "root", "Lead"},
+		)
+		assert.True(t, Isomorphic(template, swapped, nil))
+		assert.False(t, Isomorphic(template, swapped, equal))
+	})
+
+	t.Run("pairs the children of the same shape", func(t *testing.T) {
+		// the first candidate of a is b, which is needed by c: the matching has to backtrack
+		left := build(t,
+			[3]string{"root", "", "R"},
+			[3]string{"a", "root", "X"},
+			[3]string{"c", "root", "Y"},
+		)
+		right := build(t,
+			[3]string{"root", "", "R"},
+			[3]string{"b", "root", "Y"},
+			[3]string{"d", "root", "X"},
+		)
+		matchAny := func(x, y string) bool { return x == y || (x == "X" && y == "Y") }
+		assert.True(t, Isomorphic(left, right, matchAny))
+	})
+
+	t.Run("considers the empty trees isomorphic", func(t *testing.T) {
+		assert.True(t, Isomorphic(NewTree[string](), NewTree[string](), nil))
+		assert.False(t, Isomorphic(template, NewTree[string](), nil))
+	})
+}
@@ -0,0 +1,145 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Prune deletes the subtrees rooted at the Nodes matching the given predicate.
+//
+// The Tree is walked in depth-first pre-order and every matching Node is deleted along with
+// its descendants, which are therefore not evaluated. The deletions go through Delete, hence
+// the hooks and the watchers are notified as usual, and each of them waits for the limiter
+// set with WithMutationLimiter; the pruning stops at the first deletion the limiter refuses.
+//
+// Parameters:
+//   - pred: The function selecting the Nodes to prune. It is invoked without any lock held
+//     and can therefore read the Tree, e.g. to prune the empty branches.
+//
+// Returns:
+//   - pruned: The number of Nodes deleted, descendants included.
+//
+// Example usage:
+//
+//	// prune the expired sessions
+//	pruned := tree.Prune(func(node Node[Session]) bool {
+//	    return node.Value().ExpiresAt.Before(time.Now())
+//	})
+func (x *Tree[T]) Prune(pred func(node Node[T]) bool) (pruned int) {
+	if err := x.requireContext(opDelete, "", ""); err != nil {
+		x.log(slog.LevelWarn, "tree not pruned", slog.String("error", err.Error()))
+		return 0
+	}
+	return x.prune(context.Background(), pred)
+}
+
+// prune deletes the subtrees rooted at the Nodes matching the given predicate, each deletion
+// waiting for the mutation limiter, the waits being bound to the given context
+func (x *Tree[T]) prune(ctx context.Context, pred func(node Node[T]) bool) (pruned int) {
+	var (
+		matches []string
+		walk    func(node *treeNode[T])
+	)
+	walk = func(node *treeNode[T]) {
+		if value := node.GetValue(); value != nil && pred(value) {
+			matches = append(matches, node.ID)
+			return
+		}
+		for _, child := range node.Descendants.Items() {
+			walk(child)
+		}
+	}
+
+	if root := x.rootNode.Load(); root != nil {
+		walk(root)
+	}
+
+	for _, id := range matches {
+		if err := x.throttle(ctx, opDelete, id, ""); err != nil {
+			x.log(slog.LevelDebug, "tree pruning throttled", slog.String("error", err.Error()))
+			break
+		}
+
+		// a match may have been deleted concurrently in the meantime
+		removed, err := x.deleteSubtree(id)
+		if err == nil {
+			pruned += len(removed)
+		}
+	}
+
+	if pruned > 0 {
+		x.log(slog.LevelDebug, "tree pruned", slog.Int("count", pruned))
+	}
+	return pruned
+}
+
+// StartJanitor starts a background goroutine pruning the Tree on a schedule.
+//
+// Every interval, the subtrees rooted at the Nodes matching the given predicate are deleted
+// as done by Prune, the waits for the limiter set with WithMutationLimiter being bound to ctx. It complements the size and the structural constraints of the Tree with
+// a predicate-based cleanup (e.g. expired entries or empty branches).
+//
+// Parameters:
+//   - ctx: The context of the janitor. The goroutine stops once it is done.
+//   - interval: The time between two prunings. It must be positive.
+//   - pred: The function selecting the Nodes to prune. See Prune.
+//
+// Returns:
+//   - done: A channel closed once the goroutine has stopped, allowing a clean shutdown.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	done := tree.StartJanitor(ctx, time.Minute, func(node Node[string]) bool {
+//	    cursor, _ := tree.Cursor(node)
+//	    _, hasChildren := cursor.FirstChild()
+//	    return node.Value() == "branch" && !hasChildren
+//	})
+//
+//	// on shutdown
+//	cancel()
+//	<-done
+func (x *Tree[T]) StartJanitor(ctx context.Context, interval time.Duration, pred func(node Node[T]) bool) (done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				x.prune(ctx, pred)
+			}
+		}
+	}()
+	return stopped
+}
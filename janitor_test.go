@@ -0,0 +1,104 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrune(t *testing.T) {
+	tree := NewTree[string]()
+	root := NewNode("root", "root")
+	a := NewNode("a", "expired")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.Add(a, root))
+	require.NoError(t, tree.Add(NewNode("a1", "expired"), a))
+	require.NoError(t, tree.Add(NewNode("a2", "live"), a))
+	require.NoError(t, tree.Add(NewNode("b", "live"), root))
+	require.NoError(t, tree.Add(NewNode("c", "expired"), NewNode("b", "")))
+
+	var deleted []string
+	tree.OnDelete(func(node, _ Node[string]) {
+		deleted = append(deleted, node.ID())
+	})
+
+	var evaluated []string
+	pruned := tree.Prune(func(node Node[string]) bool {
+		evaluated = append(evaluated, node.ID())
+		return node.Value() == "expired"
+	})
+	assert.Equal(t, 4, pruned)
+	assert.Equal(t, []string{"root", "a", "b", "c"}, evaluated)
+	assert.Equal(t, []string{"a", "a1", "a2", "c"}, deleted)
+	assert.ElementsMatch(t, []string{"root", "b"}, tree.IDs())
+
+	assert.Zero(t, tree.Prune(func(node Node[string]) bool { return false }))
+	assert.Zero(t, NewTree[string]().Prune(func(node Node[string]) bool { return true }))
+}
+
+func TestJanitor(t *testing.T) {
+	tree := NewTree[string]()
+	root := NewNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.Add(NewNode("branch", "branch"), root))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// prune the empty branches
+	done := tree.StartJanitor(ctx, 5*time.Millisecond, func(node Node[string]) bool {
+		cursor, ok := tree.Cursor(node)
+		if !ok {
+			return false
+		}
+		_, hasChildren := cursor.FirstChild()
+		return strings.HasPrefix(node.Value(), "branch") && !hasChildren
+	})
+
+	require.Eventually(t, func() bool {
+		_, ok := tree.Find("branch")
+		return !ok
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, tree.Add(NewNode("branch2", "branch"), root))
+	require.NoError(t, tree.Add(NewNode("leaf", "leaf"), NewNode("branch2", "")))
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the janitor did not stop")
+	}
+
+	// the janitor is stopped and the non-empty branch has been kept
+	_, ok := tree.Find("branch2")
+	assert.True(t, ok)
+}
@@ -0,0 +1,157 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// jsonlRecord is a line of the JSON Lines format
+type jsonlRecord[T any] struct {
+	ID       string `json:"id"`
+	ParentID string `json:"parentID,omitempty"`
+	Value    T      `json:"value"`
+}
+
+// EncodeJSONL writes the Nodes of the Tree to the given writer in the JSON Lines format, one Node per line.
+//
+// Every line is a JSON object holding the ID of the Node, the ID of its parent, omitted for the root, and its
+// value, e.g. {"id":"a","parentID":"root","value":1}. The Nodes are written in depth-first order so that
+// every parent precedes its children, which lets the output be tailed, grepped and ingested by the log
+// pipelines, and more lines be appended later on.
+//
+// Parameters:
+//   - w: The writer receiving the lines.
+//
+// Returns:
+//   - err: An error when a value cannot be encoded or the writer fails.
+//
+// Notes:
+//   - The metadata, edges and tags of the Nodes are not encoded.
+//   - EncodeJSONL blocks the structural changes of the Tree while it captures the Nodes.
+//
+// Example usage:
+//
+//	file, _ := os.Create("tree.jsonl")
+//	defer file.Close()
+//	if err := tree.EncodeJSONL(file); err != nil {
+//	    fmt.Println("Error:", err)
+//	}
+func (x *Tree[T]) EncodeJSONL(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	for _, record := range x.snapshot().Records {
+		line := jsonlRecord[T]{ID: record.ID, ParentID: record.ParentID, Value: record.Value}
+		if err := encoder.Encode(line); err != nil {
+			return fmt.Errorf("gotree: cannot encode node %q: %w", record.ID, err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("gotree: cannot encode tree: %w", err)
+	}
+	return nil
+}
+
+// DecodeJSONL replaces the Nodes of the Tree with the Nodes read from the given JSON Lines stream,
+// as written by EncodeJSONL.
+//
+// The lines are read and added one at a time, hence the stream is never held in memory. The
+// blank lines are skipped and every parent must precede its children.
+//
+// Parameters:
+//   - r: The reader providing the lines.
+//
+// Returns:
+//   - err: ErrCorruptedFile along with the line number when a line is not a valid record, or the
+//     error of the first Node that could not be added, in which case the Tree holds the Nodes added so far.
+//
+// Notes:
+//   - The hooks, the watchers and the Storage are notified of the decoded Nodes.
+//
+// Example usage:
+//
+//	file, _ := os.Open("tree.jsonl")
+//	defer file.Close()
+//	if err := tree.DecodeJSONL(file); err != nil {
+//	    fmt.Println("Error:", err)
+//	}
+func (x *Tree[T]) DecodeJSONL(r io.Reader) error {
+	if err := x.requireContext(opLoad, "", ""); err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+	if err := x.resetContext(context.Background(), newResetConfig()); err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+	return x.appendJSONL(r)
+}
+
+// AppendJSONL adds the Nodes read from the given JSON Lines stream to the Tree, keeping its
+// current Nodes. It ingests the lines appended to a stream after a former decoding,
+// the parents being either part of the Tree or read from earlier lines.
+//
+// Example usage:
+//
+//	err := tree.AppendJSONL(strings.NewReader(`{"id":"c","parentID":"root","value":3}`))
+func (x *Tree[T]) AppendJSONL(r io.Reader) error {
+	if err := x.requireContext(opLoad, "", ""); err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+	return x.appendJSONL(r)
+}
+
+// appendJSONL adds the Nodes read from the given JSON Lines stream to the Tree
+func (x *Tree[T]) appendJSONL(r io.Reader) error {
+	reader := bufio.NewReader(r)
+	for number := 1; ; number++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("gotree: cannot decode tree: %w", err)
+		}
+
+		if content := bytes.TrimSpace(line); len(content) > 0 {
+			var record jsonlRecord[T]
+			if err := json.Unmarshal(content, &record); err != nil {
+				return fmt.Errorf("gotree: cannot decode tree: %w: line %d: %w", ErrCorruptedFile, number, err)
+			}
+			if record.ID == "" {
+				return fmt.Errorf("gotree: cannot decode tree: %w: line %d: missing id", ErrCorruptedFile, number)
+			}
+			if err := x.addByIDContext(context.Background(), NewNode(record.ID, record.Value), record.ParentID, nil); err != nil {
+				return fmt.Errorf("gotree: cannot decode tree: line %d: %w", number, err)
+			}
+		}
+
+		if err != nil {
+			return nil
+		}
+	}
+}
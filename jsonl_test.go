@@ -0,0 +1,82 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONL(t *testing.T) {
+	t.Run("writes a line per node", func(t *testing.T) {
+		tree, err := FromSExpr[int](`(root 1 (a 2 (a1 3)) (b 4))`)
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		require.NoError(t, tree.EncodeJSONL(&buf))
+		assert.Equal(t, `{"id":"root","value":1}
+{"id":"a","parentID":"root","value":2}
+{"id":"a1","parentID":"a","value":3}
+{"id":"b","parentID":"root","value":4}
+`, buf.String())
+
+		decoded := NewTree[int]()
+		require.NoError(t, decoded.AddByID(NewNode("stale", 0), ""))
+		require.NoError(t, decoded.DecodeJSONL(strings.NewReader(buf.String())))
+		assert.Equal(t, tree.Snapshot().Records, decoded.Snapshot().Records)
+	})
+
+	t.Run("appends the new lines", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.DecodeJSONL(strings.NewReader("{\"id\":\"root\",\"value\":\"r\"}\n\n")))
+		require.NoError(t, tree.AppendJSONL(strings.NewReader(`{"id":"a","parentID":"root","value":"x"}
+  {"id":"b","parentID":"a"}`)))
+
+		assert.Equal(t, []Record[string]{
+			{ID: "root", Value: "r"},
+			{ID: "a", ParentID: "root", Value: "x"},
+			{ID: "b", ParentID: "a"},
+		}, tree.Snapshot().Records)
+	})
+
+	t.Run("reports the invalid lines", func(t *testing.T) {
+		tree := NewTree[int]()
+		err := tree.DecodeJSONL(strings.NewReader("{\"id\":\"root\"}\n{\"id\":\"a\",\"value\":\"x\"}\n"))
+		require.ErrorIs(t, err, ErrCorruptedFile)
+		assert.Contains(t, err.Error(), "line 2")
+		assert.EqualValues(t, 1, tree.Size())
+
+		err = tree.DecodeJSONL(strings.NewReader(`{"parentID":"root"}`))
+		require.ErrorIs(t, err, ErrCorruptedFile)
+		assert.Contains(t, err.Error(), "line 1: missing id")
+
+		err = tree.DecodeJSONL(strings.NewReader("{\"id\":\"root\"}\n{\"id\":\"a\",\"parentID\":\"missing\"}"))
+		require.ErrorIs(t, err, ErrParentNodeNotFound)
+		assert.Contains(t, err.Error(), "line 2")
+	})
+}
@@ -0,0 +1,50 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"fmt"
+)
+
+// MutationLimiter throttles the mutations of a Tree set with WithMutationLimiter.
+// The *rate.Limiter of golang.org/x/time/rate implements it.
+type MutationLimiter interface {
+	// Wait blocks until the mutation is allowed to proceed or the context is done,
+	// in which case it returns an error
+	Wait(ctx context.Context) error
+}
+
+// throttle waits for the mutation limiter, if any, to let the given operation through.
+// The refusals are reported as NodeError values wrapping ErrThrottled
+func (x *Tree[T]) throttle(ctx context.Context, op, id, parentID string) error {
+	if x.cfg.limiter == nil {
+		return nil
+	}
+	if err := x.cfg.limiter.Wait(ctx); err != nil {
+		return newNodeError(op, id, parentID, fmt.Errorf("%w: %w", ErrThrottled, err))
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingLimiter is a MutationLimiter counting the waits, letting every mutation through
+type countingLimiter struct {
+	waits atomic.Int64
+}
+
+func (l *countingLimiter) Wait(context.Context) error {
+	l.waits.Add(1)
+	return nil
+}
+
+// closedLimiter is a MutationLimiter letting no mutation through until the context is done
+type closedLimiter struct{}
+
+func (closedLimiter) Wait(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// switchLimiter is a MutationLimiter letting every mutation through until it is closed
+type switchLimiter struct {
+	closed atomic.Bool
+}
+
+func (l *switchLimiter) Wait(ctx context.Context) error {
+	if l.closed.Load() {
+		return closedLimiter{}.Wait(ctx)
+	}
+	return nil
+}
+
+func TestWithMutationLimiter(t *testing.T) {
+	t.Run("waits once per mutation", func(t *testing.T) {
+		limiter := new(countingLimiter)
+		tree := NewTree[int](WithMutationLimiter(limiter))
+
+		root := NewNode("root", 0)
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.AddByID(NewNode("a", 1), "root"))
+		require.NoError(t, tree.AddContext(context.Background(), NewNode("b", 2), root))
+		ref, ok := tree.Ref("a")
+		require.True(t, ok)
+		_, err := tree.AddAt(ref, NewNode("a1", 3))
+		require.NoError(t, err)
+		require.NoError(t, tree.AddOrReplace(NewNode("b", 4), root))
+		require.NoError(t, tree.Update(NewNode("a", 5)))
+		require.NoError(t, tree.UpdateContext(context.Background(), NewNode("a", 6)))
+		_, version, _ := tree.FindWithVersion("a")
+		require.NoError(t, tree.UpdateIf("a", version, NewNode("a", 7)))
+		require.NoError(t, tree.MoveByID("a1", "b"))
+		require.NoError(t, tree.MoveContext(context.Background(), NewNode("a1", 0), NewNode("a", 0)))
+		require.NoError(t, tree.DeleteContext(context.Background(), NewNode("a1", 0)))
+		_, _, err = tree.DeleteSubtree(NewNode("b", 0))
+		require.NoError(t, err)
+		assert.EqualValues(t, 12, limiter.waits.Load())
+
+		tree.Find("a")
+		tree.Snapshot()
+		assert.EqualValues(t, 12, limiter.waits.Load())
+	})
+
+	t.Run("throttles every mutation", func(t *testing.T) {
+		limiter := new(countingLimiter)
+		tree := NewTree[int](WithMutationLimiter(limiter), WithMultiParent())
+		waits := func(mutate func()) int64 {
+			before := limiter.waits.Load()
+			mutate()
+			return limiter.waits.Load() - before
+		}
+
+		root := NewNode("root", 0)
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(NewNode("a", 1), root))
+		assert.EqualValues(t, 1, waits(func() { require.NoError(t, tree.AddWithEdge(NewNode("b", 2), root, EdgeData{Label: "b"})) }))
+		assert.EqualValues(t, 1, waits(func() { require.NoError(t, tree.SetEdge(NewNode("b", 0), EdgeData{Label: "c"})) }))
+		assert.EqualValues(t, 1, waits(func() { require.NoError(t, tree.InsertChildAt(root, NewNode("c", 3), 0)) }))
+		assert.EqualValues(t, 1, waits(func() { require.NoError(t, tree.MoveBefore(NewNode("c", 0), NewNode("b", 0))) }))
+		assert.EqualValues(t, 1, waits(func() { require.NoError(t, tree.MoveAfter(NewNode("c", 0), NewNode("b", 0))) }))
+		assert.EqualValues(t, 1, waits(func() { require.NoError(t, tree.Link(NewNode("c", 0), NewNode("a", 0))) }))
+		assert.EqualValues(t, 1, waits(func() { require.NoError(t, tree.Unlink(NewNode("c", 0), NewNode("a", 0))) }))
+		assert.EqualValues(t, 1, waits(func() { require.NoError(t, tree.SoftDelete(NewNode("c", 0))) }))
+		assert.EqualValues(t, 1, waits(func() { require.NoError(t, tree.RestoreDeleted("c", nil)) }))
+		assert.EqualValues(t, 1, waits(func() {
+			require.NoError(t, tree.Collapse(NewNode("c", 0), func(nodes []Node[int]) int { return len(nodes) }))
+		}))
+		assert.EqualValues(t, 2, waits(func() {
+			assert.Equal(t, 2, tree.Prune(func(node Node[int]) bool { return node.ID() == "a" || node.ID() == "b" }))
+		}))
+		assert.EqualValues(t, 1, waits(func() { tree.Reset() }))
+		assert.Zero(t, tree.Size())
+
+		// the loaders wait for the reset and for every Node they add
+		source, err := FromSExpr[int](`(root 1 (a 2) (b 3))`)
+		require.NoError(t, err)
+		var encoded, xmlEncoded, sexprEncoded, jsonlEncoded bytes.Buffer
+		require.NoError(t, source.EncodeCompressed(&encoded, NoCompression))
+		require.NoError(t, source.EncodeXML(&xmlEncoded))
+		require.NoError(t, source.EncodeSExpr(&sexprEncoded))
+		require.NoError(t, source.EncodeJSONL(&jsonlEncoded))
+		path := filepath.Join(t.TempDir(), "tree.gotree")
+		require.NoError(t, source.SaveFile(path))
+		store := &memoryBlobStore{objects: make(map[string][]byte)}
+		require.NoError(t, source.SaveTo(context.Background(), store, "tree"))
+
+		loaders := map[string]func() error{
+			"Decode":      func() error { return tree.Decode(bytes.NewReader(encoded.Bytes())) },
+			"LoadFile":    func() error { return tree.LoadFile(path) },
+			"LoadFrom":    func() error { return tree.LoadFrom(context.Background(), store, "tree") },
+			"Restore":     func() error { return tree.Restore(source.Snapshot()) },
+			"DecodeXML":   func() error { return tree.DecodeXML(bytes.NewReader(xmlEncoded.Bytes())) },
+			"DecodeSExpr": func() error { return tree.DecodeSExpr(bytes.NewReader(sexprEncoded.Bytes())) },
+			"DecodeJSONL": func() error { return tree.DecodeJSONL(bytes.NewReader(jsonlEncoded.Bytes())) },
+		}
+		for name, load := range loaders {
+			assert.EqualValues(t, 4, waits(func() { require.NoError(t, load()) }), name)
+			assert.EqualValues(t, 3, tree.Size(), name)
+		}
+
+		assert.EqualValues(t, 2, waits(func() {
+			require.NoError(t, tree.ApplyDelta(Delta[int]{Records: []Record[int]{{ID: "c", ParentID: "root", Value: 4}}, Deleted: []string{"b"}}))
+		}))
+		assert.EqualValues(t, 3, tree.Size())
+	})
+
+	t.Run("stops the janitor with its context", func(t *testing.T) {
+		limiter := new(switchLimiter)
+		tree := NewTree[int](WithMutationLimiter(limiter))
+		require.NoError(t, tree.Restore(Snapshot[int]{Records: []Record[int]{{ID: "root"}, {ID: "a", ParentID: "root"}, {ID: "b", ParentID: "root"}}}))
+		limiter.closed.Store(true)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := tree.StartJanitor(ctx, time.Millisecond, func(node Node[int]) bool { return node.ID() == "a" })
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		<-done
+		assert.EqualValues(t, 3, tree.Size())
+	})
+
+	t.Run("gives up with the context", func(t *testing.T) {
+		tree := NewTree[int](WithMutationLimiter(closedLimiter{}))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := tree.AddContext(ctx, NewNode("root", 0), nil)
+		require.ErrorIs(t, err, ErrThrottled)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Zero(t, tree.Size())
+
+		var nodeErr *NodeError
+		require.ErrorAs(t, err, &nodeErr)
+		assert.Equal(t, "add", nodeErr.Op())
+		assert.Equal(t, "root", nodeErr.NodeID())
+	})
+}
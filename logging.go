@@ -0,0 +1,78 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// log emits a record with the given level, message and attributes when a logger is set
+func (x *Tree[T]) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	logRecord(x.cfg.logger, level, msg, attrs...)
+}
+
+// logRejected emits a record for the given rejected mutation. The rejections caused by the
+// constraints of the Tree are notable and logged at the warn level, the others at the debug level.
+func (x *Tree[T]) logRejected(err error) {
+	// the changes not persisted are logged by the publisher
+	if x.cfg.logger == nil || errors.Is(err, ErrNotPersisted) {
+		return
+	}
+
+	level := slog.LevelDebug
+	switch {
+	case errors.Is(err, ErrDuplicateID),
+		errors.Is(err, ErrInvalidID),
+		errors.Is(err, ErrMaxDepthExceeded),
+		errors.Is(err, ErrMaxChildrenExceeded),
+		errors.Is(err, ErrMaxSizeExceeded),
+		errors.Is(err, ErrQuotaExceeded):
+		level = slog.LevelWarn
+	}
+
+	attrs := []slog.Attr{slog.String("error", err.Error())}
+	var nodeErr *NodeError
+	if errors.As(err, &nodeErr) {
+		attrs = append(attrs, slog.String("op", nodeErr.Op()), slog.String("node", nodeErr.NodeID()))
+		if nodeErr.ParentID() != "" {
+			attrs = append(attrs, slog.String("parent", nodeErr.ParentID()))
+		}
+	}
+	x.log(level, "tree mutation rejected", attrs...)
+}
+
+// logRecord emits a record with the given logger, which can be nil
+func logRecord(logger *slog.Logger, level slog.Level, msg string, attrs ...slog.Attr) {
+	if logger == nil {
+		return
+	}
+
+	ctx := context.Background()
+	if logger.Enabled(ctx, level) {
+		logger.LogAttrs(ctx, level, msg, attrs...)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	newLogger := func(level slog.Level) (*bytes.Buffer, slog.Handler) {
+		buffer := new(bytes.Buffer)
+		return buffer, slog.NewTextHandler(buffer, &slog.HandlerOptions{
+			Level: level,
+			ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+				if attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return attr
+			},
+		})
+	}
+
+	lines := func(buffer *bytes.Buffer) []string {
+		return strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	}
+
+	t.Run("Rejected mutations", func(t *testing.T) {
+		buffer, handler := newLogger(slog.LevelDebug)
+		tree := NewTree[string](WithLogger(handler), WithMaxChildren(1))
+
+		root := NewNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(NewNode("a", "a"), root))
+		require.Error(t, tree.Add(NewNode("a", "a"), root))
+		require.Error(t, tree.Add(NewNode("b", "b"), root))
+		require.Error(t, tree.Add(NewNode("c", "c"), NewNode("x", "")))
+		require.NoError(t, tree.DeleteByID("a"))
+
+		assert.Equal(t, []string{
+			`level=WARN msg="tree mutation rejected" error="add node \"a\" under parent \"root\": duplicate node ID" op=add node=a parent=root`,
+			`level=WARN msg="tree mutation rejected" error="add node \"b\" under parent \"root\": max children exceeded" op=add node=b parent=root`,
+			`level=DEBUG msg="tree mutation rejected" error="add node \"c\" under parent \"x\": parent node not found" op=add node=c parent=x`,
+			`level=DEBUG msg="tree nodes deleted" node=a count=1`,
+		}, lines(buffer))
+	})
+	t.Run("Repair", func(t *testing.T) {
+		buffer, handler := newLogger(slog.LevelWarn)
+		tree := NewTree[string](WithLogger(handler))
+		require.NoError(t, tree.Add(NewNode("root", "root"), nil))
+		assert.Zero(t, tree.Repair())
+		assert.Empty(t, buffer.String())
+
+		tree.size.Add(1)
+		assert.Equal(t, 1, tree.Repair())
+		assert.Equal(t, []string{`level=WARN msg="tree repaired" fixes=1`}, lines(buffer))
+	})
+	t.Run("Disconnected watcher", func(t *testing.T) {
+		buffer, handler := newLogger(slog.LevelWarn)
+		tree := NewTree[string](WithLogger(handler))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_ = tree.Watch(ctx, nil, WithBufferSize(0))
+		require.NoError(t, tree.Add(NewNode("root", "root"), nil))
+		assert.Equal(t, []string{`level=WARN msg="tree watcher disconnected" seq=1`}, lines(buffer))
+	})
+	t.Run("Without logger", func(t *testing.T) {
+		tree := NewTree[string](WithLogger(nil))
+		require.NoError(t, tree.Add(NewNode("root", "root"), nil))
+		require.Error(t, tree.Add(NewNode("root", "root"), nil))
+	})
+}
@@ -30,6 +30,8 @@ import (
 )
 
 // Shard defines a Shard
+//
+// Deprecated: use the shardedmap package instead.
 type Shard struct {
 	sync.RWMutex
 	m map[string]any
@@ -37,14 +39,27 @@ type Shard struct {
 
 // ShardedMap defines a concurrent map with sharding for
 // scalability
+//
+// Deprecated: use shardedmap.Map, its generic successor, instead.
 type ShardedMap []*Shard
 
 // NewShardedMap creates an instance of ShardedMap
+//
+// Deprecated: use shardedmap.New instead.
 func NewShardedMap(shardsCount uint64) ShardedMap {
+	return NewShardedMapWithCapacity(shardsCount, 0)
+}
+
+// NewShardedMapWithCapacity creates an instance of ShardedMap
+// where each Shard is pre-sized to hold its share of the given capacity
+//
+// Deprecated: use shardedmap.New with shardedmap.WithCapacity instead.
+func NewShardedMapWithCapacity(shardsCount, capacity uint64) ShardedMap {
+	shardCapacity := capacity / shardsCount
 	shards := make([]*Shard, shardsCount)
 	for i := range shardsCount {
 		shards[i] = &Shard{
-			m: make(map[string]any),
+			m: make(map[string]any, shardCapacity),
 		}
 	}
 	return shards
@@ -67,6 +82,21 @@ func (s ShardedMap) Store(key string, value any) {
 	shard.Unlock()
 }
 
+// LoadOrStore returns the existing value for the key if present.
+// Otherwise, it stores the given value and returns it.
+// The loaded result is true if the value was loaded, false if stored.
+func (s ShardedMap) LoadOrStore(key string, value any) (actual any, loaded bool) {
+	shard := s.getShard(key)
+	shard.Lock()
+	if existing, ok := shard.m[key]; ok {
+		shard.Unlock()
+		return existing, true
+	}
+	shard.m[key] = value
+	shard.Unlock()
+	return value, false
+}
+
 // Delete removes a given key from the sharded map
 func (s ShardedMap) Delete(key string) {
 	shard := s.getShard(key)
@@ -98,6 +128,17 @@ func (s ShardedMap) Reset() {
 	}
 }
 
+// Clear removes all the entries of the sharded map while
+// retaining the memory allocated by each Shard
+func (s ShardedMap) Clear() {
+	for i := range s {
+		shard := s[i]
+		shard.Lock()
+		clear(shard.m)
+		shard.Unlock()
+	}
+}
+
 // getShard returns the given Shard for a given key
 func (s ShardedMap) getShard(key string) *Shard {
 	hash := fnv64(key) % uint64(len(s))
@@ -0,0 +1,137 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+// SetMeta attaches a metadata entry to the Node with the given ID.
+//
+// Metadata is an optional bag of key/value annotations kept alongside each Node,
+// separate from its typed value. It suits transient annotations (e.g. UI state or
+// synchronization flags) that should not require changing T. Setting metadata does
+// not change the Node version.
+//
+// Parameters:
+//   - id: The unique identifier of the Node to annotate.
+//   - key: The metadata key. An existing entry with the same key is overwritten.
+//   - value: The metadata value.
+//
+// Returns:
+//   - err: nil on success, ErrNotFound when the Node does not exist in the Tree or
+//     ErrNotPersisted when the Storage fails to append the change.
+//
+// Example usage:
+//
+//	_ = tree.SetMeta("childID", "expanded", true)
+//	meta, _ := tree.Meta("childID")
+//	fmt.Println("Expanded:", meta["expanded"])
+func (x *Tree[T]) SetMeta(id, key string, value any) (err error) {
+	if err := x.requireContext(opSetMeta, id, ""); err != nil {
+		return err
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return x.updateMeta(opSetMeta, id, func(meta map[string]any) {
+		meta[key] = value
+	})
+}
+
+// DeleteMeta removes the metadata entry with the given key from the Node with the given ID.
+//
+// Parameters:
+//   - id: The unique identifier of the Node.
+//   - key: The metadata key to remove. Removing a missing key is a no-op.
+//
+// Returns:
+//   - err: nil on success, ErrNotFound when the Node does not exist in the Tree or
+//     ErrNotPersisted when the Storage fails to append the change.
+func (x *Tree[T]) DeleteMeta(id, key string) (err error) {
+	if err := x.requireContext(opDeleteMeta, id, ""); err != nil {
+		return err
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return x.updateMeta(opDeleteMeta, id, func(meta map[string]any) {
+		delete(meta, key)
+	})
+}
+
+// Meta returns a copy of the metadata attached to the Node with the given ID.
+//
+// Parameters:
+//   - id: The unique identifier of the Node.
+//
+// Returns:
+//   - meta: A copy of the Node metadata. It is empty when no metadata has been set.
+//     Modifying it does not affect the Tree; use SetMeta instead.
+//   - ok: A boolean indicating whether the Node exists in the Tree.
+func (x *Tree[T]) Meta(id string) (meta map[string]any, ok bool) {
+	node, ok := x.getNode(id)
+	if !ok {
+		return nil, false
+	}
+	return node.GetMeta(), true
+}
+
+// updateMeta applies the given function to a copy of the metadata of the Node with the given ID and
+// stores the result once written ahead. It must be called with the structural lock held
+func (x *Tree[T]) updateMeta(op, id string, fn func(meta map[string]any)) (err error) {
+	node, ok := x.getNode(id)
+	if !ok {
+		return newNodeError(op, id, "", ErrNotFound)
+	}
+
+	write, commit := x.feed.begin()
+	defer func() {
+		commit(err == nil)
+	}()
+
+	for {
+		current := node.Meta.Load()
+		meta := copyMeta(current)
+		fn(meta)
+		if err := write(Event[T]{Type: EventMeta, Node: node.GetValue(), Parent: x.parentValue(id), Meta: copyMeta(&meta)}); err != nil {
+			return newNodeError(op, id, "", err)
+		}
+
+		// the publication serializes the changes: the swap only fails when they are not published
+		if node.Meta.CompareAndSwap(current, &meta) {
+			return nil
+		}
+	}
+}
+
+// replaceMeta replaces the metadata of the Node with the given ID with a copy of the given one.
+// It must be called with the structural lock held
+func (x *Tree[T]) replaceMeta(id string, meta map[string]any) error {
+	return x.updateMeta(opSetMeta, id, func(current map[string]any) {
+		clear(current)
+		for key, value := range meta {
+			current[key] = value
+		}
+	})
+}
@@ -0,0 +1,96 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeta(t *testing.T) {
+	tree := NewTree[string]()
+	root := NewNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+
+	meta, ok := tree.Meta("root")
+	require.True(t, ok)
+	assert.Empty(t, meta)
+
+	require.NoError(t, tree.SetMeta("root", "expanded", true))
+	require.NoError(t, tree.SetMeta("root", "owner", "alice"))
+	_, version, _ := tree.FindWithVersion("root")
+	assert.EqualValues(t, 1, version)
+
+	meta, ok = tree.Meta("root")
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"expanded": true, "owner": "alice"}, meta)
+
+	// the returned metadata is a copy
+	meta["owner"] = "bob"
+	meta, _ = tree.Meta("root")
+	assert.Equal(t, "alice", meta["owner"])
+
+	// replacing the value keeps the metadata
+	require.NoError(t, tree.Update(NewNode("root", "new root")))
+	meta, _ = tree.Meta("root")
+	assert.Len(t, meta, 2)
+
+	require.NoError(t, tree.DeleteMeta("root", "expanded"))
+	meta, _ = tree.Meta("root")
+	assert.Equal(t, map[string]any{"owner": "alice"}, meta)
+
+	assert.ErrorIs(t, tree.SetMeta("rogue", "key", "value"), ErrNotFound)
+	assert.ErrorIs(t, tree.DeleteMeta("rogue", "key"), ErrNotFound)
+	_, ok = tree.Meta("rogue")
+	assert.False(t, ok)
+
+	// deleted nodes lose their metadata
+	require.NoError(t, tree.Delete(root))
+	require.NoError(t, tree.Add(NewNode("root", "root"), nil))
+	meta, _ = tree.Meta("root")
+	assert.Empty(t, meta)
+}
+
+func TestConcurrentSetMeta(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.Add(NewNode("root", "root"), nil))
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = tree.SetMeta("root", fmt.Sprintf("key%d", i), i)
+		}()
+	}
+	wg.Wait()
+
+	meta, _ := tree.Meta("root")
+	assert.Len(t, meta, 50)
+}
@@ -0,0 +1,91 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import "time"
+
+// Operation names an operation of the Tree. It is reported to the Metrics and the Tracer,
+// and passed to the authorizer set with WithAuthorizer.
+type Operation string
+
+// the names of the operations reported to the Metrics
+const (
+	// OperationAdd reports the additions of Nodes (Add, AddByID, AddWithEdge)
+	OperationAdd Operation = "add"
+	// OperationReplace reports the calls to AddOrReplace
+	OperationReplace Operation = "replace"
+	// OperationUpdate reports the replacements of Node values (Update, UpdateIf)
+	OperationUpdate Operation = "update"
+	// OperationDelete reports the deletions of Nodes (Delete, DeleteByID, DeleteAndCollect)
+	OperationDelete Operation = "delete"
+	// OperationMove reports the moves of Nodes (Move, MoveByID)
+	OperationMove Operation = "move"
+	// OperationFind reports the lookups of Nodes (Find, FindWithVersion). A miss reports ErrNotFound.
+	OperationFind Operation = "find"
+	// OperationReset reports the calls to Reset
+	OperationReset Operation = "reset"
+	// OperationCollapse reports the calls to Collapse
+	OperationCollapse Operation = "collapse"
+)
+
+// Metrics receives the measurements of the Tree operations.
+//
+// It is the extension point used to expose the activity of a Tree to a monitoring system:
+// counters and latency histograms are derived from the observed operations while gauges,
+// such as the size or the depth of the Tree, are read from the Tree itself. Ready-made
+// implementations are provided by the gotreeexpvar and gotreeprom packages.
+//
+// Implementations must be safe for concurrent use and fast, since they are invoked
+// synchronously by every instrumented operation.
+type Metrics interface {
+	// ObserveOperation records the completion of the given operation, one of the
+	// Operation constants, along with its duration and its error, nil on success.
+	ObserveOperation(operation Operation, duration time.Duration, err error)
+}
+
+// Gauges is the view of a Tree read by the Metrics implementations to report its shape
+type Gauges interface {
+	// Size returns the number of Nodes of the Tree
+	Size() int64
+	// Depth returns the depth of the deepest Node of the Tree
+	Depth() int
+}
+
+var _ Gauges = (*Tree[any])(nil)
+
+// observe reports the given operation started at the given time with the given error to the Metrics.
+// It is meant to be deferred by the instrumented operations when Metrics are set.
+func (x *Tree[T]) observe(operation Operation, start time.Time, err *error) {
+	x.cfg.metrics.ObserveOperation(operation, time.Since(start), *err)
+}
+
+// observeFind reports a lookup started at the given time to the Metrics
+func (x *Tree[T]) observeFind(start time.Time, ok *bool) {
+	var err error
+	if !*ok {
+		err = ErrNotFound
+	}
+	x.cfg.metrics.ObserveOperation(OperationFind, time.Since(start), err)
+}
@@ -0,0 +1,108 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type observation struct {
+	operation Operation
+	err       error
+}
+
+type recordingMetrics struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (m *recordingMetrics) ObserveOperation(operation Operation, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if duration < 0 {
+		panic("negative duration")
+	}
+	m.observations = append(m.observations, observation{operation: operation, err: err})
+}
+
+func TestMetrics(t *testing.T) {
+	metrics := new(recordingMetrics)
+	tree := NewTree[string](WithMetrics(metrics))
+
+	root := NewNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+	require.NoError(t, tree.AddWithEdge(NewNode("b", "b"), root, EdgeData{}))
+	require.Error(t, tree.Add(NewNode("a", "a"), root))
+	require.NoError(t, tree.AddOrReplace(NewNode("a", "a2"), root))
+	require.NoError(t, tree.Update(NewNode("a", "a3")))
+	require.Error(t, tree.UpdateIf("a", 42, NewNode("a", "a4")))
+	_, _ = tree.Find("a")
+	_, _, _ = tree.FindWithVersion("x")
+	require.NoError(t, tree.Delete(NewNode("b", "")))
+	_, err := tree.DeleteAndCollect(NewNode("x", ""))
+	require.Error(t, err)
+	tree.Reset()
+
+	expected := []observation{
+		{operation: OperationAdd},
+		{operation: OperationAdd},
+		{operation: OperationAdd},
+		{operation: OperationAdd, err: ErrDuplicateID},
+		{operation: OperationReplace},
+		{operation: OperationUpdate},
+		{operation: OperationUpdate, err: ErrVersionMismatch},
+		{operation: OperationFind},
+		{operation: OperationFind, err: ErrNotFound},
+		{operation: OperationDelete},
+		{operation: OperationDelete, err: ErrNotFound},
+		{operation: OperationReset},
+	}
+	require.Len(t, metrics.observations, len(expected))
+	for i, observation := range metrics.observations {
+		assert.Equal(t, expected[i].operation, observation.operation, i)
+		if expected[i].err == nil {
+			assert.NoError(t, observation.err, i)
+		} else {
+			assert.True(t, errors.Is(observation.err, expected[i].err), i)
+		}
+	}
+}
+
+func TestDepth(t *testing.T) {
+	tree := NewTree[string]()
+	assert.Zero(t, tree.Depth())
+
+	require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", "b"), "a"))
+	assert.Equal(t, 2, tree.Depth())
+}
@@ -0,0 +1,105 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Migration upgrades a value encoded with encoding/json from a schema version to the next one
+type Migration func(value json.RawMessage) (json.RawMessage, error)
+
+// Migrations is the registry of the Migrations upgrading the values of the serialized Trees.
+//
+// The values of a Tree follow a schema defined by the application, whose version starts at zero
+// and increases with every Migration registered. The serialized Trees record the version of their
+// values, so that the loaders (LoadFile, Decode, DecodeEncrypted and LoadFrom) of a Tree created
+// with WithMigrations upgrade the values written with an older schema, one version at a time,
+// before decoding them.
+//
+// Example usage:
+//
+//	migrations := NewMigrations().
+//	    Register(0, func(value json.RawMessage) (json.RawMessage, error) {
+//	        // version 1 turns the plain names into objects
+//	        var name string
+//	        if err := json.Unmarshal(value, &name); err != nil {
+//	            return nil, err
+//	        }
+//	        return json.Marshal(Employee{Name: name})
+//	    })
+//
+//	tree := NewTree[Employee](WithMigrations(migrations))
+//	err := tree.LoadFile("org.gotree") // files written with the names are upgraded
+type Migrations struct {
+	// steps holds the migrations keyed by the version they upgrade from
+	steps map[int]Migration
+	// version is the current version of the schema
+	version int
+}
+
+// NewMigrations creates an empty registry, whose schema version is zero
+func NewMigrations() *Migrations {
+	return &Migrations{steps: make(map[int]Migration)}
+}
+
+// Register registers the Migration upgrading the values from the given schema version to the next one.
+// The schema version of the registry becomes the highest version reached. It panics when a Migration
+// is already registered for the version or the version is negative.
+func (m *Migrations) Register(from int, migration Migration) *Migrations {
+	if _, ok := m.steps[from]; ok || from < 0 || migration == nil {
+		panic(fmt.Sprintf("gotree: invalid migration from schema version %d", from))
+	}
+
+	m.steps[from] = migration
+	m.version = max(m.version, from+1)
+	return m
+}
+
+// Version returns the current schema version, written along with the serialized Trees
+func (m *Migrations) Version() int {
+	if m == nil {
+		return 0
+	}
+	return m.version
+}
+
+// migrate upgrades the given value from the given schema version to the current one
+func (m *Migrations) migrate(value json.RawMessage, version int) (json.RawMessage, error) {
+	for ; version < m.Version(); version++ {
+		step, ok := m.steps[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from schema version %d", version)
+		}
+
+		upgraded, err := step(value)
+		if err != nil {
+			return nil, fmt.Errorf("migration from schema version %d: %w", version, err)
+		}
+		value = upgraded
+	}
+	return value, nil
+}
@@ -0,0 +1,139 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// employee is the value of the Trees of the migration tests
+type employee struct {
+	Name  string `json:"name"`
+	Title string `json:"title,omitempty"`
+}
+
+func TestMigrations(t *testing.T) {
+	// version 1 turns the plain names into objects
+	toObject := func(value json.RawMessage) (json.RawMessage, error) {
+		var name string
+		if err := json.Unmarshal(value, &name); err != nil {
+			return nil, err
+		}
+		return json.Marshal(employee{Name: name})
+	}
+
+	// version 2 adds the default title
+	withTitle := func(value json.RawMessage) (json.RawMessage, error) {
+		var e employee
+		if err := json.Unmarshal(value, &e); err != nil {
+			return nil, err
+		}
+		e.Title = "Staff"
+		return json.Marshal(e)
+	}
+
+	encodeNames := func(t *testing.T) *bytes.Buffer {
+		t.Helper()
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("ceo", "Alice"), ""))
+		require.NoError(t, tree.AddByID(NewNode("cto", "Bob"), "ceo"))
+
+		var buf bytes.Buffer
+		require.NoError(t, tree.EncodeCompressed(&buf, NoCompression))
+		return &buf
+	}
+
+	t.Run("upgrades the values written with an older schema", func(t *testing.T) {
+		migrations := NewMigrations().Register(0, toObject).Register(1, withTitle)
+		assert.Equal(t, 2, migrations.Version())
+
+		tree := NewTree[employee](WithMigrations(migrations))
+		require.NoError(t, tree.Decode(encodeNames(t)))
+		node, ok := tree.Find("cto")
+		require.True(t, ok)
+		assert.Equal(t, employee{Name: "Bob", Title: "Staff"}, node.Value())
+
+		// the upgraded tree is written with the current schema
+		var buf bytes.Buffer
+		require.NoError(t, tree.EncodeCompressed(&buf, NoCompression))
+		assert.Contains(t, buf.String(), `"schema":2`)
+
+		reloaded := NewTree[employee](WithMigrations(migrations))
+		require.NoError(t, reloaded.Decode(&buf))
+		assert.Equal(t, tree.Dump(), reloaded.Dump())
+	})
+
+	t.Run("applies the migrations following the written schema", func(t *testing.T) {
+		tree := NewTree[employee](WithMigrations(NewMigrations().Register(0, toObject)))
+		require.NoError(t, tree.AddByID(NewNode("ceo", employee{Name: "Alice", Title: "CEO"}), ""))
+		var buf bytes.Buffer
+		require.NoError(t, tree.EncodeCompressed(&buf, NoCompression))
+
+		upgraded := NewTree[employee](WithMigrations(NewMigrations().Register(0, toObject).Register(1, withTitle)))
+		require.NoError(t, upgraded.Decode(&buf))
+		node, ok := upgraded.Find("ceo")
+		require.True(t, ok)
+		assert.Equal(t, employee{Name: "Alice", Title: "Staff"}, node.Value())
+	})
+
+	t.Run("rejects the values written with a newer schema", func(t *testing.T) {
+		tree := NewTree[employee](WithMigrations(NewMigrations().Register(0, toObject)))
+		require.NoError(t, tree.AddByID(NewNode("ceo", employee{Name: "Alice"}), ""))
+		var buf bytes.Buffer
+		require.NoError(t, tree.EncodeCompressed(&buf, NoCompression))
+
+		assert.ErrorIs(t, NewTree[employee]().Decode(&buf), ErrUnsupportedVersion)
+	})
+
+	t.Run("returns the errors of the migrations", func(t *testing.T) {
+		failure := errors.New("unexpected value")
+		migrations := NewMigrations().Register(0, func(json.RawMessage) (json.RawMessage, error) {
+			return nil, failure
+		})
+		err := NewTree[employee](WithMigrations(migrations)).Decode(encodeNames(t))
+		assert.ErrorIs(t, err, failure)
+
+		gap := NewMigrations().Register(1, withTitle)
+		err = NewTree[employee](WithMigrations(gap)).Decode(encodeNames(t))
+		assert.ErrorContains(t, err, "no migration from schema version 0")
+	})
+
+	t.Run("returns an error instead of decoding mismatching values", func(t *testing.T) {
+		err := NewTree[employee]().Decode(encodeNames(t))
+		assert.ErrorContains(t, err, `node "ceo"`)
+	})
+
+	t.Run("rejects the duplicate migrations", func(t *testing.T) {
+		migrations := NewMigrations().Register(0, toObject)
+		assert.Panics(t, func() { migrations.Register(0, withTitle) })
+		assert.Panics(t, func() { migrations.Register(-1, withTitle) })
+	})
+}
@@ -0,0 +1,272 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Move detaches the given Node from its parent and attaches it, along with its
+// descendants, as the last child of the given parent.
+//
+// Parameters:
+//   - node: The Node[T] to move. It must be part of the Tree and cannot be the root.
+//   - parent: The Node[T] under which the `node` is attached.
+//
+// Returns:
+// - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The Node was successfully moved, or was already a child of `parent`.
+//   - ErrNotFound: The specified Node does not exist in the Tree.
+//   - ErrParentNodeNotFound: The specified parent Node does not exist in the Tree.
+//   - ErrInvalidOperation: Attempt to move the root, or to move the Node under itself
+//     or one of its own descendants, in which case ErrCycleDetected is wrapped as well.
+//   - ErrMaxDepthExceeded, ErrMaxChildrenExceeded: The move would violate the limits of the Tree.
+//
+// The returned errors are *NodeError values carrying the identifiers of the Nodes
+// involved; use errors.Is to match them against the errors above.
+//
+// Notes:
+//   - The IDs, values, metadata and tags of the moved Nodes are kept. The data of the edge
+//     linking the Node to its former parent is discarded.
+//   - The hooks registered with OnMove are invoked and an EventMove is delivered to the
+//     watchers, once for the moved Node.
+//
+// Example usage:
+//
+//	err := tree.Move(child, newParent)
+//	if errors.Is(err, ErrInvalidOperation) {
+//	    fmt.Println("Cannot move a node under its own subtree")
+//	}
+func (x *Tree[T]) Move(node, parent Node[T]) (err error) {
+	return x.MoveByID(node.ID(), parent.ID())
+}
+
+// MoveByID behaves like Move for the Nodes with the given IDs.
+// It spares the callers holding plain identifiers from fabricating Nodes.
+func (x *Tree[T]) MoveByID(id, parentID string) (err error) {
+	if err := x.requireContext(opMove, id, parentID); err != nil {
+		return err
+	}
+
+	return x.moveContext(context.Background(), id, parentID)
+}
+
+// moveContext moves the Node with the given ID under the given parent once the mutation limiter
+// lets it through, the wait being bound to the given context
+func (x *Tree[T]) moveContext(ctx context.Context, id, parentID string) (err error) {
+	if err := x.throttle(ctx, opMove, id, parentID); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationMove, time.Now(), &err)
+	}
+
+	end := x.trace(OperationMove)
+	moved, err := x.moveExclusive(id, parentID)
+	end(moved.count, err)
+
+	if err == nil && moved.count > 0 {
+		x.notifyMove(moved.node, moved.from, moved.to)
+	}
+	return err
+}
+
+// move describes a Node moved under another parent along with the number of moved Nodes
+type move[T any] struct {
+	node  Node[T]
+	from  Node[T]
+	to    Node[T]
+	count int
+}
+
+// moveExclusive moves the given node under the exclusive structural lock
+func (x *Tree[T]) moveExclusive(id, parentID string) (move[T], error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.move(id, parentID, "", false)
+}
+
+// move attaches the given node under the given parent and rebuilds the ancestor chains of its subtree.
+// The node is placed right before the given sibling, or right after it when after is set, and as the
+// last child when the sibling is empty. It must be called with the structural lock held exclusively
+func (x *Tree[T]) move(id, parentID, sibling string, after bool) (moved move[T], err error) {
+	defer func() {
+		if err != nil {
+			x.logRejected(err)
+		}
+	}()
+
+	n, ok := x.getNode(id)
+	if !ok {
+		return moved, newNodeError(opMove, id, parentID, ErrNotFound)
+	}
+
+	to, ok := x.getNode(parentID)
+	if !ok {
+		return moved, newNodeError(opMove, id, parentID, ErrParentNodeNotFound)
+	}
+
+	from, ok := x.parentNode(id)
+	if !ok {
+		// the root cannot be moved
+		return moved, newNodeError(opMove, id, parentID, ErrInvalidOperation)
+	}
+
+	if from == to {
+		if sibling == "" {
+			return moved, nil
+		}
+		return x.reorder(n, from, sibling, after)
+	}
+
+	// the node cannot be moved under its own subtree
+	for link := to.GetPath(); link != nil; link = link.parent {
+		if link.id == id {
+			return moved, newNodeError(opMove, id, parentID, errCycle)
+		}
+	}
+
+	// nor under the Nodes linked below it
+	if x.links != nil && x.reaches(parentID, id) {
+		return moved, newNodeError(opMove, id, parentID, errCycle)
+	}
+
+	subtree := append([]*treeNode[T]{n}, x.collectDescendants(n)...)
+	if err := x.checkMoveConstraints(n, to, subtree); err != nil {
+		return moved, newNodeError(opMove, id, parentID, err)
+	}
+	if !x.quotas.fits(from.GetPath(), to.GetPath(), int64(len(subtree))) {
+		return moved, newNodeError(opMove, id, parentID, ErrQuotaExceeded)
+	}
+
+	// write the move ahead, the node being placed as the last child when the sibling is not a child
+	var before string
+	placed := sibling != ""
+	if placed {
+		if before, placed = to.Descendants.Placement(id, sibling, after); !placed {
+			x.invariant("node %q is not a child of %q", sibling, to.ID)
+		}
+	}
+
+	write, commit := x.feed.begin()
+	if err := write(Event[T]{Type: EventMove, Node: n.GetValue(), Parent: to.GetValue(), Before: before}); err != nil {
+		commit(false)
+		return moved, newNodeError(opMove, id, parentID, err)
+	}
+	defer commit(true)
+
+	if !from.Descendants.Remove(id) {
+		x.invariant("node %q is not a child of its parent %q", id, from.ID)
+	}
+	from.BumpVersion()
+
+	if placed {
+		to.Descendants.Place(n, sibling, after)
+	} else {
+		to.Descendants.Append(n)
+	}
+	to.BumpVersion()
+	n.Edge.Store(nil)
+
+	x.moveRollups(id, from.GetPath(), to.GetPath())
+	x.quotas.move(from.GetPath(), to.GetPath(), int64(len(subtree)))
+	// the new parent holds the Node as primary parent from now on
+	x.links.remove(id, parentID)
+
+	// rebuild the ancestor chains of the subtree on top of the new parent chain
+	x.updateAncestors(to, n)
+	n.SetPath(newAncestry(n.ID, to.GetPath(), x.cfg.ancestorIndex))
+	for _, node := range subtree[1:] {
+		parent, ok := x.parentNode(node.ID)
+		if !ok {
+			x.invariant("node %q has no parent", node.ID)
+			continue
+		}
+		node.SetPath(newAncestry(node.ID, parent.GetPath(), x.cfg.ancestorIndex))
+		x.updateAncestors(parent, node)
+	}
+
+	x.invalidateCache()
+	x.log(slog.LevelDebug, "tree node moved",
+		slog.String("node", id),
+		slog.String("from", from.ID),
+		slog.String("to", to.ID),
+		slog.Int("count", len(subtree)))
+
+	return move[T]{
+		node:  n.GetValue(),
+		from:  from.GetValue(),
+		to:    to.GetValue(),
+		count: len(subtree),
+	}, nil
+}
+
+// reorder moves the given node right before the given sibling, or right after it when after is set,
+// among the children of the given parent. It must be called with the structural lock held exclusively
+func (x *Tree[T]) reorder(n, parent *treeNode[T], sibling string, after bool) (move[T], error) {
+	before, ok := parent.Descendants.Placement(n.ID, sibling, after)
+	if !ok {
+		return move[T]{}, newNodeError(opMove, n.ID, parent.ID, ErrNotFound)
+	}
+
+	// write the move ahead
+	moved := move[T]{node: n.GetValue(), from: parent.GetValue(), to: parent.GetValue(), count: 1}
+	write, commit := x.feed.begin()
+	if err := write(Event[T]{Type: EventMove, Node: moved.node, Parent: moved.to, Before: before}); err != nil {
+		commit(false)
+		return move[T]{}, newNodeError(opMove, n.ID, parent.ID, err)
+	}
+	defer commit(true)
+
+	parent.Descendants.Place(n, sibling, after)
+	parent.BumpVersion()
+
+	x.log(slog.LevelDebug, "tree node reordered",
+		slog.String("node", n.ID),
+		slog.String("parent", parent.ID))
+	return moved, nil
+}
+
+// checkMoveConstraints checks that the given subtree can be attached under the given parent
+// without violating the depth and children limits of the Tree
+func (x *Tree[T]) checkMoveConstraints(node, parent *treeNode[T], subtree []*treeNode[T]) error {
+	if x.cfg.maxChildren > 0 && parent.Descendants.Len() >= x.cfg.maxChildren {
+		return ErrMaxChildrenExceeded
+	}
+
+	if x.cfg.maxDepth > 0 {
+		height := 0
+		for _, n := range subtree {
+			height = max(height, n.GetPath().Depth()-node.GetPath().Depth())
+		}
+		if parent.GetPath().Depth()+1+height > x.cfg.maxDepth {
+			return ErrMaxDepthExceeded
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMoveTree builds the following Tree:
+//
+//	root
+//	├── a
+//	│   └── a1
+//	│       └── a2
+//	└── b
+func newMoveTree(t *testing.T, opts ...Option) *Tree[string] {
+	tree := NewTree[string](opts...)
+	require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("a1", "a1"), "a"))
+	require.NoError(t, tree.AddByID(NewNode("a2", "a2"), "a1"))
+	require.NoError(t, tree.AddByID(NewNode("b", "b"), "root"))
+	return tree
+}
+
+func TestMove(t *testing.T) {
+	t.Run("moves the subtree", func(t *testing.T) {
+		tree := newMoveTree(t)
+		require.NoError(t, tree.AddWithEdge(NewNode("c", "c"), NewNode("a", ""), EdgeData{Label: "edge"}))
+
+		b, _ := tree.Find("b")
+		c, _ := tree.Find("c")
+		require.NoError(t, tree.Move(c, b))
+		require.NoError(t, tree.MoveByID("a1", "c"))
+
+		assert.Equal(t, "root: root\n├── a: a\n└── b: b\n    └── c: c\n        └── a1: a1\n            └── a2: a2\n", tree.Dump())
+		ancestors, ok := tree.AncestorsByID("a2")
+		require.True(t, ok)
+		assert.Equal(t, []string{"a1", "b", "c", "root"}, queryIDs(ancestors))
+
+		parent, ok := tree.ParentAt(NewNode("a1", ""), 0)
+		require.True(t, ok)
+		assert.Equal(t, "c", parent.ID())
+
+		edge, ok := tree.Edge(c)
+		require.True(t, ok)
+		assert.Zero(t, edge)
+
+		descendants, ok := tree.DescendantsByID("a")
+		require.True(t, ok)
+		assert.Empty(t, descendants)
+		assert.Empty(t, tree.Validate())
+		assert.Equal(t, 4, tree.Depth())
+	})
+
+	t.Run("same parent is a no-op", func(t *testing.T) {
+		tree := newMoveTree(t)
+		moved := 0
+		tree.OnMove(func(_, _, _ Node[string]) { moved++ })
+
+		require.NoError(t, tree.MoveByID("a1", "a"))
+		assert.Zero(t, moved)
+		assert.Empty(t, tree.Validate())
+	})
+
+	t.Run("rejects invalid moves", func(t *testing.T) {
+		tree := newMoveTree(t)
+		testCases := []struct {
+			id, parentID string
+			err          error
+		}{
+			{id: "missing", parentID: "b", err: ErrNotFound},
+			{id: "a", parentID: "missing", err: ErrParentNodeNotFound},
+			{id: "root", parentID: "b", err: ErrInvalidOperation},
+			{id: "a", parentID: "a", err: ErrInvalidOperation},
+			{id: "a", parentID: "a2", err: ErrInvalidOperation},
+		}
+		for _, tc := range testCases {
+			err := tree.MoveByID(tc.id, tc.parentID)
+			require.ErrorIs(t, err, tc.err, tc.id)
+
+			var nodeErr *NodeError
+			require.True(t, errors.As(err, &nodeErr))
+			assert.Equal(t, "move", nodeErr.Op())
+			assert.Equal(t, tc.id, nodeErr.NodeID())
+			assert.Equal(t, tc.parentID, nodeErr.ParentID())
+		}
+		assert.Empty(t, tree.Validate())
+	})
+
+	t.Run("enforces the limits", func(t *testing.T) {
+		tree := newMoveTree(t, WithMaxDepth(3))
+		// a1 and a2 would be at depth 3 and 4
+		require.NoError(t, tree.AddByID(NewNode("b1", "b1"), "b"))
+		require.ErrorIs(t, tree.MoveByID("a1", "b1"), ErrMaxDepthExceeded)
+		require.NoError(t, tree.MoveByID("a2", "b1"))
+
+		tree = newMoveTree(t, WithMaxChildren(2))
+		require.ErrorIs(t, tree.MoveByID("a1", "root"), ErrMaxChildrenExceeded)
+		assert.Empty(t, tree.Validate())
+	})
+
+	t.Run("notifies the hooks and the watchers", func(t *testing.T) {
+		tree := newMoveTree(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := tree.Watch(ctx, nil)
+
+		var moves []string
+		tree.OnMove(func(node, from, to Node[string]) {
+			moves = append(moves, node.ID()+" "+from.ID()+" "+to.ID())
+		})
+
+		require.NoError(t, tree.MoveByID("a1", "b"))
+		assert.Equal(t, []string{"a1 a b"}, moves)
+
+		event := <-events
+		assert.Equal(t, EventMove, event.Type)
+		assert.Equal(t, "move", event.Type.String())
+		assert.Equal(t, "a1", event.Node.ID())
+		assert.Equal(t, "b", event.Parent.ID())
+	})
+
+	t.Run("reports the metrics", func(t *testing.T) {
+		metrics := new(recordingMetrics)
+		tree := newMoveTree(t, WithMetrics(metrics))
+		metrics.observations = nil
+		require.NoError(t, tree.MoveByID("a1", "b"))
+		require.Error(t, tree.MoveByID("root", "b"))
+		require.Len(t, metrics.observations, 2)
+		assert.Equal(t, OperationMove, metrics.observations[0].operation)
+		assert.NoError(t, metrics.observations[0].err)
+		assert.Equal(t, OperationMove, metrics.observations[1].operation)
+		assert.ErrorIs(t, metrics.observations[1].err, ErrInvalidOperation)
+	})
+}
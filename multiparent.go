@@ -0,0 +1,460 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Link attaches the given Node, along with its descendants, under another parent on top of its current one.
+//
+// It requires the Tree to be created with WithMultiParent. The Node keeps its position under its primary
+// parent, the one it has been added or moved under, and is shared with the linked parents, which turns
+// the Tree into a directed acyclic graph: a taxonomy entry can belong to several categories at once.
+// Deleting a shared Node drops a single reference: it is only removed along with its last parent.
+//
+// Parameters:
+//   - node: The Node[T] to link. It must be part of the Tree.
+//   - parent: The Node[T] under which the `node` is linked.
+//
+// Returns:
+// - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The Node was successfully linked, or was already a child of `parent`.
+//   - ErrNotFound: The specified Node does not exist in the Tree.
+//   - ErrParentNodeNotFound: The specified parent Node does not exist in the Tree.
+//   - ErrInvalidOperation: The Tree is not created with WithMultiParent, or the link would create a
+//     cycle, which is the case when the parent is the Node itself or one of its descendants.
+//     ErrCycleDetected is wrapped as well in the latter case.
+//   - ErrNotPersisted: The Storage failed to append the link.
+//
+// The returned errors are *NodeError values carrying the identifiers of the Nodes
+// involved; use errors.Is to match them against the errors above.
+//
+// Notes:
+//   - The traversals (Descendants, Ancestors, Query, ...) follow the primary parents only. Use Parents
+//     and LinkedChildren to walk the links.
+//   - The links are published to the watchers and persisted to the Storage as EventLink and EventUnlink events.
+//
+// Example usage:
+//
+//	tree := NewTree[string](WithMultiParent())
+//	// ...
+//	err := tree.Link(NewNode("tomato", ""), NewNode("vegetables", ""))
+func (x *Tree[T]) Link(node, parent Node[T]) (err error) {
+	id, parentID := node.ID(), parent.ID()
+	if err := x.requireContext(opLink, id, parentID); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opLink, id, parentID); err != nil {
+		return err
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if x.links == nil {
+		return newNodeError(opLink, id, parentID, ErrInvalidOperation)
+	}
+
+	if _, ok := x.getNode(id); !ok {
+		return newNodeError(opLink, id, parentID, ErrNotFound)
+	}
+
+	if _, ok := x.getNode(parentID); !ok {
+		return newNodeError(opLink, id, parentID, ErrParentNodeNotFound)
+	}
+
+	if current, ok := x.parentNode(id); ok && current.ID == parentID {
+		return nil
+	}
+	if slices.Contains(x.links.parentsOf(id), parentID) {
+		return nil
+	}
+
+	// the parent must not be reachable from the Node
+	if x.reaches(parentID, id) {
+		return newNodeError(opLink, id, parentID, errCycle)
+	}
+	return x.link(id, parentID)
+}
+
+// Unlink drops the reference the given parent holds on the given Node.
+//
+// When the parent is a linked parent, the link is removed. When it is the primary parent, the Node
+// is moved under its first linked parent, which becomes its primary parent, or removed along with its
+// descendants when it has no other parent, exactly like Delete.
+//
+// Parameters:
+//   - node: The Node[T] to unlink.
+//   - parent: The parent Node[T] dropping its reference.
+//
+// Returns:
+// - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The reference was successfully dropped.
+//   - ErrNotFound: The specified Node does not exist in the Tree or is not a child of `parent`.
+//   - ErrInvalidOperation: The Tree is not created with WithMultiParent.
+//   - ErrNotPersisted: The Storage failed to append the change.
+//
+// The returned errors are *NodeError values carrying the identifiers of the Nodes
+// involved; use errors.Is to match them against the errors above.
+//
+// Example usage:
+//
+//	err := tree.Unlink(NewNode("tomato", ""), NewNode("fruits", ""))
+func (x *Tree[T]) Unlink(node, parent Node[T]) (err error) {
+	id, parentID := node.ID(), parent.ID()
+	if x.links == nil {
+		return newNodeError(opUnlink, id, parentID, ErrInvalidOperation)
+	}
+
+	if err := x.requireContext(opUnlink, id, parentID); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opUnlink, id, parentID); err != nil {
+		return err
+	}
+
+	if unlinked, err := x.unlink(id, parentID); unlinked || err != nil {
+		return err
+	}
+
+	if current, ok := x.parentNode(id); !ok || current.ID != parentID {
+		return newNodeError(opUnlink, id, parentID, ErrNotFound)
+	}
+	_, err = x.deleteSubtree(id)
+	return err
+}
+
+// Parents returns the parents of the given Node.
+//
+// Parameters:
+//   - node: The Node[T] whose parents are retrieved.
+//
+// Returns:
+//   - parents: The primary parent of the Node followed by its linked parents, in the order they
+//     have been linked. It is empty for the root.
+//   - ok: false when the Node does not exist in the Tree.
+//
+// Example usage:
+//
+//	categories, _ := tree.Parents(NewNode("tomato", ""))
+func (x *Tree[T]) Parents(node Node[T]) (parents []Node[T], ok bool) {
+	if _, ok := x.getNode(node.ID()); !ok {
+		return nil, false
+	}
+
+	if parent, ok := x.parentNode(node.ID()); ok {
+		parents = append(parents, parent.GetValue())
+	}
+
+	for _, id := range x.links.parentsOf(node.ID()) {
+		if parent, ok := x.getNode(id); ok {
+			parents = append(parents, parent.GetValue())
+		}
+	}
+	return parents, true
+}
+
+// LinkedChildren returns the Nodes linked under the given parent with Link, in the order they
+// have been linked. The children the parent holds as primary parent are not included.
+// It returns false when the parent does not exist in the Tree.
+func (x *Tree[T]) LinkedChildren(parent Node[T]) (children []Node[T], ok bool) {
+	if _, ok := x.getNode(parent.ID()); !ok {
+		return nil, false
+	}
+
+	for _, id := range x.links.childrenOf(parent.ID()) {
+		if child, ok := x.getNode(id); ok {
+			children = append(children, child.GetValue())
+		}
+	}
+	return children, true
+}
+
+// link links the Node with the given ID under the given parent once written ahead.
+// It must be called with the structural lock held exclusively
+func (x *Tree[T]) link(id, parentID string) (err error) {
+	node, ok := x.getNode(id)
+	if !ok {
+		return newNodeError(opLink, id, parentID, ErrNotFound)
+	}
+
+	parent, ok := x.getNode(parentID)
+	if !ok {
+		return newNodeError(opLink, id, parentID, ErrParentNodeNotFound)
+	}
+
+	write, commit := x.feed.begin()
+	defer func() {
+		commit(err == nil)
+	}()
+
+	if err := write(Event[T]{Type: EventLink, Node: node.GetValue(), Parent: parent.GetValue()}); err != nil {
+		return newNodeError(opLink, id, parentID, err)
+	}
+	x.links.add(id, parentID)
+	return nil
+}
+
+// unlink removes the link of the Node with the given ID under the given parent once written ahead.
+// It reports whether they were linked
+func (x *Tree[T]) unlink(id, parentID string) (unlinked bool, err error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if !slices.Contains(x.links.parentsOf(id), parentID) {
+		return false, nil
+	}
+
+	node, _ := x.getNode(id)
+	parent, _ := x.getNode(parentID)
+	write, commit := x.feed.begin()
+	defer func() {
+		commit(err == nil)
+	}()
+
+	if err := write(Event[T]{Type: EventUnlink, Node: node.GetValue(), Parent: parent.GetValue()}); err != nil {
+		return false, newNodeError(opUnlink, id, parentID, err)
+	}
+	return x.links.remove(id, parentID), nil
+}
+
+// reaches states whether the Node with the given target ID is the Node with the given ID
+// or one of its ancestors, following both the primary and the linked parents
+func (x *Tree[T]) reaches(id, target string) bool {
+	visited := make(map[string]struct{})
+	pending := []string{id}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if current == target {
+			return true
+		}
+
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		visited[current] = struct{}{}
+
+		if parent, ok := x.parentNode(current); ok {
+			pending = append(pending, parent.ID)
+		}
+		pending = append(pending, x.links.parentsOf(current)...)
+	}
+	return false
+}
+
+// release drops the reference the primary parent holds on the Node with the given ID before it is
+// removed. The Node is moved under its first linked parent when it has one, in which case it is not
+// to be removed, and so are the descendants linked under Nodes outside of the removed subtree.
+// It must be called with the structural lock held exclusively
+func (x *Tree[T]) release(id string) (moves []move[T], released bool, err error) {
+	n, ok := x.getNode(id)
+	if !ok || x.links == nil {
+		return nil, false, nil
+	}
+
+	if parents := x.links.parentsOf(id); len(parents) > 0 {
+		moved, err := x.rehome(id, parents[0])
+		return []move[T]{moved}, true, err
+	}
+
+	subtree := x.collectDescendants(n)
+	removed := make(map[string]struct{}, len(subtree)+1)
+	removed[id] = struct{}{}
+	for _, node := range subtree {
+		removed[node.ID] = struct{}{}
+	}
+
+	// the descendants are in depth-first order: a rehomed Node takes its subtree along
+	rehomed := make(map[string]struct{})
+	for _, node := range subtree {
+		if parent, ok := x.parentNode(node.ID); ok {
+			if _, ok := rehomed[parent.ID]; ok {
+				rehomed[node.ID] = struct{}{}
+				continue
+			}
+		}
+
+		for _, parentID := range x.links.parentsOf(node.ID) {
+			if _, ok := removed[parentID]; ok {
+				continue
+			}
+
+			moved, err := x.rehome(node.ID, parentID)
+			if err != nil {
+				return moves, false, err
+			}
+			moves = append(moves, moved)
+			rehomed[node.ID] = struct{}{}
+			break
+		}
+	}
+	return moves, false, nil
+}
+
+// rehome turns the given linked parent of the Node with the given ID into its primary parent.
+// It must be called with the structural lock held exclusively
+func (x *Tree[T]) rehome(id, parentID string) (move[T], error) {
+	x.links.remove(id, parentID)
+	moved, err := x.move(id, parentID, "", false)
+	if err != nil {
+		x.links.add(id, parentID)
+	}
+	return moved, err
+}
+
+// deleteOrRelease removes the given node and its descendants under the exclusive structural lock,
+// unless the node is shared with other parents, in which case only the reference of its primary
+// parent is dropped. It returns the moves of the shared Nodes and the removed Nodes.
+func (x *Tree[T]) deleteOrRelease(id string) ([]move[T], []mutation[T], error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.releaseOrRemove(id)
+}
+
+// releaseOrRemove removes the given node and its descendants, or drops the reference of its primary
+// parent when it is shared. It must be called with the structural lock held exclusively
+func (x *Tree[T]) releaseOrRemove(id string) ([]move[T], []mutation[T], error) {
+	moves, released, err := x.release(id)
+	if released || err != nil {
+		return moves, nil, err
+	}
+
+	removed, rerr := x.remove(id)
+	if rerr != nil {
+		err = rerr
+	}
+	return moves, removed, err
+}
+
+// sharedLinks holds the links of the Nodes shared with several parents
+type sharedLinks struct {
+	mu sync.RWMutex
+	// parents maps every linked Node ID to the IDs of its linked parents, in the order they have been linked
+	parents map[string][]string
+	// children maps every parent ID to the IDs of the Nodes linked under it, in the order they have been linked
+	children map[string][]string
+}
+
+// newSharedLinks creates an empty sharedLinks
+func newSharedLinks() *sharedLinks {
+	return &sharedLinks{
+		parents:  make(map[string][]string),
+		children: make(map[string][]string),
+	}
+}
+
+// add links the given Node under the given parent
+func (l *sharedLinks) add(id, parentID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !slices.Contains(l.parents[id], parentID) {
+		l.parents[id] = append(l.parents[id], parentID)
+		l.children[parentID] = append(l.children[parentID], id)
+	}
+}
+
+// remove unlinks the given Node from the given parent and reports whether they were linked
+func (l *sharedLinks) remove(id, parentID string) bool {
+	if l == nil {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !slices.Contains(l.parents[id], parentID) {
+		return false
+	}
+	deleteMember(l.parents, id, parentID)
+	deleteMember(l.children, parentID, id)
+	return true
+}
+
+// removeNodes drops all the links of the given Nodes, as children or as parents
+func (l *sharedLinks) removeNodes(ids ...string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, id := range ids {
+		for _, parentID := range l.parents[id] {
+			deleteMember(l.children, parentID, id)
+		}
+		for _, childID := range l.children[id] {
+			deleteMember(l.parents, childID, id)
+		}
+		delete(l.parents, id)
+		delete(l.children, id)
+	}
+}
+
+// clear drops all the links
+func (l *sharedLinks) clear() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	l.parents = make(map[string][]string)
+	l.children = make(map[string][]string)
+	l.mu.Unlock()
+}
+
+// parentsOf returns the linked parents of the given Node
+func (l *sharedLinks) parentsOf(id string) []string {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return slices.Clone(l.parents[id])
+}
+
+// childrenOf returns the Nodes linked under the given parent
+func (l *sharedLinks) childrenOf(id string) []string {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return slices.Clone(l.children[id])
+}
+
+// deleteMember removes the given member from the list stored under the given key, dropping the list once empty
+func deleteMember(lists map[string][]string, key, member string) {
+	members := slices.DeleteFunc(lists[key], func(m string) bool { return m == member })
+	if len(members) == 0 {
+		delete(lists, key)
+		return
+	}
+	lists[key] = members
+}
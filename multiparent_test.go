@@ -0,0 +1,154 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiParent(t *testing.T) {
+	// food
+	// ├── fruits
+	// │   └── tomato
+	// │       └── cherry
+	// └── vegetables
+	newTaxonomy := func(t *testing.T) *Tree[string] {
+		tree := NewTree[string](WithMultiParent())
+		require.NoError(t, tree.AddByID(NewNode("food", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("fruits", ""), "food"))
+		require.NoError(t, tree.AddByID(NewNode("vegetables", ""), "food"))
+		require.NoError(t, tree.AddByID(NewNode("tomato", ""), "fruits"))
+		require.NoError(t, tree.AddByID(NewNode("cherry", ""), "tomato"))
+		return tree
+	}
+
+	ids := func(nodes []Node[string]) []string {
+		ids := make([]string, len(nodes))
+		for i, node := range nodes {
+			ids[i] = node.ID()
+		}
+		return ids
+	}
+
+	t.Run("requires the multi-parent mode", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+		assert.ErrorIs(t, tree.Link(NewNode("a", ""), NewNode("b", "")), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.Unlink(NewNode("a", ""), NewNode("b", "")), ErrInvalidOperation)
+	})
+
+	t.Run("links a node under several parents", func(t *testing.T) {
+		tree := newTaxonomy(t)
+		require.NoError(t, tree.Link(NewNode("tomato", ""), NewNode("vegetables", "")))
+		require.NoError(t, tree.Link(NewNode("tomato", ""), NewNode("vegetables", "")))
+		require.NoError(t, tree.Link(NewNode("tomato", ""), NewNode("fruits", "")))
+
+		parents, ok := tree.Parents(NewNode("tomato", ""))
+		require.True(t, ok)
+		assert.Equal(t, []string{"fruits", "vegetables"}, ids(parents))
+
+		children, ok := tree.LinkedChildren(NewNode("vegetables", ""))
+		require.True(t, ok)
+		assert.Equal(t, []string{"tomato"}, ids(children))
+
+		parents, ok = tree.Parents(NewNode("food", ""))
+		require.True(t, ok)
+		assert.Empty(t, parents)
+	})
+
+	t.Run("rejects cycles", func(t *testing.T) {
+		tree := newTaxonomy(t)
+		require.NoError(t, tree.Link(NewNode("vegetables", ""), NewNode("cherry", "")))
+		assert.ErrorIs(t, tree.Link(NewNode("tomato", ""), NewNode("vegetables", "")), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.Link(NewNode("fruits", ""), NewNode("cherry", "")), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.Link(NewNode("food", ""), NewNode("fruits", "")), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.MoveByID("tomato", "vegetables"), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.Link(NewNode("missing", ""), NewNode("fruits", "")), ErrNotFound)
+		assert.ErrorIs(t, tree.Link(NewNode("tomato", ""), NewNode("missing", "")), ErrParentNodeNotFound)
+	})
+
+	t.Run("counts the references on delete", func(t *testing.T) {
+		tree := newTaxonomy(t)
+		require.NoError(t, tree.Link(NewNode("tomato", ""), NewNode("vegetables", "")))
+
+		// the primary parent drops its reference
+		require.NoError(t, tree.DeleteByID("tomato"))
+		parents, ok := tree.Parents(NewNode("tomato", ""))
+		require.True(t, ok)
+		assert.Equal(t, []string{"vegetables"}, ids(parents))
+		_, ok = tree.Find("cherry")
+		assert.True(t, ok)
+
+		// the last reference removes the node
+		require.NoError(t, tree.DeleteByID("tomato"))
+		_, ok = tree.Find("tomato")
+		assert.False(t, ok)
+		_, ok = tree.Find("cherry")
+		assert.False(t, ok)
+		assert.Empty(t, tree.Validate())
+	})
+
+	t.Run("keeps the descendants shared outside of a deleted subtree", func(t *testing.T) {
+		tree := newTaxonomy(t)
+		require.NoError(t, tree.Link(NewNode("cherry", ""), NewNode("vegetables", "")))
+		require.NoError(t, tree.DeleteByID("fruits"))
+
+		_, ok := tree.Find("tomato")
+		assert.False(t, ok)
+		parent, ok := tree.ParentAt(NewNode("cherry", ""), 0)
+		require.True(t, ok)
+		assert.Equal(t, "vegetables", parent.ID())
+		children, _ := tree.LinkedChildren(NewNode("vegetables", ""))
+		assert.Empty(t, children)
+		assert.Empty(t, tree.Validate())
+	})
+
+	t.Run("unlinks a parent", func(t *testing.T) {
+		tree := newTaxonomy(t)
+		require.NoError(t, tree.Link(NewNode("tomato", ""), NewNode("vegetables", "")))
+		require.NoError(t, tree.Unlink(NewNode("tomato", ""), NewNode("vegetables", "")))
+		parents, _ := tree.Parents(NewNode("tomato", ""))
+		assert.Equal(t, []string{"fruits"}, ids(parents))
+
+		assert.ErrorIs(t, tree.Unlink(NewNode("tomato", ""), NewNode("vegetables", "")), ErrNotFound)
+
+		require.NoError(t, tree.Unlink(NewNode("tomato", ""), NewNode("fruits", "")))
+		_, ok := tree.Find("tomato")
+		assert.False(t, ok)
+	})
+
+	t.Run("drops the link when moving under a linked parent", func(t *testing.T) {
+		tree := newTaxonomy(t)
+		require.NoError(t, tree.Link(NewNode("tomato", ""), NewNode("vegetables", "")))
+		require.NoError(t, tree.MoveByID("tomato", "vegetables"))
+		parents, _ := tree.Parents(NewNode("tomato", ""))
+		assert.Equal(t, []string{"vegetables"}, ids(parents))
+	})
+}
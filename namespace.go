@@ -0,0 +1,364 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+package gotree
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NamespaceSeparator separates the identifier of the root of a Namespace from the identifiers
+// local to the Namespace in the identifiers held by the Tree
+const NamespaceSeparator = ":"
+
+// Namespace is a handle scoped to a subtree of a Tree.
+//
+// The operations of a Namespace only see and touch the Nodes of its subtree: the Nodes outside of it
+// are reported as missing, hence a tenant given a Namespace can neither read nor modify the Nodes of
+// the other tenants, nor move its own Nodes out of its subtree. The Node anchoring the Namespace is
+// its root: it can be read and updated but neither deleted nor moved through the Namespace.
+//
+// The identifiers are local to the Namespace: the Tree holds the Nodes of the Namespace under their
+// qualified identifier, the name of the Namespace and the local identifier joined by NamespaceSeparator,
+// so that the tenants pick their identifiers independently. The Nodes are returned with their local
+// identifier, the root keeping its own. The hooks, the watchers and the Storage of the Tree observe
+// the changes made through the Namespace like any other, with the qualified identifiers. A Namespace
+// is safe for concurrent use.
+type Namespace[T any] struct {
+	tree *Tree[T]
+	name string
+}
+
+// Namespace returns the Namespace scoped to the subtree of the Node with the given ID.
+//
+// Parameters:
+//   - name: The identifier of the Node anchoring the Namespace.
+//
+// Returns:
+//   - namespace: The Namespace scoped to the subtree of the Node.
+//   - err: nil on success, ErrNotFound when the Node does not exist in the Tree.
+//
+// Notes:
+//   - The Namespace follows its root when it is moved within the Tree, and its operations fail once
+//     its root is deleted.
+//   - The scope is checked under the structural lock of the Tree along with the changes, hence a Node
+//     moved out of the Namespace concurrently is never changed through it.
+//   - The Nodes added under the Namespace through the Tree are only visible to the Namespace when they
+//     are given a qualified identifier (see QualifiedID).
+//
+// Example usage:
+//
+//	_ = tree.Add(NewNode("tenantA", "Tenant A"), tree.Root())
+//	tenant, err := tree.Namespace("tenantA")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// added under the root of the namespace, held by the tree as "tenantA:invoices"
+//	_ = tenant.Add(NewNode("invoices", "Invoices"), nil)
+//	_, ok := tenant.Find("tenantB") // false
+func (x *Tree[T]) Namespace(name string) (namespace *Namespace[T], err error) {
+	if _, ok := x.getNode(name); !ok {
+		return nil, newNodeError(opFind, name, "", ErrNotFound)
+	}
+	return &Namespace[T]{tree: x, name: name}, nil
+}
+
+// Name returns the identifier of the root of the Namespace
+func (n *Namespace[T]) Name() string {
+	return n.name
+}
+
+// QualifiedID returns the identifier the Tree holds the Node with the given local identifier under.
+// The identifier of the root of the Namespace is returned as is.
+func (n *Namespace[T]) QualifiedID(id string) string {
+	if id == n.name {
+		return id
+	}
+	return n.name + NamespaceSeparator + id
+}
+
+// Root returns the root of the Namespace. It returns false once the root has been deleted.
+func (n *Namespace[T]) Root() (root Node[T], ok bool) {
+	return n.tree.Find(n.name)
+}
+
+// Contains states whether the Node with the given local ID is part of the Namespace
+func (n *Namespace[T]) Contains(id string) bool {
+	_, ok := n.find(n.QualifiedID(id))
+	return ok
+}
+
+// Find behaves like Tree.Find within the Namespace
+func (n *Namespace[T]) Find(id string) (item Node[T], ok bool) {
+	node, ok := n.find(n.QualifiedID(id))
+	if !ok {
+		return nil, false
+	}
+	return n.local(node.GetValue()), true
+}
+
+// Size returns the number of Nodes of the Namespace, its root included
+func (n *Namespace[T]) Size() int64 {
+	descendants, ok := n.tree.DescendantsByID(n.name)
+	if !ok {
+		return 0
+	}
+	return int64(len(descendants)) + 1
+}
+
+// Nodes returns the Nodes of the Namespace, its root first
+func (n *Namespace[T]) Nodes() []Node[T] {
+	root, ok := n.tree.Find(n.name)
+	if !ok {
+		return nil
+	}
+	descendants, _ := n.tree.DescendantsByID(n.name)
+	return append([]Node[T]{root}, n.locals(descendants)...)
+}
+
+// Add behaves like Tree.Add within the Namespace. A nil parent adds the Node under the root of the
+// Namespace, and a parent outside of the Namespace is reported as ErrParentNodeNotFound. A local
+// identifier holding NamespaceSeparator is rejected with ErrInvalidID.
+func (n *Namespace[T]) Add(node, parent Node[T]) (err error) {
+	x := n.tree
+	id, parentID := n.QualifiedID(node.ID()), n.name
+	if parent != nil {
+		parentID = n.QualifiedID(parent.ID())
+	}
+	if strings.Contains(node.ID(), NamespaceSeparator) {
+		return newNodeError(opAdd, id, parentID, fmt.Errorf("%w: %q holds the namespace separator", ErrInvalidID, node.ID()))
+	}
+	if err := x.requireContext(opAdd, id, parentID); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opAdd, id, parentID); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationAdd, time.Now(), &err)
+	}
+
+	// the parent cannot leave the Namespace while the structural lock is held
+	qualified := NewNode(id, node.Value())
+	x.mu.RLock()
+	parentNode, ok := n.find(parentID)
+	if ok {
+		_, err = x.insert(qualified, parentNode, nil, -1)
+	} else {
+		err = newNodeError(opAdd, id, parentID, ErrParentNodeNotFound)
+	}
+	x.mu.RUnlock()
+
+	if err == nil {
+		x.notifyAdd(qualified, parentNode.GetValue())
+	}
+	return err
+}
+
+// Update behaves like Tree.Update within the Namespace
+func (n *Namespace[T]) Update(node Node[T]) (err error) {
+	x := n.tree
+	id := n.QualifiedID(node.ID())
+	if err := x.requireContext(opUpdate, id, ""); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opUpdate, id, ""); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationUpdate, time.Now(), &err)
+	}
+
+	// the Node cannot leave the Namespace while the structural lock is held
+	qualified := NewNode(id, node.Value())
+	var parent Node[T]
+	x.mu.RLock()
+	existing, ok := n.find(id)
+	if ok {
+		parent, err = x.replaceValue(existing, qualified, nil)
+	} else {
+		err = newNodeError(opUpdate, id, "", ErrNotFound)
+	}
+	x.mu.RUnlock()
+
+	if err == nil {
+		x.notifyUpdate(qualified, parent)
+	}
+	return err
+}
+
+// Delete behaves like Tree.Delete within the Namespace.
+// It returns ErrInvalidOperation for the root of the Namespace.
+func (n *Namespace[T]) Delete(node Node[T]) (err error) {
+	x := n.tree
+	id := n.QualifiedID(node.ID())
+	if err := x.requireContext(opDelete, id, ""); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opDelete, id, ""); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationDelete, time.Now(), &err)
+	}
+
+	end := x.trace(OperationDelete)
+	var (
+		moves   []move[T]
+		removed []mutation[T]
+	)
+	x.mu.Lock()
+	if err = n.checkInner(opDelete, id, ""); err == nil {
+		moves, removed, err = x.releaseOrRemove(id)
+	}
+	x.mu.Unlock()
+	end(len(removed), err)
+
+	for _, moved := range moves {
+		x.notifyMove(moved.node, moved.from, moved.to)
+	}
+	x.notifyDelete(removed)
+	return err
+}
+
+// Move behaves like Tree.Move within the Namespace. A parent outside of the Namespace is reported
+// as ErrParentNodeNotFound and moving the root of the Namespace returns ErrInvalidOperation.
+func (n *Namespace[T]) Move(node, parent Node[T]) (err error) {
+	x := n.tree
+	id, parentID := n.QualifiedID(node.ID()), n.QualifiedID(parent.ID())
+	if err := x.requireContext(opMove, id, parentID); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opMove, id, parentID); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationMove, time.Now(), &err)
+	}
+
+	end := x.trace(OperationMove)
+	var moved move[T]
+	x.mu.Lock()
+	if err = n.checkInner(opMove, id, parentID); err == nil {
+		if _, ok := n.find(parentID); ok {
+			moved, err = x.move(id, parentID, "", false)
+		} else {
+			err = newNodeError(opMove, id, parentID, ErrParentNodeNotFound)
+		}
+	}
+	x.mu.Unlock()
+	end(moved.count, err)
+
+	if err == nil && moved.count > 0 {
+		x.notifyMove(moved.node, moved.from, moved.to)
+	}
+	return err
+}
+
+// Ancestors behaves like Tree.AncestorsOrdered with NearestFirst within the Namespace: the ancestors
+// are listed from the parent of the Node up to the root of the Namespace, the ancestors above it being
+// left out.
+func (n *Namespace[T]) Ancestors(node Node[T]) (ancestors []Node[T], ok bool) {
+	id := n.QualifiedID(node.ID())
+	existing, ok := n.find(id)
+	if !ok {
+		return nil, false
+	}
+
+	if id == n.name {
+		return nil, true
+	}
+
+	ok = n.tree.WalkUp(existing.GetValue(), func(ancestor Node[T]) bool {
+		ancestors = append(ancestors, ancestor)
+		return ancestor.ID() != n.name
+	})
+	if !ok {
+		return nil, false
+	}
+	return n.locals(ancestors), true
+}
+
+// Descendants behaves like Tree.Descendants within the Namespace
+func (n *Namespace[T]) Descendants(node Node[T]) (descendants []Node[T], ok bool) {
+	id := n.QualifiedID(node.ID())
+	if _, ok := n.find(id); !ok {
+		return nil, false
+	}
+	descendants, ok = n.tree.DescendantsByID(id)
+	return n.locals(descendants), ok
+}
+
+// find returns the treeNode of the given qualified ID when it is part of the Namespace
+func (n *Namespace[T]) find(id string) (*treeNode[T], bool) {
+	node, ok := n.tree.getNode(id)
+	if !ok {
+		return nil, false
+	}
+	root, ok := n.tree.getNode(n.name)
+	if !ok {
+		return nil, false
+	}
+
+	chain, depth := node.GetPath(), root.GetPath().Depth()
+	if chain.Depth() < depth {
+		return nil, false
+	}
+	return node, chain.atDepth(depth).id == n.name
+}
+
+// checkInner ensures that the Node with the given qualified ID is part of the Namespace without
+// being its root
+func (n *Namespace[T]) checkInner(op, id, parentID string) error {
+	if id == n.name {
+		return newNodeError(op, id, parentID, ErrInvalidOperation)
+	}
+	if _, ok := n.find(id); !ok {
+		return newNodeError(op, id, parentID, ErrNotFound)
+	}
+	return nil
+}
+
+// local returns the given Node of the Namespace with its local identifier
+func (n *Namespace[T]) local(node Node[T]) Node[T] {
+	id, ok := strings.CutPrefix(node.ID(), n.name+NamespaceSeparator)
+	if !ok {
+		return node
+	}
+	return NewNode(id, node.Value())
+}
+
+// locals returns the given Nodes of the Namespace with their local identifier
+func (n *Namespace[T]) locals(nodes []Node[T]) []Node[T] {
+	for i, node := range nodes {
+		nodes[i] = n.local(node)
+	}
+	return nodes
+}
@@ -0,0 +1,199 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespace(t *testing.T) {
+	newTenantTree := func(t *testing.T) *Tree[string] {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("tenants", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("tenantA", ""), "tenants"))
+		require.NoError(t, tree.AddByID(NewNode("tenantB", ""), "tenants"))
+		require.NoError(t, tree.AddByID(NewNode("tenantA:a1", "a1"), "tenantA"))
+		require.NoError(t, tree.AddByID(NewNode("tenantB:b1", "b1"), "tenantB"))
+		return tree
+	}
+
+	t.Run("requires an existing root", func(t *testing.T) {
+		_, err := newTenantTree(t).Namespace("tenantC")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("sees its subtree only", func(t *testing.T) {
+		tree := newTenantTree(t)
+		tenant, err := tree.Namespace("tenantA")
+		require.NoError(t, err)
+		assert.Equal(t, "tenantA", tenant.Name())
+
+		_, ok := tenant.Find("a1")
+		assert.True(t, ok)
+		for _, id := range []string{"b1", "tenantB", "tenants", "root", "missing"} {
+			_, ok := tenant.Find(id)
+			assert.False(t, ok, id)
+		}
+
+		require.NoError(t, tenant.Add(NewNode("a2", "a2"), nil))
+		require.NoError(t, tenant.Add(NewNode("a21", "a21"), NewNode("a2", "")))
+		assert.EqualValues(t, 4, tenant.Size())
+		assert.Equal(t, []string{"tenantA", "a1", "a2", "a21"}, queryIDs(tenant.Nodes()))
+
+		ancestors, ok := tenant.Ancestors(NewNode("a21", ""))
+		require.True(t, ok)
+		assert.Equal(t, []string{"a2", "tenantA"}, queryIDs(ancestors))
+		require.NoError(t, tenant.Add(NewNode("a211", "a211"), NewNode("a21", "")))
+		ancestors, ok = tenant.Ancestors(NewNode("a211", ""))
+		require.True(t, ok)
+		assert.Equal(t, []string{"a21", "a2", "tenantA"}, queryIDs(ancestors))
+		require.NoError(t, tenant.Delete(NewNode("a211", "")))
+		ancestors, ok = tenant.Ancestors(NewNode("tenantA", ""))
+		require.True(t, ok)
+		assert.Empty(t, ancestors)
+		_, ok = tenant.Ancestors(NewNode("b1", ""))
+		assert.False(t, ok)
+
+		descendants, ok := tenant.Descendants(NewNode("a2", ""))
+		require.True(t, ok)
+		assert.Equal(t, []string{"a21"}, queryIDs(descendants))
+		_, ok = tenant.Descendants(NewNode("tenants", ""))
+		assert.False(t, ok)
+	})
+
+	t.Run("cannot touch the other nodes", func(t *testing.T) {
+		tree := newTenantTree(t)
+		tenant, err := tree.Namespace("tenantA")
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, tenant.Add(NewNode("x", ""), NewNode("tenantB", "")), ErrParentNodeNotFound)
+		assert.ErrorIs(t, tenant.Update(NewNode("b1", "changed")), ErrNotFound)
+		assert.ErrorIs(t, tenant.Delete(NewNode("b1", "")), ErrNotFound)
+		assert.ErrorIs(t, tenant.Move(NewNode("b1", ""), NewNode("a1", "")), ErrNotFound)
+		assert.ErrorIs(t, tenant.Move(NewNode("a1", ""), NewNode("tenantB", "")), ErrParentNodeNotFound)
+		assert.ErrorIs(t, tenant.Delete(NewNode("tenantA", "")), ErrInvalidOperation)
+		assert.ErrorIs(t, tenant.Move(NewNode("tenantA", ""), NewNode("a1", "")), ErrInvalidOperation)
+
+		b1, _ := tree.Find("tenantB:b1")
+		assert.Equal(t, "b1", b1.Value())
+		assert.EqualValues(t, 6, tree.Size())
+
+		require.NoError(t, tenant.Update(NewNode("a1", "changed")))
+		require.NoError(t, tenant.Add(NewNode("a2", ""), nil))
+		require.NoError(t, tenant.Move(NewNode("a1", ""), NewNode("a2", "")))
+		require.NoError(t, tenant.Delete(NewNode("a2", "")))
+		assert.EqualValues(t, 1, tenant.Size())
+	})
+
+	t.Run("scopes the identifiers", func(t *testing.T) {
+		tree := newTenantTree(t)
+		tenantA, err := tree.Namespace("tenantA")
+		require.NoError(t, err)
+		tenantB, err := tree.Namespace("tenantB")
+		require.NoError(t, err)
+
+		// both tenants pick the same identifier
+		require.NoError(t, tenantA.Add(NewNode("a2", "A"), nil))
+		require.NoError(t, tenantB.Add(NewNode("a2", "B"), nil))
+		assert.ErrorIs(t, tenantA.Add(NewNode("a2", ""), nil), ErrDuplicateID)
+		assert.ErrorIs(t, tenantA.Add(NewNode("tenantB:b2", ""), nil), ErrInvalidID)
+
+		a2, ok := tenantA.Find("a2")
+		require.True(t, ok)
+		assert.Equal(t, "a2", a2.ID())
+		assert.Equal(t, "A", a2.Value())
+		b2, ok := tree.Find(tenantB.QualifiedID("a2"))
+		require.True(t, ok)
+		assert.Equal(t, "tenantB:a2", b2.ID())
+		assert.Equal(t, "B", b2.Value())
+
+		require.NoError(t, tenantA.Update(NewNode("a2", "A2")))
+		require.NoError(t, tenantB.Delete(NewNode("a2", "")))
+		a2, _ = tenantA.Find("a2")
+		assert.Equal(t, "A2", a2.Value())
+		assert.EqualValues(t, 3, tenantA.Size())
+		assert.EqualValues(t, 2, tenantB.Size())
+	})
+
+	t.Run("checks the scope along with the change", func(t *testing.T) {
+		tree := NewTree[string](WithChangeFeed(1_000))
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("tenantA", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("tenantB", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("tenantA:a1", ""), "tenantA"))
+		tenant, err := tree.Namespace("tenantA")
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_ = tenant.Add(NewNode(fmt.Sprintf("a1-%d", i), ""), NewNode("a1", ""))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_ = tree.MoveByID("tenantA:a1", []string{"tenantB", "tenantA"}[i%2])
+			}
+		}()
+		wg.Wait()
+
+		// no addition has been made while the parent was out of the namespace
+		events, err := tree.ChangesSince(0)
+		require.NoError(t, err)
+		outside := false
+		for _, event := range events {
+			switch {
+			case event.Type == EventMove && event.Node.ID() == "tenantA:a1":
+				outside = event.Parent.ID() == "tenantB"
+			case event.Type == EventAdd && event.Parent != nil && event.Parent.ID() == "tenantA:a1":
+				assert.False(t, outside, event.Node.ID())
+			}
+		}
+	})
+
+	t.Run("follows its root", func(t *testing.T) {
+		tree := newTenantTree(t)
+		tenant, err := tree.Namespace("tenantA")
+		require.NoError(t, err)
+
+		require.NoError(t, tree.MoveByID("tenantA", "root"))
+		assert.True(t, tenant.Contains("a1"))
+
+		require.NoError(t, tree.DeleteByID("tenantA"))
+		_, ok := tenant.Root()
+		assert.False(t, ok)
+		assert.Zero(t, tenant.Size())
+		assert.ErrorIs(t, tenant.Add(NewNode("a2", ""), nil), ErrParentNodeNotFound)
+	})
+}
@@ -76,3 +76,47 @@ type Node[T any] interface {
 	// The type of the value is defined by the generic type T.
 	Value() T
 }
+
+// basicNode is the default implementation of the Node interface
+type basicNode[T any] struct {
+	id    string
+	value T
+}
+
+// enforce compilation error
+var _ Node[any] = (*basicNode[any])(nil)
+
+// NewNode creates a Node with the given unique identifier and value.
+//
+// It is the default implementation of the Node interface, sparing consumers
+// from writing their own type when the Node only needs to carry a value.
+//
+// Parameters:
+//   - id: The unique identifier of the Node within the Tree.
+//   - value: The value held by the Node.
+//
+// Returns:
+//   - Node[T]: The created Node.
+//
+// Example usage:
+//
+//	tree := NewTree[string]()
+//	root := NewNode("root", "Root Node")
+//	_ = tree.Add(root, nil)
+//	_ = tree.Add(NewNode("child", "Child Node"), root)
+func NewNode[T any](id string, value T) Node[T] {
+	return &basicNode[T]{
+		id:    id,
+		value: value,
+	}
+}
+
+// ID returns the unique identifier of the Node
+func (n *basicNode[T]) ID() string {
+	return n.id
+}
+
+// Value returns the value held by the Node
+func (n *basicNode[T]) Value() T {
+	return n.value
+}
@@ -0,0 +1,178 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"time"
+)
+
+// NodeRef is an opaque handle on a Node of the Tree.
+//
+// A NodeRef is bound to the internal storage of the Node, hence the operations taking a NodeRef skip the
+// identifier lookups the Node based methods go through, which pays off in hot loops operating on the
+// same Nodes again and again. A NodeRef becomes stale once its Node is removed from the Tree, even if
+// a Node with the same ID is added later. The zero value is a stale NodeRef.
+type NodeRef[T any] struct {
+	tree       *Tree[T]
+	node       *treeNode[T]
+	id         string
+	generation uint64
+}
+
+// newNodeRef creates a NodeRef bound to the given treeNode
+func newNodeRef[T any](tree *Tree[T], node *treeNode[T]) NodeRef[T] {
+	return NodeRef[T]{
+		tree:       tree,
+		node:       node,
+		id:         node.ID,
+		generation: node.Generation.Load(),
+	}
+}
+
+// ID returns the identifier of the referenced Node
+func (r NodeRef[T]) ID() string {
+	return r.id
+}
+
+// Valid reports whether the referenced Node is still part of the Tree
+func (r NodeRef[T]) Valid() bool {
+	return r.node != nil && r.node.Generation.Load() == r.generation
+}
+
+// Node returns the current value of the referenced Node, or false when the NodeRef is stale
+func (r NodeRef[T]) Node() (node Node[T], ok bool) {
+	if !r.Valid() {
+		return nil, false
+	}
+	node = r.node.GetValue()
+	// the treeNode may have been recycled while reading it
+	if !r.Valid() {
+		return nil, false
+	}
+	return node, true
+}
+
+// Ref returns a NodeRef on the Node with the given ID.
+//
+// Parameters:
+//   - id: The identifier of the Node to reference.
+//
+// Returns:
+//   - ref: The NodeRef[T] bound to the Node.
+//   - ok: A boolean indicating whether the Node exists in the Tree.
+//
+// Notes:
+//   - The lookup is paid once: the NodeRef is then handed to AddAt, ChildrenOf or NodeRef.Node.
+//   - The NodeRef stays valid while the Node is moved or updated, and goes stale when it is removed.
+//
+// Example usage:
+//
+//	ref, ok := tree.Ref("parentID")
+//	for i := range 1000 {
+//	    if _, err := tree.AddAt(ref, NewNode(fmt.Sprintf("child%d", i), "child")); err != nil {
+//	        return err
+//	    }
+//	}
+func (x *Tree[T]) Ref(id string) (ref NodeRef[T], ok bool) {
+	node, ok := x.getNode(id)
+	if !ok {
+		return NodeRef[T]{}, false
+	}
+	return newNodeRef(x, node), true
+}
+
+// AddAt behaves like Add, the parent Node being given as a NodeRef.
+// It returns the NodeRef of the added Node, which can be used as the parent of the next additions.
+//
+// ErrParentNodeNotFound is returned when the NodeRef is stale or belongs to another Tree.
+//
+// Example usage:
+//
+//	parent, _ := tree.Ref("rootID")
+//	child, err := tree.AddAt(parent, NewNode("childID", "child"))
+//	if err == nil {
+//	    _, err = tree.AddAt(child, NewNode("grandchildID", "grandchild"))
+//	}
+func (x *Tree[T]) AddAt(ref NodeRef[T], node Node[T]) (child NodeRef[T], err error) {
+	if err := x.requireContext(opAdd, node.ID(), ref.ID()); err != nil {
+		return NodeRef[T]{}, err
+	}
+	if err := x.throttle(context.Background(), opAdd, node.ID(), ref.ID()); err != nil {
+		return NodeRef[T]{}, err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationAdd, time.Now(), &err)
+	}
+
+	added, parent, err := x.addAt(ref, node)
+	if err == nil {
+		x.notifyAdd(node, parent)
+		child = newNodeRef(x, added)
+	}
+	return child, err
+}
+
+// addAt adds the given node under the referenced parent under the shared structural lock
+func (x *Tree[T]) addAt(ref NodeRef[T], node Node[T]) (added *treeNode[T], parent Node[T], err error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	// removals hold the exclusive lock, hence a valid reference stays valid until unlocking
+	if ref.tree != x || !ref.Valid() {
+		err = newNodeError(opAdd, node.ID(), ref.id, ErrParentNodeNotFound)
+		x.logRejected(err)
+		return nil, nil, err
+	}
+
+	added, err = x.insert(node, ref.node, nil, -1)
+	return added, ref.node.GetValue(), err
+}
+
+// ChildrenOf returns the direct children of the referenced Node in their order.
+// It returns false when the NodeRef is stale or belongs to another Tree.
+//
+// Example usage:
+//
+//	ref, _ := tree.Ref("parentID")
+//	children, ok := tree.ChildrenOf(ref)
+func (x *Tree[T]) ChildrenOf(ref NodeRef[T]) (children []Node[T], ok bool) {
+	if ref.tree != x || !ref.Valid() {
+		return nil, false
+	}
+
+	items := ref.node.Descendants.Items()
+	children = make([]Node[T], 0, len(items))
+	for _, child := range items {
+		children = append(children, child.GetValue())
+	}
+
+	// the treeNode may have been recycled while reading it
+	if !ref.Valid() {
+		return nil, false
+	}
+	return children, true
+}
@@ -0,0 +1,119 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeRef(t *testing.T) {
+	t.Run("adds and lists the children through the handles", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+
+		root, ok := tree.Ref("root")
+		require.True(t, ok)
+		assert.Equal(t, "root", root.ID())
+		assert.True(t, root.Valid())
+
+		var added []Node[string]
+		tree.OnAdd(func(node, _ Node[string]) { added = append(added, node) })
+
+		child, err := tree.AddAt(root, NewNode("a", "a"))
+		require.NoError(t, err)
+		for i := range 3 {
+			_, err := tree.AddAt(child, NewNode("a"+strconv.Itoa(i), "leaf"))
+			require.NoError(t, err)
+		}
+		assert.Len(t, added, 4)
+
+		children, ok := tree.ChildrenOf(child)
+		require.True(t, ok)
+		assert.Equal(t, []string{"a0", "a1", "a2"}, queryIDs(children))
+
+		ancestors, ok := tree.AncestorsByID("a2")
+		require.True(t, ok)
+		assert.Equal(t, []string{"a", "root"}, queryIDs(ancestors))
+
+		_, err = tree.AddAt(root, NewNode("a", "a"))
+		assert.ErrorIs(t, err, ErrDuplicateID)
+	})
+
+	t.Run("follows the updates and the moves", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "b"), "root"))
+
+		ref, ok := tree.Ref("b")
+		require.True(t, ok)
+		require.NoError(t, tree.Update(NewNode("b", "updated")))
+		require.NoError(t, tree.MoveByID("b", "a"))
+
+		node, ok := ref.Node()
+		require.True(t, ok)
+		assert.Equal(t, "updated", node.Value())
+	})
+
+	t.Run("goes stale once the node is removed", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+
+		ref, ok := tree.Ref("a")
+		require.True(t, ok)
+		require.NoError(t, tree.DeleteByID("a"))
+		require.NoError(t, tree.AddByID(NewNode("a", "again"), "root"))
+
+		assert.False(t, ref.Valid())
+		_, ok = ref.Node()
+		assert.False(t, ok)
+		_, ok = tree.ChildrenOf(ref)
+		assert.False(t, ok)
+		_, err := tree.AddAt(ref, NewNode("child", "child"))
+		assert.ErrorIs(t, err, ErrParentNodeNotFound)
+
+		_, ok = tree.Ref("missing")
+		assert.False(t, ok)
+		assert.False(t, NodeRef[string]{}.Valid())
+	})
+
+	t.Run("rejects the handles of another tree", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+		other := NewTree[string]()
+		require.NoError(t, other.AddByID(NewNode("root", "root"), ""))
+
+		ref, _ := other.Ref("root")
+		_, err := tree.AddAt(ref, NewNode("child", "child"))
+		assert.ErrorIs(t, err, ErrParentNodeNotFound)
+		_, ok := tree.ChildrenOf(ref)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,528 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Mode defines how the Tree reacts to internal inconsistencies
+type Mode int
+
+const (
+	// LenientMode makes the Tree operate on a best-effort basis: internal
+	// inconsistencies (e.g. an ancestor that is no longer part of the Tree)
+	// are skipped silently. This is the default mode, suited to production.
+	LenientMode Mode = iota
+	// StrictMode makes the Tree panic as soon as an internal inconsistency is
+	// detected, with an error wrapping ErrInvariantViolation that describes it.
+	// This surfaces bugs early and is suited to tests.
+	StrictMode
+)
+
+// Option defines a configuration option that can be applied
+// to a Tree at creation time.
+type Option interface {
+	// Apply sets the Option value of a config.
+	Apply(cfg *config)
+}
+
+var _ Option = OptionFunc(nil)
+
+// OptionFunc implements the Option interface.
+type OptionFunc func(cfg *config)
+
+// Apply applies the Option to the given config
+func (f OptionFunc) Apply(cfg *config) {
+	f(cfg)
+}
+
+// config holds the Tree settings
+type config struct {
+	// expectedSize is the number of nodes the Tree is expected to hold.
+	// It is used to pre-size the internal maps
+	expectedSize uint64
+	// readCacheSize is the number of slots of the read cache.
+	// The read cache is disabled when zero
+	readCacheSize int
+	// maxDepth is the maximum depth of a Node, the root being at depth zero.
+	// The depth is unbounded when zero
+	maxDepth int
+	// maxChildren is the maximum number of children of a Node.
+	// The number of children is unbounded when zero
+	maxChildren int
+	// maxSize is the maximum number of Nodes of the Tree.
+	// The size is unbounded when zero
+	maxSize int64
+	// idValidator validates the Node identifiers on Add
+	idValidator func(id string) error
+	// mode defines how the Tree reacts to internal inconsistencies
+	mode Mode
+	// pathSeparator separates the segments of the Node paths
+	pathSeparator string
+	// pathEscape escapes a Node ID rendered as a path segment
+	pathEscape func(id string) string
+	// pathUnescape reverts pathEscape
+	pathUnescape func(segment string) (string, error)
+	// changeFeedSize is the number of change events retained for ChangesSince
+	changeFeedSize int
+	// metrics receives the measurements of the operations
+	metrics Metrics
+	// tracer starts the spans of the expensive operations
+	tracer Tracer
+	// logger emits the records of the notable events
+	logger *slog.Logger
+	// storage is the Storage[T] persisting the Tree. It is checked against
+	// the type of the values when the Tree is created
+	storage any
+	// migrations upgrade the values of the serialized Trees written with an older schema
+	migrations *Migrations
+	// ancestorIndex states whether the ancestor chains hold jump pointers
+	ancestorIndex bool
+	// multiParent states whether the Nodes can be linked under several parents
+	multiParent bool
+	// aggregates holds the functions extracting the values of the aggregates by name.
+	// They are typed as any since the config is not bound to the type of the values
+	aggregates map[string]any
+	// authorizer is the function consulted by the context-aware methods, typed as any for the same reason
+	authorizer any
+	// history states whether the values of the Nodes are recorded over time
+	history bool
+	// historyLimit is the maximum number of values recorded per Node, unlimited when not positive
+	historyLimit int
+	// childResolver is the function materializing the children on demand, typed as any for the same reason
+	childResolver any
+	// limiter throttles the mutations. They are not throttled when nil
+	limiter MutationLimiter
+}
+
+// newConfig creates a config with the default settings
+// and applies the given options
+func newConfig(opts ...Option) *config {
+	cfg := &config{
+		pathSeparator: DefaultPathSeparator,
+	}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+
+	if cfg.pathEscape == nil || cfg.pathUnescape == nil {
+		cfg.pathEscape, cfg.pathUnescape = percentEscaper(cfg.pathSeparator)
+	}
+	return cfg
+}
+
+// WithExpectedSize hints the Tree about the number of Nodes it is expected to hold.
+//
+// The hint is used to pre-size the internal sharded maps so that bulk ingestion
+// does not pay for repeated map growth. The Tree can still grow beyond the given size.
+func WithExpectedSize(size uint64) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.expectedSize = size
+	})
+}
+
+// WithReadCache enables a small lock-free cache of recently resolved Nodes
+// in front of the internal sharded map.
+//
+// It benefits workloads that repeatedly resolve the same hot Nodes, for instance
+// during traversals. The size is rounded up to the next power of two. Any deletion
+// invalidates the whole cache, hence it is best suited to read-heavy Trees.
+func WithReadCache(size int) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.readCacheSize = size
+	})
+}
+
+// WithMaxDepth limits the depth of the Tree. The root Node is at depth zero,
+// its children at depth one and so on.
+//
+// Adding a Node beyond the given depth fails with ErrMaxDepthExceeded.
+// The depth is unbounded when zero.
+func WithMaxDepth(depth int) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.maxDepth = depth
+	})
+}
+
+// WithMaxChildren limits the number of direct children of every Node of the Tree.
+//
+// Adding a Node under a parent that already holds the given number of children fails
+// with ErrMaxChildrenExceeded. The number of children is unbounded when zero.
+func WithMaxChildren(children int) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.maxChildren = children
+	})
+}
+
+// WithMaxSize limits the total number of Nodes of the Tree.
+//
+// Adding a Node to a Tree that already holds the given number of Nodes fails
+// with ErrMaxSizeExceeded. The size is unbounded when zero.
+func WithMaxSize(size int64) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.maxSize = size
+	})
+}
+
+// WithIDValidator sets the function used to validate the identifier of every Node added to the Tree.
+//
+// Add rejects the Node up front with an error wrapping both ErrInvalidID and the error returned by
+// the validator. This prevents malformed identifiers (e.g. empty strings or characters reserved by
+// path rendering) from entering the Tree.
+func WithIDValidator(validator func(id string) error) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.idValidator = validator
+	})
+}
+
+// WithMode sets the operating mode of the Tree. It defaults to LenientMode.
+//
+// In both modes, operations on unknown Nodes return a *NodeError describing the Nodes
+// involved. In StrictMode, any internal inconsistency detected while operating on the Tree
+// panics early instead of being skipped.
+func WithMode(mode Mode) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.mode = mode
+	})
+}
+
+// WithPathSeparator sets the separator of the Node paths rendered by PathOf
+// and resolved by FindByPath. It defaults to DefaultPathSeparator. An empty
+// separator is ignored.
+func WithPathSeparator(separator string) Option {
+	return OptionFunc(func(cfg *config) {
+		if separator != "" {
+			cfg.pathSeparator = separator
+		}
+	})
+}
+
+// WithPathEscaping sets the functions escaping the Node IDs rendered as path segments
+// and reverting the escaping when resolving paths.
+//
+// The escaped IDs must not contain the path separator. By default, "%" and the
+// separator are percent-encoded.
+func WithPathEscaping(escape func(id string) string, unescape func(segment string) (string, error)) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.pathEscape = escape
+		cfg.pathUnescape = unescape
+	})
+}
+
+// WithChangeFeed enables the change feed: every change of the Tree is assigned a
+// sequence number and the latest given number of changes are retained so that
+// consumers can catch up with ChangesSince. A size of zero or less disables it.
+func WithChangeFeed(size int) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.changeFeedSize = size
+	})
+}
+
+// WithMetrics sets the Metrics receiving the measurements of the Tree operations.
+// The operations are not measured by default.
+func WithMetrics(metrics Metrics) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.metrics = metrics
+	})
+}
+
+// WithTracer sets the Tracer starting the spans of the expensive operations of the Tree.
+// The operations are not traced by default.
+func WithTracer(tracer Tracer) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.tracer = tracer
+	})
+}
+
+// WithLogger sets the handler of the records emitted for the notable events of the Tree:
+//   - warn: a mutation rejected by the constraints of the Tree (duplicate ID, invalid ID,
+//     maximum depth, children or size exceeded), an internal inconsistency skipped in
+//     LenientMode, a repair performed or a watcher disconnected.
+//   - debug: the other rejected mutations and the deletions.
+//   - error: a change that the Storage set with WithStorage failed to persist.
+//
+// Nothing is logged by default.
+func WithLogger(handler slog.Handler) Option {
+	return OptionFunc(func(cfg *config) {
+		if handler != nil {
+			cfg.logger = slog.New(handler)
+		}
+	})
+}
+
+// WithStorage sets the Storage persisting the Tree.
+//
+// Every change of the Tree is appended to the Storage before it is applied; Persist saves a snapshot
+// of the Tree and Recover rebuilds it from the Storage. A change that fails to be appended is rejected:
+// it is logged, the Tree is left unchanged and the mutation returns an error wrapping ErrNotPersisted.
+// The Tree panics on creation when the Storage does not hold values of the type of the Tree.
+func WithStorage[T any](storage Storage[T]) Option {
+	return OptionFunc(func(cfg *config) {
+		if storage != nil {
+			cfg.storage = storage
+		}
+	})
+}
+
+// WithMigrations sets the registry of the Migrations upgrading the values of the serialized Trees.
+//
+// The serialized Trees record the schema version of the registry, and the loaders upgrade the values
+// written with an older schema before decoding them instead of failing. Loading a Tree written with
+// a newer schema returns an error wrapping ErrUnsupportedVersion.
+func WithMigrations(migrations *Migrations) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.migrations = migrations
+	})
+}
+
+// WithAncestorIndex indexes the ancestors of every Node so that the ancestor lookups are logarithmic
+// in the depth of the Tree instead of linear.
+//
+// ParentAt, Distance and LowestCommonAncestor benefit from the index, which pays off on very deep
+// Trees. It is maintained incrementally at the cost of one pointer per Node, and a move rebuilds
+// the index of the moved subtree along with its ancestor chains.
+func WithAncestorIndex() Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.ancestorIndex = true
+	})
+}
+
+// WithAggregate registers an aggregate summing the values extracted from the Nodes of every subtree.
+//
+// The Tree maintains the aggregate on every mutation at the cost of walking the ancestors of the
+// changed Nodes, so that Rollup reads the aggregate of any subtree in constant time. A nil extract
+// function counts the Nodes. Registering another aggregate with the same name replaces it. The Tree
+// panics on creation when the function does not extract values of the type of the Tree.
+func WithAggregate[T any](name string, extract func(value T) float64) Option {
+	return OptionFunc(func(cfg *config) {
+		if cfg.aggregates == nil {
+			cfg.aggregates = make(map[string]any)
+		}
+		cfg.aggregates[name] = extract
+	})
+}
+
+// WithMultiParent enables the multi-parent mode, in which Link shares a Node, along with its
+// descendants, with several parents, turning the Tree into a directed acyclic graph.
+//
+// Deleting a shared Node drops the reference of its primary parent: the Node is moved under its first
+// linked parent and only removed along with its last parent. The descendants of a deleted Node linked
+// under other parents are kept the same way.
+func WithMultiParent() Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.multiParent = true
+	})
+}
+
+// WithAuthorizer sets the function consulted by the context-aware methods (AddContext, UpdateContext,
+// DeleteContext, MoveContext, ResetContext, FindContext, DescendantsContext, Expand and LoadFrom) before
+// they operate, so that multi-tenant services enforce their permissions per subtree within the Tree.
+//
+// The authorizer receives the context of the caller, the operation, one of the Operation constants, and the
+// Node it applies to; it rejects the operation by returning an error, which the method returns wrapped along
+// with ErrPermissionDenied. Since the authorizer needs the context of the caller, the Tree is then only changed
+// through the context-aware methods: the methods changing it without context (Add, Update, Delete, Move, SetMeta,
+// Tag, Link, SoftDelete, Collapse, Decode, ...) are rejected with ErrPermissionDenied, and Reset and Prune leave
+// the Tree untouched. The Tree panics on creation when the function does not authorize Nodes of the type of
+// the Tree.
+func WithAuthorizer[T any](authorizer func(ctx context.Context, operation Operation, node Node[T]) error) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.authorizer = authorizer
+	})
+}
+
+// WithMutationLimiter throttles the mutations of the Tree with the given limiter, so that the bursty writers,
+// such as the bulk synchronization jobs, are slowed down within the library rather than starving the readers
+// of the structural locks.
+//
+// Every addition, update, deletion, move, link, edge change, collapse and reset waits for the limiter
+// before it takes any lock; the *rate.Limiter of golang.org/x/time/rate fits. The bulk loaders, such as
+// Decode, LoadFile, Restore and ApplyDelta, wait once for the reset and once per Node they change, so that
+// a large load is paced like the equivalent writes. The context-aware methods (AddContext, UpdateContext,
+// DeleteContext, MoveContext and LoadFrom) and the janitor give up waiting when their context is done and
+// return an error wrapping ErrThrottled, the other methods wait as long as the limiter requires.
+// The reads are never throttled.
+//
+// Example usage:
+//
+//	tree := NewTree[string](WithMutationLimiter(rate.NewLimiter(rate.Limit(1000), 100)))
+func WithMutationLimiter(limiter MutationLimiter) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.limiter = limiter
+	})
+}
+
+// WithHistory records the values held by the Nodes over time, so that History and ValueAt answer
+// what the value of a Node was at a given time.
+//
+// Every addition, update and deletion of a Node is recorded along with its time. The limit bounds the
+// number of entries retained per Node, the oldest being discarded first; a limit of zero or less
+// retains them all. The history of the deleted Nodes is retained as well, hence an unlimited history
+// grows with every Node ever added.
+func WithHistory(limit int) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.history = true
+		cfg.historyLimit = limit
+	})
+}
+
+// WithChildResolver sets the function materializing the children of the Nodes on demand.
+//
+// The children of a Node are resolved the first time they are requested with Expand, or walked by a
+// Cursor, and added to the Tree, so that the subtrees of a catalog too large to be loaded eagerly are
+// only fetched when explored. The Tree panics on creation when the function does not resolve Nodes of
+// the type of the Tree.
+func WithChildResolver[T any](resolve func(ctx context.Context, id string) ([]Node[T], error)) Option {
+	return OptionFunc(func(cfg *config) {
+		cfg.childResolver = resolve
+	})
+}
+
+// ResetOption defines a configuration option that can be applied
+// when resetting a Tree.
+type ResetOption interface {
+	// Apply sets the ResetOption value of a resetConfig.
+	Apply(cfg *resetConfig)
+}
+
+var _ ResetOption = ResetOptionFunc(nil)
+
+// ResetOptionFunc implements the ResetOption interface.
+type ResetOptionFunc func(cfg *resetConfig)
+
+// Apply applies the ResetOption to the given resetConfig
+func (f ResetOptionFunc) Apply(cfg *resetConfig) {
+	f(cfg)
+}
+
+// resetConfig holds the Reset settings
+type resetConfig struct {
+	// keepCapacity states whether the internal maps retain their allocated memory
+	keepCapacity bool
+}
+
+// newResetConfig creates a resetConfig with the default settings
+// and applies the given options
+func newResetConfig(opts ...ResetOption) *resetConfig {
+	cfg := &resetConfig{}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+	return cfg
+}
+
+// WithKeepCapacity makes Reset clear the internal maps in place so that
+// the memory they allocated is reused when the Tree is populated again.
+func WithKeepCapacity() ResetOption {
+	return ResetOptionFunc(func(cfg *resetConfig) {
+		cfg.keepCapacity = true
+	})
+}
+
+// WithReleaseMemory makes Reset drop the internal maps so that the memory
+// they allocated can be reclaimed by the garbage collector. This is the default.
+func WithReleaseMemory() ResetOption {
+	return ResetOptionFunc(func(cfg *resetConfig) {
+		cfg.keepCapacity = false
+	})
+}
+
+// Backpressure defines how a watcher reacts when its buffer is full
+// because its consumer does not keep up with the changes of the Tree.
+type Backpressure int
+
+const (
+	// BackpressureDisconnect closes the channel of the watcher, which can then
+	// watch the Tree again. This is the default.
+	BackpressureDisconnect Backpressure = iota
+	// BackpressureDropNewest discards the events that do not fit in the buffer.
+	BackpressureDropNewest
+	// BackpressureDropOldest discards the oldest buffered event to make room for the new one.
+	BackpressureDropOldest
+	// BackpressureBlock waits for the consumer to make room in the buffer. The mutations
+	// of the Tree are blocked meanwhile, hence it only suits consumers that never stall.
+	BackpressureBlock
+)
+
+// WatchOption defines a configuration option that can be applied
+// when watching a Tree.
+type WatchOption interface {
+	// Apply sets the WatchOption value of a watchConfig.
+	Apply(cfg *watchConfig)
+}
+
+var _ WatchOption = WatchOptionFunc(nil)
+
+// WatchOptionFunc implements the WatchOption interface.
+type WatchOptionFunc func(cfg *watchConfig)
+
+// Apply applies the WatchOption to the given watchConfig
+func (f WatchOptionFunc) Apply(cfg *watchConfig) {
+	f(cfg)
+}
+
+// DefaultWatchBufferSize is the default number of events buffered for a watcher
+const DefaultWatchBufferSize = 128
+
+// watchConfig holds the Watch settings
+type watchConfig struct {
+	// bufferSize is the capacity of the watcher channel
+	bufferSize int
+	// backpressure defines how a full buffer is handled
+	backpressure Backpressure
+}
+
+// newWatchConfig creates a watchConfig with the default settings
+// and applies the given options
+func newWatchConfig(opts ...WatchOption) *watchConfig {
+	cfg := &watchConfig{
+		bufferSize:   DefaultWatchBufferSize,
+		backpressure: BackpressureDisconnect,
+	}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+	return cfg
+}
+
+// WithBufferSize sets the number of events buffered for the watcher.
+// It defaults to DefaultWatchBufferSize. A negative size is ignored.
+func WithBufferSize(size int) WatchOption {
+	return WatchOptionFunc(func(cfg *watchConfig) {
+		if size >= 0 {
+			cfg.bufferSize = size
+		}
+	})
+}
+
+// WithBackpressure sets how the watcher reacts when its buffer is full.
+// It defaults to BackpressureDisconnect.
+func WithBackpressure(backpressure Backpressure) WatchOption {
+	return WatchOptionFunc(func(cfg *watchConfig) {
+		cfg.backpressure = backpressure
+	})
+}
@@ -0,0 +1,184 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"time"
+)
+
+// InsertChildAt inserts the given Node into the Tree as the child of the given parent located at the given index.
+//
+// It behaves like Add, except that the position of the Node among its siblings is chosen instead of
+// being the last one, which gives editors built on top of the Tree control over the order of the children.
+//
+// Parameters:
+//   - parent: The Node[T] under which the `node` is added.
+//   - node: The Node[T] to add.
+//   - index: The position of the Node among the children of the parent, zero being the first.
+//     An index past the last child appends the Node.
+//
+// Returns:
+// - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The Node was successfully added.
+//   - ErrInvalidOperation: The parent is nil or the index is negative.
+//   - ErrParentNodeNotFound: The specified parent Node does not exist in the Tree.
+//   - ErrDuplicateID: A Node with the same ID already exists in the Tree.
+//   - ErrMaxDepthExceeded, ErrMaxChildrenExceeded, ErrMaxSizeExceeded: The addition would
+//     violate the limits of the Tree.
+//
+// The returned errors are *NodeError values carrying the identifiers of the Nodes
+// involved; use errors.Is to match them against the errors above.
+//
+// Example usage:
+//
+//	// insert a new heading as the first section of the chapter
+//	err := tree.InsertChildAt(chapter, NewNode("intro", "Introduction"), 0)
+//	if err != nil {
+//	    log.Fatal("Error inserting the section:", err)
+//	}
+func (x *Tree[T]) InsertChildAt(parent, node Node[T], index int) (err error) {
+	if err := x.requireContext(opAdd, node.ID(), parentID(parent)); err != nil {
+		return err
+	}
+	return x.insertChildAt(parent, node, index, nil)
+}
+
+// insertChildAt adds the given node under the given parent at the given index with the given optional edge data
+func (x *Tree[T]) insertChildAt(parent, node Node[T], index int, edge *EdgeData) (err error) {
+	if err := x.throttle(context.Background(), opAdd, node.ID(), parentID(parent)); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationAdd, time.Now(), &err)
+	}
+
+	if parent == nil || index < 0 {
+		return newNodeError(opAdd, node.ID(), parentID(parent), ErrInvalidOperation)
+	}
+
+	x.mu.RLock()
+	err = x.add(node, parent, edge, index)
+	x.mu.RUnlock()
+	if err == nil {
+		x.notifyAdd(node, parent)
+	}
+	return err
+}
+
+// MoveBefore moves the given Node, along with its descendants, right before the given sibling.
+//
+// The Node is attached under the parent of the sibling, which may differ from its current parent,
+// hence MoveBefore both reorders the children of a Node and moves a Node to a chosen position.
+//
+// Parameters:
+//   - node: The Node[T] to move. It must be part of the Tree and cannot be the root.
+//   - sibling: The Node[T] the `node` is placed before.
+//
+// Returns:
+// - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The Node was successfully moved.
+//   - ErrNotFound: The Node or the sibling does not exist in the Tree.
+//   - ErrInvalidOperation: Attempt to move the root, to move a Node next to itself or to the
+//     root, or to move the Node under its own subtree.
+//   - ErrMaxDepthExceeded, ErrMaxChildrenExceeded: The move would violate the limits of the Tree.
+//
+// The returned errors are *NodeError values carrying the identifiers of the Nodes
+// involved; use errors.Is to match them against the errors above.
+//
+// Notes:
+//   - As for Move, the hooks registered with OnMove are invoked and an EventMove is delivered to the
+//     watchers. Its Before field holds the identifier of the sibling.
+//
+// Example usage:
+//
+//	// move the conclusion before the appendix
+//	err := tree.MoveBefore(conclusion, appendix)
+func (x *Tree[T]) MoveBefore(node, sibling Node[T]) (err error) {
+	if err := x.requireContext(opMove, node.ID(), ""); err != nil {
+		return err
+	}
+
+	return x.moveNextTo(node.ID(), sibling.ID(), false)
+}
+
+// MoveAfter moves the given Node, along with its descendants, right after the given sibling.
+//
+// It behaves like MoveBefore, the Node being placed after the sibling instead.
+//
+// Example usage:
+//
+//	// move the summary right after the introduction
+//	err := tree.MoveAfter(summary, introduction)
+func (x *Tree[T]) MoveAfter(node, sibling Node[T]) (err error) {
+	if err := x.requireContext(opMove, node.ID(), ""); err != nil {
+		return err
+	}
+
+	return x.moveNextTo(node.ID(), sibling.ID(), true)
+}
+
+// moveNextTo moves the Node with the given ID right before the given sibling, or right after it
+// when after is set
+func (x *Tree[T]) moveNextTo(id, sibling string, after bool) (err error) {
+	if err := x.throttle(context.Background(), opMove, id, ""); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationMove, time.Now(), &err)
+	}
+
+	end := x.trace(OperationMove)
+	moved, err := x.moveNextToExclusive(id, sibling, after)
+	end(moved.count, err)
+
+	if err == nil && moved.count > 0 {
+		x.notifyMove(moved.node, moved.from, moved.to)
+	}
+	return err
+}
+
+// moveNextToExclusive moves the given node next to the given sibling under the exclusive structural lock
+func (x *Tree[T]) moveNextToExclusive(id, sibling string, after bool) (move[T], error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if id == sibling {
+		return move[T]{}, newNodeError(opMove, id, "", ErrInvalidOperation)
+	}
+
+	if _, ok := x.getNode(sibling); !ok {
+		return move[T]{}, newNodeError(opMove, id, "", ErrNotFound)
+	}
+
+	parent, ok := x.parentNode(sibling)
+	if !ok {
+		// the root has no siblings
+		return move[T]{}, newNodeError(opMove, id, "", ErrInvalidOperation)
+	}
+	return x.move(id, parent.ID, sibling, after)
+}
@@ -0,0 +1,134 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedChildren(t *testing.T) {
+	childIDs := func(t *testing.T, tree *Tree[string], id string) []string {
+		t.Helper()
+		node, ok := tree.getNode(id)
+		require.True(t, ok)
+		var ids []string
+		for _, child := range node.Descendants.Items() {
+			ids = append(ids, child.ID)
+		}
+		return ids
+	}
+
+	newOrderedTree := func(t *testing.T, opts ...Option) *Tree[string] {
+		tree := NewTree[string](opts...)
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("c", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("c1", ""), "c"))
+		return tree
+	}
+
+	t.Run("inserts children at a position", func(t *testing.T) {
+		tree := newOrderedTree(t)
+		root := NewNode("root", "")
+		require.NoError(t, tree.InsertChildAt(root, NewNode("b", ""), 1))
+		require.NoError(t, tree.InsertChildAt(root, NewNode("first", ""), 0))
+		require.NoError(t, tree.InsertChildAt(root, NewNode("last", ""), 100))
+		assert.Equal(t, []string{"first", "a", "b", "c", "last"}, childIDs(t, tree, "root"))
+
+		assert.ErrorIs(t, tree.InsertChildAt(root, NewNode("x", ""), -1), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.InsertChildAt(nil, NewNode("x", ""), 0), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.InsertChildAt(root, NewNode("a", ""), 0), ErrDuplicateID)
+		assert.ErrorIs(t, tree.InsertChildAt(NewNode("missing", ""), NewNode("x", ""), 0), ErrParentNodeNotFound)
+	})
+
+	t.Run("reorders siblings", func(t *testing.T) {
+		tree := newOrderedTree(t)
+		require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+		require.NoError(t, tree.MoveBefore(NewNode("b", ""), NewNode("c", "")))
+		assert.Equal(t, []string{"a", "b", "c"}, childIDs(t, tree, "root"))
+
+		require.NoError(t, tree.MoveAfter(NewNode("a", ""), NewNode("c", "")))
+		assert.Equal(t, []string{"b", "c", "a"}, childIDs(t, tree, "root"))
+		assert.Empty(t, tree.Validate())
+	})
+
+	t.Run("moves next to a sibling under another parent", func(t *testing.T) {
+		tree := newOrderedTree(t)
+		require.NoError(t, tree.MoveBefore(NewNode("a", ""), NewNode("c1", "")))
+		assert.Equal(t, []string{"c"}, childIDs(t, tree, "root"))
+		assert.Equal(t, []string{"a", "c1"}, childIDs(t, tree, "c"))
+
+		parent, ok := tree.ParentAt(NewNode("a", ""), 0)
+		require.True(t, ok)
+		assert.Equal(t, "c", parent.ID())
+		assert.Empty(t, tree.Validate())
+	})
+
+	t.Run("rejects invalid moves", func(t *testing.T) {
+		tree := newOrderedTree(t)
+		assert.ErrorIs(t, tree.MoveBefore(NewNode("a", ""), NewNode("a", "")), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.MoveBefore(NewNode("a", ""), NewNode("root", "")), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.MoveBefore(NewNode("c", ""), NewNode("c1", "")), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.MoveAfter(NewNode("a", ""), NewNode("missing", "")), ErrNotFound)
+		assert.ErrorIs(t, tree.MoveAfter(NewNode("missing", ""), NewNode("a", "")), ErrNotFound)
+	})
+
+	t.Run("publishes the positions", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tree := newOrderedTree(t)
+		events := tree.Watch(ctx, nil)
+		require.NoError(t, tree.InsertChildAt(NewNode("root", ""), NewNode("b", ""), 1))
+		require.NoError(t, tree.MoveAfter(NewNode("a", ""), NewNode("c", "")))
+
+		event := <-events
+		assert.Equal(t, EventAdd, event.Type)
+		assert.Equal(t, "c", event.Before)
+		event = <-events
+		assert.Equal(t, EventMove, event.Type)
+		assert.Equal(t, "root", event.Parent.ID())
+		assert.Empty(t, event.Before)
+	})
+
+	t.Run("recovers the positions", func(t *testing.T) {
+		storage := NewMemoryStorage[string]()
+		tree := newOrderedTree(t, WithStorage[string](storage))
+		require.NoError(t, tree.InsertChildAt(NewNode("root", ""), NewNode("b", ""), 1))
+		require.NoError(t, tree.MoveAfter(NewNode("a", ""), NewNode("c", "")))
+		require.NoError(t, tree.MoveBefore(NewNode("c", ""), NewNode("b", "")))
+		require.NoError(t, tree.MoveAfter(NewNode("c1", ""), NewNode("a", "")))
+		require.NoError(t, tree.MoveAfter(NewNode("b", ""), NewNode("c1", "")))
+		assert.Equal(t, []string{"c", "a", "c1", "b"}, childIDs(t, tree, "root"))
+
+		recovered := NewTree[string](WithStorage[string](storage))
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, []string{"c", "a", "c1", "b"}, childIDs(t, recovered, "root"))
+	})
+}
@@ -0,0 +1,278 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultPathSeparator is the separator used to render the Node paths
+const DefaultPathSeparator = "/"
+
+// PathOf returns the path of the given Node, made of the IDs of the Nodes
+// from the root down to the Node itself, e.g. "/root/a/b".
+//
+// Every segment is escaped so that IDs containing the separator do not produce
+// ambiguous paths. By default, "%" and the separator are percent-encoded; the separator
+// and the escaping can be configured with WithPathSeparator and WithPathEscaping.
+//
+// Parameters:
+//   - node: The Node[T] whose path is requested.
+//
+// Returns:
+//   - path: The path of the Node.
+//   - ok: A boolean indicating whether the Node exists in the Tree.
+//
+// Example usage:
+//
+//	path, ok := tree.PathOf(node)
+//	if ok {
+//	    fmt.Println("Node path:", path) // Output: /root/a/b
+//	}
+func (x *Tree[T]) PathOf(node Node[T]) (path string, ok bool) {
+	treeNode, ok := x.getNode(node.ID())
+	if !ok {
+		return "", false
+	}
+
+	ids := treeNode.GetPath().IDs()
+	var builder strings.Builder
+	for i := len(ids) - 1; i >= 0; i-- {
+		builder.WriteString(x.cfg.pathSeparator)
+		builder.WriteString(x.cfg.pathEscape(ids[i]))
+	}
+	return builder.String(), true
+}
+
+// FindByPath resolves the Node located at the given path.
+//
+// The path must start with the separator followed by the ID of the root Node and the
+// IDs of the Nodes down to the requested one, as rendered by PathOf (e.g. "/root/a/b").
+// Every segment is resolved among the children of the previous one in constant time.
+//
+// Parameters:
+//   - path: The path of the Node to resolve.
+//
+// Returns:
+//   - item: The Node[T] located at the given path.
+//   - ok: A boolean indicating whether a Node exists at the given path.
+//
+// Example usage:
+//
+//	node, ok := tree.FindByPath("/root/a/b")
+//	if ok {
+//	    fmt.Println("Node found:", node.ID())
+//	}
+func (x *Tree[T]) FindByPath(path string) (item Node[T], ok bool) {
+	segments, err := x.splitPath(path)
+	if err != nil || len(segments) == 0 {
+		return nil, false
+	}
+
+	current := x.rootNode.Load()
+	if current == nil || current.ID != segments[0] {
+		return nil, false
+	}
+
+	for _, segment := range segments[1:] {
+		current, ok = current.Descendants.Get(segment)
+		if !ok {
+			return nil, false
+		}
+	}
+	return current.GetValue(), true
+}
+
+// splitPath splits the given path into the unescaped IDs of its segments
+func (x *Tree[T]) splitPath(path string) ([]string, error) {
+	separator := x.cfg.pathSeparator
+	if !strings.HasPrefix(path, separator) {
+		return nil, fmt.Errorf("path %q does not start with %q", path, separator)
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, separator), separator)
+	for i, segment := range segments {
+		id, err := x.cfg.pathUnescape(segment)
+		if err != nil {
+			return nil, err
+		}
+		segments[i] = id
+	}
+	return segments, nil
+}
+
+// percentEscaper returns the default path escaping functions for the given separator.
+// They percent-encode "%" and every byte of the separator.
+func percentEscaper(separator string) (escape func(string) string, unescape func(string) (string, error)) {
+	replacements := []string{"%", "%25"}
+	for i := 0; i < len(separator); i++ {
+		if separator[i] != '%' {
+			replacements = append(replacements, string(separator[i]), fmt.Sprintf("%%%02X", separator[i]))
+		}
+	}
+
+	replacer := strings.NewReplacer(replacements...)
+	return replacer.Replace, url.PathUnescape
+}
+
+// Glob returns the Nodes whose path matches the given pattern, in pre-order.
+//
+// The pattern is a path whose segments are either Node IDs, escaped as rendered by PathOf,
+// or wildcards:
+//   - "*" matches exactly one segment.
+//   - "**" matches zero or more segments.
+//
+// Parameters:
+//   - pattern: The path pattern to match, e.g. "/root/*/config/**".
+//
+// Returns:
+//   - nodes: The matching Nodes. It is empty when no Node matches or when the pattern is invalid.
+//
+// Example usage:
+//
+//	// every Node under the config Node of any child of the root, including config itself
+//	nodes := tree.Glob("/root/*/config/**")
+//	for _, node := range nodes {
+//	    fmt.Println("Node matched:", node.ID())
+//	}
+func (x *Tree[T]) Glob(pattern string) (nodes []Node[T]) {
+	end := x.trace(OperationGlob)
+	defer func() {
+		end(len(nodes), nil)
+	}()
+
+	segments, err := x.splitPattern(pattern)
+	if err != nil || len(segments) == 0 {
+		return nil
+	}
+
+	root := x.rootNode.Load()
+	if root == nil {
+		return nil
+	}
+
+	matcher := &globMatcher{segments: segments}
+	var walk func(node *treeNode[T], states []bool)
+	guard := x.newCycleGuard(false, root)
+	walk = func(node *treeNode[T], states []bool) {
+		next, ok := matcher.step(states, node.ID)
+		if !ok {
+			return
+		}
+		if matcher.matches(next) {
+			nodes = append(nodes, node.GetValue())
+		}
+		for _, child := range node.Descendants.Items() {
+			if guard.visit(node, child) {
+				walk(child, next)
+			}
+		}
+	}
+
+	walk(root, matcher.start())
+	return nodes
+}
+
+// splitPattern splits the given glob pattern into its segments, leaving the wildcards as is
+func (x *Tree[T]) splitPattern(pattern string) ([]string, error) {
+	separator := x.cfg.pathSeparator
+	if !strings.HasPrefix(pattern, separator) {
+		return nil, fmt.Errorf("pattern %q does not start with %q", pattern, separator)
+	}
+
+	segments := strings.Split(strings.TrimPrefix(pattern, separator), separator)
+	for i, segment := range segments {
+		if segment == globOne || segment == globAny {
+			continue
+		}
+
+		id, err := x.cfg.pathUnescape(segment)
+		if err != nil {
+			return nil, err
+		}
+		segments[i] = id
+	}
+	return segments, nil
+}
+
+const (
+	// globOne matches exactly one path segment
+	globOne = "*"
+	// globAny matches zero or more path segments
+	globAny = "**"
+)
+
+// globMatcher matches paths against glob segments one Node ID at a time.
+// The states are the positions reached in the segments, so that "**" never
+// yields duplicates nor requires backtracking.
+type globMatcher struct {
+	segments []string
+}
+
+// start returns the states before any segment is consumed
+func (m *globMatcher) start() []bool {
+	states := make([]bool, len(m.segments)+1)
+	states[0] = true
+	return m.closure(states)
+}
+
+// step consumes the given Node ID and returns the states reached.
+// It returns false when no state is reached.
+func (m *globMatcher) step(states []bool, id string) ([]bool, bool) {
+	next := make([]bool, len(states))
+	reached := false
+	for i, active := range states[:len(m.segments)] {
+		if !active {
+			continue
+		}
+
+		switch segment := m.segments[i]; {
+		case segment == globAny:
+			next[i] = true
+			reached = true
+		case segment == globOne || segment == id:
+			next[i+1] = true
+			reached = true
+		}
+	}
+	return m.closure(next), reached
+}
+
+// matches returns true when the given states have consumed all the segments
+func (m *globMatcher) matches(states []bool) bool {
+	return states[len(m.segments)]
+}
+
+// closure activates the states following every active "**" since it matches zero segments
+func (m *globMatcher) closure(states []bool) []bool {
+	for i, segment := range m.segments {
+		if states[i] && segment == globAny {
+			states[i+1] = true
+		}
+	}
+	return states
+}
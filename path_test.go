@@ -0,0 +1,205 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath(t *testing.T) {
+	t.Run("PathOf and FindByPath", func(t *testing.T) {
+		tree := NewTree[string]()
+		root := NewNode("root", "root")
+		a := NewNode("a", "a")
+		b := NewNode("b", "b")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(a, root))
+		require.NoError(t, tree.Add(b, a))
+
+		path, ok := tree.PathOf(b)
+		require.True(t, ok)
+		assert.Equal(t, "/root/a/b", path)
+
+		path, ok = tree.PathOf(root)
+		require.True(t, ok)
+		assert.Equal(t, "/root", path)
+
+		node, ok := tree.FindByPath("/root/a/b")
+		require.True(t, ok)
+		assert.Equal(t, "b", node.ID())
+
+		node, ok = tree.FindByPath("/root")
+		require.True(t, ok)
+		assert.Equal(t, "root", node.ID())
+	})
+	t.Run("With unknown paths", func(t *testing.T) {
+		tree := NewTree[string]()
+		_, ok := tree.FindByPath("/root")
+		assert.False(t, ok)
+
+		root := NewNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(NewNode("a", "a"), root))
+
+		_, ok = tree.PathOf(NewNode("x", "x"))
+		assert.False(t, ok)
+
+		for _, path := range []string{"", "/", "root/a", "/a", "/root/b", "/root/a/b", "/root/%zz"} {
+			_, ok = tree.FindByPath(path)
+			assert.False(t, ok, path)
+		}
+	})
+	t.Run("With IDs containing the separator", func(t *testing.T) {
+		tree := NewTree[string]()
+		root := NewNode("root", "root")
+		child := NewNode("a/b", "a/b")
+		grandChild := NewNode("100%", "100%")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(child, root))
+		require.NoError(t, tree.Add(grandChild, child))
+
+		path, ok := tree.PathOf(grandChild)
+		require.True(t, ok)
+		assert.Equal(t, "/root/a%2Fb/100%25", path)
+
+		node, ok := tree.FindByPath(path)
+		require.True(t, ok)
+		assert.Equal(t, "100%", node.ID())
+	})
+	t.Run("With custom separator", func(t *testing.T) {
+		tree := NewTree[string](WithPathSeparator("::"))
+		root := NewNode("root", "root")
+		child := NewNode("a::b", "a::b")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(child, root))
+
+		path, ok := tree.PathOf(child)
+		require.True(t, ok)
+		assert.Equal(t, "::root::a%3A%3Ab", path)
+
+		node, ok := tree.FindByPath(path)
+		require.True(t, ok)
+		assert.Equal(t, "a::b", node.ID())
+	})
+	t.Run("With custom escaping", func(t *testing.T) {
+		// JSON pointer style escaping
+		escape := strings.NewReplacer("~", "~0", ".", "~1").Replace
+		unescape := func(segment string) (string, error) {
+			if strings.HasSuffix(segment, "~") {
+				return "", fmt.Errorf("invalid segment %q", segment)
+			}
+			return strings.NewReplacer("~1", ".", "~0", "~").Replace(segment), nil
+		}
+
+		tree := NewTree[string](WithPathSeparator("."), WithPathEscaping(escape, unescape))
+		root := NewNode("root", "root")
+		child := NewNode("v1.2~beta", "v1.2~beta")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(child, root))
+
+		path, ok := tree.PathOf(child)
+		require.True(t, ok)
+		assert.Equal(t, ".root.v1~12~0beta", path)
+
+		node, ok := tree.FindByPath(path)
+		require.True(t, ok)
+		assert.Equal(t, "v1.2~beta", node.ID())
+
+		_, ok = tree.FindByPath(".root.v1~")
+		assert.False(t, ok)
+	})
+}
+
+func TestGlob(t *testing.T) {
+	// root
+	// ├── a
+	// │   ├── config
+	// │   │   └── db
+	// │   │       └── url
+	// │   └── data
+	// └── b
+	//     └── config
+	tree := NewTree[string]()
+	root := NewNode("root", "root")
+	a := NewNode("a", "a")
+	aConfig := NewNode("a-config", "config")
+	b := NewNode("b", "b")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.Add(a, root))
+	require.NoError(t, tree.Add(b, root))
+	require.NoError(t, tree.Add(aConfig, a))
+	require.NoError(t, tree.Add(NewNode("db", "db"), aConfig))
+	require.NoError(t, tree.Add(NewNode("url", "url"), NewNode("db", "db")))
+	require.NoError(t, tree.Add(NewNode("data", "data"), a))
+	require.NoError(t, tree.Add(NewNode("b-config", "config"), b))
+
+	ids := func(nodes []Node[string]) []string {
+		result := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			result = append(result, node.ID())
+		}
+		return result
+	}
+
+	testCases := []struct {
+		pattern  string
+		expected []string
+	}{
+		{pattern: "/root", expected: []string{"root"}},
+		{pattern: "/*", expected: []string{"root"}},
+		{pattern: "/root/*", expected: []string{"a", "b"}},
+		{pattern: "/root/*/a-config", expected: []string{"a-config"}},
+		{pattern: "/root/a/a-config/**", expected: []string{"a-config", "db", "url"}},
+		{pattern: "/**/url", expected: []string{"url"}},
+		{pattern: "/**", expected: []string{"root", "a", "a-config", "db", "url", "data", "b", "b-config"}},
+		{pattern: "/root/**/*", expected: []string{"a", "a-config", "db", "url", "data", "b", "b-config"}},
+		{pattern: "/**/**/db/*", expected: []string{"url"}},
+		{pattern: "/root/*/*/*", expected: []string{"db"}},
+		{pattern: "/root/c/**", expected: nil},
+		{pattern: "root/**", expected: nil},
+		{pattern: "/root/%zz", expected: nil},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.pattern, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, nilIfEmpty(ids(tree.Glob(testCase.pattern))))
+		})
+	}
+
+	t.Run("With an empty tree", func(t *testing.T) {
+		assert.Empty(t, NewTree[string]().Glob("/**"))
+	})
+}
+
+func nilIfEmpty(ids []string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	return ids
+}
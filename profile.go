@@ -0,0 +1,250 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// profileTop is the number of longest chains and widest Nodes recorded by a Profile
+const profileTop = 10
+
+// Profile is the shape of a Tree as written by WriteProfile
+type Profile struct {
+	// Nodes is the number of Nodes of the Tree
+	Nodes int64 `json:"nodes"`
+	// MaxDepth is the depth of the deepest Node, the root being at depth zero
+	MaxDepth int `json:"maxDepth"`
+	// Depths holds the number of Nodes of every level, indexed by depth
+	Depths []int64 `json:"depths"`
+	// Fanout holds the number of Nodes per number of children, by increasing number of children
+	Fanout []ProfileBucket `json:"fanout"`
+	// Chains holds the longest chains of Nodes having a single child, the longest first
+	Chains []ProfileEntry `json:"chains"`
+	// Widest holds the Nodes having the most children, the widest first
+	Widest []ProfileEntry `json:"widest"`
+}
+
+// ProfileBucket is a bucket of a histogram of a Profile
+type ProfileBucket struct {
+	// Value is the value counted by the bucket
+	Value int `json:"value"`
+	// Count is the number of Nodes having the value
+	Count int64 `json:"count"`
+}
+
+// ProfileEntry locates a notable Node of a Profile
+type ProfileEntry struct {
+	// ID is the identifier of the Node, the top of the chain for a chain
+	ID string `json:"id"`
+	// Depth is the depth of the Node
+	Depth int `json:"depth"`
+	// Size is the number of edges of the chain or the number of children of the Node
+	Size int `json:"size"`
+}
+
+// AnomalyKind defines the kinds of anomaly flagged by Profile.Analyze
+type AnomalyKind uint8
+
+const (
+	// AnomalyChain flags a degenerate chain of Nodes having a single child
+	AnomalyChain AnomalyKind = iota + 1
+	// AnomalyFanout flags a Node having too many children
+	AnomalyFanout
+)
+
+// String returns the name of the AnomalyKind
+func (k AnomalyKind) String() string {
+	switch k {
+	case AnomalyChain:
+		return "chain"
+	case AnomalyFanout:
+		return "fanout"
+	default:
+		return "unknown"
+	}
+}
+
+// Anomaly is an anomaly of the shape of a Tree flagged by Profile.Analyze
+type Anomaly struct {
+	// Kind is the kind of anomaly
+	Kind AnomalyKind
+	// Entry locates the Node of the anomaly
+	Entry ProfileEntry
+}
+
+// String describes the Anomaly
+func (a Anomaly) String() string {
+	if a.Kind == AnomalyChain {
+		return fmt.Sprintf("chain: %d single-child links from node %q at depth %d", a.Entry.Size, a.Entry.ID, a.Entry.Depth)
+	}
+	return fmt.Sprintf("%s: node %q at depth %d has %d children", a.Kind, a.Entry.ID, a.Entry.Depth, a.Entry.Size)
+}
+
+// ProfileLimits holds the thresholds above which Profile.Analyze flags an anomaly.
+// A zero threshold uses the default one
+type ProfileLimits struct {
+	// MaxChain is the longest acceptable chain of Nodes having a single child. It defaults to 64
+	MaxChain int
+	// MaxFanout is the largest acceptable number of children of a Node. It defaults to 10000
+	MaxFanout int
+}
+
+// WriteProfile writes the shape of the Tree to the given writer as a JSON encoded Profile.
+//
+// The profile holds the histogram of the depths and the histogram of the number of children of
+// the Nodes, along with the longest chains of Nodes having a single child and the Nodes having
+// the most children. It is meant for the capacity investigations: the profiles are collected
+// from the running services, compared over time and checked with Profile.Analyze.
+//
+// Parameters:
+//   - w: The writer receiving the profile.
+//
+// Returns:
+//   - err: An error when the writer fails.
+//
+// Notes:
+//   - Only the identifiers of the notable Nodes are written, never the values.
+//   - WriteProfile blocks the structural changes of the Tree while it walks the Nodes.
+//
+// Example usage:
+//
+//	file, _ := os.Create("tree.profile.json")
+//	defer file.Close()
+//	if err := tree.WriteProfile(file); err != nil {
+//	    log.Println("Cannot write the profile:", err)
+//	}
+func (x *Tree[T]) WriteProfile(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(x.profile()); err != nil {
+		return fmt.Errorf("gotree: cannot write profile: %w", err)
+	}
+	return nil
+}
+
+// ReadProfile reads a Profile written by WriteProfile
+func ReadProfile(r io.Reader) (Profile, error) {
+	var profile Profile
+	if err := json.NewDecoder(r).Decode(&profile); err != nil {
+		return Profile{}, fmt.Errorf("gotree: cannot read profile: %w", err)
+	}
+	return profile, nil
+}
+
+// Analyze flags the degenerate chains and the Nodes having too many children, the chains first
+func (p Profile) Analyze(limits ProfileLimits) []Anomaly {
+	maxChain := cmp.Or(limits.MaxChain, 64)
+	maxFanout := cmp.Or(limits.MaxFanout, 10000)
+
+	var anomalies []Anomaly
+	for _, chain := range p.Chains {
+		if chain.Size > maxChain {
+			anomalies = append(anomalies, Anomaly{Kind: AnomalyChain, Entry: chain})
+		}
+	}
+	for _, node := range p.Widest {
+		if node.Size > maxFanout {
+			anomalies = append(anomalies, Anomaly{Kind: AnomalyFanout, Entry: node})
+		}
+	}
+	return anomalies
+}
+
+// profile computes the Profile of the Tree
+func (x *Tree[T]) profile() Profile {
+	records := x.snapshot().Records
+	profile := Profile{Nodes: int64(len(records)), Depths: []int64{}, Fanout: []ProfileBucket{}}
+
+	// the records are in depth-first order: the parents come before their children
+	positions := make(map[string]int, len(records))
+	parents := make([]int, len(records))
+	depths := make([]int, len(records))
+	for i, record := range records {
+		positions[record.ID] = i
+		parents[i] = -1
+		if parent, ok := positions[record.ParentID]; ok {
+			parents[i] = parent
+			depths[i] = depths[parent] + 1
+		}
+
+		if depths[i] == len(profile.Depths) {
+			profile.Depths = append(profile.Depths, 0)
+		}
+		profile.Depths[depths[i]]++
+		profile.MaxDepth = max(profile.MaxDepth, depths[i])
+	}
+
+	// count the children and the chains from the leaves up
+	children := make([]int, len(records))
+	chains := make([]int, len(records))
+	fanout := make(map[int]int64)
+	for i := len(records) - 1; i >= 0; i-- {
+		if children[i] != 1 {
+			chains[i] = 0
+		}
+		fanout[children[i]]++
+		if children[i] > 1 {
+			profile.Widest = append(profile.Widest, ProfileEntry{ID: records[i].ID, Depth: depths[i], Size: children[i]})
+		}
+
+		if parent := parents[i]; parent >= 0 {
+			children[parent]++
+			// the chain of the parent only matters when i is its single child
+			chains[parent] = chains[i] + 1
+		}
+	}
+
+	// a chain is reported from its top Node, the one not continuing the chain of its parent
+	for i := range records {
+		if chains[i] > 0 && (parents[i] < 0 || children[parents[i]] != 1) {
+			profile.Chains = append(profile.Chains, ProfileEntry{ID: records[i].ID, Depth: depths[i], Size: chains[i]})
+		}
+	}
+
+	for children, count := range fanout {
+		profile.Fanout = append(profile.Fanout, ProfileBucket{Value: children, Count: count})
+	}
+	slices.SortFunc(profile.Fanout, func(a, b ProfileBucket) int { return cmp.Compare(a.Value, b.Value) })
+	profile.Chains = topProfileEntries(profile.Chains)
+	profile.Widest = topProfileEntries(profile.Widest)
+	return profile
+}
+
+// topProfileEntries returns the profileTop largest entries, the largest first and the
+// entries of the same size by increasing depth and identifier
+func topProfileEntries(entries []ProfileEntry) []ProfileEntry {
+	slices.SortFunc(entries, func(a, b ProfileEntry) int {
+		return cmp.Or(cmp.Compare(b.Size, a.Size), cmp.Compare(a.Depth, b.Depth), cmp.Compare(a.ID, b.ID))
+	})
+	if entries == nil {
+		return []ProfileEntry{}
+	}
+	return slices.Clip(entries[:min(profileTop, len(entries))])
+}
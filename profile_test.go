@@ -0,0 +1,95 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteProfile(t *testing.T) {
+	t.Run("writes the histograms", func(t *testing.T) {
+		tree, err := FromSExpr[string](`(root (a (a1) (a2) (a3)) (b (b1 (b11 (b111)))) (c))`)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, tree.WriteProfile(&buf))
+		profile, err := ReadProfile(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, Profile{
+			Nodes:    10,
+			MaxDepth: 4,
+			Depths:   []int64{1, 3, 4, 1, 1},
+			Fanout:   []ProfileBucket{{Value: 0, Count: 5}, {Value: 1, Count: 3}, {Value: 3, Count: 2}},
+			Chains:   []ProfileEntry{{ID: "b", Depth: 1, Size: 3}},
+			Widest:   []ProfileEntry{{ID: "root", Depth: 0, Size: 3}, {ID: "a", Depth: 1, Size: 3}},
+		}, profile)
+		assert.Empty(t, profile.Analyze(ProfileLimits{}))
+
+		buf.Reset()
+		require.NoError(t, NewTree[string]().WriteProfile(&buf))
+		empty, err := ReadProfile(&buf)
+		require.NoError(t, err)
+		assert.Zero(t, empty.Nodes)
+		assert.Empty(t, empty.Chains)
+
+		_, err = ReadProfile(strings.NewReader("{"))
+		assert.Error(t, err)
+	})
+
+	t.Run("flags the anomalies", func(t *testing.T) {
+		tree := NewTree[int]()
+		require.NoError(t, tree.AddByID(NewNode("root", 0), ""))
+		parent := "root"
+		for i := range 100 {
+			id := fmt.Sprintf("chain-%d", i)
+			require.NoError(t, tree.AddByID(NewNode(id, 0), parent))
+			parent = id
+		}
+		require.NoError(t, tree.AddByID(NewNode("hub", 0), "root"))
+		for i := range 20 {
+			require.NoError(t, tree.AddByID(NewNode(fmt.Sprintf("leaf-%d", i), 0), "hub"))
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, tree.WriteProfile(&buf))
+		profile, err := ReadProfile(&buf)
+		require.NoError(t, err)
+
+		anomalies := profile.Analyze(ProfileLimits{MaxFanout: 10})
+		require.Len(t, anomalies, 2)
+		assert.Equal(t, Anomaly{Kind: AnomalyChain, Entry: ProfileEntry{ID: "chain-0", Depth: 1, Size: 99}}, anomalies[0])
+		assert.Equal(t, Anomaly{Kind: AnomalyFanout, Entry: ProfileEntry{ID: "hub", Depth: 1, Size: 20}}, anomalies[1])
+		assert.Equal(t, `chain: 99 single-child links from node "chain-0" at depth 1`, anomalies[0].String())
+		assert.Equal(t, `fanout: node "hub" at depth 1 has 20 children`, anomalies[1].String())
+
+		assert.Empty(t, profile.Analyze(ProfileLimits{MaxChain: 99, MaxFanout: 20}))
+	})
+}
@@ -0,0 +1,379 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Query selects the Nodes of a Tree matching a composition of structural and value predicates.
+//
+// A Query is created with Tree.Query, refined with its builder methods and executed with Run.
+// The Nodes are visited in depth-first pre-order, children in insertion order, and the
+// subtrees deeper than the depth bounds are never visited.
+//
+// A Query is not safe for concurrent use by multiple goroutines. It can be run several times,
+// every run reflecting the current state of the Tree.
+type Query[T any] struct {
+	tree       *Tree[T]
+	start      string
+	conditions []Condition
+	filters    []func(node Node[T]) bool
+	minDepth   int
+	maxDepth   int
+	leavesOnly bool
+	limit      int
+}
+
+// Query creates a Query over the whole Tree.
+//
+// Returns:
+//   - query: The Query matching every Node of the Tree until refined.
+//
+// Example usage:
+//
+//	pods := tree.Query().
+//	    Where(gotree.ValueField("kind").Eq("pod")).
+//	    DepthLessThan(3).
+//	    Run()
+func (x *Tree[T]) Query() *Query[T] {
+	return &Query[T]{
+		tree:     x,
+		maxDepth: -1,
+	}
+}
+
+// Where restricts the Query to the Nodes whose value satisfies all the given conditions
+func (q *Query[T]) Where(conditions ...Condition) *Query[T] {
+	q.conditions = append(q.conditions, conditions...)
+	return q
+}
+
+// Filter restricts the Query to the Nodes satisfying the given function.
+// Unlike Where, the function is given the typed Node which avoids reflection.
+func (q *Query[T]) Filter(fn func(node Node[T]) bool) *Query[T] {
+	q.filters = append(q.filters, fn)
+	return q
+}
+
+// Under restricts the Query to the subtree rooted at the given Node, the Node included
+func (q *Query[T]) Under(node Node[T]) *Query[T] {
+	q.start = node.ID()
+	return q
+}
+
+// DepthLessThan restricts the Query to the Nodes whose depth is less than the given one.
+// The root Node is at depth zero.
+func (q *Query[T]) DepthLessThan(depth int) *Query[T] {
+	q.maxDepth = depth
+	return q
+}
+
+// DepthAtLeast restricts the Query to the Nodes whose depth is at least the given one.
+// The root Node is at depth zero.
+func (q *Query[T]) DepthAtLeast(depth int) *Query[T] {
+	q.minDepth = depth
+	return q
+}
+
+// Leaves restricts the Query to the Nodes without children
+func (q *Query[T]) Leaves() *Query[T] {
+	q.leavesOnly = true
+	return q
+}
+
+// Limit stops the Query once the given number of Nodes matched. Zero or a negative limit means no limit.
+func (q *Query[T]) Limit(limit int) *Query[T] {
+	q.limit = limit
+	return q
+}
+
+// Run executes the Query and returns the matching Nodes in depth-first pre-order.
+// It returns an empty slice when the Tree is empty or the Node given to Under does not exist.
+func (q *Query[T]) Run() (nodes []Node[T]) {
+	end := q.tree.trace(OperationQuery)
+	defer func() {
+		end(len(nodes), nil)
+	}()
+
+	start := q.tree.rootNode.Load()
+	if q.start != "" {
+		start, _ = q.tree.getNode(q.start)
+	}
+	if start == nil {
+		return nil
+	}
+
+	var walk func(node *treeNode[T], depth int) bool
+	guard := q.tree.newCycleGuard(false, start)
+	walk = func(node *treeNode[T], depth int) bool {
+		if q.maxDepth >= 0 && depth >= q.maxDepth {
+			return true
+		}
+
+		if value := node.GetValue(); value != nil && q.matches(node, value, depth) {
+			nodes = append(nodes, value)
+			if q.limit > 0 && len(nodes) >= q.limit {
+				return false
+			}
+		}
+
+		for _, child := range node.Descendants.Items() {
+			if guard.visit(node, child) && !walk(child, depth+1) {
+				return false
+			}
+		}
+		return true
+	}
+
+	walk(start, start.GetPath().Depth())
+	return nodes
+}
+
+// First executes the Query and returns the first matching Node
+func (q *Query[T]) First() (node Node[T], ok bool) {
+	limit := q.limit
+	nodes := q.Limit(1).Run()
+	q.limit = limit
+	if len(nodes) == 0 {
+		return nil, false
+	}
+	return nodes[0], true
+}
+
+// matches returns true when the given Node satisfies all the predicates of the Query
+func (q *Query[T]) matches(node *treeNode[T], value Node[T], depth int) bool {
+	if depth < q.minDepth {
+		return false
+	}
+
+	if q.leavesOnly && node.Descendants.Len() > 0 {
+		return false
+	}
+
+	for _, filter := range q.filters {
+		if !filter(value) {
+			return false
+		}
+	}
+
+	if len(q.conditions) > 0 {
+		data := any(value.Value())
+		for _, condition := range q.conditions {
+			if !condition(data) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Condition is a predicate evaluated against the value of a Node
+type Condition func(value any) bool
+
+// And returns a Condition satisfied when all the given conditions are satisfied
+func And(conditions ...Condition) Condition {
+	return func(value any) bool {
+		for _, condition := range conditions {
+			if !condition(value) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a Condition satisfied when at least one of the given conditions is satisfied
+func Or(conditions ...Condition) Condition {
+	return func(value any) bool {
+		for _, condition := range conditions {
+			if condition(value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a Condition satisfied when the given condition is not
+func Not(condition Condition) Condition {
+	return func(value any) bool {
+		return !condition(value)
+	}
+}
+
+// Field references a field of the Node values to build Conditions
+type Field struct {
+	path []string
+}
+
+// ValueField references a field of the Node values by name.
+//
+// The name is resolved against struct fields, by Go name or JSON tag name, and against the keys
+// of maps with string keys. Pointers and interfaces are followed and nested fields are referenced
+// using dots, e.g. "metadata.labels.app".
+//
+// The resolution relies on reflection; Query.Filter is cheaper on hot paths.
+func ValueField(name string) Field {
+	return Field{path: strings.Split(name, ".")}
+}
+
+// Eq returns a Condition satisfied when the field exists and equals the given value.
+// Strings and numbers are compared by value regardless of their Go type.
+func (f Field) Eq(expected any) Condition {
+	return f.Matches(func(value any) bool {
+		return equal(value, expected)
+	})
+}
+
+// Ne returns a Condition satisfied when the field exists and differs from the given value
+func (f Field) Ne(expected any) Condition {
+	return f.Matches(func(value any) bool {
+		return !equal(value, expected)
+	})
+}
+
+// In returns a Condition satisfied when the field exists and equals one of the given values
+func (f Field) In(values ...any) Condition {
+	return f.Matches(func(value any) bool {
+		for _, expected := range values {
+			if equal(value, expected) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Exists returns a Condition satisfied when the field exists
+func (f Field) Exists() Condition {
+	return f.Matches(func(any) bool {
+		return true
+	})
+}
+
+// Matches returns a Condition satisfied when the field exists and satisfies the given function
+func (f Field) Matches(fn func(value any) bool) Condition {
+	return func(value any) bool {
+		field, ok := f.resolve(value)
+		return ok && fn(field)
+	}
+}
+
+// resolve returns the value of the field within the given value
+func (f Field) resolve(value any) (any, bool) {
+	current := reflect.ValueOf(value)
+	for _, name := range f.path {
+		current = indirect(current)
+		switch current.Kind() {
+		case reflect.Struct:
+			current = structField(current, name)
+		case reflect.Map:
+			if current.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+			current = current.MapIndex(reflect.ValueOf(name).Convert(current.Type().Key()))
+		default:
+			return nil, false
+		}
+
+		if !current.IsValid() {
+			return nil, false
+		}
+	}
+
+	current = indirect(current)
+	if !current.IsValid() || !current.CanInterface() {
+		return nil, false
+	}
+	return current.Interface(), true
+}
+
+// indirect follows the pointers and interfaces of the given value.
+// It returns the zero reflect.Value when a nil is met.
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// structField returns the exported field of the given struct matching the given name or JSON tag name
+func structField(value reflect.Value, name string) reflect.Value {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Name == name || (tag != "" && tag == name) {
+			return value.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// equal compares the given values, converting strings and numbers of different Go types
+func equal(actual, expected any) bool {
+	if reflect.DeepEqual(actual, expected) {
+		return true
+	}
+
+	left, right := reflect.ValueOf(actual), reflect.ValueOf(expected)
+	if !left.IsValid() || !right.IsValid() {
+		return false
+	}
+
+	if left.Kind() == reflect.String && right.Kind() == reflect.String {
+		return left.String() == right.String()
+	}
+
+	leftNumber, ok := number(left)
+	if !ok {
+		return false
+	}
+	rightNumber, ok := number(right)
+	return ok && leftNumber == rightNumber
+}
+
+// number returns the given value as a float64 when it is a number
+func number(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type resource struct {
+	Kind     string            `json:"kind"`
+	Replicas int               `json:"replicas,omitempty"`
+	Labels   map[string]string `json:"labels"`
+	Owner    *resource
+	secret   string
+}
+
+func newQueryTestTree(t *testing.T) *Tree[*resource] {
+	t.Helper()
+
+	// cluster
+	// ├── ns-a
+	// │   ├── deploy
+	// │   │   └── pod-1
+	// │   └── pod-2
+	// └── ns-b
+	//     └── pod-3
+	tree := NewTree[*resource]()
+	cluster := NewNode("cluster", &resource{Kind: "cluster"})
+	nsA := NewNode("ns-a", &resource{Kind: "namespace", Labels: map[string]string{"env": "prod"}})
+	nsB := NewNode("ns-b", &resource{Kind: "namespace", Labels: map[string]string{"env": "dev"}})
+	deploy := NewNode("deploy", &resource{Kind: "deployment", Replicas: 1, Labels: map[string]string{"app": "api"}})
+	require.NoError(t, tree.Add(cluster, nil))
+	require.NoError(t, tree.Add(nsA, cluster))
+	require.NoError(t, tree.Add(nsB, cluster))
+	require.NoError(t, tree.Add(deploy, nsA))
+	require.NoError(t, tree.Add(NewNode("pod-1", &resource{Kind: "pod", Labels: map[string]string{"app": "api"}, Owner: deploy.Value(), secret: "s"}), deploy))
+	require.NoError(t, tree.Add(NewNode("pod-2", &resource{Kind: "pod", Labels: map[string]string{"app": "web"}}), nsA))
+	require.NoError(t, tree.Add(NewNode("pod-3", &resource{Kind: "pod"}), nsB))
+	return tree
+}
+
+func queryIDs[T any](nodes []Node[T]) []string {
+	var ids []string
+	for _, node := range nodes {
+		ids = append(ids, node.ID())
+	}
+	return ids
+}
+
+func TestQuery(t *testing.T) {
+	tree := newQueryTestTree(t)
+
+	testCases := []struct {
+		name     string
+		query    *Query[*resource]
+		expected []string
+	}{
+		{name: "all", query: tree.Query(), expected: []string{"cluster", "ns-a", "deploy", "pod-1", "pod-2", "ns-b", "pod-3"}},
+		{name: "by field name", query: tree.Query().Where(ValueField("Kind").Eq("pod")), expected: []string{"pod-1", "pod-2", "pod-3"}},
+		{name: "by json tag", query: tree.Query().Where(ValueField("kind").Eq("pod")).DepthLessThan(3), expected: []string{"pod-2", "pod-3"}},
+		{name: "depth at least", query: tree.Query().DepthAtLeast(2), expected: []string{"deploy", "pod-1", "pod-2", "pod-3"}},
+		{name: "map key", query: tree.Query().Where(ValueField("labels.app").Eq("api")), expected: []string{"deploy", "pod-1"}},
+		{name: "exists", query: tree.Query().Where(ValueField("labels.env").Exists()), expected: []string{"ns-a", "ns-b"}},
+		{name: "in", query: tree.Query().Where(ValueField("kind").In("cluster", "deployment")), expected: []string{"cluster", "deploy"}},
+		{name: "ne", query: tree.Query().Where(ValueField("kind").Ne("pod")), expected: []string{"cluster", "ns-a", "deploy", "ns-b"}},
+		{name: "numbers", query: tree.Query().Where(ValueField("replicas").Eq(int64(1))), expected: []string{"deploy"}},
+		{name: "pointer", query: tree.Query().Where(ValueField("Owner.kind").Eq("deployment")), expected: []string{"pod-1"}},
+		{name: "unexported", query: tree.Query().Where(ValueField("secret").Exists()), expected: nil},
+		{name: "unknown", query: tree.Query().Where(ValueField("kind.name").Exists()), expected: nil},
+		{
+			name:     "combinators",
+			query:    tree.Query().Where(Or(ValueField("kind").Eq("namespace"), And(ValueField("kind").Eq("pod"), Not(ValueField("labels.app").Exists())))),
+			expected: []string{"ns-a", "ns-b", "pod-3"},
+		},
+		{name: "under", query: tree.Query().Under(NewNode[*resource]("ns-a", nil)), expected: []string{"ns-a", "deploy", "pod-1", "pod-2"}},
+		{name: "under with depth", query: tree.Query().Under(NewNode[*resource]("deploy", nil)).DepthLessThan(3), expected: []string{"deploy"}},
+		{name: "under unknown", query: tree.Query().Under(NewNode[*resource]("x", nil)), expected: nil},
+		{name: "leaves", query: tree.Query().Leaves(), expected: []string{"pod-1", "pod-2", "pod-3"}},
+		{name: "limit", query: tree.Query().Leaves().Limit(2), expected: []string{"pod-1", "pod-2"}},
+		{
+			name: "filter",
+			query: tree.Query().Filter(func(node Node[*resource]) bool {
+				return node.Value().Kind == "namespace"
+			}),
+			expected: []string{"ns-a", "ns-b"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, queryIDs(testCase.query.Run()))
+		})
+	}
+
+	t.Run("First", func(t *testing.T) {
+		query := tree.Query().Where(ValueField("kind").Eq("pod"))
+		node, ok := query.First()
+		require.True(t, ok)
+		assert.Equal(t, "pod-1", node.ID())
+		assert.Len(t, query.Run(), 3)
+
+		_, ok = tree.Query().Where(ValueField("kind").Eq("service")).First()
+		assert.False(t, ok)
+	})
+	t.Run("With an empty tree", func(t *testing.T) {
+		assert.Empty(t, NewTree[string]().Query().Run())
+	})
+	t.Run("With scalar values", func(t *testing.T) {
+		tree := NewTree[map[string]any]()
+		require.NoError(t, tree.Add(NewNode("root", map[string]any{"size": 3.0}), nil))
+		assert.Len(t, tree.Query().Where(ValueField("size").Eq(3)).Run(), 1)
+	})
+}
@@ -0,0 +1,248 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// SetQuota limits the number of Nodes of the subtree rooted at the given Node.
+//
+// The quota is enforced by every operation growing the subtree: the additions (Add, AddByID, AddWithEdge,
+// InsertChildAt, AddOrReplace, RestoreDeleted...) and the moves of Nodes into the subtree fail with
+// ErrQuotaExceeded once the subtree holds the given number of Nodes. It prevents a tenant sharing a Tree
+// with others from exhausting the memory for everyone.
+//
+// Parameters:
+//   - node: The Node[T] at the root of the subtree. It counts in the quota.
+//   - maxNodes: The maximum number of Nodes of the subtree. Zero or less removes the quota.
+//
+// Returns:
+//   - err: nil on success, ErrNotFound when the Node does not exist in the Tree.
+//
+// Notes:
+//   - A quota lower than the current size of the subtree is accepted: the subtree cannot grow until
+//     enough Nodes have been deleted.
+//   - The quotas nest: an addition must fit within the quotas of all its ancestors.
+//   - The quota is dropped along with the Node, and the quotas are not persisted.
+//
+// Example usage:
+//
+//	if err := tree.SetQuota(tenant, 10_000); err != nil {
+//	    log.Fatal(err)
+//	}
+//	err := tree.Add(NewNode("doc-10001", doc), tenant)
+//	if errors.Is(err, ErrQuotaExceeded) {
+//	    fmt.Println("The tenant is full")
+//	}
+func (x *Tree[T]) SetQuota(node Node[T], maxNodes int) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	n, ok := x.getNode(node.ID())
+	if !ok {
+		return newNodeError(opSetQuota, node.ID(), "", ErrNotFound)
+	}
+
+	if maxNodes <= 0 {
+		x.quotas.remove(n.ID)
+		return nil
+	}
+	x.quotas.set(n.ID, int64(maxNodes), int64(1+len(x.collectDescendants(n))))
+	return nil
+}
+
+// Quota returns the quota of the given Node along with the number of Nodes of its subtree.
+// It returns false when the Node has no quota.
+//
+// Example usage:
+//
+//	if maxNodes, used, ok := tree.Quota(tenant); ok {
+//	    fmt.Printf("%d/%d nodes\n", used, maxNodes)
+//	}
+func (x *Tree[T]) Quota(node Node[T]) (maxNodes, used int, ok bool) {
+	limit, ok := x.quotas.get(node.ID())
+	if !ok {
+		return 0, 0, false
+	}
+	return int(limit.max), int(limit.used.Load()), true
+}
+
+// recountQuotas recomputes the number of Nodes of the subtrees having a quota, dropping the quotas
+// of the Nodes no longer part of the Tree. It must be called with the structural lock held exclusively
+func (x *Tree[T]) recountQuotas() {
+	for _, id := range x.quotas.ids() {
+		n, ok := x.getNode(id)
+		if !ok {
+			x.quotas.remove(id)
+			continue
+		}
+		if limit, ok := x.quotas.get(id); ok {
+			limit.used.Store(int64(1 + len(x.collectDescendants(n))))
+		}
+	}
+}
+
+// quota is the limit of the number of Nodes of a subtree
+type quota struct {
+	max  int64
+	used atomic.Int64
+}
+
+// quotaSet holds the quotas of the subtrees by root ID
+type quotaSet struct {
+	mu     sync.RWMutex
+	limits map[string]*quota
+	// count is the number of quotas, sparing the lookups when there are none
+	count atomic.Int64
+}
+
+// newQuotaSet creates an empty quotaSet
+func newQuotaSet() *quotaSet {
+	return &quotaSet{limits: make(map[string]*quota)}
+}
+
+// set sets the quota of the given Node whose subtree holds the given number of Nodes
+func (q *quotaSet) set(id string, maxNodes, used int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	limit := &quota{max: maxNodes}
+	limit.used.Store(used)
+	if _, ok := q.limits[id]; !ok {
+		q.count.Add(1)
+	}
+	q.limits[id] = limit
+}
+
+// get returns the quota of the given Node
+func (q *quotaSet) get(id string) (*quota, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	limit, ok := q.limits[id]
+	return limit, ok
+}
+
+// ids returns the identifiers of the Nodes having a quota
+func (q *quotaSet) ids() []string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	ids := make([]string, 0, len(q.limits))
+	for id := range q.limits {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// remove drops the quotas of the given Nodes
+func (q *quotaSet) remove(ids ...string) {
+	if q.count.Load() == 0 {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, id := range ids {
+		if _, ok := q.limits[id]; ok {
+			delete(q.limits, id)
+			q.count.Add(-1)
+		}
+	}
+}
+
+// clear drops all the quotas
+func (q *quotaSet) clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	clear(q.limits)
+	q.count.Store(0)
+}
+
+// along returns the quotas of the links of the given chain deeper than the given depth
+func (q *quotaSet) along(chain *ancestry, depth int) []*quota {
+	if q.count.Load() == 0 {
+		return nil
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	var limits []*quota
+	for link := chain; link != nil && link.depth > depth; link = link.parent {
+		if limit, ok := q.limits[link.id]; ok {
+			limits = append(limits, limit)
+		}
+	}
+	return limits
+}
+
+// reserve accounts for the given number of Nodes added under the given chain unless it exceeds one
+// of the quotas of the chain. It reports whether the Nodes have been accounted for
+func (q *quotaSet) reserve(chain *ancestry, count int64) bool {
+	limits := q.along(chain, -1)
+	for i, limit := range limits {
+		for {
+			used := limit.used.Load()
+			if used+count > limit.max {
+				for _, reserved := range limits[:i] {
+					reserved.used.Add(-count)
+				}
+				return false
+			}
+			if limit.used.CompareAndSwap(used, used+count) {
+				break
+			}
+		}
+	}
+	return true
+}
+
+// release accounts for the given number of Nodes removed under the given chain
+func (q *quotaSet) release(chain *ancestry, count int64) {
+	for _, limit := range q.along(chain, -1) {
+		limit.used.Add(-count)
+	}
+}
+
+// fits reports whether the given number of Nodes moved from the from chain to the to chain fit within
+// the quotas of the subtrees they enter. It must be called with the structural lock held exclusively
+func (q *quotaSet) fits(from, to *ancestry, count int64) bool {
+	for _, limit := range q.along(to, from.Common(to).Depth()) {
+		if limit.used.Load()+count > limit.max {
+			return false
+		}
+	}
+	return true
+}
+
+// move accounts for the given number of Nodes moved from the from chain to the to chain
+func (q *quotaSet) move(from, to *ancestry, count int64) {
+	depth := from.Common(to).Depth()
+	for _, limit := range q.along(from, depth) {
+		limit.used.Add(-count)
+	}
+	for _, limit := range q.along(to, depth) {
+		limit.used.Add(count)
+	}
+}
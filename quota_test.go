@@ -0,0 +1,137 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuota(t *testing.T) {
+	newQuotaTree := func(t *testing.T) *Tree[string] {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", ""), "a"))
+		require.NoError(t, tree.SetQuota(NewNode("a", ""), 3))
+		return tree
+	}
+
+	t.Run("limits the additions", func(t *testing.T) {
+		tree := newQuotaTree(t)
+		maxNodes, used, ok := tree.Quota(NewNode("a", ""))
+		require.True(t, ok)
+		assert.Equal(t, 3, maxNodes)
+		assert.Equal(t, 2, used)
+
+		require.NoError(t, tree.AddByID(NewNode("a11", ""), "a1"))
+		err := tree.AddByID(NewNode("a2", ""), "a")
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+		assert.ErrorIs(t, tree.InsertChildAt(NewNode("a1", ""), NewNode("a12", ""), 0), ErrQuotaExceeded)
+		assert.EqualValues(t, 5, tree.Size())
+
+		// the other subtrees are not limited
+		require.NoError(t, tree.AddByID(NewNode("b1", ""), "b"))
+
+		// deleting frees the quota
+		require.NoError(t, tree.DeleteByID("a11"))
+		require.NoError(t, tree.AddByID(NewNode("a2", ""), "a"))
+		_, used, _ = tree.Quota(NewNode("a", ""))
+		assert.Equal(t, 3, used)
+	})
+
+	t.Run("limits the moves", func(t *testing.T) {
+		tree := newQuotaTree(t)
+		require.NoError(t, tree.AddByID(NewNode("b1", ""), "b"))
+		require.NoError(t, tree.AddByID(NewNode("b11", ""), "b1"))
+
+		assert.ErrorIs(t, tree.MoveByID("b1", "a"), ErrQuotaExceeded)
+		require.NoError(t, tree.MoveByID("b11", "a1"))
+		_, used, _ := tree.Quota(NewNode("a", ""))
+		assert.Equal(t, 3, used)
+
+		// moving within the subtree does not change the quota
+		require.NoError(t, tree.MoveByID("b11", "a"))
+		_, used, _ = tree.Quota(NewNode("a", ""))
+		assert.Equal(t, 3, used)
+
+		require.NoError(t, tree.MoveByID("a1", "b"))
+		_, used, _ = tree.Quota(NewNode("a", ""))
+		assert.Equal(t, 2, used)
+	})
+
+	t.Run("nests the quotas", func(t *testing.T) {
+		tree := newQuotaTree(t)
+		require.NoError(t, tree.SetQuota(tree.Root(), 5))
+		require.NoError(t, tree.AddByID(NewNode("b1", ""), "b"))
+		assert.ErrorIs(t, tree.AddByID(NewNode("a2", ""), "a"), ErrQuotaExceeded)
+		_, used, _ := tree.Quota(NewNode("a", ""))
+		assert.Equal(t, 2, used)
+		assert.ErrorIs(t, tree.AddByID(NewNode("b2", ""), "b"), ErrQuotaExceeded)
+	})
+
+	t.Run("removes the quotas", func(t *testing.T) {
+		tree := newQuotaTree(t)
+		require.NoError(t, tree.SetQuota(NewNode("a", ""), 0))
+		_, _, ok := tree.Quota(NewNode("a", ""))
+		assert.False(t, ok)
+		require.NoError(t, tree.AddByID(NewNode("a2", ""), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a3", ""), "a"))
+
+		require.NoError(t, tree.SetQuota(NewNode("b", ""), 1))
+		require.NoError(t, tree.DeleteByID("b"))
+		_, _, ok = tree.Quota(NewNode("b", ""))
+		assert.False(t, ok)
+		require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b1", ""), "b"))
+
+		assert.ErrorIs(t, tree.SetQuota(NewNode("missing", ""), 1), ErrNotFound)
+	})
+
+	t.Run("holds under concurrent additions", func(t *testing.T) {
+		tree := newQuotaTree(t)
+		require.NoError(t, tree.SetQuota(NewNode("a", ""), 50))
+
+		var wg sync.WaitGroup
+		for i := range 100 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = tree.AddByID(NewNode("n"+strconv.Itoa(i), ""), "a1")
+			}()
+		}
+		wg.Wait()
+
+		descendants, _ := tree.DescendantsByID("a")
+		assert.Len(t, descendants, 49)
+		_, used, _ := tree.Quota(NewNode("a", ""))
+		assert.Equal(t, 50, used)
+	})
+}
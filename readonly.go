@@ -0,0 +1,301 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import "context"
+
+// ReadOnlyTree is a read-only view of a Tree.
+//
+// A ReadOnlyTree only exposes the methods of the Tree that do not modify it, so that handing it over
+// to third-party code (e.g. plugins) guarantees at compile time that the hierarchy is not modified
+// through it. It does not copy the Tree: the view reflects the changes made by the owner of the Tree.
+// Take a Snapshot when a point-in-time copy is needed instead.
+//
+// The values of the Nodes are shared with the Tree, hence values holding pointers remain mutable
+// through them. A ReadOnlyTree is safe for concurrent use.
+type ReadOnlyTree[T any] struct {
+	tree *Tree[T]
+}
+
+// Freeze returns a read-only view of the Tree.
+//
+// Returns:
+//   - ReadOnlyTree[T]: The view exposing the read methods of the Tree only.
+//
+// Notes:
+//   - The view is live: it does not prevent the owner of the Tree from modifying it.
+//   - Creating a view is cheap, nothing is copied.
+//
+// Example usage:
+//
+//	plugin.Inspect(tree.Freeze())
+func (x *Tree[T]) Freeze() ReadOnlyTree[T] {
+	return ReadOnlyTree[T]{tree: x}
+}
+
+// Find behaves like Tree.Find
+func (r ReadOnlyTree[T]) Find(key string) (item Node[T], ok bool) {
+	return r.tree.Find(key)
+}
+
+// FindWithVersion behaves like Tree.FindWithVersion
+func (r ReadOnlyTree[T]) FindWithVersion(key string) (item Node[T], version uint64, ok bool) {
+	return r.tree.FindWithVersion(key)
+}
+
+// Root behaves like Tree.Root
+func (r ReadOnlyTree[T]) Root() Node[T] {
+	return r.tree.Root()
+}
+
+// RootOK behaves like Tree.RootOK
+func (r ReadOnlyTree[T]) RootOK() (root Node[T], ok bool) {
+	return r.tree.RootOK()
+}
+
+// Size behaves like Tree.Size
+func (r ReadOnlyTree[T]) Size() int64 {
+	return r.tree.Size()
+}
+
+// Depth behaves like Tree.Depth
+func (r ReadOnlyTree[T]) Depth() int {
+	return r.tree.Depth()
+}
+
+// Nodes behaves like Tree.Nodes
+func (r ReadOnlyTree[T]) Nodes() []Node[T] {
+	return r.tree.Nodes()
+}
+
+// Values behaves like Tree.Values
+func (r ReadOnlyTree[T]) Values() []T {
+	return r.tree.Values()
+}
+
+// IDs behaves like Tree.IDs
+func (r ReadOnlyTree[T]) IDs() []string {
+	return r.tree.IDs()
+}
+
+// Ancestors behaves like Tree.Ancestors
+func (r ReadOnlyTree[T]) Ancestors(node Node[T]) (ancestors []Node[T], ok bool) {
+	return r.tree.Ancestors(node)
+}
+
+// AncestorsByID behaves like Tree.AncestorsByID
+func (r ReadOnlyTree[T]) AncestorsByID(id string) (ancestors []Node[T], ok bool) {
+	return r.tree.AncestorsByID(id)
+}
+
+// DescendantsTree behaves like Tree.DescendantsTree
+func (r ReadOnlyTree[T]) DescendantsTree(node Node[T]) (entries []DescendantEntry[T], ok bool) {
+	return r.tree.DescendantsTree(node)
+}
+
+// AncestorsOrdered behaves like Tree.AncestorsOrdered
+func (r ReadOnlyTree[T]) AncestorsOrdered(node Node[T], order AncestorOrder) (ancestors []Node[T], ok bool) {
+	return r.tree.AncestorsOrdered(node, order)
+}
+
+// WalkUp behaves like Tree.WalkUp
+func (r ReadOnlyTree[T]) WalkUp(node Node[T], fn func(ancestor Node[T]) bool) (ok bool) {
+	return r.tree.WalkUp(node, fn)
+}
+
+// ParentOf behaves like Tree.ParentOf
+func (r ReadOnlyTree[T]) ParentOf(node Node[T]) (parent Node[T], ok bool) {
+	return r.tree.ParentOf(node)
+}
+
+// Child behaves like Tree.Child
+func (r ReadOnlyTree[T]) Child(parent Node[T], childID string) (child Node[T], ok bool) {
+	return r.tree.Child(parent, childID)
+}
+
+// ParentAt behaves like Tree.ParentAt
+func (r ReadOnlyTree[T]) ParentAt(node Node[T], level uint) (parent Node[T], ok bool) {
+	return r.tree.ParentAt(node, level)
+}
+
+// Descendants behaves like Tree.Descendants
+func (r ReadOnlyTree[T]) Descendants(node Node[T]) (descendants []Node[T], ok bool) {
+	return r.tree.Descendants(node)
+}
+
+// DescendantsByID behaves like Tree.DescendantsByID
+func (r ReadOnlyTree[T]) DescendantsByID(id string) (descendants []Node[T], ok bool) {
+	return r.tree.DescendantsByID(id)
+}
+
+// DescendantsPage behaves like Tree.DescendantsPage
+func (r ReadOnlyTree[T]) DescendantsPage(node Node[T], cursor string, limit int) (page []Node[T], nextCursor string, ok bool) {
+	return r.tree.DescendantsPage(node, cursor, limit)
+}
+
+// Cursor behaves like Tree.Cursor
+func (r ReadOnlyTree[T]) Cursor(start Node[T]) (cursor *Cursor[T], ok bool) {
+	return r.tree.Cursor(start)
+}
+
+// Zipper behaves like Tree.Zipper. The edits of the Zipper never reach the Tree
+func (r ReadOnlyTree[T]) Zipper() (zipper *Zipper[T], ok bool) {
+	return r.tree.Zipper()
+}
+
+// PathOf behaves like Tree.PathOf
+func (r ReadOnlyTree[T]) PathOf(node Node[T]) (path string, ok bool) {
+	return r.tree.PathOf(node)
+}
+
+// FindByPath behaves like Tree.FindByPath
+func (r ReadOnlyTree[T]) FindByPath(path string) (item Node[T], ok bool) {
+	return r.tree.FindByPath(path)
+}
+
+// Glob behaves like Tree.Glob
+func (r ReadOnlyTree[T]) Glob(pattern string) (nodes []Node[T]) {
+	return r.tree.Glob(pattern)
+}
+
+// Query behaves like Tree.Query
+func (r ReadOnlyTree[T]) Query() *Query[T] {
+	return r.tree.Query()
+}
+
+// Lookup behaves like Tree.Lookup
+func (r ReadOnlyTree[T]) Lookup(name, key string) (nodes []Node[T], ok bool) {
+	return r.tree.Lookup(name, key)
+}
+
+// FindByTag behaves like Tree.FindByTag
+func (r ReadOnlyTree[T]) FindByTag(tag string) (nodes []Node[T]) {
+	return r.tree.FindByTag(tag)
+}
+
+// Tags behaves like Tree.Tags
+func (r ReadOnlyTree[T]) Tags(id string) (tags []string, ok bool) {
+	return r.tree.Tags(id)
+}
+
+// Meta behaves like Tree.Meta
+func (r ReadOnlyTree[T]) Meta(id string) (meta map[string]any, ok bool) {
+	return r.tree.Meta(id)
+}
+
+// Edge behaves like Tree.Edge
+func (r ReadOnlyTree[T]) Edge(node Node[T]) (edge EdgeData, ok bool) {
+	return r.tree.Edge(node)
+}
+
+// Parents behaves like Tree.Parents
+func (r ReadOnlyTree[T]) Parents(node Node[T]) (parents []Node[T], ok bool) {
+	return r.tree.Parents(node)
+}
+
+// LinkedChildren behaves like Tree.LinkedChildren
+func (r ReadOnlyTree[T]) LinkedChildren(parent Node[T]) (children []Node[T], ok bool) {
+	return r.tree.LinkedChildren(parent)
+}
+
+// Rollup behaves like Tree.Rollup
+func (r ReadOnlyTree[T]) Rollup(name string, node Node[T]) (value float64, ok bool) {
+	return r.tree.Rollup(name, node)
+}
+
+// LowestCommonAncestor behaves like Tree.LowestCommonAncestor
+func (r ReadOnlyTree[T]) LowestCommonAncestor(a, b Node[T]) (ancestor Node[T], ok bool) {
+	return r.tree.LowestCommonAncestor(a, b)
+}
+
+// Distance behaves like Tree.Distance
+func (r ReadOnlyTree[T]) Distance(a, b Node[T]) (distance int, ok bool) {
+	return r.tree.Distance(a, b)
+}
+
+// Diameter behaves like Tree.Diameter
+func (r ReadOnlyTree[T]) Diameter() int {
+	return r.tree.Diameter()
+}
+
+// Center behaves like Tree.Center
+func (r ReadOnlyTree[T]) Center() []Node[T] {
+	return r.tree.Center()
+}
+
+// EulerTour behaves like Tree.EulerTour
+func (r ReadOnlyTree[T]) EulerTour() EulerTour[T] {
+	return r.tree.EulerTour()
+}
+
+// Stats behaves like Tree.Stats
+func (r ReadOnlyTree[T]) Stats() Stats {
+	return r.tree.Stats()
+}
+
+// Balance behaves like Tree.Balance
+func (r ReadOnlyTree[T]) Balance() Balance {
+	return r.tree.Balance()
+}
+
+// TopSubtrees behaves like Tree.TopSubtrees
+func (r ReadOnlyTree[T]) TopSubtrees(k int) []SubtreeInfo {
+	return r.tree.TopSubtrees(k)
+}
+
+// TopSubtreesBy behaves like Tree.TopSubtreesBy
+func (r ReadOnlyTree[T]) TopSubtreesBy(k int, weight func(node Node[T]) float64) []SubtreeInfo {
+	return r.tree.TopSubtreesBy(k, weight)
+}
+
+// FindDuplicateSubtrees behaves like Tree.FindDuplicateSubtrees
+func (r ReadOnlyTree[T]) FindDuplicateSubtrees(hash func(T) []byte) [][]Node[T] {
+	return r.tree.FindDuplicateSubtrees(hash)
+}
+
+// Validate behaves like Tree.Validate
+func (r ReadOnlyTree[T]) Validate() []error {
+	return r.tree.Validate()
+}
+
+// Snapshot behaves like Tree.Snapshot
+func (r ReadOnlyTree[T]) Snapshot() Snapshot[T] {
+	return r.tree.Snapshot()
+}
+
+// Watch behaves like Tree.Watch
+func (r ReadOnlyTree[T]) Watch(ctx context.Context, filter func(event Event[T]) bool, opts ...WatchOption) (events <-chan Event[T]) {
+	return r.tree.Watch(ctx, filter, opts...)
+}
+
+// String behaves like Tree.String
+func (r ReadOnlyTree[T]) String() string {
+	return r.tree.String()
+}
+
+// Dump behaves like Tree.Dump
+func (r ReadOnlyTree[T]) Dump() string {
+	return r.tree.Dump()
+}
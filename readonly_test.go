@@ -0,0 +1,77 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeze(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", "b"), "a"))
+
+	view := tree.Freeze()
+
+	t.Run("reads the tree", func(t *testing.T) {
+		assert.EqualValues(t, 3, view.Size())
+		assert.Equal(t, "root", view.Root().ID())
+		assert.Equal(t, 2, view.Depth())
+
+		b, ok := view.Find("b")
+		require.True(t, ok)
+		ancestors, ok := view.Ancestors(b)
+		require.True(t, ok)
+		assert.Equal(t, []string{"a", "root"}, queryIDs(ancestors))
+
+		descendants, ok := view.DescendantsByID("root")
+		require.True(t, ok)
+		assert.Len(t, descendants, 2)
+		assert.Equal(t, tree.Dump(), view.Dump())
+	})
+
+	t.Run("reflects the changes of the tree", func(t *testing.T) {
+		require.NoError(t, tree.AddByID(NewNode("c", "c"), "root"))
+		_, ok := view.Find("c")
+		assert.True(t, ok)
+		assert.EqualValues(t, 4, view.Size())
+	})
+
+	t.Run("exposes no mutating method", func(t *testing.T) {
+		viewType := reflect.TypeOf(view)
+		for _, name := range []string{
+			"Add", "AddByID", "AddOrReplace", "Update", "UpdateIf", "Delete", "DeleteByID", "Move", "MoveByID",
+			"Reset", "Restore", "ApplyDelta", "SetMeta", "Tag", "Link", "Expand", "CreateIndex", "Prune", "Repair",
+		} {
+			_, ok := viewType.MethodByName(name)
+			assert.False(t, ok, name)
+		}
+	})
+}
@@ -0,0 +1,212 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+// Expand materializes the children of the given Node with the resolver set by WithChildResolver.
+//
+// The resolver is only called the first time the children of a Node are requested: the resolved
+// children are added to the Tree like any other Node, hence they are indexed, watched and persisted,
+// and the later calls return the children held by the Tree. It lets a Tree index a catalog too large
+// to be loaded eagerly, the subtrees being fetched as the Tree is explored.
+//
+// Parameters:
+//   - ctx: The context passed to the resolver.
+//   - node: The Node[T] whose children are requested.
+//
+// Returns:
+//   - []Node[T]: The children of the Node, in order.
+//   - error: ErrNotFound when the Node is not part of the Tree, or the error of the resolver or of the
+//     insertion of the resolved children. The Node remains unresolved on error, hence the next call
+//     retries.
+//
+// Notes:
+//   - The resolved children already part of the Tree are skipped, so that a resolver may return the
+//     children added with Add.
+//   - Concurrent calls for the same Node share a single call of the resolver.
+//   - Cursor resolves the children as it descends, hence the walks of DescendantsPage do as well. The
+//     other traversals only visit the Nodes already materialized.
+//   - Deleting a Node forgets its resolution: its children are resolved again once it is added back.
+//   - Expand returns the children held by the Tree when no resolver is set.
+//   - With WithAuthorizer, the authorizer is consulted with OperationAdd and the given Node first.
+//
+// Example usage:
+//
+//	children, err := tree.Expand(ctx, folder)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, child := range children {
+//	    fmt.Println("Child:", child.ID())
+//	}
+func (x *Tree[T]) Expand(ctx context.Context, node Node[T]) ([]Node[T], error) {
+	if err := x.authorizeExisting(ctx, OperationAdd, node.ID(), opExpand, ""); err != nil {
+		return nil, err
+	}
+	if err := x.expand(ctx, node.ID()); err != nil {
+		return nil, err
+	}
+
+	n, ok := x.getNode(node.ID())
+	if !ok {
+		return nil, newNodeError(opExpand, node.ID(), "", ErrNotFound)
+	}
+	items := n.Descendants.Items()
+	children := make([]Node[T], len(items))
+	for i, item := range items {
+		children[i] = item.GetValue()
+	}
+	return children, nil
+}
+
+// expand resolves the children of the Node with the given ID unless they already are
+func (x *Tree[T]) expand(ctx context.Context, id string) error {
+	if x.resolver == nil {
+		return nil
+	}
+	if _, ok := x.getNode(id); !ok {
+		return newNodeError(opExpand, id, "", ErrNotFound)
+	}
+
+	call, leader := x.resolver.begin(id)
+	if !leader {
+		select {
+		case <-call.done:
+			return call.err
+		case <-ctx.Done():
+			return newNodeError(opExpand, id, "", ctx.Err())
+		}
+	}
+
+	call.err = x.materialize(ctx, id)
+	x.resolver.end(id, call)
+	return call.err
+}
+
+// materialize calls the resolver and adds the resolved children under the Node with the given ID
+func (x *Tree[T]) materialize(ctx context.Context, id string) error {
+	children, err := x.resolver.resolve(ctx, id)
+	if err != nil {
+		return newNodeError(opExpand, id, "", err)
+	}
+
+	for _, child := range children {
+		if err := x.addByIDContext(ctx, child, id, nil); err != nil && !errors.Is(err, ErrDuplicateID) {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandQuietly resolves the children of the Node with the given ID for the traversals which do not
+// report errors. The failures are logged and the Node is walked as it is
+func (x *Tree[T]) expandQuietly(id string) {
+	if err := x.expand(context.Background(), id); err != nil {
+		x.log(slog.LevelWarn, "tree children not resolved", slog.String("node", id), slog.String("error", err.Error()))
+	}
+}
+
+// childResolver tracks the Nodes whose children have been materialized by the resolver
+type childResolver[T any] struct {
+	resolve  func(ctx context.Context, id string) ([]Node[T], error)
+	mu       sync.Mutex
+	resolved map[string]struct{}
+	inflight map[string]*resolveCall
+}
+
+// resolveCall is a resolution in progress, shared by the concurrent callers
+type resolveCall struct {
+	done chan struct{}
+	err  error
+}
+
+// newChildResolver creates a childResolver calling the given resolver
+func newChildResolver[T any](resolve func(ctx context.Context, id string) ([]Node[T], error)) *childResolver[T] {
+	return &childResolver[T]{
+		resolve:  resolve,
+		resolved: make(map[string]struct{}),
+		inflight: make(map[string]*resolveCall),
+	}
+}
+
+// begin returns the resolution of the given Node and whether the caller leads it. A resolved Node
+// yields a completed call
+func (r *childResolver[T]) begin(id string) (*resolveCall, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.resolved[id]; ok {
+		call := &resolveCall{done: make(chan struct{})}
+		close(call.done)
+		return call, false
+	}
+	if call, ok := r.inflight[id]; ok {
+		return call, false
+	}
+
+	call := &resolveCall{done: make(chan struct{})}
+	r.inflight[id] = call
+	return call, true
+}
+
+// end completes the resolution of the given Node, marking it resolved on success
+func (r *childResolver[T]) end(id string, call *resolveCall) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.inflight, id)
+	if call.err == nil {
+		r.resolved[id] = struct{}{}
+	}
+	close(call.done)
+}
+
+// forget drops the resolution of the given Nodes. It is nil safe
+func (r *childResolver[T]) forget(ids ...string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range ids {
+		delete(r.resolved, id)
+	}
+}
+
+// clear drops the resolution of all the Nodes. It is nil safe
+func (r *childResolver[T]) clear() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.resolved)
+}
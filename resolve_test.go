@@ -0,0 +1,203 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpand(t *testing.T) {
+	// catalog resolves two children per Node down to the third level
+	newCatalog := func(calls *atomic.Int64) Option {
+		return WithChildResolver(func(_ context.Context, id string) ([]Node[string], error) {
+			calls.Add(1)
+			if len(id) > 3 {
+				return nil, nil
+			}
+			return []Node[string]{NewNode(id+"0", id+"0"), NewNode(id+"1", id+"1")}, nil
+		})
+	}
+
+	t.Run("resolves the children once", func(t *testing.T) {
+		var calls atomic.Int64
+		tree := NewTree[string](newCatalog(&calls))
+		root := NewNode("r", "r")
+		require.NoError(t, tree.Add(root, nil))
+		assert.EqualValues(t, 1, tree.Size())
+
+		children, err := tree.Expand(context.Background(), root)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"r0", "r1"}, queryIDs(children))
+		assert.EqualValues(t, 3, tree.Size())
+
+		children, err = tree.Expand(context.Background(), root)
+		require.NoError(t, err)
+		assert.Len(t, children, 2)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("materializes the subtrees walked by a cursor", func(t *testing.T) {
+		var calls atomic.Int64
+		tree := NewTree[string](newCatalog(&calls))
+		root := NewNode("r", "r")
+		require.NoError(t, tree.Add(root, nil))
+
+		cursor, ok := tree.Cursor(root)
+		require.True(t, ok)
+		var walked []string
+		for node, ok := cursor.Next(); ok; node, ok = cursor.Next() {
+			walked = append(walked, node.ID())
+		}
+		assert.Equal(t, []string{"r", "r0", "r00", "r000", "r001", "r01", "r010", "r011", "r1", "r10", "r100", "r101", "r11", "r110", "r111"}, walked)
+		assert.EqualValues(t, 15, tree.Size())
+		assert.EqualValues(t, 15, calls.Load())
+
+		// the materialized subtrees are not resolved again
+		page, _, ok := tree.DescendantsPage(root, "", 100)
+		require.True(t, ok)
+		assert.Len(t, page, 14)
+		assert.EqualValues(t, 15, calls.Load())
+	})
+
+	t.Run("skips the children already added", func(t *testing.T) {
+		var calls atomic.Int64
+		tree := NewTree[string](newCatalog(&calls))
+		require.NoError(t, tree.AddByID(NewNode("r", "r"), ""))
+		require.NoError(t, tree.AddByID(NewNode("r1", "local"), "r"))
+
+		root, _ := tree.Find("r")
+		children, err := tree.Expand(context.Background(), root)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"r1", "r0"}, queryIDs(children))
+		assert.Equal(t, "local", children[0].Value())
+	})
+
+	t.Run("resolves again the deleted nodes", func(t *testing.T) {
+		var calls atomic.Int64
+		tree := NewTree[string](newCatalog(&calls))
+		root := NewNode("r", "r")
+		require.NoError(t, tree.Add(root, nil))
+		_, err := tree.Expand(context.Background(), root)
+		require.NoError(t, err)
+
+		require.NoError(t, tree.DeleteByID("r"))
+		require.NoError(t, tree.Add(root, nil))
+		children, err := tree.Expand(context.Background(), root)
+		require.NoError(t, err)
+		assert.Len(t, children, 2)
+		assert.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("reports the errors", func(t *testing.T) {
+		failure := errors.New("catalog unavailable")
+		var fail atomic.Bool
+		fail.Store(true)
+		tree := NewTree[string](WithChildResolver(func(context.Context, string) ([]Node[string], error) {
+			if fail.Load() {
+				return nil, failure
+			}
+			return []Node[string]{NewNode("child", "")}, nil
+		}))
+		root := NewNode("root", "")
+		require.NoError(t, tree.Add(root, nil))
+
+		_, err := tree.Expand(context.Background(), root)
+		require.ErrorIs(t, err, failure)
+		var nodeErr *NodeError
+		require.ErrorAs(t, err, &nodeErr)
+		assert.Equal(t, "expand", nodeErr.Op())
+
+		// the cursor walks the node as it is
+		cursor, _ := tree.Cursor(root)
+		_, ok := cursor.FirstChild()
+		assert.False(t, ok)
+
+		// the node remains unresolved
+		fail.Store(false)
+		children, err := tree.Expand(context.Background(), root)
+		require.NoError(t, err)
+		assert.Len(t, children, 1)
+
+		_, err = tree.Expand(context.Background(), NewNode("missing", ""))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("shares the concurrent resolutions", func(t *testing.T) {
+		var calls atomic.Int64
+		release := make(chan struct{})
+		tree := NewTree[string](WithChildResolver(func(context.Context, string) ([]Node[string], error) {
+			calls.Add(1)
+			<-release
+			return []Node[string]{NewNode("child", "")}, nil
+		}))
+		root := NewNode("root", "")
+		require.NoError(t, tree.Add(root, nil))
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := tree.Expand(context.Background(), root)
+				errs <- err
+			}()
+		}
+		close(release)
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+		assert.EqualValues(t, 1, calls.Load())
+		assert.EqualValues(t, 2, tree.Size())
+	})
+
+	t.Run("returns the children without resolver", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("child", ""), "root"))
+		root, _ := tree.Find("root")
+		children, err := tree.Expand(context.Background(), root)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"child"}, queryIDs(children))
+	})
+
+	t.Run("panics on a resolver of another type", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewTree[int](WithChildResolver(func(context.Context, string) ([]Node[string], error) {
+				return nil, fmt.Errorf("unused")
+			}))
+		})
+	})
+}
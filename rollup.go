@@ -0,0 +1,175 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import "sync"
+
+// Rollup returns the value of the named aggregate over the subtree rooted at the given Node.
+//
+// The aggregates are registered with WithAggregate when the Tree is created and kept up to date
+// by every mutation, so that reading a rollup is a constant time lookup rather than a traversal.
+//
+// Parameters:
+//   - name: The name of the aggregate given to WithAggregate.
+//   - node: The root of the subtree.
+//
+// Returns:
+//   - value: The sum of the values extracted from the Nodes of the subtree, the given Node included.
+//   - ok: false when no aggregate is registered under the name or the Node is not part of the Tree.
+//
+// Example usage:
+//
+//	tree := NewTree[Employee](
+//	    WithAggregate[Employee]("headcount", nil),
+//	    WithAggregate("payroll", func(employee Employee) float64 { return employee.Salary }),
+//	)
+//	// ...
+//	headcount, _ := tree.Rollup("headcount", department)
+//	payroll, _ := tree.Rollup("payroll", department)
+func (x *Tree[T]) Rollup(name string, node Node[T]) (value float64, ok bool) {
+	aggregate, ok := x.rollups[name]
+	if !ok {
+		return 0, false
+	}
+
+	aggregate.mu.RLock()
+	defer aggregate.mu.RUnlock()
+	value, ok = aggregate.totals[node.ID()]
+	return value, ok
+}
+
+// rollup maintains the sum of the values extracted from the Nodes of every subtree
+type rollup[T any] struct {
+	extract func(value T) float64
+	mu      sync.RWMutex
+	// own maps every Node ID to the value extracted from the Node
+	own map[string]float64
+	// totals maps every Node ID to the sum of the values extracted from its subtree
+	totals map[string]float64
+}
+
+// newRollup creates an empty rollup extracting the values with the given function.
+// A nil function counts the Nodes
+func newRollup[T any](extract func(value T) float64) *rollup[T] {
+	if extract == nil {
+		extract = func(T) float64 { return 1 }
+	}
+	return &rollup[T]{
+		extract: extract,
+		own:     make(map[string]float64),
+		totals:  make(map[string]float64),
+	}
+}
+
+// rollup updates the aggregates with the current value of the given Node, which is either new or
+// updated, by adding the difference with its previous value to the Node and its ancestors.
+// The value is read under the aggregate lock so that concurrent updates of the same Node leave
+// the aggregates with its latest value.
+func (x *Tree[T]) rollup(node *treeNode[T]) {
+	for _, aggregate := range x.rollups {
+		aggregate.mu.Lock()
+		if data := node.GetValue(); data != nil {
+			value := aggregate.extract(data.Value())
+			delta := value - aggregate.own[node.ID]
+			aggregate.own[node.ID] = value
+			for link := node.GetPath(); link != nil; link = link.parent {
+				aggregate.totals[link.id] += delta
+			}
+		}
+		aggregate.mu.Unlock()
+	}
+}
+
+// unrollup removes the subtree made of the Nodes with the given IDs, the first being its root,
+// from the aggregates of the ancestors of the given chain
+func (x *Tree[T]) unrollup(parent *ancestry, ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	for _, aggregate := range x.rollups {
+		aggregate.mu.Lock()
+		total := aggregate.totals[ids[0]]
+		for link := parent; link != nil; link = link.parent {
+			aggregate.totals[link.id] -= total
+		}
+		for _, id := range ids {
+			delete(aggregate.own, id)
+			delete(aggregate.totals, id)
+		}
+		aggregate.mu.Unlock()
+	}
+}
+
+// moveRollups moves the aggregates of the subtree rooted at the Node with the given ID from the
+// ancestors of the from chain to the ancestors of the to chain
+func (x *Tree[T]) moveRollups(id string, from, to *ancestry) {
+	for _, aggregate := range x.rollups {
+		aggregate.mu.Lock()
+		total := aggregate.totals[id]
+		for link := from; link != nil; link = link.parent {
+			aggregate.totals[link.id] -= total
+		}
+		for link := to; link != nil; link = link.parent {
+			aggregate.totals[link.id] += total
+		}
+		aggregate.mu.Unlock()
+	}
+}
+
+// clearRollups removes all the entries of the aggregates, keeping their definitions
+func (x *Tree[T]) clearRollups() {
+	for _, aggregate := range x.rollups {
+		aggregate.mu.Lock()
+		aggregate.own = make(map[string]float64)
+		aggregate.totals = make(map[string]float64)
+		aggregate.mu.Unlock()
+	}
+}
+
+// rebuildRollups recomputes the aggregates from the Nodes reachable from the root
+func (x *Tree[T]) rebuildRollups() {
+	if len(x.rollups) == 0 {
+		return
+	}
+
+	x.clearRollups()
+	root := x.rootNode.Load()
+	if root == nil {
+		return
+	}
+
+	var walk func(node *treeNode[T])
+	guard := x.newCycleGuard(false, root)
+	walk = func(node *treeNode[T]) {
+		x.rollup(node)
+		for _, child := range node.Descendants.Items() {
+			if guard.visit(node, child) {
+				walk(child)
+			}
+		}
+	}
+	walk(root)
+}
@@ -0,0 +1,146 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollup(t *testing.T) {
+	newRollupTree := func(t *testing.T) *Tree[int] {
+		tree := NewTree[int](
+			WithAggregate[int]("count", nil),
+			WithAggregate("sum", func(value int) float64 { return float64(value) }),
+		)
+		require.NoError(t, tree.AddByID(NewNode("root", 1), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", 2), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", 3), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", 4), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a2", 5), "a"))
+		return tree
+	}
+
+	assertRollup := func(t *testing.T, tree *Tree[int], name, id string, expected float64) {
+		t.Helper()
+		value, ok := tree.Rollup(name, NewNode(id, 0))
+		require.True(t, ok, "%s of %s", name, id)
+		assert.InDelta(t, expected, value, 0.0001, "%s of %s", name, id)
+	}
+
+	t.Run("rolls up the additions", func(t *testing.T) {
+		tree := newRollupTree(t)
+		assertRollup(t, tree, "count", "root", 5)
+		assertRollup(t, tree, "count", "a", 3)
+		assertRollup(t, tree, "count", "a1", 1)
+		assertRollup(t, tree, "sum", "root", 15)
+		assertRollup(t, tree, "sum", "a", 11)
+
+		_, ok := tree.Rollup("missing", NewNode("root", 0))
+		assert.False(t, ok)
+		_, ok = tree.Rollup("sum", NewNode("missing", 0))
+		assert.False(t, ok)
+	})
+
+	t.Run("rolls up the updates", func(t *testing.T) {
+		tree := newRollupTree(t)
+		require.NoError(t, tree.Update(NewNode("a1", 10)))
+		assertRollup(t, tree, "sum", "a1", 10)
+		assertRollup(t, tree, "sum", "a", 17)
+		assertRollup(t, tree, "sum", "root", 21)
+
+		require.NoError(t, tree.AddOrReplace(NewNode("b", 0), NewNode("root", 0)))
+		assertRollup(t, tree, "sum", "root", 18)
+		assertRollup(t, tree, "count", "root", 5)
+
+		// replacing under another parent removes the subtree first
+		require.NoError(t, tree.AddOrReplace(NewNode("a", 1), NewNode("b", 0)))
+		assertRollup(t, tree, "sum", "b", 1)
+		assertRollup(t, tree, "count", "b", 2)
+		assertRollup(t, tree, "count", "root", 3)
+	})
+
+	t.Run("rolls up the deletions", func(t *testing.T) {
+		tree := newRollupTree(t)
+		require.NoError(t, tree.DeleteByID("a"))
+		assertRollup(t, tree, "count", "root", 2)
+		assertRollup(t, tree, "sum", "root", 4)
+		_, ok := tree.Rollup("sum", NewNode("a1", 0))
+		assert.False(t, ok)
+
+		tree.Reset()
+		_, ok = tree.Rollup("sum", NewNode("root", 0))
+		assert.False(t, ok)
+
+		require.NoError(t, tree.AddByID(NewNode("root", 7), ""))
+		assertRollup(t, tree, "sum", "root", 7)
+	})
+
+	t.Run("rolls up the moves", func(t *testing.T) {
+		tree := newRollupTree(t)
+		require.NoError(t, tree.MoveByID("a", "b"))
+		assertRollup(t, tree, "count", "b", 4)
+		assertRollup(t, tree, "sum", "b", 14)
+		assertRollup(t, tree, "sum", "a", 11)
+		assertRollup(t, tree, "sum", "root", 15)
+
+		require.NoError(t, tree.MoveByID("a1", "root"))
+		assertRollup(t, tree, "sum", "b", 10)
+		assertRollup(t, tree, "sum", "a", 7)
+		assertRollup(t, tree, "sum", "root", 15)
+	})
+
+	t.Run("rolls up concurrent changes", func(t *testing.T) {
+		tree := newRollupTree(t)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				parent := fmt.Sprintf("p%d", i)
+				assert.NoError(t, tree.AddByID(NewNode(parent, 1), "a"))
+				for j := 0; j < 50; j++ {
+					assert.NoError(t, tree.AddByID(NewNode(fmt.Sprintf("%s-%d", parent, j), 1), parent))
+				}
+				assert.NoError(t, tree.Update(NewNode(parent, 2)))
+			}(i)
+		}
+		wg.Wait()
+
+		assertRollup(t, tree, "count", "root", 5+8*51)
+		assertRollup(t, tree, "sum", "a", 11+8*52)
+		assertRollup(t, tree, "sum", "root", 15+8*52)
+	})
+
+	t.Run("panics when the aggregate does not match the values", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewTree[string](WithAggregate("sum", func(value int) float64 { return float64(value) }))
+		})
+	})
+}
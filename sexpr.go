@@ -0,0 +1,378 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EncodeSExpr writes the Nodes of the Tree to the given writer as an s-expression, e.g.
+// (root (a (b) (c))), a compact text format suited to the test fixtures.
+//
+// Every Node is a list starting with its ID, followed by its value unless it is the zero value, and by
+// the lists of its children in their order. The values are encoded with encoding/json: the strings,
+// numbers and booleans are written as they are, and the other values as a quoted string holding their
+// JSON encoding. The IDs holding spaces, parentheses, quotes or semicolons are quoted.
+//
+// Parameters:
+//   - w: The writer receiving the s-expression.
+//
+// Returns:
+//   - err: An error when a value cannot be encoded or the writer fails.
+//
+// Notes:
+//   - The metadata, edges and tags of the Nodes are not encoded.
+//   - EncodeSExpr blocks the structural changes of the Tree while it captures the Nodes.
+//
+// Example usage:
+//
+//	var buf strings.Builder
+//	_ = tree.EncodeSExpr(&buf)
+//	fmt.Println(buf.String()) // Output: (root "root value" (a 1) (b))
+func (x *Tree[T]) EncodeSExpr(w io.Writer) error {
+	records := x.snapshot().Records
+	zero, err := json.Marshal(*new(T))
+	if err != nil {
+		return fmt.Errorf("gotree: cannot encode tree: %w", err)
+	}
+
+	writer := bufio.NewWriter(w)
+	var ids []string
+	for i, record := range records {
+		// close the lists of the Nodes the record does not descend from
+		for len(ids) > 0 && ids[len(ids)-1] != record.ParentID {
+			writer.WriteByte(')')
+			ids = ids[:len(ids)-1]
+		}
+		if i > 0 {
+			writer.WriteByte(' ')
+		}
+
+		writer.WriteByte('(')
+		writer.WriteString(sexprAtom(record.ID))
+		value, err := json.Marshal(record.Value)
+		if err != nil {
+			return fmt.Errorf("gotree: cannot encode node %q: %w", record.ID, err)
+		}
+		if !bytes.Equal(value, zero) {
+			writer.WriteByte(' ')
+			writer.WriteString(sexprValue(value))
+		}
+		ids = append(ids, record.ID)
+	}
+	writer.WriteString(strings.Repeat(")", len(ids)))
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("gotree: cannot encode tree: %w", err)
+	}
+	return nil
+}
+
+// sexprAtom returns the given text as an atom, quoted when it cannot be written bare
+func sexprAtom(text string) string {
+	if text == "" || strings.ContainsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(`()";`, r) || !unicode.IsPrint(r)
+	}) {
+		return strconv.Quote(text)
+	}
+	return text
+}
+
+// sexprValue returns the atom of the given JSON encoded value: the strings, numbers and
+// booleans are kept as they are, the other values are quoted
+func sexprValue(value []byte) string {
+	switch {
+	case value[0] == '"' && json.Valid(value) && !bytes.ContainsAny(value, "\\"):
+		return string(value)
+	case value[0] == '"':
+		var text string
+		if json.Unmarshal(value, &text) == nil {
+			return strconv.Quote(text)
+		}
+	case value[0] != '{' && value[0] != '[' && value[0] != 'n':
+		return string(value)
+	}
+	return strconv.Quote(string(value))
+}
+
+// DecodeSExpr replaces the Nodes of the Tree with the Nodes of the given s-expression, as written by
+// EncodeSExpr.
+//
+// The parser is tolerant so that the trees can be written by hand: the atoms can be bare or quoted,
+// the spacing is free, the lines starting with a semicolon are comments and the values can be omitted,
+// in which case the Nodes hold the zero value. A bare atom is decoded as a string when it is not valid
+// JSON for the values of the Tree, e.g. (root hello) for a Tree of strings.
+//
+// Parameters:
+//   - r: The reader providing the s-expression.
+//
+// Returns:
+//   - err: ErrCorruptedFile when the s-expression is malformed, or the error of the first Node
+//     that could not be added, in which case the Tree holds the Nodes added so far.
+//
+// Notes:
+//   - The hooks, the watchers and the Storage are notified of the decoded Nodes.
+//
+// Example usage:
+//
+//	tree := NewTree[int]()
+//	err := tree.DecodeSExpr(strings.NewReader(`
+//	    ; the fixture of the test
+//	    (root 1
+//	        (a 2 (a1) (a2))
+//	        (b 3))`))
+func (x *Tree[T]) DecodeSExpr(r io.Reader) error {
+	if err := x.requireContext(opLoad, "", ""); err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+
+	parser := &sexprParser{data: data, line: 1}
+	records, err := parseSExpr[T](parser)
+	if err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+
+	if err := x.restore(context.Background(), records); err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+	return nil
+}
+
+// FromSExpr builds a Tree from the given s-expression as decoded by DecodeSExpr. It is meant
+// for the test fixtures written by hand.
+//
+// Example usage:
+//
+//	tree, err := FromSExpr[string](`(root (a (b) (c)))`)
+func FromSExpr[T any](sexpr string, opts ...Option) (*Tree[T], error) {
+	tree := NewTree[T](opts...)
+	if err := tree.DecodeSExpr(strings.NewReader(sexpr)); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// sexprToken is a token of an s-expression
+type sexprToken struct {
+	// kind is '(' or ')' for the parentheses, 'a' for the atoms and 0 at the end of the input
+	kind byte
+	// text is the text of the atom, unquoted
+	text string
+	// quoted states whether the atom was quoted
+	quoted bool
+	// line and column locate the token
+	line, column int
+}
+
+// sexprParser reads the tokens of an s-expression
+type sexprParser struct {
+	data         []byte
+	offset       int
+	line, column int
+}
+
+// errorf returns an ErrCorruptedFile error located at the given token
+func (p *sexprParser) errorf(token sexprToken, format string, args ...any) error {
+	return fmt.Errorf("%w: line %d, column %d: %s", ErrCorruptedFile, token.line, token.column+1, fmt.Sprintf(format, args...))
+}
+
+// advance consumes the given number of bytes, tracking the position
+func (p *sexprParser) advance(n int) {
+	for _, b := range p.data[p.offset : p.offset+n] {
+		if b == '\n' {
+			p.line, p.column = p.line+1, 0
+		} else {
+			p.column++
+		}
+	}
+	p.offset += n
+}
+
+// next returns the next token, skipping the spaces and the comments
+func (p *sexprParser) next() (sexprToken, error) {
+	for p.offset < len(p.data) {
+		switch b := p.data[p.offset]; {
+		case b == ';':
+			end := bytes.IndexByte(p.data[p.offset:], '\n')
+			if end < 0 {
+				end = len(p.data) - p.offset
+			}
+			p.advance(end)
+		case unicode.IsSpace(rune(b)):
+			p.advance(1)
+		default:
+			return p.token()
+		}
+	}
+	return sexprToken{line: p.line, column: p.column}, nil
+}
+
+// token reads the token starting at the current offset
+func (p *sexprParser) token() (sexprToken, error) {
+	token := sexprToken{line: p.line, column: p.column}
+	rest := p.data[p.offset:]
+	switch rest[0] {
+	case '(', ')':
+		token.kind = rest[0]
+		p.advance(1)
+		return token, nil
+	case '"':
+		// find the closing quote, skipping the escaped characters
+		end := 1
+		for end < len(rest) && rest[end] != '"' {
+			if rest[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(rest) {
+			return token, p.errorf(token, "unterminated string")
+		}
+		text, err := strconv.Unquote(string(rest[:end+1]))
+		if err != nil {
+			return token, p.errorf(token, "invalid string %s", rest[:end+1])
+		}
+		token.kind, token.text, token.quoted = 'a', text, true
+		p.advance(end + 1)
+		return token, nil
+	default:
+		end := bytes.IndexFunc(rest, func(r rune) bool {
+			return unicode.IsSpace(r) || strings.ContainsRune(`()";`, r)
+		})
+		if end < 0 {
+			end = len(rest)
+		}
+		token.kind, token.text = 'a', string(rest[:end])
+		p.advance(end)
+		return token, nil
+	}
+}
+
+// parseSExpr parses the single Node list of an s-expression into records in depth-first order
+func parseSExpr[T any](p *sexprParser) ([]Record[T], error) {
+	token, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if token.kind == 0 {
+		return nil, nil
+	}
+	if token.kind != '(' {
+		return nil, p.errorf(token, "expected '(', got %q", token.text)
+	}
+
+	records, err := parseSExprNode[T](p, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if token, err = p.next(); err != nil {
+		return nil, err
+	}
+	if token.kind != 0 {
+		return nil, p.errorf(token, "unexpected content after the root")
+	}
+	return records, nil
+}
+
+// parseSExprNode parses the Node whose list has just been opened, up to its end,
+// and appends it along with its descendants to the given records
+func parseSExprNode[T any](p *sexprParser, parentID string, records []Record[T]) ([]Record[T], error) {
+	token, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if token.kind != 'a' {
+		return nil, p.errorf(token, "expected the ID of a node")
+	}
+
+	id := token.text
+	position := len(records)
+	records = append(records, Record[T]{ID: id, ParentID: parentID})
+
+	valued := false
+	for {
+		token, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+
+		switch token.kind {
+		case 0:
+			return nil, p.errorf(token, "unterminated node %q", id)
+		case ')':
+			return records, nil
+		case '(':
+			if records, err = parseSExprNode(p, id, records); err != nil {
+				return nil, err
+			}
+		case 'a':
+			if valued || position != len(records)-1 {
+				return nil, p.errorf(token, "unexpected value %q in node %q", token.text, id)
+			}
+			if records[position].Value, err = sexprDecodeValue[T](token); err != nil {
+				return nil, p.errorf(token, "invalid value of node %q: %v", id, err)
+			}
+			valued = true
+		}
+	}
+}
+
+// sexprDecodeValue decodes the value of the given atom
+func sexprDecodeValue[T any](token sexprToken) (T, error) {
+	var value T
+	text, _ := json.Marshal(token.text)
+
+	// the quoted atoms hold either a string or a JSON encoded value, and
+	// the bare atoms either a JSON literal or a string written without quotes
+	candidates := [][]byte{[]byte(token.text)}
+	if kind := reflect.TypeFor[T]().Kind(); token.quoted {
+		candidates = [][]byte{text, []byte(token.text)}
+	} else if kind == reflect.String || kind == reflect.Interface {
+		candidates = append(candidates, text)
+	}
+
+	var err error
+	for _, candidate := range candidates {
+		if err = json.Unmarshal(candidate, &value); err == nil {
+			return value, nil
+		}
+	}
+	return value, err
+}
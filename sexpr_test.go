@@ -0,0 +1,119 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSExpr(t *testing.T) {
+	t.Run("encodes compactly", func(t *testing.T) {
+		tree, err := FromSExpr[string](`(root (a (b) (c)) (d))`)
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		require.NoError(t, tree.EncodeSExpr(&buf))
+		assert.Equal(t, `(root (a (b) (c)) (d))`, buf.String())
+
+		buf.Reset()
+		require.NoError(t, NewTree[string]().EncodeSExpr(&buf))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("round-trips the values", func(t *testing.T) {
+		tree := NewTree[part]()
+		require.NoError(t, tree.AddByID(NewNode("root", part{Name: "engine", Qty: 1}), ""))
+		require.NoError(t, tree.AddByID(NewNode("a b", part{}), "root"))
+		require.NoError(t, tree.AddByID(NewNode("(c)", part{Name: `quote " and paren )`}), "a b"))
+
+		var buf strings.Builder
+		require.NoError(t, tree.EncodeSExpr(&buf))
+		assert.Equal(t, `(root "{\"Name\":\"engine\",\"Qty\":1,\"Note\":\"\"}" ("a b" ("(c)" "{\"Name\":\"quote \\\" and paren )\",\"Qty\":0,\"Note\":\"\"}")))`, buf.String())
+
+		decoded, err := FromSExpr[part](buf.String())
+		require.NoError(t, err)
+		assert.Equal(t, tree.Snapshot().Records, decoded.Snapshot().Records)
+
+		strs := NewTree[string]()
+		require.NoError(t, strs.AddByID(NewNode("root", "a <b>\n\x01"), ""))
+		require.NoError(t, strs.AddByID(NewNode("x", "12"), "root"))
+		buf.Reset()
+		require.NoError(t, strs.EncodeSExpr(&buf))
+		assert.Equal(t, `(root "a <b>\n\x01" (x "12"))`, buf.String())
+		decodedStrs, err := FromSExpr[string](buf.String())
+		require.NoError(t, err)
+		assert.Equal(t, strs.Snapshot().Records, decodedStrs.Snapshot().Records)
+	})
+
+	t.Run("parses hand-written trees", func(t *testing.T) {
+		tree, err := FromSExpr[int](`
+			; the fixture
+			(root 1
+				(a 2   ; the first child
+					(a1) (a2 "3"))
+				(b))
+		`)
+		require.NoError(t, err)
+		assert.Equal(t, []Record[int]{
+			{ID: "root", Value: 1},
+			{ID: "a", ParentID: "root", Value: 2},
+			{ID: "a1", ParentID: "a"},
+			{ID: "a2", ParentID: "a", Value: 3},
+			{ID: "b", ParentID: "root"},
+		}, tree.Snapshot().Records)
+
+		words, err := FromSExpr[string](`(root hello (a 12) (b true))`)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"hello", "12", "true"}, words.Values())
+
+		empty, err := FromSExpr[string](" ; nothing\n")
+		require.NoError(t, err)
+		assert.Zero(t, empty.Size())
+	})
+
+	t.Run("locates the syntax errors", func(t *testing.T) {
+		for input, message := range map[string]string{
+			`root`:                 "line 1, column 1: expected '('",
+			`(root (a)`:            "line 1, column 10: unterminated node \"root\"",
+			"(root)\n(other)":      "line 2, column 1: unexpected content after the root",
+			`(root "unterminated)`: "line 1, column 7: unterminated string",
+			`(root 1 2)`:           "line 1, column 9: unexpected value \"2\"",
+			`(root (a) 1)`:         "line 1, column 11: unexpected value \"1\"",
+			`(root x)`:             "line 1, column 7: invalid value of node \"root\"",
+			`(())`:                 "line 1, column 2: expected the ID of a node",
+		} {
+			_, err := FromSExpr[int](input)
+			require.ErrorIs(t, err, ErrCorruptedFile, input)
+			assert.Contains(t, err.Error(), message, input)
+		}
+
+		_, err := FromSExpr[int](`(root (a) (a))`)
+		assert.ErrorIs(t, err, ErrDuplicateID)
+	})
+}
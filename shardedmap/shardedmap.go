@@ -0,0 +1,296 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package shardedmap provides Map, a generic concurrent map split into shards.
+//
+// Every shard is a plain map guarded by its own lock, so that the operations on keys of different
+// shards do not contend. It suits the write-heavy workloads where sync.Map, which is optimized for
+// keys written once and read many times, degrades. Map mirrors the API of sync.Map with typed keys
+// and values:
+//
+//	index := shardedmap.New[string, []string](shardedmap.WithCapacity(100_000))
+//	index.Store("node1", []string{"tag"})
+//	tags, ok := index.Load("node1")
+//
+// It is the primitive holding the Nodes of a gotree.Tree, exposed for the side indexes of its users.
+package shardedmap
+
+import (
+	"fmt"
+	"hash/maphash"
+	"runtime"
+	"sync"
+)
+
+// maxShards is the maximum number of shards picked by default
+const maxShards = 1024
+
+// Option configures a Map
+type Option func(cfg *config)
+
+// config holds the settings of a Map
+type config struct {
+	shards   int
+	capacity int
+	// hasher is the func(K) uint64 hashing the keys, typed as any since the config is not bound to the type of the keys
+	hasher any
+}
+
+// WithShards sets the number of shards. It defaults to four times the number of CPUs, up to 1024.
+func WithShards(shards int) Option {
+	return func(cfg *config) {
+		cfg.shards = shards
+	}
+}
+
+// WithCapacity pre-sizes the shards to hold the given number of entries altogether
+func WithCapacity(capacity int) Option {
+	return func(cfg *config) {
+		cfg.capacity = capacity
+	}
+}
+
+// WithHasher sets the function hashing the keys to pick their shard. The strings and the integers
+// are hashed natively and the other keys through their default formatting, which a dedicated
+// function spares. New panics when the function does not hash keys of the type of the Map.
+func WithHasher[K comparable](hash func(key K) uint64) Option {
+	return func(cfg *config) {
+		cfg.hasher = hash
+	}
+}
+
+// shard is a part of the Map
+type shard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// Map is a generic concurrent map split into shards.
+//
+// The zero value is not usable: create a Map with New. A Map is safe for concurrent use.
+type Map[K comparable, V any] struct {
+	shards []*shard[K, V]
+	hash   func(key K) uint64
+}
+
+// New creates an empty Map with the given options
+func New[K comparable, V any](opts ...Option) *Map[K, V] {
+	cfg := &config{shards: min(runtime.NumCPU()*4, maxShards)}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	cfg.shards = max(cfg.shards, 1)
+
+	hash := defaultHasher[K]()
+	if cfg.hasher != nil {
+		var ok bool
+		if hash, ok = cfg.hasher.(func(key K) uint64); !ok {
+			panic(fmt.Sprintf("shardedmap: hasher %T does not hash the keys of the map", cfg.hasher))
+		}
+	}
+
+	shards := make([]*shard[K, V], cfg.shards)
+	for i := range shards {
+		shards[i] = &shard[K, V]{m: make(map[K]V, cfg.capacity/cfg.shards)}
+	}
+	return &Map[K, V]{shards: shards, hash: hash}
+}
+
+// Load returns the value stored for the given key, if any
+func (m *Map[K, V]) Load(key K) (value V, ok bool) {
+	s := m.shard(key)
+	s.mu.RLock()
+	value, ok = s.m[key]
+	s.mu.RUnlock()
+	return value, ok
+}
+
+// Store sets the value of the given key
+func (m *Map[K, V]) Store(key K, value V) {
+	s := m.shard(key)
+	s.mu.Lock()
+	s.m[key] = value
+	s.mu.Unlock()
+}
+
+// LoadOrStore returns the existing value of the given key if present. Otherwise, it stores the given
+// value and returns it. The loaded result is true if the value was loaded, false if stored.
+func (m *Map[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.m[key]; ok {
+		return existing, true
+	}
+	s.m[key] = value
+	return value, false
+}
+
+// LoadAndDelete deletes the value of the given key, returning the previous value if any.
+// The loaded result reports whether the key was present.
+func (m *Map[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, loaded = s.m[key]
+	delete(s.m, key)
+	return value, loaded
+}
+
+// Delete deletes the value of the given key
+func (m *Map[K, V]) Delete(key K) {
+	s := m.shard(key)
+	s.mu.Lock()
+	delete(s.m, key)
+	s.mu.Unlock()
+}
+
+// Swap stores the given value for the key and returns the previous value if any.
+// The loaded result reports whether the key was present.
+func (m *Map[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, loaded = s.m[key]
+	s.m[key] = value
+	return previous, loaded
+}
+
+// CompareAndSwap swaps the old and new values of the given key if the value stored in the Map is
+// equal to old. As with sync.Map, it panics when the values are not comparable.
+func (m *Map[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current, ok := s.m[key]; !ok || any(current) != any(old) {
+		return false
+	}
+	s.m[key] = new
+	return true
+}
+
+// CompareAndDelete deletes the entry of the given key if its value is equal to old. As with
+// sync.Map, it panics when the values are not comparable.
+func (m *Map[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current, ok := s.m[key]; !ok || any(current) != any(old) {
+		return false
+	}
+	delete(s.m, key)
+	return true
+}
+
+// Range calls the given function for every entry of the Map until it returns false.
+//
+// The entries are visited shard by shard, each shard being read-locked while its entries are
+// visited: the function must not modify the Map, collect the keys and modify it afterwards instead.
+// As with sync.Map, Range does not reflect a consistent snapshot of the Map.
+func (m *Map[K, V]) Range(f func(key K, value V) bool) {
+	for _, s := range m.shards {
+		s.mu.RLock()
+		for k, v := range s.m {
+			if !f(k, v) {
+				s.mu.RUnlock()
+				return
+			}
+		}
+		s.mu.RUnlock()
+	}
+}
+
+// Len returns the number of entries of the Map
+func (m *Map[K, V]) Len() int {
+	var count int
+	for _, s := range m.shards {
+		s.mu.RLock()
+		count += len(s.m)
+		s.mu.RUnlock()
+	}
+	return count
+}
+
+// Clear deletes all the entries while retaining the memory allocated by the shards
+func (m *Map[K, V]) Clear() {
+	for _, s := range m.shards {
+		s.mu.Lock()
+		clear(s.m)
+		s.mu.Unlock()
+	}
+}
+
+// Reset deletes all the entries and releases the memory allocated by the shards
+func (m *Map[K, V]) Reset() {
+	for _, s := range m.shards {
+		s.mu.Lock()
+		s.m = make(map[K]V)
+		s.mu.Unlock()
+	}
+}
+
+// shard returns the shard of the given key
+func (m *Map[K, V]) shard(key K) *shard[K, V] {
+	if len(m.shards) == 1 {
+		return m.shards[0]
+	}
+	return m.shards[m.hash(key)%uint64(len(m.shards))]
+}
+
+// seed is the seed of the default hashers
+var seed = maphash.MakeSeed()
+
+// defaultHasher returns the hasher of the keys of the given type
+func defaultHasher[K comparable]() func(key K) uint64 {
+	return func(key K) uint64 {
+		switch k := any(key).(type) {
+		case string:
+			return maphash.String(seed, k)
+		case int:
+			return mix(uint64(k))
+		case int64:
+			return mix(uint64(k))
+		case int32:
+			return mix(uint64(k))
+		case uint:
+			return mix(uint64(k))
+		case uint64:
+			return mix(k)
+		case uint32:
+			return mix(uint64(k))
+		default:
+			return maphash.String(seed, fmt.Sprintf("%#v", key))
+		}
+	}
+}
+
+// mix spreads the bits of the given integer so that consecutive integers land on different shards
+func mix(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb3f94fce49ec
+	x ^= x >> 33
+	return x
+}
@@ -0,0 +1,150 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package shardedmap
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMap(t *testing.T) {
+	t.Run("mirrors sync.Map", func(t *testing.T) {
+		m := New[string, int]()
+		_, ok := m.Load("a")
+		assert.False(t, ok)
+
+		m.Store("a", 1)
+		value, ok := m.Load("a")
+		require.True(t, ok)
+		assert.Equal(t, 1, value)
+
+		actual, loaded := m.LoadOrStore("a", 2)
+		assert.True(t, loaded)
+		assert.Equal(t, 1, actual)
+		actual, loaded = m.LoadOrStore("b", 2)
+		assert.False(t, loaded)
+		assert.Equal(t, 2, actual)
+
+		previous, loaded := m.Swap("a", 10)
+		assert.True(t, loaded)
+		assert.Equal(t, 1, previous)
+
+		assert.False(t, m.CompareAndSwap("a", 1, 11))
+		assert.True(t, m.CompareAndSwap("a", 10, 11))
+		assert.False(t, m.CompareAndSwap("missing", 0, 1))
+		assert.False(t, m.CompareAndDelete("b", 3))
+		assert.True(t, m.CompareAndDelete("b", 2))
+
+		value, loaded = m.LoadAndDelete("a")
+		assert.True(t, loaded)
+		assert.Equal(t, 11, value)
+		_, loaded = m.LoadAndDelete("a")
+		assert.False(t, loaded)
+		assert.Zero(t, m.Len())
+
+		m.Store("c", 3)
+		m.Delete("c")
+		assert.Zero(t, m.Len())
+	})
+
+	t.Run("ranges over the entries", func(t *testing.T) {
+		m := New[int, string](WithShards(4), WithCapacity(100))
+		for i := range 100 {
+			m.Store(i, strconv.Itoa(i))
+		}
+		assert.Equal(t, 100, m.Len())
+
+		seen := make(map[int]string)
+		m.Range(func(key int, value string) bool {
+			seen[key] = value
+			return true
+		})
+		assert.Len(t, seen, 100)
+		assert.Equal(t, "42", seen[42])
+
+		var visited int
+		m.Range(func(int, string) bool {
+			visited++
+			return visited < 10
+		})
+		assert.Equal(t, 10, visited)
+
+		m.Clear()
+		assert.Zero(t, m.Len())
+		m.Store(1, "1")
+		m.Reset()
+		assert.Zero(t, m.Len())
+	})
+
+	t.Run("hashes any comparable key", func(t *testing.T) {
+		type point struct{ x, y int }
+		m := New[point, bool](WithShards(8))
+		m.Store(point{1, 2}, true)
+		_, ok := m.Load(point{1, 2})
+		assert.True(t, ok)
+		_, ok = m.Load(point{2, 1})
+		assert.False(t, ok)
+
+		custom := New[point, bool](WithHasher(func(p point) uint64 { return uint64(p.x) }))
+		custom.Store(point{1, 2}, true)
+		_, ok = custom.Load(point{1, 2})
+		assert.True(t, ok)
+
+		assert.Panics(t, func() {
+			New[point, bool](WithHasher(func(string) uint64 { return 0 }))
+		})
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		m := New[string, int]()
+		var wg sync.WaitGroup
+		for i := range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				key := strconv.Itoa(i % 10)
+				m.LoadOrStore(key, 0)
+				for {
+					current, _ := m.Load(key)
+					if m.CompareAndSwap(key, current, current+1) {
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		var total int
+		m.Range(func(_ string, value int) bool {
+			total += value
+			return true
+		})
+		assert.Equal(t, 50, total)
+	})
+}
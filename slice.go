@@ -25,10 +25,15 @@
 package gotree
 
 import (
+	"slices"
 	"sync"
 )
 
 // Slice type that can be safely shared between goroutines.
+//
+// Every method locks the Slice for its own duration, hence a sequence of calls is not atomic: use
+// the methods operating on a whole Slice (Sort, RemoveFunc...) rather than combining Len, Get and
+// Delete when the Slice is modified concurrently.
 type Slice[T any] struct {
 	data []T
 	mu   sync.RWMutex
@@ -64,12 +69,11 @@ func (cs *Slice[T]) AppendMany(item ...T) {
 // Get returns the slice item at the given index
 func (cs *Slice[T]) Get(index int) (item T) {
 	cs.mu.RLock()
+	defer cs.mu.RUnlock()
 	if index < 0 || index >= len(cs.data) {
 		var zero T
-		cs.mu.RUnlock()
 		return zero
 	}
-	cs.mu.RUnlock()
 	return cs.data[index]
 }
 
@@ -99,3 +103,82 @@ func (cs *Slice[T]) Reset() {
 	cs.data = []T{}
 	cs.mu.Unlock()
 }
+
+// InsertAt inserts the given item at the given index, shifting the following items.
+// An index equal to the length appends the item. It returns false when the index is out of range.
+func (cs *Slice[T]) InsertAt(index int, item T) bool {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if index < 0 || index > len(cs.data) {
+		return false
+	}
+	cs.data = slices.Insert(cs.data, index, item)
+	return true
+}
+
+// RemoveFunc removes the items matching the given function and returns the number of removed items
+func (cs *Slice[T]) RemoveFunc(match func(item T) bool) int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	count := len(cs.data)
+	cs.data = slices.DeleteFunc(cs.data, match)
+	return count - len(cs.data)
+}
+
+// RemoveValue removes the first occurrence of the given value from the given Slice.
+// It returns false when the Slice does not hold the value.
+func RemoveValue[T comparable](cs *Slice[T], value T) bool {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	index := slices.Index(cs.data, value)
+	if index < 0 {
+		return false
+	}
+	cs.data = slices.Delete(cs.data, index, index+1)
+	return true
+}
+
+// Swap swaps the items at the given indexes. It returns false when an index is out of range.
+func (cs *Slice[T]) Swap(i, j int) bool {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if i < 0 || j < 0 || i >= len(cs.data) || j >= len(cs.data) {
+		return false
+	}
+	cs.data[i], cs.data[j] = cs.data[j], cs.data[i]
+	return true
+}
+
+// Sort sorts the items in place with the given comparison function, which returns a negative number
+// when a precedes b, a positive number when b precedes a and zero otherwise. The sort is stable.
+func (cs *Slice[T]) Sort(cmp func(a, b T) int) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	slices.SortStableFunc(cs.data, cmp)
+}
+
+// Clone returns a new Slice holding a copy of the items
+func (cs *Slice[T]) Clone() *Slice[T] {
+	return &Slice[T]{data: cs.Items()}
+}
+
+// Range calls the given function for every item, in order, until it returns false.
+// It walks a copy of the items, hence the function may modify the Slice.
+func (cs *Slice[T]) Range(fn func(index int, item T) bool) {
+	for index, item := range cs.Items() {
+		if !fn(index, item) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the indexes and the items, which walks a copy of the items like Range.
+// The iterator is called with a yield function and stops as soon as yield returns false:
+//
+//	items.All()(func(index int, item T) bool {
+//	    fmt.Println(index, item)
+//	    return true
+//	})
+func (cs *Slice[T]) All() func(yield func(index int, item T) bool) {
+	return cs.Range
+}
@@ -25,6 +25,7 @@
 package gotree
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -55,3 +56,58 @@ func TestSlice(t *testing.T) {
 	// remove the element at index 1
 	sl.Delete(1)
 }
+
+func TestSliceOperations(t *testing.T) {
+	t.Run("inserts and removes", func(t *testing.T) {
+		sl := NewSlice[int]()
+		sl.AppendMany(1, 3)
+		assert.True(t, sl.InsertAt(1, 2))
+		assert.True(t, sl.InsertAt(0, 0))
+		assert.True(t, sl.InsertAt(4, 4))
+		assert.False(t, sl.InsertAt(6, 6))
+		assert.False(t, sl.InsertAt(-1, 6))
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, sl.Items())
+
+		assert.True(t, RemoveValue(sl, 3))
+		assert.False(t, RemoveValue(sl, 3))
+		assert.Equal(t, 2, sl.RemoveFunc(func(item int) bool { return item%2 == 0 && item > 0 }))
+		assert.Equal(t, []int{0, 1}, sl.Items())
+	})
+
+	t.Run("reorders", func(t *testing.T) {
+		sl := NewSlice[string]()
+		sl.AppendMany("b", "c", "a")
+		assert.True(t, sl.Swap(0, 2))
+		assert.False(t, sl.Swap(0, 3))
+		assert.Equal(t, []string{"a", "c", "b"}, sl.Items())
+
+		sl.Sort(func(a, b string) int { return strings.Compare(b, a) })
+		assert.Equal(t, []string{"c", "b", "a"}, sl.Items())
+	})
+
+	t.Run("clones and iterates", func(t *testing.T) {
+		sl := NewSlice[int]()
+		sl.AppendMany(1, 2, 3)
+		clone := sl.Clone()
+		clone.Append(4)
+		assert.Equal(t, 3, sl.Len())
+		assert.Equal(t, 4, clone.Len())
+
+		var visited []int
+		sl.Range(func(index, item int) bool {
+			// the slice can be modified while ranged over
+			sl.Append(item * 10)
+			visited = append(visited, index)
+			return index < 1
+		})
+		assert.Equal(t, []int{0, 1}, visited)
+		assert.Equal(t, []int{1, 2, 3, 10, 20}, sl.Items())
+
+		var sum int
+		sl.All()(func(_ int, item int) bool {
+			sum += item
+			return true
+		})
+		assert.Equal(t, 36, sum)
+	})
+}
@@ -0,0 +1,307 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Stats holds summary statistics about the shape of a Tree
+type Stats struct {
+	// Nodes is the number of Nodes of the Tree
+	Nodes int64
+	// Leaves is the number of Nodes without children
+	Leaves int64
+	// MaxDepth is the depth of the deepest Node, the root being at depth zero
+	MaxDepth int
+	// AverageBranching is the average number of children of the non-leaf Nodes
+	AverageBranching float64
+	// WidestLevel is the depth of the level holding the most Nodes.
+	// The shallowest level wins ties
+	WidestLevel int
+	// WidestLevelSize is the number of Nodes of the widest level
+	WidestLevelSize int64
+}
+
+// Stats computes summary statistics about the shape of the Tree in a single pass.
+//
+// The statistics include the number of Nodes and leaves, the maximum depth, the
+// average branching factor and the widest level, which is handy to expose on a
+// metrics endpoint.
+//
+// Returns:
+//   - Stats: The statistics of the Tree. They are all zero for an empty Tree.
+//
+// Notes:
+//   - The statistics are computed from a single traversal of the internal maps
+//     and may not reflect concurrent modifications applied during the computation.
+//
+// Example usage:
+//
+//	stats := tree.Stats()
+//	fmt.Printf("nodes=%d leaves=%d depth=%d branching=%.2f\n",
+//	    stats.Nodes, stats.Leaves, stats.MaxDepth, stats.AverageBranching)
+func (x *Tree[T]) Stats() Stats {
+	var (
+		stats    Stats
+		children int64
+		levels   = make(map[int]int64)
+	)
+
+	end := x.trace(OperationStats)
+	defer func() {
+		end(int(stats.Nodes), nil)
+	}()
+
+	x.nodes.Range(func(_ string, value *treeNode[T]) bool {
+		node := value
+		stats.Nodes++
+
+		count := node.Descendants.Len()
+		if count == 0 {
+			stats.Leaves++
+		}
+		children += int64(count)
+
+		depth := node.GetPath().Depth()
+		if depth > stats.MaxDepth {
+			stats.MaxDepth = depth
+		}
+		levels[depth]++
+		return true
+	})
+
+	if internal := stats.Nodes - stats.Leaves; internal > 0 {
+		stats.AverageBranching = float64(children) / float64(internal)
+	}
+
+	for depth := 0; depth <= stats.MaxDepth; depth++ {
+		if levels[depth] > stats.WidestLevelSize {
+			stats.WidestLevel = depth
+			stats.WidestLevelSize = levels[depth]
+		}
+	}
+	return stats
+}
+
+// Balance holds the measures of the balance of a Tree, revealing the pathologically deep chains
+type Balance struct {
+	// Nodes is the number of Nodes of the Tree
+	Nodes int64
+	// MaxDepth is the depth of the deepest Node, the root being at depth zero
+	MaxDepth int
+	// MeanDepth is the average depth of the Nodes
+	MeanDepth float64
+	// DepthDistribution holds the number of Nodes of every level, indexed by depth
+	DepthDistribution []int64
+	// BranchSizes holds the number of Nodes of the subtree of every child of the root, keyed by identifier
+	BranchSizes map[string]int64
+	// LongestChain is the number of edges of the longest path whose Nodes have a single child
+	LongestChain int
+	// Skewness scores how far the Tree is from being balanced: zero when it is no deeper than
+	// a balanced binary Tree of the same size, up to one when it is a single chain
+	Skewness float64
+}
+
+// Balance measures how balanced the Tree is.
+//
+// On top of the depth distribution, it reports the size of the branches hanging off the root, the
+// longest chain of Nodes having a single child and a skewness score, so that the pathologically deep
+// chains created by bad upstream data are detected before they hurt the traversals.
+//
+// Returns:
+//   - Balance: The measures of the Tree. They are all zero for an empty Tree.
+//
+// Notes:
+//   - Balance blocks the structural changes of the Tree while it walks the Nodes.
+//
+// Example usage:
+//
+//	balance := tree.Balance()
+//	if balance.Skewness > 0.5 || balance.LongestChain > 1000 {
+//	    log.Printf("tree is skewed: depth=%d chain=%d", balance.MaxDepth, balance.LongestChain)
+//	}
+func (x *Tree[T]) Balance() Balance {
+	snapshot := x.snapshot()
+	balance := Balance{Nodes: int64(len(snapshot.Records)), BranchSizes: make(map[string]int64)}
+	end := x.trace(OperationBalance)
+	defer func() {
+		end(int(balance.Nodes), nil)
+	}()
+
+	if balance.Nodes == 0 {
+		return balance
+	}
+
+	// the records are in depth-first order: the parents come before their children
+	positions := make(map[string]int, len(snapshot.Records))
+	parents := make([]int, len(snapshot.Records))
+	depths := make([]int, len(snapshot.Records))
+	var total int64
+	for i, record := range snapshot.Records {
+		positions[record.ID] = i
+		parents[i] = -1
+		if parent, ok := positions[record.ParentID]; ok {
+			parents[i] = parent
+			depths[i] = depths[parent] + 1
+		}
+
+		if depths[i] == len(balance.DepthDistribution) {
+			balance.DepthDistribution = append(balance.DepthDistribution, 0)
+		}
+		balance.DepthDistribution[depths[i]]++
+		balance.MaxDepth = max(balance.MaxDepth, depths[i])
+		total += int64(depths[i])
+	}
+	balance.MeanDepth = float64(total) / float64(balance.Nodes)
+
+	// accumulate the sizes and the chains from the leaves up
+	sizes := make([]int64, len(snapshot.Records))
+	children := make([]int, len(snapshot.Records))
+	chains := make([]int, len(snapshot.Records))
+	for i := len(snapshot.Records) - 1; i >= 0; i-- {
+		sizes[i]++
+		if children[i] != 1 {
+			chains[i] = 0
+		}
+		balance.LongestChain = max(balance.LongestChain, chains[i])
+
+		if parent := parents[i]; parent >= 0 {
+			sizes[parent] += sizes[i]
+			children[parent]++
+			// the chain of the parent only matters when i is its single child
+			chains[parent] = chains[i] + 1
+			if depths[i] == 1 {
+				balance.BranchSizes[snapshot.Records[i].ID] = sizes[i]
+			}
+		}
+	}
+
+	// a balanced binary Tree of n Nodes is floor(log2(n)) deep and a chain n-1 deep
+	if balanced := int(math.Log2(float64(balance.Nodes))); balance.Nodes-1 > int64(balanced) {
+		skew := float64(balance.MaxDepth-balanced) / float64(balance.Nodes-1-int64(balanced))
+		balance.Skewness = max(0, min(1, skew))
+	}
+	return balance
+}
+
+// SubtreeInfo describes a subtree of a Tree
+type SubtreeInfo struct {
+	// ID is the identifier of the root of the subtree
+	ID string
+	// Depth is the depth of the root of the subtree, the root of the Tree being at depth zero
+	Depth int
+	// Size is the number of Nodes of the subtree, its root included
+	Size int64
+	// Weight is the sum of the weights of the Nodes of the subtree. It is the size of the subtree
+	// unless a weight function is given
+	Weight float64
+}
+
+// TopSubtrees returns the k largest subtrees of the Tree by number of Nodes.
+//
+// It finds the hot branches worth sharding off to other workers. The whole Tree is not reported,
+// hence the largest subtree is the one of the largest child of the root.
+//
+// Parameters:
+//   - k: The maximum number of subtrees to report.
+//
+// Returns:
+//   - []SubtreeInfo: The largest subtrees, the largest first. The subtrees of the same size are
+//     in depth-first order. It is empty when k is not positive.
+//
+// Notes:
+//   - The subtrees of the report may be nested.
+//   - TopSubtrees blocks the structural changes of the Tree while it walks the Nodes.
+//
+// Example usage:
+//
+//	for _, subtree := range tree.TopSubtrees(3) {
+//	    fmt.Printf("%s holds %d nodes\n", subtree.ID, subtree.Size)
+//	}
+func (x *Tree[T]) TopSubtrees(k int) []SubtreeInfo {
+	return x.TopSubtreesBy(k, nil)
+}
+
+// TopSubtreesBy behaves like TopSubtrees, ranking the subtrees by the sum of the weights of their Nodes.
+// A nil weight function counts the Nodes.
+//
+// Example usage:
+//
+//	hot := tree.TopSubtreesBy(3, func(node Node[Service]) float64 {
+//	    return node.Value().RequestsPerSecond
+//	})
+func (x *Tree[T]) TopSubtreesBy(k int, weight func(node Node[T]) float64) []SubtreeInfo {
+	if k <= 0 {
+		return nil
+	}
+
+	records := x.snapshot().Records
+	positions := make(map[string]int, len(records))
+	subtrees := make([]SubtreeInfo, len(records))
+	parents := make([]int, len(records))
+	for i, record := range records {
+		positions[record.ID] = i
+		parents[i] = -1
+		subtrees[i].ID = record.ID
+		if parent, ok := positions[record.ParentID]; ok {
+			parents[i] = parent
+			subtrees[i].Depth = subtrees[parent].Depth + 1
+		}
+	}
+
+	// accumulate the sizes and the weights from the leaves up:
+	// the children follow their parent in depth-first order
+	for i := len(records) - 1; i >= 0; i-- {
+		subtrees[i].Size++
+		if weight == nil {
+			subtrees[i].Weight++
+		} else if node, ok := x.Find(records[i].ID); ok {
+			subtrees[i].Weight += weight(node)
+		}
+
+		if parent := parents[i]; parent >= 0 {
+			subtrees[parent].Size += subtrees[i].Size
+			subtrees[parent].Weight += subtrees[i].Weight
+		}
+	}
+
+	// leave the whole Tree out, the stable sort keeps the depth-first order of the ties
+	if len(subtrees) > 0 {
+		subtrees = subtrees[1:]
+	}
+	slices.SortStableFunc(subtrees, func(a, b SubtreeInfo) int {
+		return cmp.Compare(b.Weight, a.Weight)
+	})
+	return slices.Clip(subtrees[:min(k, len(subtrees))])
+}
+
+// Depth returns the depth of the deepest Node of the Tree, the root being at depth zero.
+// It returns zero for an empty Tree. It walks all the Nodes of the Tree.
+func (x *Tree[T]) Depth() int {
+	return x.depth()
+}
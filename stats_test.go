@@ -0,0 +1,140 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	tree := NewTree[string]()
+	assert.Zero(t, tree.Stats())
+
+	root := NewNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	node1 := NewNode("node1", "node1")
+	require.NoError(t, tree.Add(node1, root))
+	require.NoError(t, tree.Add(NewNode("node2", "node2"), root))
+	require.NoError(t, tree.Add(NewNode("node3", "node3"), node1))
+	require.NoError(t, tree.Add(NewNode("node4", "node4"), node1))
+	require.NoError(t, tree.Add(NewNode("node5", "node5"), node1))
+
+	stats := tree.Stats()
+	assert.EqualValues(t, 6, stats.Nodes)
+	assert.EqualValues(t, 4, stats.Leaves)
+	assert.Equal(t, 2, stats.MaxDepth)
+	assert.InDelta(t, 2.5, stats.AverageBranching, 0.0001)
+	assert.Equal(t, 2, stats.WidestLevel)
+	assert.EqualValues(t, 3, stats.WidestLevelSize)
+}
+
+func TestBalance(t *testing.T) {
+	t.Run("measures an empty tree", func(t *testing.T) {
+		balance := NewTree[string]().Balance()
+		assert.Zero(t, balance.Nodes)
+		assert.Empty(t, balance.BranchSizes)
+	})
+
+	t.Run("measures a balanced tree", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", ""), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a2", ""), "a"))
+		require.NoError(t, tree.AddByID(NewNode("b1", ""), "b"))
+		require.NoError(t, tree.AddByID(NewNode("b2", ""), "b"))
+
+		balance := tree.Balance()
+		assert.EqualValues(t, 7, balance.Nodes)
+		assert.Equal(t, 2, balance.MaxDepth)
+		assert.InDelta(t, 10.0/7, balance.MeanDepth, 0.0001)
+		assert.Equal(t, []int64{1, 2, 4}, balance.DepthDistribution)
+		assert.Equal(t, map[string]int64{"a": 3, "b": 3}, balance.BranchSizes)
+		assert.Zero(t, balance.LongestChain)
+		assert.Zero(t, balance.Skewness)
+	})
+
+	t.Run("detects the deep chains", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, tree.AddByID(NewNode("leaf", ""), "root"))
+		parent := "root"
+		for i := range 10 {
+			id := string(rune('a' + i))
+			require.NoError(t, tree.AddByID(NewNode(id, ""), parent))
+			parent = id
+		}
+
+		balance := tree.Balance()
+		assert.Equal(t, 10, balance.MaxDepth)
+		assert.Equal(t, 9, balance.LongestChain)
+		assert.Equal(t, map[string]int64{"a": 10, "leaf": 1}, balance.BranchSizes)
+		assert.InDelta(t, 7.0/8, balance.Skewness, 0.0001)
+
+		chain := NewTree[string]()
+		require.NoError(t, chain.AddByID(NewNode("root", ""), ""))
+		require.NoError(t, chain.AddByID(NewNode("a", ""), "root"))
+		require.NoError(t, chain.AddByID(NewNode("b", ""), "a"))
+		assert.InDelta(t, 1, chain.Balance().Skewness, 0.0001)
+		assert.Equal(t, 2, chain.Balance().LongestChain)
+	})
+}
+
+func TestTopSubtrees(t *testing.T) {
+	tree := NewTree[int]()
+	require.NoError(t, tree.AddByID(NewNode("root", 1), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", 1), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", 1), "root"))
+	require.NoError(t, tree.AddByID(NewNode("c", 100), "root"))
+	require.NoError(t, tree.AddByID(NewNode("a1", 1), "a"))
+	require.NoError(t, tree.AddByID(NewNode("a2", 1), "a"))
+	require.NoError(t, tree.AddByID(NewNode("a11", 1), "a1"))
+	require.NoError(t, tree.AddByID(NewNode("b1", 1), "b"))
+
+	t.Run("ranks by size", func(t *testing.T) {
+		top := tree.TopSubtrees(3)
+		assert.Equal(t, []SubtreeInfo{
+			{ID: "a", Depth: 1, Size: 4, Weight: 4},
+			{ID: "a1", Depth: 2, Size: 2, Weight: 2},
+			{ID: "b", Depth: 1, Size: 2, Weight: 2},
+		}, top)
+
+		assert.Len(t, tree.TopSubtrees(100), 7)
+		assert.Empty(t, tree.TopSubtrees(0))
+		assert.Empty(t, NewTree[int]().TopSubtrees(3))
+	})
+
+	t.Run("ranks by weight", func(t *testing.T) {
+		top := tree.TopSubtreesBy(2, func(node Node[int]) float64 { return float64(node.Value()) })
+		assert.Equal(t, []SubtreeInfo{
+			{ID: "c", Depth: 1, Size: 1, Weight: 100},
+			{ID: "a", Depth: 1, Size: 4, Weight: 4},
+		}, top)
+	})
+}
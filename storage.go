@@ -0,0 +1,458 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Storage persists the state of a Tree so that it survives restarts.
+//
+// The state is made of the latest snapshot of the Tree and the changes applied after it.
+// The Tree appends its changes, in order, before applying them: a change the Storage fails to
+// append is rejected. Persist saves a snapshot whenever the caller sees fit (e.g. periodically
+// or on shutdown) and Recover rebuilds the Tree from the latest snapshot and the changes following it.
+//
+// The implementations decide how durable the state is; MemoryStorage is the in-memory
+// reference implementation. They must be safe for concurrent use.
+type Storage[T any] interface {
+	// SaveSnapshot saves the given snapshot. The changes up to the sequence number of the
+	// snapshot are no longer needed once it is saved and can be discarded.
+	SaveSnapshot(snapshot Snapshot[T]) error
+	// LoadSnapshot returns the latest saved snapshot, an empty snapshot when none has been saved.
+	LoadSnapshot() (Snapshot[T], error)
+	// AppendChanges appends the events of a change, e.g. the removal of a Node along with its
+	// descendants, before the change is applied: either all of them are appended or none is.
+	// The changes are appended in the order of their sequence numbers.
+	AppendChanges(changes []Event[T]) error
+	// ReplayChanges calls apply with the appended changes whose sequence number follows the
+	// given one, in order. It stops at the first error returned by apply and returns it.
+	ReplayChanges(after uint64, apply func(change Event[T]) error) error
+}
+
+// Record is a Node captured by a Snapshot
+type Record[T any] struct {
+	// ID is the identifier of the Node
+	ID string
+	// ParentID is the identifier of the parent of the Node, empty for the root
+	ParentID string
+	// Value is the value of the Node
+	Value T
+	// Edge is the data of the edge linking the Node to its parent
+	Edge EdgeData
+	// Meta is the metadata of the Node, nil when it has none
+	Meta map[string]any
+	// Tags are the sorted tags of the Node, nil when it has none
+	Tags []string
+	// Links are the identifiers of the parents the Node is linked under in the multi-parent mode,
+	// on top of its parent, in the order they have been linked
+	Links []string
+}
+
+// Snapshot is the state of a Tree at a given sequence number
+type Snapshot[T any] struct {
+	// Seq is the sequence number of the last change captured by the snapshot
+	Seq uint64
+	// Records holds the Nodes of the Tree in depth-first order: a parent is always
+	// recorded before its children, which are recorded in insertion order.
+	// A linked parent may be recorded after the Nodes linked under it.
+	Records []Record[T]
+}
+
+// Persist saves a snapshot of the Tree to the Storage set with WithStorage.
+//
+// The snapshot captures every Node of the Tree along with the sequence number of the last
+// change applied, so that the Storage can discard the changes it already covers.
+//
+// Returns:
+//   - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The snapshot was saved.
+//   - ErrInvalidOperation: No Storage is set.
+//   - Any error returned by the Storage.
+//
+// Notes:
+//   - The structural changes of the Tree are blocked while the snapshot is captured,
+//     not while it is saved.
+//
+// Example usage:
+//
+//	storage := NewMemoryStorage[string]()
+//	tree := NewTree[string](WithStorage[string](storage))
+//	// ...
+//	if err := tree.Persist(); err != nil {
+//	    log.Println("Cannot persist the tree:", err)
+//	}
+func (x *Tree[T]) Persist() error {
+	storage := x.feed.storage
+	if storage == nil {
+		return fmt.Errorf("%w: storage not set", ErrInvalidOperation)
+	}
+
+	snapshot := x.snapshot()
+	if err := storage.SaveSnapshot(snapshot); err != nil {
+		return fmt.Errorf("gotree: cannot save snapshot %d: %w", snapshot.Seq, err)
+	}
+	x.feed.persisted.Store(snapshot.Seq)
+
+	x.log(slog.LevelDebug, "tree persisted", slog.Uint64("seq", snapshot.Seq), slog.Int("count", len(snapshot.Records)))
+	return nil
+}
+
+// Recover rebuilds the Tree from the Storage set with WithStorage.
+//
+// The Tree is reset, populated from the latest snapshot and brought up to date by replaying
+// the changes appended after it. The sequence numbers of the subsequent changes then follow
+// the last recovered one.
+//
+// Returns:
+//   - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The Tree was recovered.
+//   - ErrInvalidOperation: No Storage is set.
+//   - Any error returned by the Storage or raised while applying the recovered state,
+//     in which case the Tree holds the state recovered so far.
+//
+// Notes:
+//   - Recover must be called before the Tree is used: the changes applied concurrently are
+//     not persisted.
+//   - The hooks and the watchers are notified of the recovered changes.
+//
+// Example usage:
+//
+//	tree := NewTree[string](WithStorage[string](storage))
+//	if err := tree.Recover(); err != nil {
+//	    log.Fatal("Cannot recover the tree:", err)
+//	}
+func (x *Tree[T]) Recover() error {
+	if err := x.requireContext(opLoad, "", ""); err != nil {
+		return fmt.Errorf("gotree: cannot recover tree: %w", err)
+	}
+	return x.recover()
+}
+
+// recover replaces the Nodes of the Tree with the persisted ones
+func (x *Tree[T]) recover() error {
+	storage := x.feed.storage
+	if storage == nil {
+		return fmt.Errorf("%w: storage not set", ErrInvalidOperation)
+	}
+
+	snapshot, err := storage.LoadSnapshot()
+	if err != nil {
+		return fmt.Errorf("gotree: cannot load snapshot: %w", err)
+	}
+	x.feed.persisted.Store(snapshot.Seq)
+
+	// the recovered changes are already persisted
+	x.feed.replaying.Store(true)
+	defer x.feed.replaying.Store(false)
+
+	if err := x.restore(context.Background(), snapshot.Records); err != nil {
+		return fmt.Errorf("gotree: cannot restore snapshot %d: %w", snapshot.Seq, err)
+	}
+
+	last := snapshot.Seq
+	err = storage.ReplayChanges(snapshot.Seq, func(change Event[T]) error {
+		if err := x.replay(change); err != nil {
+			return fmt.Errorf("gotree: cannot replay change %d: %w", change.Seq, err)
+		}
+		last = change.Seq
+		return nil
+	})
+
+	x.feed.mu.Lock()
+	x.feed.seq = last
+	x.feed.mu.Unlock()
+
+	x.log(slog.LevelDebug, "tree recovered", slog.Uint64("seq", last), slog.Int64("count", x.Size()))
+	return err
+}
+
+// restore resets the Tree and adds the Nodes of the given records, listed in depth-first order.
+// The reset and every addition wait for the mutation limiter, the waits being bound to the given context
+func (x *Tree[T]) restore(ctx context.Context, records []Record[T]) error {
+	if err := x.resetContext(ctx, newResetConfig()); err != nil {
+		return err
+	}
+	for _, record := range records {
+		var edge *EdgeData
+		if record.Edge != (EdgeData{}) {
+			edge = &record.Edge
+		}
+		if err := x.addByIDContext(ctx, NewNode(record.ID, record.Value), record.ParentID, edge); err != nil {
+			return err
+		}
+		if err := x.annotate(record); err != nil {
+			return err
+		}
+	}
+
+	// the linked parents may be recorded after the Nodes linked under them
+	for _, record := range records {
+		if err := x.relink(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// annotate replaces the metadata and the tags of the Node of the given record with the recorded ones
+// when they differ
+func (x *Tree[T]) annotate(record Record[T]) error {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	node, ok := x.getNode(record.ID)
+	if !ok {
+		return newNodeError(opSetMeta, record.ID, "", ErrNotFound)
+	}
+
+	if !reflect.DeepEqual(node.GetMeta(), copyMeta(&record.Meta)) {
+		if err := x.replaceMeta(record.ID, record.Meta); err != nil {
+			return err
+		}
+	}
+
+	if !slices.Equal(x.tags.tags(record.ID), record.Tags) {
+		return x.replaceTags(record.ID, record.Tags)
+	}
+	return nil
+}
+
+// relink links the Node of the given record under the recorded linked parents and unlinks it
+// from the other ones
+func (x *Tree[T]) relink(record Record[T]) error {
+	if x.links == nil {
+		if len(record.Links) > 0 {
+			return newNodeError(opLink, record.ID, record.Links[0], ErrInvalidOperation)
+		}
+		return nil
+	}
+
+	current := x.links.parentsOf(record.ID)
+	for _, parentID := range current {
+		if !slices.Contains(record.Links, parentID) {
+			if _, err := x.unlink(record.ID, parentID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, parentID := range record.Links {
+		if !slices.Contains(current, parentID) {
+			x.mu.Lock()
+			err := x.link(record.ID, parentID)
+			x.mu.Unlock()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// snapshot captures the Nodes of the Tree in depth-first order along with the last sequence number
+func (x *Tree[T]) snapshot() Snapshot[T] {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.feed.mu.Lock()
+	snapshot := Snapshot[T]{Seq: x.feed.seq, Records: make([]Record[T], 0, x.Size())}
+	x.feed.mu.Unlock()
+
+	var walk func(node *treeNode[T], parentID string)
+	guard := x.newCycleGuard(false, x.rootNode.Load())
+	walk = func(node *treeNode[T], parentID string) {
+		snapshot.Records = append(snapshot.Records, x.record(node, parentID))
+		for _, child := range node.Descendants.Items() {
+			if guard.visit(node, child) {
+				walk(child, node.ID)
+			}
+		}
+	}
+
+	if root := x.rootNode.Load(); root != nil {
+		walk(root, "")
+	}
+	return snapshot
+}
+
+// record captures the given node, held by the parent with the given ID, along with its edge,
+// metadata, tags and links
+func (x *Tree[T]) record(node *treeNode[T], parentID string) Record[T] {
+	record := Record[T]{
+		ID:       node.ID,
+		ParentID: parentID,
+		Value:    node.GetValue().Value(),
+		Edge:     node.GetEdge(),
+		Links:    x.links.parentsOf(node.ID),
+	}
+	if meta := node.Meta.Load(); meta != nil && len(*meta) > 0 {
+		record.Meta = copyMeta(meta)
+	}
+	if tags := x.tags.tags(node.ID); len(tags) > 0 {
+		record.Tags = tags
+	}
+	return record
+}
+
+// replay applies the given persisted change
+func (x *Tree[T]) replay(change Event[T]) error {
+	switch change.Type {
+	case EventAdd:
+		var edge *EdgeData
+		if change.Edge != (EdgeData{}) {
+			edge = &change.Edge
+		}
+
+		if change.Before != "" && change.Parent != nil {
+			// insert the Node at the position it was added at
+			parent, ok := x.getNode(change.Parent.ID())
+			if !ok {
+				return newNodeError(opAdd, change.Node.ID(), change.Parent.ID(), ErrParentNodeNotFound)
+			}
+			if index := parent.Descendants.IndexOf(change.Before); index >= 0 {
+				return x.insertChildAt(parent.GetValue(), change.Node, index, edge)
+			}
+		}
+		return x.addByIDContext(context.Background(), change.Node, parentID(change.Parent), edge)
+	case EventUpdate:
+		return x.updateContext(context.Background(), change.Node)
+	case EventDelete:
+		// the descendants of a deleted Node are deleted along with it
+		if err := x.deleteContext(context.Background(), change.Node.ID()); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		return nil
+	case EventMove:
+		if change.Before != "" {
+			return x.moveNextTo(change.Node.ID(), change.Before, false)
+		}
+
+		// a Node moved among its siblings has been placed as the last child
+		if parent, ok := x.parentNode(change.Node.ID()); ok && parent.ID == parentID(change.Parent) {
+			if last, ok := parent.Descendants.Last(); ok && last.ID != change.Node.ID() {
+				return x.moveNextTo(change.Node.ID(), last.ID, true)
+			}
+			return nil
+		}
+		return x.moveContext(context.Background(), change.Node.ID(), parentID(change.Parent))
+	case EventMeta:
+		x.mu.RLock()
+		defer x.mu.RUnlock()
+		return x.replaceMeta(change.Node.ID(), change.Meta)
+	case EventTag:
+		x.mu.RLock()
+		defer x.mu.RUnlock()
+		return x.replaceTags(change.Node.ID(), change.Tags)
+	case EventEdge:
+		return x.setEdge(change.Node.ID(), change.Edge)
+	case EventLink:
+		x.mu.Lock()
+		defer x.mu.Unlock()
+		if x.links == nil {
+			return newNodeError(opLink, change.Node.ID(), parentID(change.Parent), ErrInvalidOperation)
+		}
+		return x.link(change.Node.ID(), parentID(change.Parent))
+	case EventUnlink:
+		_, err := x.unlink(change.Node.ID(), parentID(change.Parent))
+		return err
+	default:
+		return fmt.Errorf("%w: unknown change type %d", ErrInvalidOperation, change.Type)
+	}
+}
+
+// MemoryStorage is the in-memory reference implementation of Storage.
+//
+// It keeps the latest snapshot and the changes following it. It does not survive the
+// process, hence it suits tests and the implementations of other Storage backends.
+type MemoryStorage[T any] struct {
+	mu       sync.RWMutex
+	snapshot Snapshot[T]
+	changes  []Event[T]
+}
+
+// enforce compilation error
+var _ Storage[any] = (*MemoryStorage[any])(nil)
+
+// NewMemoryStorage creates an empty MemoryStorage
+func NewMemoryStorage[T any]() *MemoryStorage[T] {
+	return &MemoryStorage[T]{}
+}
+
+// SaveSnapshot saves the given snapshot and discards the changes it covers
+func (s *MemoryStorage[T]) SaveSnapshot(snapshot Snapshot[T]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshot = Snapshot[T]{Seq: snapshot.Seq, Records: append([]Record[T](nil), snapshot.Records...)}
+
+	kept := s.changes[:0]
+	for _, change := range s.changes {
+		if change.Seq > snapshot.Seq {
+			kept = append(kept, change)
+		}
+	}
+	clear(s.changes[len(kept):])
+	s.changes = kept
+	return nil
+}
+
+// LoadSnapshot returns the latest saved snapshot
+func (s *MemoryStorage[T]) LoadSnapshot() (Snapshot[T], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return Snapshot[T]{Seq: s.snapshot.Seq, Records: append([]Record[T](nil), s.snapshot.Records...)}, nil
+}
+
+// AppendChanges appends the events of a change
+func (s *MemoryStorage[T]) AppendChanges(changes []Event[T]) error {
+	s.mu.Lock()
+	s.changes = append(s.changes, changes...)
+	s.mu.Unlock()
+	return nil
+}
+
+// ReplayChanges calls apply with the changes following the given sequence number
+func (s *MemoryStorage[T]) ReplayChanges(after uint64, apply func(change Event[T]) error) error {
+	s.mu.RLock()
+	changes := append([]Event[T](nil), s.changes...)
+	s.mu.RUnlock()
+
+	for _, change := range changes {
+		if change.Seq <= after {
+			continue
+		}
+		if err := apply(change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingStorage is a MemoryStorage failing to append the changes when broken
+type failingStorage[T any] struct {
+	*MemoryStorage[T]
+	broken bool
+}
+
+func (s *failingStorage[T]) AppendChanges(changes []Event[T]) error {
+	if s.broken {
+		return errors.New("disk full")
+	}
+	return s.MemoryStorage.AppendChanges(changes)
+}
+
+func TestStorage(t *testing.T) {
+	populate := func(t *testing.T, tree *Tree[string]) {
+		t.Helper()
+		require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "A"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "B"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", "A1"), "a"))
+		require.NoError(t, tree.AddByID(NewNode("a2", "A2"), "a"))
+	}
+
+	annotate := func(t *testing.T, tree *Tree[string]) {
+		t.Helper()
+		b, _ := tree.Find("b")
+		require.NoError(t, tree.AddWithEdge(NewNode("b1", "B1"), b, EdgeData{Label: "first", Weight: 0.5}))
+		require.NoError(t, tree.SetEdge(NewNode("a1", ""), EdgeData{Label: "left"}))
+		require.NoError(t, tree.SetMeta("a", "owner", "alice"))
+		require.NoError(t, tree.SetMeta("a", "draft", "yes"))
+		require.NoError(t, tree.DeleteMeta("a", "draft"))
+		require.NoError(t, tree.Tag("a1", "critical", "billing"))
+		require.NoError(t, tree.Untag("a1", "billing"))
+		require.NoError(t, tree.Link(NewNode("a2", ""), NewNode("b", "")))
+		require.NoError(t, tree.Link(NewNode("a1", ""), NewNode("b1", "")))
+		require.NoError(t, tree.Unlink(NewNode("a1", ""), NewNode("b1", "")))
+	}
+
+	t.Run("recovers from the changes", func(t *testing.T) {
+		storage := NewMemoryStorage[string]()
+		tree := NewTree[string](WithStorage[string](storage))
+		populate(t, tree)
+		require.NoError(t, tree.Update(NewNode("a1", "A1'")))
+		require.NoError(t, tree.MoveByID("a2", "b"))
+		require.NoError(t, tree.DeleteByID("a"))
+
+		recovered := NewTree[string](WithStorage[string](storage))
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, tree.Dump(), recovered.Dump())
+		assert.Equal(t, tree.LastSeq(), recovered.LastSeq())
+		assert.Empty(t, recovered.Validate())
+	})
+
+	t.Run("recovers from a snapshot and the following changes", func(t *testing.T) {
+		storage := NewMemoryStorage[string]()
+		tree := NewTree[string](WithStorage[string](storage))
+		populate(t, tree)
+		require.NoError(t, tree.Persist())
+
+		snapshot, err := storage.LoadSnapshot()
+		require.NoError(t, err)
+		assert.EqualValues(t, 5, snapshot.Seq)
+		assert.Equal(t, []Record[string]{
+			{ID: "root", Value: "Root"},
+			{ID: "a", ParentID: "root", Value: "A"},
+			{ID: "a1", ParentID: "a", Value: "A1"},
+			{ID: "a2", ParentID: "a", Value: "A2"},
+			{ID: "b", ParentID: "root", Value: "B"},
+		}, snapshot.Records)
+
+		require.NoError(t, tree.AddByID(NewNode("b1", "B1"), "b"))
+		require.NoError(t, tree.Update(NewNode("root", "Root'")))
+
+		var replayed []EventType
+		require.NoError(t, storage.ReplayChanges(0, func(change Event[string]) error {
+			replayed = append(replayed, change.Type)
+			return nil
+		}))
+		assert.Equal(t, []EventType{EventAdd, EventUpdate}, replayed)
+
+		recovered := NewTree[string](WithStorage[string](storage))
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, tree.Dump(), recovered.Dump())
+		assert.EqualValues(t, 7, recovered.LastSeq())
+
+		// the changes following the recovery are persisted after the recovered ones
+		require.NoError(t, recovered.DeleteByID("b1"))
+		var last uint64
+		require.NoError(t, storage.ReplayChanges(0, func(change Event[string]) error {
+			last = change.Seq
+			return nil
+		}))
+		assert.EqualValues(t, 8, last)
+	})
+
+	t.Run("recovers the edges, metadata, tags and links", func(t *testing.T) {
+		storage := NewMemoryStorage[string]()
+		tree := NewTree[string](WithStorage[string](storage), WithMultiParent())
+		populate(t, tree)
+		annotate(t, tree)
+
+		recovered := NewTree[string](WithStorage[string](storage), WithMultiParent())
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, tree.Snapshot(), recovered.Snapshot())
+		meta, _ := recovered.Meta("a")
+		assert.Equal(t, map[string]any{"owner": "alice"}, meta)
+		tags, _ := recovered.Tags("a1")
+		assert.Equal(t, []string{"critical"}, tags)
+		edge, _ := recovered.Edge(NewNode("b1", ""))
+		assert.Equal(t, EdgeData{Label: "first", Weight: 0.5}, edge)
+		parents, _ := recovered.Parents(NewNode("a2", ""))
+		assert.Equal(t, []string{"a", "b"}, queryIDs(parents))
+
+		// the snapshot captures them as well, and the changes following it are replayed
+		require.NoError(t, tree.Persist())
+		require.NoError(t, tree.SetMeta("b", "owner", "bob"))
+		require.NoError(t, tree.Unlink(NewNode("a2", ""), NewNode("b", "")))
+		recovered = NewTree[string](WithStorage[string](storage), WithMultiParent())
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, tree.Snapshot(), recovered.Snapshot())
+
+		// the links require the multi-parent mode
+		require.NoError(t, tree.Link(NewNode("a2", ""), NewNode("b", "")))
+		assert.ErrorIs(t, NewTree[string](WithStorage[string](storage)).Recover(), ErrInvalidOperation)
+	})
+
+	t.Run("rejects the changes not persisted", func(t *testing.T) {
+		buffer := new(bytes.Buffer)
+		storage := &failingStorage[string]{MemoryStorage: NewMemoryStorage[string]()}
+		tree := NewTree[string](WithStorage[string](storage), WithLogger(slog.NewTextHandler(buffer, nil)), WithChangeFeed(10))
+		populate(t, tree)
+		dump := tree.Dump()
+
+		var notified []string
+		tree.OnAdd(func(node, _ Node[string]) { notified = append(notified, node.ID()) })
+		tree.OnUpdate(func(node, _ Node[string]) { notified = append(notified, node.ID()) })
+		tree.OnDelete(func(node, _ Node[string]) { notified = append(notified, node.ID()) })
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := tree.Watch(ctx, nil)
+
+		storage.broken = true
+		err := tree.AddByID(NewNode("c", "C"), "root")
+		require.ErrorIs(t, err, ErrNotPersisted)
+		var nodeErr *NodeError
+		require.ErrorAs(t, err, &nodeErr)
+		assert.Equal(t, "c", nodeErr.NodeID())
+		assert.ErrorIs(t, tree.Update(NewNode("a", "A'")), ErrNotPersisted)
+		assert.ErrorIs(t, tree.MoveByID("a1", "b"), ErrNotPersisted)
+		assert.ErrorIs(t, tree.MoveBefore(NewNode("b", ""), NewNode("a", "")), ErrNotPersisted)
+		assert.ErrorIs(t, tree.DeleteByID("a"), ErrNotPersisted)
+		assert.ErrorIs(t, tree.SetMeta("a", "owner", "alice"), ErrNotPersisted)
+		assert.ErrorIs(t, tree.Tag("a", "critical"), ErrNotPersisted)
+		assert.ErrorIs(t, tree.SetEdge(NewNode("a1", ""), EdgeData{Label: "left"}), ErrNotPersisted)
+		tree.Reset()
+
+		// the Tree is left unchanged and nothing is notified
+		assert.Equal(t, dump, tree.Dump())
+		assert.EqualValues(t, 5, tree.Size())
+		meta, _ := tree.Meta("a")
+		assert.Empty(t, meta)
+		tags, _ := tree.Tags("a")
+		assert.Empty(t, tags)
+		edge, _ := tree.Edge(NewNode("a1", ""))
+		assert.Zero(t, edge)
+		assert.Empty(t, notified)
+		assert.Empty(t, events)
+		assert.Contains(t, buffer.String(), "tree change not persisted")
+		assert.Contains(t, buffer.String(), "tree not reset")
+		assert.NotContains(t, buffer.String(), "tree mutation rejected")
+		assert.Contains(t, buffer.String(), "disk full")
+
+		// the storage holds the Tree as is, and the sequence numbers go on
+		storage.broken = false
+		require.NoError(t, tree.AddByID(NewNode("c", "C"), "root"))
+		event := <-events
+		assert.EqualValues(t, 6, event.Seq)
+		recovered := NewTree[string](WithStorage[string](storage))
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, tree.Dump(), recovered.Dump())
+	})
+
+	t.Run("requires a storage", func(t *testing.T) {
+		tree := NewTree[string]()
+		assert.ErrorIs(t, tree.Persist(), ErrInvalidOperation)
+		assert.ErrorIs(t, tree.Recover(), ErrInvalidOperation)
+	})
+
+	t.Run("rejects a storage of another type", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewTree[string](WithStorage[int](NewMemoryStorage[int]()))
+		})
+	})
+}
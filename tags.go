@@ -0,0 +1,291 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"sort"
+	"sync"
+)
+
+// Tag attaches the given tags to the Node with the given ID.
+//
+// Tags are labels orthogonal to the hierarchy: any Node can carry any number of tags and
+// the Nodes sharing a tag are retrieved with FindByTag without scanning the Tree. Tagging
+// does not change the Node version and the tags are discarded when the Node is deleted.
+//
+// Parameters:
+//   - id: The unique identifier of the Node to tag.
+//   - tags: The tags to attach. Tags already attached to the Node are ignored.
+//
+// Returns:
+//   - err: nil on success, ErrNotFound when the Node does not exist in the Tree or
+//     ErrNotPersisted when the Storage fails to append the change.
+//
+// Example usage:
+//
+//	_ = tree.Tag("childID", "critical", "billing")
+//	nodes := tree.FindByTag("critical")
+func (x *Tree[T]) Tag(id string, tags ...string) (err error) {
+	if err := x.requireContext(opTag, id, ""); err != nil {
+		return err
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return x.updateTags(opTag, id, func(current map[string]struct{}) {
+		for _, tag := range tags {
+			current[tag] = struct{}{}
+		}
+	})
+}
+
+// Untag detaches the given tags from the Node with the given ID.
+//
+// Parameters:
+//   - id: The unique identifier of the Node.
+//   - tags: The tags to detach. Tags not attached to the Node are ignored.
+//
+// Returns:
+//   - err: nil on success, ErrNotFound when the Node does not exist in the Tree or
+//     ErrNotPersisted when the Storage fails to append the change.
+func (x *Tree[T]) Untag(id string, tags ...string) (err error) {
+	if err := x.requireContext(opUntag, id, ""); err != nil {
+		return err
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return x.updateTags(opUntag, id, func(current map[string]struct{}) {
+		for _, tag := range tags {
+			delete(current, tag)
+		}
+	})
+}
+
+// FindByTag returns the Nodes carrying the given tag, in an unspecified order.
+//
+// Parameters:
+//   - tag: The tag to look up.
+//
+// Returns:
+//   - nodes: The Nodes carrying the tag. It is empty when no Node carries it.
+//
+// Example usage:
+//
+//	for _, node := range tree.FindByTag("critical") {
+//	    fmt.Println("Critical node:", node.ID())
+//	}
+func (x *Tree[T]) FindByTag(tag string) (nodes []Node[T]) {
+	for _, id := range x.tags.nodes(tag) {
+		if node, ok := x.getNode(id); ok {
+			nodes = append(nodes, node.GetValue())
+		}
+	}
+	return nodes
+}
+
+// Tags returns the tags attached to the Node with the given ID, sorted.
+//
+// Parameters:
+//   - id: The unique identifier of the Node.
+//
+// Returns:
+//   - tags: The tags of the Node. It is empty when the Node has no tag.
+//   - ok: A boolean indicating whether the Node exists in the Tree.
+func (x *Tree[T]) Tags(id string) (tags []string, ok bool) {
+	if _, ok := x.getNode(id); !ok {
+		return nil, false
+	}
+	return x.tags.tags(id), true
+}
+
+// updateTags applies the given function to the set of tags of the Node with the given ID and stores
+// the result once written ahead. It must be called with the structural lock held
+func (x *Tree[T]) updateTags(op, id string, fn func(tags map[string]struct{})) (err error) {
+	node, ok := x.getNode(id)
+	if !ok {
+		return newNodeError(op, id, "", ErrNotFound)
+	}
+
+	write, commit := x.feed.begin()
+	defer func() {
+		commit(err == nil)
+	}()
+
+	return x.tags.update(id, fn, func(tags []string) error {
+		if err := write(Event[T]{Type: EventTag, Node: node.GetValue(), Parent: x.parentValue(id), Tags: tags}); err != nil {
+			return newNodeError(op, id, "", err)
+		}
+		return nil
+	})
+}
+
+// replaceTags replaces the tags of the Node with the given ID with the given ones.
+// It must be called with the structural lock held
+func (x *Tree[T]) replaceTags(id string, tags []string) error {
+	return x.updateTags(opTag, id, func(current map[string]struct{}) {
+		clear(current)
+		for _, tag := range tags {
+			current[tag] = struct{}{}
+		}
+	})
+}
+
+// tagIndex is the inverted index of the Node tags
+type tagIndex struct {
+	mu sync.RWMutex
+	// byTag maps every tag to the set of Node IDs carrying it
+	byTag map[string]map[string]struct{}
+	// byNode maps every Node ID to the set of its tags
+	byNode map[string]map[string]struct{}
+}
+
+// newTagIndex creates an empty tagIndex
+func newTagIndex() *tagIndex {
+	return &tagIndex{
+		byTag:  make(map[string]map[string]struct{}),
+		byNode: make(map[string]map[string]struct{}),
+	}
+}
+
+// add attaches the given tags to the given Node
+func (t *tagIndex) add(id string, tags ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, tag := range tags {
+		link(t.byTag, tag, id)
+		link(t.byNode, id, tag)
+	}
+}
+
+// update applies the given function to a copy of the set of tags of the given Node and replaces them
+// with the result, unless the given write function, called with the resulting tags sorted, fails
+func (t *tagIndex) update(id string, fn func(tags map[string]struct{}), write func(tags []string) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := t.byNode[id]
+	next := make(map[string]struct{}, len(current))
+	for tag := range current {
+		next[tag] = struct{}{}
+	}
+	fn(next)
+
+	tags := setKeys(next)
+	sort.Strings(tags)
+	if err := write(tags); err != nil {
+		return err
+	}
+
+	for tag := range current {
+		if _, ok := next[tag]; !ok {
+			unlink(t.byTag, tag, id)
+		}
+	}
+	for tag := range next {
+		link(t.byTag, tag, id)
+	}
+
+	if len(next) == 0 {
+		delete(t.byNode, id)
+		return nil
+	}
+	t.byNode[id] = next
+	return nil
+}
+
+// removeNodes detaches all the tags of the given Nodes
+func (t *tagIndex) removeNodes(ids ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, id := range ids {
+		for tag := range t.byNode[id] {
+			unlink(t.byTag, tag, id)
+		}
+		delete(t.byNode, id)
+	}
+}
+
+// clear removes all the tags
+func (t *tagIndex) clear() {
+	t.mu.Lock()
+	t.byTag = make(map[string]map[string]struct{})
+	t.byNode = make(map[string]map[string]struct{})
+	t.mu.Unlock()
+}
+
+// nodes returns the IDs of the Nodes carrying the given tag
+func (t *tagIndex) nodes(tag string) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return setKeys(t.byTag[tag])
+}
+
+// tags returns the sorted tags of the given Node
+func (t *tagIndex) tags(id string) []string {
+	t.mu.RLock()
+	tags := setKeys(t.byNode[id])
+	t.mu.RUnlock()
+
+	sort.Strings(tags)
+	return tags
+}
+
+// link adds the given member to the set stored under the given key
+func link(sets map[string]map[string]struct{}, key, member string) {
+	set, ok := sets[key]
+	if !ok {
+		set = make(map[string]struct{})
+		sets[key] = set
+	}
+	set[member] = struct{}{}
+}
+
+// unlink removes the given member from the set stored under the given key,
+// dropping the set once empty
+func unlink(sets map[string]map[string]struct{}, key, member string) {
+	set, ok := sets[key]
+	if !ok {
+		return
+	}
+
+	delete(set, member)
+	if len(set) == 0 {
+		delete(sets, key)
+	}
+}
+
+// setKeys returns the members of the given set
+func setKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	return keys
+}
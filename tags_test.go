@@ -0,0 +1,82 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags(t *testing.T) {
+	tree := NewTree[string]()
+	root := NewNode("root", "root")
+	a := NewNode("a", "a")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.Add(a, root))
+	require.NoError(t, tree.Add(NewNode("b", "b"), a))
+	require.NoError(t, tree.Add(NewNode("c", "c"), root))
+
+	require.NoError(t, tree.Tag("a", "critical", "billing"))
+	require.NoError(t, tree.Tag("b", "critical"))
+	require.NoError(t, tree.Tag("c", "critical", "critical"))
+
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, queryIDs(tree.FindByTag("critical")))
+	assert.ElementsMatch(t, []string{"a"}, queryIDs(tree.FindByTag("billing")))
+	assert.Empty(t, tree.FindByTag("unknown"))
+
+	tags, ok := tree.Tags("a")
+	require.True(t, ok)
+	assert.Equal(t, []string{"billing", "critical"}, tags)
+
+	tags, ok = tree.Tags("root")
+	require.True(t, ok)
+	assert.Empty(t, tags)
+
+	_, ok = tree.Tags("x")
+	assert.False(t, ok)
+
+	err := tree.Tag("x", "critical")
+	require.ErrorIs(t, err, ErrNotFound)
+	err = tree.Untag("x", "critical")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, tree.Untag("c", "critical", "unknown"))
+	assert.ElementsMatch(t, []string{"a", "b"}, queryIDs(tree.FindByTag("critical")))
+
+	// the tags of the deleted nodes are discarded
+	require.NoError(t, tree.Delete(a))
+	assert.Empty(t, tree.FindByTag("critical"))
+	assert.Empty(t, tree.FindByTag("billing"))
+
+	require.NoError(t, tree.Add(NewNode("a", "a"), root))
+	tags, _ = tree.Tags("a")
+	assert.Empty(t, tags)
+
+	require.NoError(t, tree.Tag("a", "critical"))
+	tree.Reset()
+	assert.Empty(t, tree.FindByTag("critical"))
+}
@@ -0,0 +1,74 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+// Tracer starts the spans of the expensive operations of the Tree: the traversals and the
+// bulk operations whose cost grows with the number of Nodes involved (Descendants, Delete of
+// a subtree, Reset, Collapse, Nodes, Stats, Balance, Validate, Repair, Glob, Query and
+// CreateIndex).
+//
+// It is the extension point used to make the hotspots of a Tree visible to a tracing system.
+// A ready-made OpenTelemetry implementation is provided by the gotreeotel package.
+//
+// Implementations must be safe for concurrent use.
+type Tracer interface {
+	// StartSpan starts the span of the given operation. The returned function ends it
+	// with the number of Nodes processed by the operation and its error, nil on success.
+	StartSpan(operation Operation) (end func(nodes int, err error))
+}
+
+// the names of the traced operations that are not reported to the Metrics
+const (
+	// OperationDescendants reports the calls to Descendants and DescendantsByID
+	OperationDescendants Operation = "descendants"
+	// OperationNodes reports the calls to Nodes
+	OperationNodes Operation = "nodes"
+	// OperationStats reports the calls to Stats
+	OperationStats Operation = "stats"
+	// OperationBalance reports the calls to Balance
+	OperationBalance Operation = "balance"
+	// OperationValidate reports the calls to Validate
+	OperationValidate Operation = "validate"
+	// OperationRepair reports the calls to Repair
+	OperationRepair Operation = "repair"
+	// OperationGlob reports the calls to Glob
+	OperationGlob Operation = "glob"
+	// OperationQuery reports the runs of a Query
+	OperationQuery Operation = "query"
+	// OperationCreateIndex reports the calls to CreateIndex
+	OperationCreateIndex Operation = "create index"
+)
+
+// trace starts the span of the given operation when a Tracer is set.
+// The returned function ends it and is never nil.
+func (x *Tree[T]) trace(operation Operation) (end func(nodes int, err error)) {
+	if x.cfg.tracer == nil {
+		return noopSpan
+	}
+	return x.cfg.tracer.StartSpan(operation)
+}
+
+// noopSpan ends a span that has not been started
+func noopSpan(int, error) {}
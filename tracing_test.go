@@ -0,0 +1,93 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type span struct {
+	operation Operation
+	nodes     int
+	err       error
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []span
+}
+
+func (r *recordingTracer) StartSpan(operation Operation) func(nodes int, err error) {
+	return func(nodes int, err error) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.spans = append(r.spans, span{operation: operation, nodes: nodes, err: err})
+	}
+}
+
+func TestTracer(t *testing.T) {
+	tracer := new(recordingTracer)
+	tree := NewTree[string](WithTracer(tracer))
+
+	root := NewNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", "b"), "a"))
+	require.NoError(t, tree.AddByID(NewNode("c", "c"), "root"))
+	assert.Empty(t, tracer.spans)
+
+	_, _ = tree.Descendants(root)
+	_ = tree.Nodes()
+	_ = tree.Stats()
+	_ = tree.Balance()
+	_ = tree.Validate()
+	_ = tree.Repair()
+	_ = tree.Glob("/root/*")
+	_ = tree.Query().Leaves().Run()
+	require.NoError(t, tree.CreateIndex("value", func(value string) string { return value }))
+	require.NoError(t, tree.DeleteByID("a"))
+	_, err := tree.DeleteAndCollect(NewNode("x", ""))
+	require.ErrorIs(t, err, ErrNotFound)
+	tree.Reset()
+
+	assert.Equal(t, []span{
+		{operation: OperationDescendants, nodes: 3},
+		{operation: OperationNodes, nodes: 4},
+		{operation: OperationStats, nodes: 4},
+		{operation: OperationBalance, nodes: 4},
+		{operation: OperationValidate, nodes: 4},
+		{operation: OperationRepair, nodes: 4},
+		{operation: OperationGlob, nodes: 2},
+		{operation: OperationQuery, nodes: 2},
+		{operation: OperationCreateIndex, nodes: 4},
+		{operation: OperationDelete, nodes: 2},
+		{operation: OperationDelete, nodes: 0, err: err},
+		{operation: OperationReset, nodes: 2},
+	}, tracer.spans)
+}
@@ -0,0 +1,442 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SoftDelete removes the given Node, along with its descendants, from the Tree and retains the
+// removed subtree in the trash, so that the deletion can be undone with RestoreDeleted.
+//
+// For the rest of the Tree, the subtree is deleted: its Nodes are no longer found, the watchers and the
+// Storage receive the deletions and the delete hooks are called. The trash keeps the values of the Nodes
+// along with their order, edges, metadata and tags.
+//
+// Parameters:
+//   - node: The Node[T] to delete along with its descendants.
+//
+// Returns:
+//   - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The subtree was moved to the trash.
+//   - ErrNotFound: The Node does not exist in the Tree.
+//
+// Notes:
+//   - Soft deleting a Node already in the trash replaces the retained subtree.
+//   - The trash is held in memory: it is neither persisted nor part of the snapshots, and it is emptied
+//     when the Tree is reset. Use PurgeTrash to release the subtrees no longer needed.
+//   - The links of the multi-parent mode are dropped along with the subtree, as with Delete.
+//
+// Example usage:
+//
+//	if err := tree.SoftDelete(folder); err != nil {
+//	    log.Println("Cannot delete the folder:", err)
+//	}
+//	// the user clicks on undo
+//	err := tree.RestoreDeleted(folder.ID(), nil)
+func (x *Tree[T]) SoftDelete(node Node[T]) (err error) {
+	if err := x.requireContext(opDelete, node.ID(), ""); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opDelete, node.ID(), ""); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationDelete, time.Now(), &err)
+	}
+
+	end := x.trace(OperationDelete)
+	x.mu.Lock()
+	entry, ok := x.captureSubtree(node.ID())
+	if !ok {
+		x.mu.Unlock()
+		err = newNodeError(opDelete, node.ID(), "", ErrNotFound)
+		end(0, err)
+		return err
+	}
+
+	removed, err := x.remove(node.ID())
+	if err == nil {
+		x.trash.put(entry)
+	}
+	x.mu.Unlock()
+	end(len(removed), err)
+
+	x.notifyDelete(removed)
+	return err
+}
+
+// RestoreDeleted reattaches a subtree deleted with SoftDelete and removes it from the trash.
+//
+// Parameters:
+//   - id: The identifier of the root of the deleted subtree.
+//   - parent: The Node[T] under which the subtree is reattached. When nil, the subtree is reattached
+//     to its former parent, at its former position when possible, or as the root of the Tree when
+//     it was the root.
+//
+// Returns:
+//   - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The subtree was restored.
+//   - ErrNotFound: No subtree with the given root is in the trash.
+//   - ErrParentNodeNotFound: The parent, or the former parent, no longer exists in the Tree.
+//   - ErrDuplicateID: A Node of the subtree has been added to the Tree again in the meantime.
+//   - ErrInvalidOperation, ErrMaxDepthExceeded, ErrMaxChildrenExceeded, ErrMaxSizeExceeded,
+//     ErrQuotaExceeded, ErrInvalidID: The subtree cannot be reattached without violating the
+//     constraints of the Tree.
+//   - ErrNotPersisted: The Storage failed to append the restored Nodes.
+//
+// Notes:
+//   - The subtree is checked and written ahead as a whole before any Node is added, hence it is
+//     restored entirely or not at all, and it remains in the trash on failure.
+//   - The restored Nodes are published as additions and the add hooks are called, parents first.
+//   - The edge linking the subtree to its former parent is only restored along with the former parent.
+//
+// Example usage:
+//
+//	for _, node := range tree.Trash() {
+//	    if err := tree.RestoreDeleted(node.ID(), archive); err != nil {
+//	        log.Println("Cannot restore", node.ID(), ":", err)
+//	    }
+//	}
+func (x *Tree[T]) RestoreDeleted(id string, parent Node[T]) (err error) {
+	if err := x.requireContext(opAdd, id, parentID(parent)); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opAdd, id, parentID(parent)); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationAdd, time.Now(), &err)
+	}
+
+	entry, ok := x.trash.take(id)
+	if !ok {
+		return newNodeError(opAdd, id, parentID(parent), ErrNotFound)
+	}
+
+	end := x.trace(OperationAdd)
+	x.mu.Lock()
+	added, err := x.restoreSubtree(entry, parent)
+	x.mu.Unlock()
+	end(len(added), err)
+
+	for _, m := range added {
+		x.notifyAdd(m.node, m.parent)
+	}
+	if err != nil {
+		x.trash.putBack(entry)
+	}
+	return err
+}
+
+// Trash returns the roots of the subtrees retained by SoftDelete, the most recently deleted last.
+//
+// Example usage:
+//
+//	for _, node := range tree.Trash() {
+//	    fmt.Println("Deleted:", node.ID())
+//	}
+func (x *Tree[T]) Trash() []Node[T] {
+	return x.trash.roots()
+}
+
+// PurgeTrash permanently discards the deleted subtree with the given root from the trash.
+// It returns false when no such subtree is in the trash.
+//
+// Example usage:
+//
+//	tree.PurgeTrash(folder.ID())
+func (x *Tree[T]) PurgeTrash(id string) bool {
+	if x.requireContext(opDelete, id, "") != nil {
+		return false
+	}
+	_, ok := x.trash.take(id)
+	return ok
+}
+
+// captureSubtree records the subtree rooted at the Node with the given ID.
+// It must be called with the structural lock held exclusively
+func (x *Tree[T]) captureSubtree(id string) (*trashEntry[T], bool) {
+	n, ok := x.getNode(id)
+	if !ok {
+		return nil, false
+	}
+
+	entry := &trashEntry[T]{index: -1}
+	if parent, ok := x.parentNode(id); ok {
+		entry.parentID = parent.ID
+		entry.index = parent.Descendants.IndexOf(id)
+	}
+
+	type frame struct {
+		node     *treeNode[T]
+		parentID string
+	}
+	stack := []frame{{node: n}}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		entry.nodes = append(entry.nodes, trashedNode[T]{
+			node:     current.node.GetValue(),
+			parentID: current.parentID,
+			edge:     current.node.GetEdge(),
+			meta:     current.node.GetMeta(),
+			tags:     x.tags.tags(current.node.ID),
+		})
+
+		items := current.node.Descendants.Items()
+		for i := len(items) - 1; i >= 0; i-- {
+			stack = append(stack, frame{node: items[i], parentID: current.node.ID})
+		}
+	}
+	return entry, true
+}
+
+// restoreSubtree adds the Nodes of the given trash entry under the given parent, or the former parent when nil,
+// and returns the additions. The subtree is checked and written ahead as a whole before any Node is added,
+// hence it is restored entirely or not at all.
+// It must be called with the structural lock held exclusively
+func (x *Tree[T]) restoreSubtree(entry *trashEntry[T], parent Node[T]) ([]mutation[T], error) {
+	root := entry.nodes[0]
+	index := -1
+	var edge *EdgeData
+	if parent == nil && entry.parentID != "" {
+		former, ok := x.getNode(entry.parentID)
+		if !ok {
+			return nil, newNodeError(opAdd, root.node.ID(), entry.parentID, ErrParentNodeNotFound)
+		}
+		parent, index, edge = former.GetValue(), entry.index, &root.edge
+	}
+
+	var parentNode *treeNode[T]
+	if parent != nil {
+		var ok bool
+		if parentNode, ok = x.getNode(parent.ID()); !ok {
+			return nil, newNodeError(opAdd, root.node.ID(), parent.ID(), ErrParentNodeNotFound)
+		}
+	}
+	if err := x.checkRestore(entry, parentNode); err != nil {
+		x.logRejected(err)
+		return nil, err
+	}
+
+	// write the additions ahead at once, along with the metadata and the tags of the Nodes,
+	// the root being placed before the child currently at the index
+	added := make([]mutation[T], len(entry.nodes))
+	events := make([]Event[T], 0, len(entry.nodes))
+	values := map[string]Node[T]{"": parent}
+	for i, record := range entry.nodes {
+		added[i] = mutation[T]{node: record.node, parent: values[record.parentID]}
+		values[record.node.ID()] = record.node
+
+		event := Event[T]{Type: EventAdd, Node: record.node, Parent: added[i].parent}
+		if i > 0 {
+			event.Edge = record.edge
+		} else if parentNode != nil {
+			event.Before = parentNode.Descendants.At(index)
+			if edge != nil {
+				event.Edge = *edge
+			}
+		}
+		events = append(events, event)
+
+		if len(record.meta) > 0 {
+			events = append(events, Event[T]{Type: EventMeta, Node: record.node, Parent: added[i].parent, Meta: copyMeta(&record.meta)})
+		}
+		if len(record.tags) > 0 {
+			events = append(events, Event[T]{Type: EventTag, Node: record.node, Parent: added[i].parent, Tags: record.tags})
+		}
+	}
+	commit, err := x.feed.stage(events)
+	if err != nil {
+		err = newNodeError(opAdd, root.node.ID(), parentNode.GetID(), err)
+		x.logRejected(err)
+		return nil, err
+	}
+	defer commit()
+
+	for i, record := range entry.nodes {
+		if i > 0 {
+			parentNode, _ = x.getNode(record.parentID)
+			index, edge = -1, &record.edge
+		}
+
+		n, err := x.insert(record.node, parentNode, edge, index)
+		if err != nil {
+			// the subtree has been checked as a whole beforehand
+			x.invariant("subtree %q partially restored: %v", root.node.ID(), err)
+			return added[:i], err
+		}
+
+		if len(record.meta) > 0 {
+			meta := record.meta
+			n.Meta.Store(&meta)
+		}
+		x.tags.add(n.ID, record.tags...)
+	}
+	return added, nil
+}
+
+// checkRestore checks that the Nodes of the given trash entry can all be added under the given parent,
+// or as the root of the Tree when nil, without violating the constraints of the Tree.
+// It must be called with the structural lock held exclusively
+func (x *Tree[T]) checkRestore(entry *trashEntry[T], parentNode *treeNode[T]) error {
+	root, parentID := entry.nodes[0].node.ID(), parentNode.GetID()
+	if parentNode == nil && x.rootNode.Load() != nil {
+		return newNodeError(opAdd, root, "", ErrInvalidOperation)
+	}
+	if err := x.checkConstraints(parentNode); err != nil {
+		return newNodeError(opAdd, root, parentID, err)
+	}
+
+	count := int64(len(entry.nodes))
+	if x.cfg.maxSize > 0 && x.size.Load()+count > x.cfg.maxSize {
+		return newNodeError(opAdd, root, parentID, ErrMaxSizeExceeded)
+	}
+	if !x.quotas.reserve(parentNode.GetPath(), count) {
+		return newNodeError(opAdd, root, parentID, ErrQuotaExceeded)
+	}
+	x.quotas.release(parentNode.GetPath(), count)
+
+	// the depths are relative to the root of the subtree
+	base := parentNode.GetPath().Depth() + 1
+	depths := make(map[string]int, len(entry.nodes))
+	children := make(map[string]int, len(entry.nodes))
+	for i, record := range entry.nodes {
+		id := record.node.ID()
+		if x.cfg.idValidator != nil {
+			if err := x.cfg.idValidator(id); err != nil {
+				return newNodeError(opAdd, id, record.parentID, fmt.Errorf("%w: %w", ErrInvalidID, err))
+			}
+		}
+		if _, ok := x.getNode(id); ok {
+			return newNodeError(opAdd, id, "", ErrDuplicateID)
+		}
+		if i == 0 {
+			continue
+		}
+
+		depths[id] = depths[record.parentID] + 1
+		if x.cfg.maxDepth > 0 && base+depths[id] > x.cfg.maxDepth {
+			return newNodeError(opAdd, id, record.parentID, ErrMaxDepthExceeded)
+		}
+		children[record.parentID]++
+		if x.cfg.maxChildren > 0 && children[record.parentID] > x.cfg.maxChildren {
+			return newNodeError(opAdd, id, record.parentID, ErrMaxChildrenExceeded)
+		}
+	}
+	return nil
+}
+
+// trashEntry is a subtree retained by SoftDelete
+type trashEntry[T any] struct {
+	// parentID is the identifier of the former parent of the subtree, empty for the root of the Tree
+	parentID string
+	// index is the former position of the subtree among the children of its parent
+	index int
+	// nodes holds the Nodes of the subtree in depth-first order, starting with its root
+	nodes []trashedNode[T]
+}
+
+// trashedNode is a Node retained in the trash
+type trashedNode[T any] struct {
+	node     Node[T]
+	parentID string
+	edge     EdgeData
+	meta     map[string]any
+	tags     []string
+}
+
+// trashBin holds the subtrees retained by SoftDelete by root ID, in deletion order
+type trashBin[T any] struct {
+	mu      sync.Mutex
+	entries []*trashEntry[T]
+}
+
+// newTrashBin creates an empty trashBin
+func newTrashBin[T any]() *trashBin[T] {
+	return &trashBin[T]{}
+}
+
+// put retains the given subtree, replacing any subtree with the same root
+func (b *trashBin[T]) put(entry *trashEntry[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = slices.DeleteFunc(b.entries, func(e *trashEntry[T]) bool { return e.root() == entry.root() })
+	b.entries = append(b.entries, entry)
+}
+
+// putBack retains again a subtree which could not be restored, unless a subtree with the same root
+// has been retained in the meantime
+func (b *trashBin[T]) putBack(entry *trashEntry[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !slices.ContainsFunc(b.entries, func(e *trashEntry[T]) bool { return e.root() == entry.root() }) {
+		b.entries = append(b.entries, entry)
+	}
+}
+
+// take removes the subtree with the given root from the trash and returns it
+func (b *trashBin[T]) take(id string) (*trashEntry[T], bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := slices.IndexFunc(b.entries, func(e *trashEntry[T]) bool { return e.root() == id })
+	if i < 0 {
+		return nil, false
+	}
+	entry := b.entries[i]
+	b.entries = slices.Delete(b.entries, i, i+1)
+	return entry, true
+}
+
+// roots returns the roots of the retained subtrees
+func (b *trashBin[T]) roots() []Node[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	roots := make([]Node[T], len(b.entries))
+	for i, entry := range b.entries {
+		roots[i] = entry.nodes[0].node
+	}
+	return roots
+}
+
+// clear discards all the retained subtrees
+func (b *trashBin[T]) clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = nil
+}
+
+// root returns the identifier of the root of the subtree
+func (e *trashEntry[T]) root() string {
+	return e.nodes[0].node.ID()
+}
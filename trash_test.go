@@ -0,0 +1,196 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDelete(t *testing.T) {
+	newTrashTree := func(t *testing.T, opts ...Option) *Tree[string] {
+		tree := NewTree[string](opts...)
+		require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "b"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("c", "c"), "root"))
+		b, _ := tree.Find("b")
+		require.NoError(t, tree.AddWithEdge(NewNode("b1", "b1"), b, EdgeData{Label: "first"}))
+		require.NoError(t, tree.AddByID(NewNode("b2", "b2"), "b"))
+		require.NoError(t, tree.SetMeta("b1", "owner", "alice"))
+		require.NoError(t, tree.Tag("b2", "draft"))
+		return tree
+	}
+
+	t.Run("moves the subtree to the trash", func(t *testing.T) {
+		tree := newTrashTree(t)
+		b, _ := tree.Find("b")
+		require.NoError(t, tree.SoftDelete(b))
+
+		assert.EqualValues(t, 3, tree.Size())
+		_, ok := tree.Find("b1")
+		assert.False(t, ok)
+		assert.Empty(t, tree.FindByTag("draft"))
+		assert.Equal(t, []string{"b"}, queryIDs(tree.Trash()))
+
+		assert.ErrorIs(t, tree.SoftDelete(b), ErrNotFound)
+	})
+
+	t.Run("restores the subtree at its former position", func(t *testing.T) {
+		tree := newTrashTree(t)
+		before := tree.Dump()
+		b, _ := tree.Find("b")
+		require.NoError(t, tree.SoftDelete(b))
+		require.NoError(t, tree.RestoreDeleted("b", nil))
+
+		assert.Equal(t, before, tree.Dump())
+		assert.Empty(t, tree.Trash())
+
+		b1, _ := tree.Find("b1")
+		edge, ok := tree.Edge(b1)
+		require.True(t, ok)
+		assert.Equal(t, "first", edge.Label)
+		meta, _ := tree.Meta("b1")
+		assert.Equal(t, "alice", meta["owner"])
+		assert.Equal(t, []string{"b2"}, queryIDs(tree.FindByTag("draft")))
+		assert.Empty(t, tree.Validate())
+
+		assert.ErrorIs(t, tree.RestoreDeleted("b", nil), ErrNotFound)
+	})
+
+	t.Run("restores the subtree under a new parent", func(t *testing.T) {
+		tree := newTrashTree(t)
+		b, _ := tree.Find("b")
+		c, _ := tree.Find("c")
+		require.NoError(t, tree.SoftDelete(b))
+		require.NoError(t, tree.RestoreDeleted("b", c))
+
+		ancestors, ok := tree.AncestorsByID("b2")
+		require.True(t, ok)
+		assert.Equal(t, []string{"b", "c", "root"}, queryIDs(ancestors))
+	})
+
+	t.Run("restores the root", func(t *testing.T) {
+		tree := newTrashTree(t)
+		require.NoError(t, tree.SoftDelete(tree.Root()))
+		assert.Zero(t, tree.Size())
+		require.NoError(t, tree.RestoreDeleted("root", nil))
+		assert.EqualValues(t, 6, tree.Size())
+	})
+
+	t.Run("keeps the subtree in the trash on failure", func(t *testing.T) {
+		tree := newTrashTree(t)
+		b, _ := tree.Find("b")
+		require.NoError(t, tree.SoftDelete(b))
+
+		require.NoError(t, tree.AddByID(NewNode("b2", "again"), "a"))
+		assert.ErrorIs(t, tree.RestoreDeleted("b", nil), ErrDuplicateID)
+		assert.Len(t, tree.Trash(), 1)
+		_, ok := tree.Find("b")
+		assert.False(t, ok)
+
+		require.NoError(t, tree.DeleteByID("a"))
+		assert.ErrorIs(t, tree.RestoreDeleted("b", NewNode("a", "")), ErrParentNodeNotFound)
+		require.NoError(t, tree.RestoreDeleted("b", nil))
+		assert.EqualValues(t, 5, tree.Size())
+	})
+
+	t.Run("checks the whole subtree before restoring it", func(t *testing.T) {
+		tree := newTrashTree(t, WithMaxDepth(2), WithChangeFeed(16))
+		b, _ := tree.Find("b")
+		c, _ := tree.Find("c")
+		require.NoError(t, tree.SoftDelete(b))
+		seq := tree.LastSeq()
+
+		var added []string
+		tree.OnAdd(func(node, _ Node[string]) { added = append(added, node.ID()) })
+		assert.ErrorIs(t, tree.RestoreDeleted("b", c), ErrMaxDepthExceeded)
+		assert.EqualValues(t, 3, tree.Size())
+		assert.Len(t, tree.Trash(), 1)
+		assert.Empty(t, tree.Validate())
+
+		// nothing has been published
+		assert.Empty(t, added)
+		assert.Equal(t, seq, tree.LastSeq())
+	})
+
+	t.Run("keeps the subtree in the trash when it is not persisted", func(t *testing.T) {
+		storage := &failingStorage[string]{MemoryStorage: NewMemoryStorage[string]()}
+		tree := newTrashTree(t, WithStorage[string](storage))
+		b, _ := tree.Find("b")
+		require.NoError(t, tree.SoftDelete(b))
+		seq := tree.LastSeq()
+
+		storage.broken = true
+		assert.ErrorIs(t, tree.RestoreDeleted("b", nil), ErrNotPersisted)
+		assert.EqualValues(t, 3, tree.Size())
+		assert.Len(t, tree.Trash(), 1)
+		assert.Equal(t, seq, tree.LastSeq())
+
+		storage.broken = false
+		require.NoError(t, tree.RestoreDeleted("b", nil))
+		recovered := NewTree[string](WithStorage[string](storage.MemoryStorage))
+		require.NoError(t, recovered.Recover())
+		assert.Equal(t, tree.Snapshot().Records, recovered.Snapshot().Records)
+	})
+
+	t.Run("publishes the changes", func(t *testing.T) {
+		tree := newTrashTree(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := tree.Watch(ctx, nil)
+
+		var added []string
+		tree.OnAdd(func(node, _ Node[string]) { added = append(added, node.ID()) })
+
+		b, _ := tree.Find("b")
+		require.NoError(t, tree.SoftDelete(b))
+		require.NoError(t, tree.RestoreDeleted("b", nil))
+		assert.Equal(t, []string{"b", "b1", "b2"}, added)
+
+		var types []EventType
+		for range 8 {
+			types = append(types, (<-events).Type)
+		}
+		assert.Equal(t, []EventType{EventDelete, EventDelete, EventDelete, EventAdd, EventAdd, EventMeta, EventAdd, EventTag}, types)
+	})
+
+	t.Run("purges the trash", func(t *testing.T) {
+		tree := newTrashTree(t)
+		b, _ := tree.Find("b")
+		require.NoError(t, tree.SoftDelete(b))
+		assert.True(t, tree.PurgeTrash("b"))
+		assert.False(t, tree.PurgeTrash("b"))
+		assert.ErrorIs(t, tree.RestoreDeleted("b", nil), ErrNotFound)
+
+		a, _ := tree.Find("a")
+		require.NoError(t, tree.SoftDelete(a))
+		tree.Reset()
+		assert.Empty(t, tree.Trash())
+	})
+}
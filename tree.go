@@ -25,13 +25,39 @@
 package gotree
 
 import (
-	"runtime"
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
+
+	"github.com/tochemey/gotree/shardedmap"
 )
 
-const maxShards = 1024
+// the names of the operations reported by NodeError
+const (
+	opAdd        = "add"
+	opReplace    = "replace"
+	opDelete     = "delete"
+	opMove       = "move"
+	opUpdate     = "update"
+	opSetMeta    = "set meta"
+	opDeleteMeta = "delete meta"
+	opSetEdge    = "set edge"
+	opTag        = "tag"
+	opUntag      = "untag"
+	opLink       = "link"
+	opUnlink     = "unlink"
+	opExpand     = "expand"
+	opCollapse   = "collapse"
+	opLoad       = "load"
+	opReset      = "reset"
+	opFind       = "find"
+	opSetQuota   = "set quota"
+)
 
 // Tree defines and implements a thread-safe, flexible Tree-like data structure.
 //
@@ -66,14 +92,45 @@ const maxShards = 1024
 // - The Tree structure is optimized for efficient addition and traversal of Nodes.
 // - Use thread-safe methods (e.g., Add, Delete) when performing operations in concurrent environments.
 type Tree[T any] struct {
-	nodes      ShardedMap
-	parents    ShardedMap
+	// mu is the structural lock. Additions hold it shared while
+	// any other structural change holds it exclusively so that
+	// a Node is never attached under a subtree being removed.
+	mu         sync.RWMutex
+	nodes      *shardedmap.Map[string, *treeNode[T]]
+	parents    *shardedmap.Map[string, *ancestry]
 	nodesPool  *sync.Pool
 	valuesPool *sync.Pool
 	size       atomic.Int64
+	// cfg holds the Tree settings
+	cfg *config
+	// cache is the optional read cache in front of the nodes map
+	cache *readCache[T]
 	// rootNode represents the tree root node
 	// and there can only one root node
-	rootNode *treeNode[T]
+	rootNode atomic.Pointer[treeNode[T]]
+	// indexes holds the secondary indexes by name
+	indexes atomic.Pointer[map[string]*valueIndex[T]]
+	// tags is the inverted index of the Node tags
+	tags *tagIndex
+	// rollups holds the aggregates registered with WithAggregate by name
+	rollups map[string]*rollup[T]
+	// links holds the Nodes shared with several parents. It is nil unless WithMultiParent is set
+	links *sharedLinks
+	// resolver materializes the children on demand. It is nil unless WithChildResolver is set
+	resolver *childResolver[T]
+	// feed publishes the changes to the watchers
+	feed *publisher[T]
+	// authorizer is consulted by the context-aware methods. It is nil unless WithAuthorizer is set
+	authorizer func(ctx context.Context, operation Operation, node Node[T]) error
+	// history retains the values of the Nodes over time. It is nil unless WithHistory is set
+	history *valueHistory[T]
+	// quotas limits the number of Nodes of the subtrees
+	quotas *quotaSet
+	// trash retains the subtrees deleted with SoftDelete
+	trash *trashBin[T]
+	// hooks holds the registered mutation hooks
+	hooks   atomic.Pointer[hooks[T]]
+	hooksMu sync.Mutex
 }
 
 // Add inserts a given Node into the Tree with the specified parent Node.
@@ -94,9 +151,18 @@ type Tree[T any] struct {
 //   - nil: The Node was successfully added to the Tree.
 //   - ErrInvalidOperation: Attempt to add a second root Node, which is not allowed.
 //   - ErrParentNodeNotFound: The specified parent Node does not exist in the Tree.
+//   - ErrDuplicateID: A Node with the same ID already exists in the Tree.
+//   - ErrInvalidID: The Node ID is rejected by the validator set with WithIDValidator.
+//   - ErrMaxDepthExceeded: The Node would be deeper than the limit set with WithMaxDepth.
+//   - ErrMaxChildrenExceeded: The parent Node already holds the number of children set with WithMaxChildren.
+//   - ErrMaxSizeExceeded: The Tree already holds the number of Nodes set with WithMaxSize.
+//
+// The returned errors are *NodeError values carrying the identifiers of the Nodes
+// involved; use errors.Is to match them against the errors above.
 //
 // Notes:
 //   - The `node` being added must have a unique ID not already present in the Tree.
+//     Use AddOrReplace to overwrite an existing Node.
 //   - Adding a Node to a non-existent parent or a parent not currently part of the Tree will
 //     result in an error.
 //   - The Tree structure is updated to reflect the addition.
@@ -119,59 +185,471 @@ type Tree[T any] struct {
 //
 //	fmt.Println("Tree structure updated successfully")
 func (x *Tree[T]) Add(node, parent Node[T]) (err error) {
-	var (
-		parentNode *treeNode[T]
-		ok         bool
-	)
+	if err := x.requireContext(opAdd, node.ID(), parentID(parent)); err != nil {
+		return err
+	}
 
-	// check whether the node to be added is a root node
-	if parent == nil && x.rootNode != nil {
-		return ErrInvalidOperation
+	return x.addContext(context.Background(), node, parent, nil)
+}
+
+// addContext adds the given node under the given parent with the given optional edge data once the
+// mutation limiter lets it through, the wait being bound to the given context
+func (x *Tree[T]) addContext(ctx context.Context, node, parent Node[T], edge *EdgeData) (err error) {
+	if err := x.throttle(ctx, opAdd, node.ID(), parentID(parent)); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationAdd, time.Now(), &err)
 	}
 
-	// check parent node
+	if err = x.addShared(node, parent, edge); err == nil {
+		x.notifyAdd(node, parent)
+	}
+	return err
+}
+
+// AddByID behaves like Add, the parent Node being identified by its ID.
+// An empty parentID adds the given Node as the root of the Tree.
+//
+// It spares the callers holding plain identifiers from looking up the parent Node first.
+//
+// Example usage:
+//
+//	err := tree.AddByID(NewNode("childID", "child"), "rootID")
+//	if errors.Is(err, ErrParentNodeNotFound) {
+//	    fmt.Println("The parent node does not exist")
+//	}
+func (x *Tree[T]) AddByID(node Node[T], parentID string) (err error) {
+	if err := x.requireContext(opAdd, node.ID(), parentID); err != nil {
+		return err
+	}
+
+	return x.addByIDContext(context.Background(), node, parentID, nil)
+}
+
+// addByIDContext adds the given node under the Node with the given ID with the given optional edge data
+// once the mutation limiter lets it through, the wait being bound to the given context
+func (x *Tree[T]) addByIDContext(ctx context.Context, node Node[T], parentID string, edge *EdgeData) error {
+	var parent Node[T]
+	if parentID != "" {
+		parentNode, ok := x.getNode(parentID)
+		if !ok {
+			return newNodeError(opAdd, node.ID(), parentID, ErrParentNodeNotFound)
+		}
+		parent = parentNode.GetValue()
+	}
+	return x.addContext(ctx, node, parent, edge)
+}
+
+// addShared adds the given node under the shared structural lock:
+// additions can run concurrently with each other but not with deletions
+func (x *Tree[T]) addShared(node, parent Node[T], edge *EdgeData) error {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.add(node, parent, edge, -1)
+}
+
+// add inserts the given node under the given parent with the given optional edge data, at the given
+// index among the children of the parent or as the last child when negative.
+// It must be called with the structural lock held
+func (x *Tree[T]) add(node, parent Node[T], edge *EdgeData, index int) error {
+	var parentNode *treeNode[T]
 	if parent != nil {
-		parentNode, ok = x.getNode(parent.ID())
-		if !ok || parentNode == nil {
-			return ErrParentNodeNotFound
+		var ok bool
+		if parentNode, ok = x.getNode(parent.ID()); !ok || parentNode == nil {
+			err := newNodeError(opAdd, node.ID(), parent.ID(), ErrParentNodeNotFound)
+			x.logRejected(err)
+			return err
 		}
 	}
 
+	_, err := x.insert(node, parentNode, edge, index)
+	return err
+}
+
+// insert adds the given node under the given resolved parent treeNode, the root being added when nil.
+// It returns the treeNode holding the node once stored in the Tree.
+// It must be called with the structural lock held
+func (x *Tree[T]) insert(node Node[T], parentNode *treeNode[T], edge *EdgeData, index int) (added *treeNode[T], err error) {
+	defer func() {
+		if err != nil {
+			x.logRejected(err)
+		}
+	}()
+
+	// validate the node identifier
+	if x.cfg.idValidator != nil {
+		if err := x.cfg.idValidator(node.ID()); err != nil {
+			return nil, newNodeError(opAdd, node.ID(), parentNode.GetID(), fmt.Errorf("%w: %w", ErrInvalidID, err))
+		}
+	}
+
+	// publish the addition in order with the concurrent changes
+	write, commit := x.feed.begin()
+	defer func() {
+		commit(err == nil)
+	}()
+
+	// check whether the node to be added is a root node
+	if parentNode == nil && x.rootNode.Load() != nil {
+		return nil, newNodeError(opAdd, node.ID(), "", ErrInvalidOperation)
+	}
+
+	// fail fast when the node already exists
+	if _, ok := x.getNode(node.ID()); ok {
+		return nil, newNodeError(opAdd, node.ID(), parentNode.GetID(), ErrDuplicateID)
+	}
+
+	// enforce the structural constraints
+	if err := x.checkConstraints(parentNode); err != nil {
+		return nil, newNodeError(opAdd, node.ID(), parentNode.GetID(), err)
+	}
+
+	// reserve the node slot in the tree size
+	if !x.reserveSize() {
+		return nil, newNodeError(opAdd, node.ID(), parentNode.GetID(), ErrMaxSizeExceeded)
+	}
+
+	// account for the node in the quotas of its ancestors
+	if !x.quotas.reserve(parentNode.GetPath(), 1) {
+		x.size.Add(-1)
+		return nil, newNodeError(opAdd, node.ID(), parentNode.GetID(), ErrQuotaExceeded)
+	}
+
 	// get a node from the nodes pool
 	childNode := x.nodesPool.Get().(*treeNode[T])
 	childNode.ID = node.ID()
 	val := x.valuesPool.Get().(*value[T])
 	val.data = node
+	val.version = 1
 
 	// store the value atomically in the node
 	childNode.SetValue(val)
 
-	// store the node in the tree
-	x.nodes.Store(node.ID(), childNode)
+	// build the node ancestry chain on top of its parent chain
+	childNode.SetPath(newAncestry(childNode.ID, parentNode.GetPath(), x.cfg.ancestorIndex))
+	if parentNode != nil && edge != nil {
+		childNode.Edge.Store(edge)
+	}
+
+	// rollback undoes the addition when it cannot be completed
+	rollback := func(stored bool, err error) error {
+		if stored {
+			x.nodes.Delete(childNode.ID)
+			// a concurrent lookup may have cached the node before it is recycled
+			x.invalidateCache()
+		}
+		x.size.Add(-1)
+		x.quotas.release(parentNode.GetPath(), 1)
+		x.valuesPool.Put(val)
+		x.recycle(childNode)
+		return newNodeError(opAdd, node.ID(), parentNode.GetID(), err)
+	}
+
+	// write the addition ahead, the node being placed before the child currently at the index
+	event := Event[T]{Type: EventAdd, Node: node, Parent: parentNode.GetValue()}
+	if parentNode != nil {
+		event.Before = parentNode.Descendants.At(index)
+		event.Edge = childNode.GetEdge()
+	}
+	if err := write(event); err != nil {
+		return nil, rollback(false, err)
+	}
+
+	// the races below only occur when the changes are not published, the publication
+	// serializing the additions otherwise
+
+	// store the node in the tree unless a concurrent Add stored it first
+	if _, loaded := x.nodes.LoadOrStore(node.ID(), childNode); loaded {
+		return nil, rollback(false, ErrDuplicateID)
+	}
 
 	// add the given node to the parent descendants
 	// and update the ancestors hierarchy
 	if parentNode != nil {
-		parentNode.Descendants.Append(childNode)
-		x.updateAncestors(parent.ID(), node.ID())
+		if _, ok := parentNode.Descendants.InsertIfLess(childNode, index, x.cfg.maxChildren); !ok {
+			// concurrent additions have reached the limit first
+			return nil, rollback(true, ErrMaxChildrenExceeded)
+		}
+		x.updateAncestors(parentNode, childNode)
+		parentNode.BumpVersion()
 	}
 
 	// only set the root node when parent is nil
-	if parentNode == nil {
-		// set the given node as root node
-		x.rootNode = childNode
+	if parentNode == nil && !x.rootNode.CompareAndSwap(nil, childNode) {
+		// a concurrent Add has set the root node first
+		return nil, rollback(true, ErrInvalidOperation)
 	}
 
-	// increase the size
-	x.size.Add(1)
-	return nil
+	x.reindex(childNode)
+	x.rollup(childNode)
+	x.history.record(childNode)
+	return childNode, nil
+}
+
+// AddOrReplace inserts a given Node into the Tree with the specified parent Node,
+// overwriting any existing Node with the same ID.
+//
+// When no Node with the same ID exists, AddOrReplace behaves exactly like Add.
+// Otherwise:
+//   - if the existing Node is located under the same parent (or is the root and `parent`
+//     is nil), its value is replaced in place and its descendants are kept.
+//   - if the existing Node is located under a different parent, it is deleted along with
+//     its descendants and the given `node` is added under `parent`.
+//
+// Parameters:
+//   - node: The Node[T] to be added to the Tree or to overwrite the existing one.
+//   - parent: The Node[T] under which the `node` will be added as a child. If `parent` is nil,
+//     the `node` will be set as the root Node of the Tree.
+//
+// Returns:
+// - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The Node was successfully added or replaced.
+//   - ErrInvalidOperation: Attempt to add a second root Node, or to move the Node under
+//     one of its own descendants.
+//   - ErrParentNodeNotFound: The specified parent Node does not exist in the Tree.
+//
+// The returned errors are *NodeError values carrying the identifiers of the Nodes
+// involved; use errors.Is to match them against the errors above.
+//
+// Example usage:
+//
+//	tree := NewTree[string]()
+//	_ = tree.Add(NewNode("rootID", "rootValue"), nil)
+//
+//	// replace the root value while keeping its descendants
+//	err := tree.AddOrReplace(NewNode("rootID", "newRootValue"), nil)
+//	if err != nil {
+//	    log.Fatal("Error replacing root:", err)
+//	}
+func (x *Tree[T]) AddOrReplace(node, parent Node[T]) (err error) {
+	if err := x.requireContext(opReplace, node.ID(), parentID(parent)); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opReplace, node.ID(), parentID(parent)); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationReplace, time.Now(), &err)
+	}
+
+	replaced, removed, err := x.addOrReplace(node, parent)
+	if err != nil {
+		return err
+	}
+
+	if replaced {
+		x.notifyUpdate(node, parent)
+		return err
+	}
+
+	x.notifyDelete(removed)
+	x.notifyAdd(node, parent)
+	return err
+}
+
+// addOrReplace implements AddOrReplace under the structural lock. It returns whether the
+// value has been replaced in place, and otherwise the Nodes removed to add the given Node.
+func (x *Tree[T]) addOrReplace(node, parent Node[T]) (replaced bool, removed []mutation[T], err error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	existing, ok := x.getNode(node.ID())
+	if !ok {
+		return false, nil, x.add(node, parent, nil, -1)
+	}
+
+	// resolve the current parent of the existing node
+	currentParent, hasParent := x.parentNode(node.ID())
+	switch {
+	case parent == nil && !hasParent,
+		parent != nil && hasParent && currentParent.ID == parent.ID():
+		// same position, replace the value in place
+		write, commit := x.feed.begin()
+		if err := write(Event[T]{Type: EventUpdate, Node: node, Parent: currentParent.GetValue()}); err != nil {
+			commit(false)
+			return true, nil, newNodeError(opReplace, node.ID(), parentID(parent), err)
+		}
+		existing.ReplaceValue(node, nil)
+		x.reindex(existing)
+		x.rollup(existing)
+		x.history.record(existing)
+		commit(true)
+		return true, nil, nil
+	case parent == nil:
+		// the existing node is not the root and there is already a root
+		return false, nil, newNodeError(opReplace, node.ID(), "", ErrInvalidOperation)
+	}
+
+	// the new parent must not be part of the subtree being replaced
+	parentNode, ok := x.getNode(parent.ID())
+	if !ok {
+		return false, nil, newNodeError(opReplace, node.ID(), parent.ID(), ErrParentNodeNotFound)
+	}
+
+	for link := parentNode.GetPath(); link != nil; link = link.parent {
+		if link.id == node.ID() {
+			return false, nil, newNodeError(opReplace, node.ID(), parent.ID(), ErrInvalidOperation)
+		}
+	}
+
+	if removed, err = x.remove(node.ID()); err != nil {
+		return false, nil, err
+	}
+	return false, removed, x.add(node, parent, nil, -1)
+}
+
+// Update replaces the value of an existing Node of the Tree.
+//
+// The Node to update is identified by the ID of the given `node`. Its position in the
+// Tree and its descendants are kept, and its version is incremented.
+//
+// Parameters:
+//   - node: The Node[T] holding the new value. A Node with the same ID must exist in the Tree.
+//
+// Returns:
+// - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The Node was successfully updated.
+//   - ErrNotFound: The specified Node does not exist in the Tree.
+//
+// Example usage:
+//
+//	err := tree.Update(NewNode("childID", "newValue"))
+//	if errors.Is(err, ErrNotFound) {
+//	    fmt.Println("Node not found")
+//	}
+func (x *Tree[T]) Update(node Node[T]) (err error) {
+	if err := x.requireContext(opUpdate, node.ID(), ""); err != nil {
+		return err
+	}
+
+	return x.updateContext(context.Background(), node)
+}
+
+// updateContext replaces the value of the given node once the mutation limiter lets it through,
+// the wait being bound to the given context
+func (x *Tree[T]) updateContext(ctx context.Context, node Node[T]) (err error) {
+	if err := x.throttle(ctx, opUpdate, node.ID(), ""); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationUpdate, time.Now(), &err)
+	}
+
+	parent, err := x.update(node.ID(), node, nil)
+	if err != nil {
+		return err
+	}
+
+	x.notifyUpdate(node, parent)
+	return err
+}
+
+// UpdateIf replaces the value of the Node with the given ID only when its current
+// version matches the expected one (compare-and-swap semantics).
+//
+// Every Node carries a version that starts at 1 when the Node is added and is incremented
+// whenever its value is replaced or its direct children change. Concurrent writers read the
+// Node along with its version using FindWithVersion and update it with UpdateIf, which
+// detects lost updates.
+//
+// Parameters:
+//   - id: The unique identifier of the Node to update.
+//   - expectedVersion: The version the Node is expected to be at.
+//   - newValue: The Node[T] holding the new value. Its ID must be the given id.
+//
+// Returns:
+// - err: An error indicating the outcome of the operation. Possible values:
+//   - nil: The Node was successfully updated.
+//   - ErrNotFound: The specified Node does not exist in the Tree.
+//   - ErrVersionMismatch: The Node version differs from the expected one.
+//   - ErrInvalidOperation: The ID of newValue differs from the given id.
+//
+// Example usage:
+//
+//	node, version, _ := tree.FindWithVersion("childID")
+//	err := tree.UpdateIf("childID", version, NewNode("childID", node.Value()+"!"))
+//	if errors.Is(err, ErrVersionMismatch) {
+//	    fmt.Println("The node has been updated concurrently, retry")
+//	}
+func (x *Tree[T]) UpdateIf(id string, expectedVersion uint64, newValue Node[T]) (err error) {
+	if err := x.requireContext(opUpdate, id, ""); err != nil {
+		return err
+	}
+	if err := x.throttle(context.Background(), opUpdate, id, ""); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationUpdate, time.Now(), &err)
+	}
+
+	if newValue.ID() != id {
+		return newNodeError(opUpdate, id, "", ErrInvalidOperation)
+	}
+
+	parent, err := x.update(id, newValue, &expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	x.notifyUpdate(newValue, parent)
+	return err
+}
+
+// update replaces the value of the Node with the given ID, when its version matches the expected
+// one if any. It returns the parent of the Node for the update hooks.
+func (x *Tree[T]) update(id string, newValue Node[T], expectedVersion *uint64) (parent Node[T], err error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	existing, ok := x.getNode(id)
+	if !ok {
+		return nil, newNodeError(opUpdate, id, "", ErrNotFound)
+	}
+	return x.replaceValue(existing, newValue, expectedVersion)
+}
+
+// replaceValue replaces the value of the given node, when its version matches the expected one if any,
+// and returns its parent, which is resolved only when the update is observed.
+// It must be called with the structural lock held
+func (x *Tree[T]) replaceValue(existing *treeNode[T], newValue Node[T], expectedVersion *uint64) (parent Node[T], err error) {
+	// publish the update in order with the concurrent changes
+	write, commit := x.feed.begin()
+	defer func() {
+		commit(err == nil)
+	}()
+
+	// check the version before writing the update ahead
+	if expectedVersion != nil && existing.GetVersion() != *expectedVersion {
+		return nil, newNodeError(opUpdate, existing.ID, "", ErrVersionMismatch)
+	}
+
+	if x.hasUpdateHooks() || x.feed.active.Load() {
+		parent = x.parentValue(existing.ID)
+	}
+	if err := write(Event[T]{Type: EventUpdate, Node: newValue, Parent: parent}); err != nil {
+		return nil, newNodeError(opUpdate, existing.ID, "", err)
+	}
+
+	// the version can only change in the meantime when the changes are not published
+	if _, ok := existing.ReplaceValue(newValue, expectedVersion); !ok {
+		return nil, newNodeError(opUpdate, existing.ID, "", ErrVersionMismatch)
+	}
+
+	x.reindex(existing)
+	x.rollup(existing)
+	x.history.record(existing)
+	return parent, nil
 }
 
 // Ancestors retrieves all the ancestor Nodes of a given Node in the Tree sorted by the ID.
 //
 // An ancestor of a Node is any Node located on the path from the root of the Tree
-// to the specified Node (excluding the Node itself). The ancestors are returned
-// in order, starting from the root and progressing toward the specified Node.
+// to the specified Node (excluding the Node itself).
 //
 // Parameters:
 // - node: The Node[T] for which the ancestors are to be retrieved.
@@ -198,16 +676,31 @@ func (x *Tree[T]) Add(node, parent Node[T]) (err error) {
 //	} else {
 //	    fmt.Println("Node not found or no ancestors exist")
 //	}
+//
+// Deprecated: the ancestors are sorted by ID, which loses their genealogical order.
+// Use AncestorsOrdered instead.
 func (x *Tree[T]) Ancestors(node Node[T]) (ancestors []Node[T], ok bool) {
-	ancestorIDs, ok := x.getAncestors(node.ID())
+	return x.AncestorsByID(node.ID())
+}
+
+// AncestorsByID behaves like Ancestors for the Node with the given ID.
+// It spares the callers holding plain identifiers from fabricating a Node.
+//
+// Deprecated: the ancestors are sorted by ID, which loses their genealogical order.
+// Use AncestorsOrdered instead.
+func (x *Tree[T]) AncestorsByID(id string) (ancestors []Node[T], ok bool) {
+	ancestorIDs, ok := x.getAncestors(id)
 	if !ok {
 		return nil, false
 	}
 
 	for _, ancestorID := range ancestorIDs {
-		if ancestor, ok := x.getNode(ancestorID); ok {
-			ancestors = append(ancestors, ancestor.GetValue())
+		ancestor, ok := x.getNode(ancestorID)
+		if !ok {
+			x.invariant("ancestor %q of node %q is not part of the tree", ancestorID, id)
+			continue
 		}
+		ancestors = append(ancestors, ancestor.GetValue())
 	}
 
 	// sort the ancestors
@@ -233,6 +726,10 @@ func (x *Tree[T]) Ancestors(node Node[T]) (ancestors []Node[T], ok bool) {
 //   - ok: A boolean indicating whether the ancestor was found.
 //     Returns false if the level is invalid or there is no ancestor at the specified level.
 //
+// Notes:
+//   - The lookup walks the ancestors of the Node, hence it is linear in the level. It is logarithmic
+//     in the depth of the Node when the Tree is created with WithAncestorIndex.
+//
 // Usage Example:
 //
 //	parent, ok := tree.ParentAt(currentNode, 1)
@@ -249,6 +746,66 @@ func (x *Tree[T]) ParentAt(node Node[T], level uint) (parent Node[T], ok bool) {
 	return ancestor.GetValue(), true
 }
 
+// ParentOf retrieves the direct parent of a given Node.
+//
+// It is the constant time shortcut of ParentAt(node, 0): the parent is read from the ancestor chain
+// the Tree maintains for every Node.
+//
+// Parameters:
+//   - node: The Node[T] whose parent is being queried.
+//
+// Returns:
+//   - parent: The parent Node[T] of the Node.
+//   - ok: A boolean indicating whether the parent was found. It is false for the root
+//     and for a Node that does not exist in the Tree.
+//
+// Example usage:
+//
+//	parent, ok := tree.ParentOf(node)
+//	if ok {
+//	    fmt.Println("Parent:", parent.ID())
+//	}
+func (x *Tree[T]) ParentOf(node Node[T]) (parent Node[T], ok bool) {
+	parentNode, ok := x.parentNode(node.ID())
+	if !ok {
+		return nil, false
+	}
+	return parentNode.GetValue(), true
+}
+
+// Child retrieves the child of a given parent Node with the given ID.
+//
+// Unlike Find, Child checks the relationship between both Nodes: the child is only returned when it
+// is a direct child of the parent. The lookup goes through the children of the parent only.
+//
+// Parameters:
+//   - parent: The Node[T] whose child is being queried.
+//   - childID: The identifier of the child.
+//
+// Returns:
+//   - child: The child Node[T].
+//   - ok: A boolean indicating whether the child was found. It is false when the parent does not
+//     exist in the Tree or when the Node with the given ID is not one of its children.
+//
+// Example usage:
+//
+//	settings, ok := tree.Child(tenant, "settings")
+//	if !ok {
+//	    fmt.Println("The tenant has no settings")
+//	}
+func (x *Tree[T]) Child(parent Node[T], childID string) (child Node[T], ok bool) {
+	parentNode, ok := x.getNode(parent.ID())
+	if !ok {
+		return nil, false
+	}
+
+	childNode, ok := parentNode.Descendants.Get(childID)
+	if !ok {
+		return nil, false
+	}
+	return childNode.GetValue(), true
+}
+
 // Descendants retrieves all the descendant Nodes of a given Node in the Tree sorted by the ID.
 //
 // Descendants of a Node include all Nodes that are directly or indirectly
@@ -287,17 +844,33 @@ func (x *Tree[T]) ParentAt(node Node[T], level uint) (parent Node[T], ok bool) {
 //	    fmt.Println("Node not found")
 //	}
 func (x *Tree[T]) Descendants(node Node[T]) (descendants []Node[T], ok bool) {
-	treeNode, ok := x.getNode(node.ID())
+	return x.DescendantsByID(node.ID())
+}
+
+// DescendantsByID behaves like Descendants for the Node with the given ID.
+// It spares the callers holding plain identifiers from fabricating a Node.
+func (x *Tree[T]) DescendantsByID(id string) (descendants []Node[T], ok bool) {
+	end := x.trace(OperationDescendants)
+	defer func() {
+		end(len(descendants), nil)
+	}()
+
+	treeNode, ok := x.getNode(id)
 	if !ok {
 		return nil, false
 	}
 
-	treeNodes := collectDescendants(treeNode)
-	for _, treeNode := range treeNodes {
-		descendants = append(descendants, treeNode.GetValue())
+	treeNodes := x.collectDescendants(treeNode)
+	for _, descendant := range treeNodes {
+		if x.cfg.mode == StrictMode {
+			if current, ok := x.loadNode(descendant.ID); !ok || current != descendant {
+				x.invariant("descendant %q of node %q is not part of the tree", descendant.ID, id)
+			}
+		}
+		descendants = append(descendants, descendant.GetValue())
 	}
 
-	// sort the ancestors
+	// sort the descendants
 	sort.SliceStable(descendants, func(i, j int) bool {
 		return descendants[i].ID() < descendants[j].ID()
 	})
@@ -305,6 +878,69 @@ func (x *Tree[T]) Descendants(node Node[T]) (descendants []Node[T], ok bool) {
 	return descendants, true
 }
 
+// DescendantEntry is a descendant returned by DescendantsTree along with its position in the subtree
+type DescendantEntry[T any] struct {
+	// Node is the descendant Node
+	Node Node[T]
+	// ParentID is the identifier of the parent of the descendant
+	ParentID string
+	// Depth is the depth of the descendant below the Node the descendants are retrieved for,
+	// its children being at depth one
+	Depth int
+}
+
+// DescendantsTree retrieves all the descendant Nodes of a given Node in hierarchical order.
+//
+// Unlike Descendants, which sorts the descendants by ID, DescendantsTree lists them depth-first: every
+// descendant comes right after its parent, followed by its own descendants, the children keeping their
+// order. Each descendant is annotated with its parent and its depth, hence renderers can draw the
+// subtree without looking the parents up.
+//
+// Parameters:
+//   - node: The Node[T] for which the descendants are to be retrieved.
+//
+// Returns:
+//   - entries: The descendants of the Node in depth-first order. It is empty for a leaf.
+//   - ok: A boolean indicating whether the Node exists in the Tree.
+//
+// Notes:
+//   - This method does not include the specified Node itself in the results.
+//   - The walk does not block the changes of the Tree, like Descendants.
+//
+// Example usage:
+//
+//	entries, ok := tree.DescendantsTree(root)
+//	if ok {
+//	    for _, entry := range entries {
+//	        fmt.Printf("%s%s\n", strings.Repeat("  ", entry.Depth-1), entry.Node.ID())
+//	    }
+//	}
+func (x *Tree[T]) DescendantsTree(node Node[T]) (entries []DescendantEntry[T], ok bool) {
+	end := x.trace(OperationDescendants)
+	defer func() {
+		end(len(entries), nil)
+	}()
+
+	start, ok := x.getNode(node.ID())
+	if !ok {
+		return nil, false
+	}
+
+	entries = []DescendantEntry[T]{}
+	guard := x.newCycleGuard(false, start)
+	var walk func(parent *treeNode[T], depth int)
+	walk = func(parent *treeNode[T], depth int) {
+		for _, child := range parent.Descendants.Items() {
+			if guard.visit(parent, child) {
+				entries = append(entries, DescendantEntry[T]{Node: child.GetValue(), ParentID: parent.ID, Depth: depth})
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(start, 1)
+	return entries, true
+}
+
 // Delete removes the specified Node from the Tree.
 //
 // If the given Node exists in the Tree, it will be removed along with all its
@@ -320,6 +956,9 @@ func (x *Tree[T]) Descendants(node Node[T]) (descendants []Node[T], ok bool) {
 //   - nil: The Node was successfully deleted.
 //   - ErrNotFound: The specified Node does not exist in the Tree.
 //
+// The returned errors are *NodeError values carrying the identifiers of the Nodes
+// involved; use errors.Is to match them against the errors above.
+//
 // Notes:
 //   - This operation will remove the entire subtree rooted at the specified Node.
 //     Use with caution if the Node has descendants.
@@ -342,37 +981,200 @@ func (x *Tree[T]) Descendants(node Node[T]) (descendants []Node[T], ok bool) {
 //	    fmt.Println("Node deleted successfully")
 //	}
 func (x *Tree[T]) Delete(node Node[T]) (err error) {
-	n, ok := x.getNode(node.ID())
+	return x.DeleteByID(node.ID())
+}
+
+// DeleteByID behaves like Delete for the Node with the given ID.
+// It spares the callers holding plain identifiers from fabricating a Node.
+func (x *Tree[T]) DeleteByID(id string) (err error) {
+	if err := x.requireContext(opDelete, id, ""); err != nil {
+		return err
+	}
+
+	return x.deleteContext(context.Background(), id)
+}
+
+// deleteContext deletes the Node with the given ID once the mutation limiter lets it through,
+// the wait being bound to the given context
+func (x *Tree[T]) deleteContext(ctx context.Context, id string) (err error) {
+	if err := x.throttle(ctx, opDelete, id, ""); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationDelete, time.Now(), &err)
+	}
+
+	_, err = x.deleteSubtree(id)
+	return err
+}
+
+// DeleteAndCollect removes the specified Node and its descendants from the Tree
+// and returns the removed Nodes.
+//
+// It behaves exactly like Delete, except that the removed Nodes are returned
+// so that callers can archive or publish what was removed. Collecting them
+// during the deletion avoids calling Descendants beforehand, which would be
+// racy with concurrent additions.
+//
+// Parameters:
+//   - node: The Node[T] to be removed from the Tree along with its descendants.
+//
+// Returns:
+//   - removed: The removed Nodes in depth-first order, starting with the given Node.
+//   - err: nil on success, ErrNotFound when the Node does not exist in the Tree.
+//
+// Example usage:
+//
+//	removed, err := tree.DeleteAndCollect(child)
+//	if err == nil {
+//	    for _, node := range removed {
+//	        fmt.Println("Removed:", node.ID())
+//	    }
+//	}
+func (x *Tree[T]) DeleteAndCollect(node Node[T]) (removed []Node[T], err error) {
+	if err := x.requireContext(opDelete, node.ID(), ""); err != nil {
+		return nil, err
+	}
+	if err := x.throttle(context.Background(), opDelete, node.ID(), ""); err != nil {
+		return nil, err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationDelete, time.Now(), &err)
+	}
+
+	mutations, err := x.deleteSubtree(node.ID())
+	for _, m := range mutations {
+		removed = append(removed, m.node)
+	}
+	return removed, err
+}
+
+// DeleteSubtree removes the specified Node and its descendants from the Tree
+// and reports how many Nodes were removed along with their IDs.
+//
+// It behaves exactly like Delete. The identifiers spare the callers maintaining external indexes or
+// metrics from walking the subtree beforehand, without holding on to the removed Nodes like
+// DeleteAndCollect.
+//
+// Example usage:
+//
+//	count, ids, err := tree.DeleteSubtree(child)
+//	if err == nil {
+//	    search.Remove(ids...)
+//	    removedTotal.Add(float64(count))
+//	}
+func (x *Tree[T]) DeleteSubtree(node Node[T]) (count int, ids []string, err error) {
+	if err := x.requireContext(opDelete, node.ID(), ""); err != nil {
+		return 0, nil, err
+	}
+	if err := x.throttle(context.Background(), opDelete, node.ID(), ""); err != nil {
+		return 0, nil, err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationDelete, time.Now(), &err)
+	}
+
+	mutations, err := x.deleteSubtree(node.ID())
+	ids = make([]string, 0, len(mutations))
+	for _, m := range mutations {
+		ids = append(ids, m.node.ID())
+	}
+	return len(ids), ids, err
+}
+
+// deleteSubtree deletes the Node with the given ID along with its descendants,
+// tracing the deletion and notifying the delete hooks
+func (x *Tree[T]) deleteSubtree(id string) ([]mutation[T], error) {
+	end := x.trace(OperationDelete)
+	moves, removed, err := x.deleteOrRelease(id)
+	end(len(removed), err)
+
+	for _, moved := range moves {
+		x.notifyMove(moved.node, moved.from, moved.to)
+	}
+	x.notifyDelete(removed)
+	return removed, err
+}
+
+// remove deletes the given node and its descendants, cleaning up their ancestor chains.
+// It must be called with the structural lock held exclusively
+func (x *Tree[T]) remove(id string) ([]mutation[T], error) {
+	n, ok := x.getNode(id)
 	if !ok {
-		return ErrNotFound
+		return nil, newNodeError(opDelete, id, "", ErrNotFound)
 	}
 
-	// remove the node from its parent's Children slice
-	if ancestors, ok := x.parents.Load(node.ID()); ok && len(ancestors.([]string)) > 0 {
-		parentID := ancestors.([]string)[0]
-		if parent, found := x.getNode(parentID); found {
-			children := filterOutChild(parent.Descendants, node.ID())
-			parent.Descendants.Reset()
-			parent.Descendants.AppendMany(children.Items()...)
+	// collect the node and its descendants in depth-first order
+	var (
+		removed   []*treeNode[T]
+		mutations []mutation[T]
+		collect   func(n *treeNode[T], parent Node[T])
+	)
+	guard := x.newCycleGuard(true, n)
+	collect = func(n *treeNode[T], parent Node[T]) {
+		removed = append(removed, n)
+		mutations = append(mutations, mutation[T]{node: n.GetValue(), parent: parent})
+		for _, child := range n.Descendants.Items() {
+			if guard.visit(n, child) {
+				collect(child, n.GetValue())
+			}
 		}
 	}
 
-	// recursive function to delete a node and its descendants
-	var deleteChildren func(n *treeNode[T])
-	deleteChildren = func(n *treeNode[T]) {
-		for index, child := range n.Descendants.Items() {
-			n.Descendants.Delete(index)
-			deleteChildren(child)
+	parent, ok := x.parentNode(id)
+	collect(n, parent.GetValue())
+
+	// write the removal ahead
+	write, commit := x.feed.begin()
+	if err := write(toEvents(EventDelete, mutations)...); err != nil {
+		commit(false)
+		return nil, newNodeError(opDelete, id, "", err)
+	}
+	defer commit(true)
+
+	// remove the node from its parent's children
+	if ok {
+		if !parent.Descendants.Remove(id) {
+			x.invariant("node %q is not a child of its parent %q", id, parent.ID)
 		}
-		// delete node from maps and pool
+		parent.BumpVersion()
+	} else if x.rootNode.Load() != n {
+		x.invariant("node %q has no parent and is not the root", id)
+	}
+
+	// deleting the root empties the Tree
+	x.rootNode.CompareAndSwap(n, nil)
+
+	for _, n := range removed {
 		x.nodes.Delete(n.ID)
 		x.parents.Delete(n.ID)
-		x.nodesPool.Put(n)
-		x.size.Add(-1)
 	}
+	x.size.Add(-int64(len(removed)))
 
-	deleteChildren(n)
-	return nil
+	// discard the cached nodes before recycling the removed ones
+	x.invalidateCache()
+	ids := make([]string, len(removed))
+	for i, n := range removed {
+		ids[i] = n.ID
+	}
+	x.unindex(ids...)
+	x.unrollup(parent.GetPath(), ids...)
+	x.quotas.release(parent.GetPath(), int64(len(ids)))
+	x.quotas.remove(ids...)
+	x.tags.removeNodes(ids...)
+	x.links.removeNodes(ids...)
+	x.resolver.forget(ids...)
+	x.history.delete(ids...)
+
+	for _, n := range removed {
+		x.recycle(n)
+	}
+
+	x.log(slog.LevelDebug, "tree nodes deleted", slog.String("node", id), slog.Int("count", len(mutations)))
+	return mutations, nil
 }
 
 // Find searches for a Node in the Tree with the specified key.
@@ -402,6 +1204,10 @@ func (x *Tree[T]) Delete(node Node[T]) (err error) {
 //	    fmt.Println("Node not found")
 //	}
 func (x *Tree[T]) Find(key string) (item Node[T], ok bool) {
+	if x.cfg.metrics != nil {
+		defer x.observeFind(time.Now(), &ok)
+	}
+
 	treeNode, ok := x.getNode(key)
 	if !ok {
 		return nil, false
@@ -409,6 +1215,40 @@ func (x *Tree[T]) Find(key string) (item Node[T], ok bool) {
 	return treeNode.GetValue(), true
 }
 
+// FindWithVersion searches for a Node in the Tree with the specified key and returns
+// it along with its current version.
+//
+// The Node and the version are read atomically, which makes the pair suitable
+// for a subsequent UpdateIf.
+//
+// Parameters:
+// - key: A string representing the unique identifier of the Node to be searched.
+//
+// Returns:
+// - item: The Node[T] associated with the given key if found, nil otherwise.
+// - version: The version of the Node if found, zero otherwise.
+// - ok: A boolean indicating whether the Node was found (true) or not (false).
+//
+// Example usage:
+//
+//	node, version, ok := tree.FindWithVersion("exampleKey")
+//	if ok {
+//	    fmt.Println("Node found:", node, "at version", version)
+//	}
+func (x *Tree[T]) FindWithVersion(key string) (item Node[T], version uint64, ok bool) {
+	if x.cfg.metrics != nil {
+		defer x.observeFind(time.Now(), &ok)
+	}
+
+	treeNode, ok := x.getNode(key)
+	if !ok {
+		return nil, 0, false
+	}
+
+	current := treeNode.Value.Load()
+	return current.Data(), current.version, true
+}
+
 // Root returns the root Node of the Tree.
 //
 // The root Node is the top-most Node in the Tree, from which all other Nodes
@@ -434,7 +1274,32 @@ func (x *Tree[T]) Find(key string) (item Node[T], ok bool) {
 //	    fmt.Println("Root node:", root)
 //	}
 func (x *Tree[T]) Root() Node[T] {
-	return x.rootNode.GetValue()
+	return x.rootNode.Load().GetValue()
+}
+
+// RootOK returns the root Node of the Tree and whether the Tree has a root.
+//
+// It is the comma-ok variant of Root, allowing the caller to detect an empty
+// Tree without comparing the result against nil.
+//
+// Returns:
+//   - root: The root Node of the Tree, nil when the Tree is empty.
+//   - ok: A boolean indicating whether the Tree has a root Node (true) or is empty (false).
+//
+// Example usage:
+//
+//	tree := NewTree[string]()
+//	if root, ok := tree.RootOK(); ok {
+//	    fmt.Println("Root node:", root.ID())
+//	} else {
+//	    fmt.Println("The Tree is empty")
+//	}
+func (x *Tree[T]) RootOK() (root Node[T], ok bool) {
+	rootNode := x.rootNode.Load()
+	if rootNode == nil {
+		return nil, false
+	}
+	return rootNode.GetValue(), true
 }
 
 // Size returns the current number of Nodes in the Tree.
@@ -470,18 +1335,30 @@ func (x *Tree[T]) Size() int64 {
 // This method clears the Tree, effectively removing the root node, all its
 // descendants, and any other Nodes that were added. After calling Reset,
 // the Tree will be empty, and subsequent operations (such as finding,
-// adding, or removing Nodes) will operate on a newly reset Tree.
+// adding, or removing Nodes) will operate on a newly reset Tree. A new root
+// Node can be added right after the reset.
 //
 // Use cases for this method include situations where the Tree needs to be
 // cleared and reinitialized, such as when reloading data, or when the Tree
 // needs to be reset for a new operation or test case.
 //
+// Parameters:
+//   - opts: Optional settings driving how memory is handled:
+//   - WithReleaseMemory: (default) the internal maps are dropped so that their memory
+//     can be reclaimed by the garbage collector.
+//   - WithKeepCapacity: the internal maps are cleared in place so that their memory is
+//     reused when the Tree is populated again.
+//
 // Notes:
 //   - This method does not change the underlying Tree structure itself,
 //     but rather removes all Nodes, setting the Tree back to an empty state.
+//   - The removed Nodes are returned to the internal pools to be reused by subsequent additions.
 //   - Any references to Nodes before calling Reset will become invalid once
 //     the Nodes are removed, so be cautious when retaining pointers to Nodes
 //     that may be cleared.
+//   - The reset waits for the limiter set with WithMutationLimiter. When the limiter refuses it,
+//     or the Storage set with WithStorage fails to append it, the Tree is left untouched and the
+//     refusal is logged.
 //
 // Example usage:
 //
@@ -489,12 +1366,110 @@ func (x *Tree[T]) Size() int64 {
 //	tree.Add(&MyNode{id: "1", value: "Root Node"}, nil)
 //	fmt.Println("Before reset, tree size:", tree.Size()) // Output: 1
 //
-//	tree.Reset()
+//	tree.Reset(WithKeepCapacity())
 //	fmt.Println("After reset, tree size:", tree.Size()) // Output: 0
-func (x *Tree[T]) Reset() {
-	x.nodes.Reset()   // Reset nodes map
-	x.parents.Reset() // Reset parents map
+func (x *Tree[T]) Reset(opts ...ResetOption) {
+	err := x.requireContext(opReset, "", "")
+	if err == nil {
+		err = x.resetContext(context.Background(), newResetConfig(opts...))
+	}
+	if err != nil {
+		x.log(slog.LevelWarn, "tree not reset", slog.String("error", err.Error()))
+	}
+}
+
+// resetContext removes all the Nodes once the mutation limiter lets it through,
+// the wait being bound to the given context
+func (x *Tree[T]) resetContext(ctx context.Context, cfg *resetConfig) (err error) {
+	if err := x.throttle(ctx, opReset, "", ""); err != nil {
+		return err
+	}
+
+	if x.cfg.metrics != nil {
+		defer x.observe(OperationReset, time.Now(), &err)
+	}
+
+	end := x.trace(OperationReset)
+	removed, count, err := x.reset(cfg)
+	end(count, err)
+	if err != nil {
+		return err
+	}
+
+	x.notifyDelete(removed)
+	return nil
+}
+
+// reset removes all the Nodes under the exclusive structural lock.
+// It returns the removed Nodes for the delete hooks, when observed, and their number.
+func (x *Tree[T]) reset(cfg *resetConfig) ([]mutation[T], int, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	// collect the nodes to recycle them once the maps are cleared
+	var removed []*treeNode[T]
+	x.nodes.Range(func(_ string, value *treeNode[T]) bool {
+		removed = append(removed, value)
+		return true
+	})
+
+	// collect the removed nodes in depth-first order for the delete hooks and the watchers
+	var mutations []mutation[T]
+	if x.hasDeleteHooks() || x.feed.active.Load() {
+		var walk func(n *treeNode[T], parent Node[T])
+		guard := x.newCycleGuard(false, x.rootNode.Load())
+		walk = func(n *treeNode[T], parent Node[T]) {
+			mutations = append(mutations, mutation[T]{node: n.GetValue(), parent: parent})
+			for _, child := range n.Descendants.Items() {
+				if guard.visit(n, child) {
+					walk(child, n.GetValue())
+				}
+			}
+		}
+		if root := x.rootNode.Load(); root != nil {
+			walk(root, nil)
+		}
+	}
+
+	// write the removals ahead
+	write, commit := x.feed.begin()
+	if err := write(toEvents(EventDelete, mutations)...); err != nil {
+		commit(false)
+		return nil, 0, newNodeError(opReset, "", "", err)
+	}
+	defer commit(true)
+
+	if cfg.keepCapacity {
+		x.nodes.Clear()
+		x.parents.Clear()
+	} else {
+		x.nodes.Reset()   // Reset nodes map
+		x.parents.Reset() // Reset parents map
+	}
+
+	x.rootNode.Store(nil)
+	x.invalidateCache()
+	x.clearIndexes()
+	x.clearRollups()
+	x.tags.clear()
+	x.links.clear()
+	x.resolver.clear()
+	x.trash.clear()
+	x.quotas.clear()
 	x.size.Store(0)
+
+	if x.history != nil {
+		ids := make([]string, len(removed))
+		for i, n := range removed {
+			ids[i] = n.ID
+		}
+		x.history.delete(ids...)
+	}
+
+	for _, n := range removed {
+		x.recycle(n)
+	}
+	return mutations, len(removed), nil
 }
 
 // Nodes retrieves all the Nodes present in the Tree.
@@ -522,15 +1497,60 @@ func (x *Tree[T]) Reset() {
 //	    fmt.Println("Node ID:", node.ID(), "Value:", node.Value())
 //	}
 func (x *Tree[T]) Nodes() []Node[T] {
+	end := x.trace(OperationNodes)
 	var nodes []Node[T]
-	x.nodes.Range(func(_, value any) bool {
-		node := value.(*treeNode[T])
+	defer func() {
+		end(len(nodes), nil)
+	}()
+
+	x.nodes.Range(func(_ string, value *treeNode[T]) bool {
+		node := value
 		nodes = append(nodes, node.GetValue())
 		return true
 	})
 	return nodes
 }
 
+// Values retrieves the values of all the Nodes present in the Tree.
+//
+// It behaves like Nodes without allocating the Node wrappers, which suits callers
+// that only need the payloads. The values are returned in an unspecified order.
+//
+// Example usage:
+//
+//	for _, value := range tree.Values() {
+//	    fmt.Println("Value:", value)
+//	}
+func (x *Tree[T]) Values() []T {
+	values := make([]T, 0, x.size.Load())
+	x.nodes.Range(func(_ string, value *treeNode[T]) bool {
+		if data := value.GetValue(); data != nil {
+			values = append(values, data.Value())
+		}
+		return true
+	})
+	return values
+}
+
+// IDs retrieves the identifiers of all the Nodes present in the Tree.
+//
+// It behaves like Nodes without allocating the Node wrappers, which suits callers
+// that only need the keys. The identifiers are returned in an unspecified order.
+//
+// Example usage:
+//
+//	for _, id := range tree.IDs() {
+//	    fmt.Println("Node ID:", id)
+//	}
+func (x *Tree[T]) IDs() []string {
+	ids := make([]string, 0, x.size.Load())
+	x.nodes.Range(func(key string, _ *treeNode[T]) bool {
+		ids = append(ids, key)
+		return true
+	})
+	return ids
+}
+
 // NewTree creates and initializes a new instance of a Tree.
 //
 // This function returns a pointer to a newly created Tree, which is empty by default
@@ -542,6 +1562,9 @@ func (x *Tree[T]) Nodes() []Node[T] {
 // The returned Tree is empty initially, meaning there is no root Node or child Nodes.
 // You can add a root Node and subsequent child Nodes using the Add method.
 //
+// Parameters:
+//   - opts: Optional settings applied to the Tree (e.g., WithExpectedSize).
+//
 // Returns:
 // - *Tree[T]: A pointer to a new, empty Tree instance.
 //
@@ -554,19 +1577,35 @@ func (x *Tree[T]) Nodes() []Node[T] {
 //	tree.Add(rootNode, nil) // Add the root node to the Tree
 //	fmt.Println("Tree size:", tree.Size()) // Output: 1 (Tree has one node)
 //
+//	bulk := NewTree[string](WithExpectedSize(1_000_000)) // Pre-size the Tree for bulk ingestion
+//
 // Notes:
 //   - The Tree is initialized without any nodes. It must be populated with Nodes using
 //     the Add method or other Tree methods.
 //   - The Tree can handle nodes of any type, allowing flexible use cases for different data types.
-func NewTree[T any]() *Tree[T] {
-	numShards := determineShards()
-	return &Tree[T]{
-		nodes:   NewShardedMap(numShards),
-		parents: NewShardedMap(numShards),
+func NewTree[T any](opts ...Option) *Tree[T] {
+	cfg := newConfig(opts...)
+
+	var storage Storage[T]
+	if cfg.storage != nil {
+		var ok bool
+		if storage, ok = cfg.storage.(Storage[T]); !ok {
+			panic(fmt.Sprintf("gotree: storage %T does not hold %s values", cfg.storage, reflect.TypeFor[T]()))
+		}
+	}
+
+	tree := &Tree[T]{
+		cfg:     cfg,
+		tags:    newTagIndex(),
+		trash:   newTrashBin[T](),
+		quotas:  newQuotaSet(),
+		feed:    newPublisher(cfg.changeFeedSize, cfg.logger, storage),
+		nodes:   shardedmap.New[string, *treeNode[T]](shardedmap.WithCapacity(int(cfg.expectedSize))),
+		parents: shardedmap.New[string, *ancestry](shardedmap.WithCapacity(int(cfg.expectedSize))),
 		nodesPool: &sync.Pool{
 			New: func() any {
 				return &treeNode[T]{
-					Descendants: NewSlice[*treeNode[T]](),
+					Descendants: newChildren[T](),
 				}
 			},
 		},
@@ -576,74 +1615,202 @@ func NewTree[T any]() *Tree[T] {
 			},
 		},
 	}
+
+	if cfg.readCacheSize > 0 {
+		tree.cache = newReadCache[T](cfg.readCacheSize)
+	}
+
+	if cfg.authorizer != nil {
+		authorizer, ok := cfg.authorizer.(func(ctx context.Context, operation Operation, node Node[T]) error)
+		if !ok {
+			panic(fmt.Sprintf("gotree: authorizer does not authorize %s values", reflect.TypeFor[T]()))
+		}
+		tree.authorizer = authorizer
+	}
+
+	if cfg.history {
+		tree.history = newValueHistory[T](cfg.historyLimit)
+	}
+
+	if cfg.multiParent {
+		tree.links = newSharedLinks()
+	}
+
+	if cfg.childResolver != nil {
+		resolve, ok := cfg.childResolver.(func(ctx context.Context, id string) ([]Node[T], error))
+		if !ok {
+			panic(fmt.Sprintf("gotree: child resolver does not resolve %s values", reflect.TypeFor[T]()))
+		}
+		if resolve != nil {
+			tree.resolver = newChildResolver(resolve)
+		}
+	}
+
+	if len(cfg.aggregates) > 0 {
+		tree.rollups = make(map[string]*rollup[T], len(cfg.aggregates))
+		for name, extract := range cfg.aggregates {
+			fn, ok := extract.(func(value T) float64)
+			if !ok {
+				panic(fmt.Sprintf("gotree: aggregate %q does not extract %s values", name, reflect.TypeFor[T]()))
+			}
+			tree.rollups[name] = newRollup(fn)
+		}
+	}
+	return tree
 }
 
+// getNode resolves the treeNode of the given id, going through the read cache when enabled
 func (x *Tree[T]) getNode(id string) (*treeNode[T], bool) {
-	value, ok := x.nodes.Load(id)
-	if !ok {
-		return nil, false
+	if x.cache == nil {
+		return x.loadNode(id)
+	}
+
+	if node, ok := x.cache.Get(id); ok {
+		return node, true
+	}
+
+	epoch := x.cache.Epoch()
+	node, ok := x.loadNode(id)
+	if ok {
+		x.cache.Put(id, node, epoch)
 	}
-	node, ok := value.(*treeNode[T])
 	return node, ok
 }
 
+// loadNode resolves the treeNode of the given id from the nodes map
+func (x *Tree[T]) loadNode(id string) (*treeNode[T], bool) {
+	return x.nodes.Load(id)
+}
+
+// checkConstraints checks that a node can be added under the given parent
+// without violating the depth and children limits of the Tree
+func (x *Tree[T]) checkConstraints(parent *treeNode[T]) error {
+	if parent == nil {
+		return nil
+	}
+
+	if x.cfg.maxDepth > 0 && parent.GetPath().Depth()+1 > x.cfg.maxDepth {
+		return ErrMaxDepthExceeded
+	}
+
+	if x.cfg.maxChildren > 0 && parent.Descendants.Len() >= x.cfg.maxChildren {
+		return ErrMaxChildrenExceeded
+	}
+	return nil
+}
+
+// reserveSize increments the size of the Tree unless the Tree is full.
+// It reports whether the slot has been reserved
+func (x *Tree[T]) reserveSize() bool {
+	if x.cfg.maxSize <= 0 {
+		x.size.Add(1)
+		return true
+	}
+
+	for {
+		size := x.size.Load()
+		if size >= x.cfg.maxSize {
+			return false
+		}
+		if x.size.CompareAndSwap(size, size+1) {
+			return true
+		}
+	}
+}
+
+// invariant reports an internal inconsistency described by the given format and arguments.
+// It panics in StrictMode and does nothing in LenientMode
+func (x *Tree[T]) invariant(format string, args ...any) {
+	err := fmt.Errorf("%w: "+format, append([]any{ErrInvariantViolation}, args...)...)
+	if x.cfg.mode == StrictMode {
+		panic(err)
+	}
+	x.log(slog.LevelWarn, "tree inconsistency skipped", slog.String("error", err.Error()))
+}
+
+// recycle returns a removed treeNode to the nodes pool
+func (x *Tree[T]) recycle(n *treeNode[T]) {
+	// invalidate the handles first so that they never observe the recycled state
+	n.Generation.Add(1)
+	n.Descendants.Reset()
+	n.SetPath(nil)
+	n.Meta.Store(nil)
+	n.Edge.Store(nil)
+	x.nodesPool.Put(n)
+}
+
+// invalidateCache discards the read cache entries when enabled
+func (x *Tree[T]) invalidateCache() {
+	if x.cache != nil {
+		x.cache.Invalidate()
+	}
+}
+
 // getAncestors returns the list of ancestor nodes
 func (x *Tree[T]) getAncestors(id string) ([]string, bool) {
 	if value, ok := x.parents.Load(id); ok {
-		return value.([]string), true
+		return value.IDs(), true
 	}
 	return nil, false
 }
 
 // updateAncestors updates the parent/ancestor relationships.
-func (x *Tree[T]) updateAncestors(parentID, childID string) {
-	if ancestors, ok := x.getAncestors(parentID); ok {
-		x.parents.Store(childID, append([]string{parentID}, ancestors...))
-	} else {
-		x.parents.Store(childID, []string{parentID})
+// The parent ancestry chain is stored as is so that it is shared
+// with the child's siblings rather than copied.
+func (x *Tree[T]) updateAncestors(parent, child *treeNode[T]) {
+	x.parents.Store(child.ID, parent.GetPath())
+}
+
+// parentNode retrieves the direct parent of the node with the given id
+func (x *Tree[T]) parentNode(id string) (*treeNode[T], bool) {
+	value, ok := x.parents.Load(id)
+	if !ok {
+		return nil, false
 	}
+	return x.getNode(value.id)
+}
+
+// parentValue returns the value of the parent of the Node with the given ID or nil for the root
+func (x *Tree[T]) parentValue(id string) Node[T] {
+	if parent, ok := x.parentNode(id); ok {
+		return parent.GetValue()
+	}
+	return nil
 }
 
 // ancestorAt retrieves the ancestor at the specified level (0 for parent, 1 for grandparent, etc.)
 func (x *Tree[T]) ancestorAt(node Node[T], level int) (*treeNode[T], bool) {
-	ancestors, ok := x.getAncestors(node.ID())
-	if ok && len(ancestors) > level {
-		return x.getNode(ancestors[level])
+	value, ok := x.parents.Load(node.ID())
+	if !ok {
+		return nil, false
+	}
+	if link, ok := value.At(level); ok {
+		return x.getNode(link.id)
 	}
 	return nil, false
 }
 
 // collectDescendants collects all the descendants and grand children
-func collectDescendants[T any](node *treeNode[T]) []*treeNode[T] {
+func (x *Tree[T]) collectDescendants(node *treeNode[T]) []*treeNode[T] {
 	output := NewSlice[*treeNode[T]]()
+	guard := x.newCycleGuard(false, node)
 	var recursive func(*treeNode[T])
 	recursive = func(currentNode *treeNode[T]) {
 		for _, child := range currentNode.Descendants.Items() {
-			output.Append(child)
-			recursive(child)
+			if guard.visit(currentNode, child) {
+				output.Append(child)
+				recursive(child)
+			}
 		}
 	}
 	recursive(node)
 	return output.Items()
 }
 
-// filterOutChild removes the node with the given ID from the Children slice.
-func filterOutChild[T any](children *Slice[*treeNode[T]], childID string) *Slice[*treeNode[T]] {
-	for i, child := range children.Items() {
-		if child.ID == childID {
-			children.Delete(i)
-			return children
-		}
-	}
-	return children
-}
-
-// determineShards returns the total number of shards
-// to use
-func determineShards() uint64 {
-	optimalShards := runtime.NumCPU() * 4
-	if optimalShards > maxShards {
-		return uint64(maxShards)
+// parentID returns the identifier of the given parent Node or an empty string when nil
+func parentID[T any](parent Node[T]) string {
+	if parent == nil {
+		return ""
 	}
-	return uint64(optimalShards)
+	return parent.ID()
 }
@@ -25,7 +25,9 @@
 package gotree
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -135,6 +137,628 @@ func TestTree(t *testing.T) {
 	tree.Reset()
 }
 
+func TestTreeWithExpectedSize(t *testing.T) {
+	tree := NewTree[string](WithExpectedSize(1000))
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+
+	for i := 0; i < 1000; i++ {
+		id := fmt.Sprintf("%d", i)
+		require.NoError(t, tree.Add(newTestNode(id, id), root))
+	}
+
+	assert.EqualValues(t, 1001, tree.Size())
+	descendants, ok := tree.Descendants(root)
+	assert.True(t, ok)
+	assert.Len(t, descendants, 1000)
+}
+
+func TestAncestryInterning(t *testing.T) {
+	tree := NewTree[string]()
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	parent := newTestNode("parent", "parent")
+	require.NoError(t, tree.Add(parent, root))
+	child1 := newTestNode("child1", "child1")
+	require.NoError(t, tree.Add(child1, parent))
+	child2 := newTestNode("child2", "child2")
+	require.NoError(t, tree.Add(child2, parent))
+
+	// siblings share the same ancestry chain
+	chain1, ok := tree.parents.Load(child1.ID())
+	require.True(t, ok)
+	chain2, ok := tree.parents.Load(child2.ID())
+	require.True(t, ok)
+	assert.Same(t, chain1, chain2)
+
+	ancestors, ok := tree.getAncestors(child2.ID())
+	assert.True(t, ok)
+	assert.Equal(t, []string{"parent", "root"}, ancestors)
+}
+
+func TestAncestorIndex(t *testing.T) {
+	indexed := NewTree[string](WithAncestorIndex())
+	plain := NewTree[string]()
+	for _, tree := range []*Tree[string]{indexed, plain} {
+		require.NoError(t, tree.AddByID(NewNode("0", ""), ""))
+		// a deep chain with a branch hanging off every tenth node
+		for i := 1; i < 500; i++ {
+			require.NoError(t, tree.AddByID(NewNode(strconv.Itoa(i), ""), strconv.Itoa(i-1)))
+			if i%10 == 0 {
+				require.NoError(t, tree.AddByID(NewNode(fmt.Sprintf("b%d", i), ""), strconv.Itoa(i)))
+				require.NoError(t, tree.AddByID(NewNode(fmt.Sprintf("b%d-1", i), ""), fmt.Sprintf("b%d", i)))
+			}
+		}
+		require.NoError(t, tree.Move(NewNode("b250", ""), NewNode("b480", "")))
+	}
+
+	ids := []string{"0", "1", "7", "120", "b10-1", "b250-1", "b250", "499", "b480", "b490-1"}
+	for _, id := range ids {
+		for level := uint(0); level < 520; level += 13 {
+			want, wantOK := plain.ParentAt(NewNode(id, ""), level)
+			got, gotOK := indexed.ParentAt(NewNode(id, ""), level)
+			require.Equal(t, wantOK, gotOK, "%s at %d", id, level)
+			if wantOK {
+				assert.Equal(t, want.ID(), got.ID(), "%s at %d", id, level)
+			}
+		}
+
+		for _, other := range ids {
+			want, wantOK := plain.LowestCommonAncestor(NewNode(id, ""), NewNode(other, ""))
+			got, gotOK := indexed.LowestCommonAncestor(NewNode(id, ""), NewNode(other, ""))
+			require.Equal(t, wantOK, gotOK)
+			assert.Equal(t, want.ID(), got.ID(), "%s and %s", id, other)
+
+			wantDistance, _ := plain.Distance(NewNode(id, ""), NewNode(other, ""))
+			gotDistance, _ := indexed.Distance(NewNode(id, ""), NewNode(other, ""))
+			assert.Equal(t, wantDistance, gotDistance, "%s and %s", id, other)
+		}
+	}
+
+	// the ancestors are reached in a logarithmic number of hops
+	node, ok := indexed.getNode("499")
+	require.True(t, ok)
+	for _, depth := range []int{1, 17, 250, 400} {
+		hops := 0
+		for link := node.GetPath(); link.depth > depth; hops++ {
+			if link.jump.depth >= depth {
+				link = link.jump
+				continue
+			}
+			link = link.parent
+		}
+		assert.Less(t, hops, 30, "depth %d", depth)
+	}
+}
+
+func TestTreeWithReadCache(t *testing.T) {
+	tree := NewTree[string](WithReadCache(10))
+	require.NotNil(t, tree.cache)
+	assert.Len(t, tree.cache.slots, 16)
+
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	node1 := newTestNode("node1", "node1")
+	require.NoError(t, tree.Add(node1, root))
+	node2 := newTestNode("node2", "node2")
+	require.NoError(t, tree.Add(node2, node1))
+
+	// the first lookup populates the cache
+	actual, ok := tree.Find("node2")
+	require.True(t, ok)
+	assert.Equal(t, node2.ID(), actual.ID())
+	cached, ok := tree.cache.Get("node2")
+	require.True(t, ok)
+	assert.Equal(t, "node2", cached.ID)
+
+	// deletion invalidates the cache
+	require.NoError(t, tree.Delete(node1))
+	_, ok = tree.cache.Get("node2")
+	assert.False(t, ok)
+	_, ok = tree.Find("node2")
+	assert.False(t, ok)
+	_, ok = tree.Find("node1")
+	assert.False(t, ok)
+	assert.EqualValues(t, 1, tree.Size())
+
+	// an entry whose treeNode has been recycled for another id is a miss
+	reused := &treeNode[string]{ID: "node3"}
+	tree.cache.Put("node4", reused, tree.cache.Epoch())
+	_, ok = tree.cache.Get("node4")
+	assert.False(t, ok)
+}
+
+func TestDeleteAndCollect(t *testing.T) {
+	tree := NewTree[string]()
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	node1 := newTestNode("node1", "node1")
+	require.NoError(t, tree.Add(node1, root))
+	require.NoError(t, tree.Add(newTestNode("node2", "node2"), node1))
+	require.NoError(t, tree.Add(newTestNode("node3", "node3"), node1))
+	require.NoError(t, tree.Add(newTestNode("node4", "node4"), root))
+
+	removed, err := tree.DeleteAndCollect(node1)
+	require.NoError(t, err)
+	require.Len(t, removed, 3)
+	assert.Equal(t, "node1", removed[0].ID())
+	assert.Equal(t, "node2", removed[1].ID())
+	assert.Equal(t, "node3", removed[2].ID())
+	assert.Equal(t, "node2", removed[1].Value())
+	assert.EqualValues(t, 2, tree.Size())
+
+	removed, err = tree.DeleteAndCollect(node1)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Empty(t, removed)
+}
+
+func TestDescendantsTree(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+	require.NoError(t, tree.AddByID(NewNode("z", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("z2", ""), "z"))
+	require.NoError(t, tree.AddByID(NewNode("z1", ""), "z"))
+	require.NoError(t, tree.AddByID(NewNode("z21", ""), "z2"))
+
+	entries, ok := tree.DescendantsTree(tree.Root())
+	require.True(t, ok)
+	var got []string
+	for _, entry := range entries {
+		got = append(got, fmt.Sprintf("%d:%s/%s", entry.Depth, entry.ParentID, entry.Node.ID()))
+	}
+	assert.Equal(t, []string{"1:root/z", "2:z/z2", "3:z2/z21", "2:z/z1", "1:root/a"}, got)
+
+	z, _ := tree.Find("z")
+	entries, ok = tree.DescendantsTree(z)
+	require.True(t, ok)
+	require.Len(t, entries, 3)
+	assert.Equal(t, DescendantEntry[string]{Node: entries[0].Node, ParentID: "z", Depth: 1}, entries[0])
+
+	a, _ := tree.Find("a")
+	entries, ok = tree.DescendantsTree(a)
+	require.True(t, ok)
+	assert.Empty(t, entries)
+
+	_, ok = tree.DescendantsTree(NewNode("missing", ""))
+	assert.False(t, ok)
+}
+
+func TestDeleteSubtree(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+	require.NoError(t, tree.AddByID(NewNode("node1", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("node2", ""), "node1"))
+	require.NoError(t, tree.AddByID(NewNode("node3", ""), "node2"))
+	require.NoError(t, tree.AddByID(NewNode("node4", ""), "root"))
+
+	count, ids, err := tree.DeleteSubtree(NewNode("node1", ""))
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []string{"node1", "node2", "node3"}, ids)
+	assert.EqualValues(t, 2, tree.Size())
+
+	count, ids, err = tree.DeleteSubtree(NewNode("node1", ""))
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Zero(t, count)
+	assert.Empty(t, ids)
+}
+
+func TestParentOf(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", ""), "a"))
+
+	parent, ok := tree.ParentOf(NewNode("b", ""))
+	require.True(t, ok)
+	assert.Equal(t, "a", parent.ID())
+
+	require.NoError(t, tree.MoveByID("b", "root"))
+	parent, ok = tree.ParentOf(NewNode("b", ""))
+	require.True(t, ok)
+	assert.Equal(t, "root", parent.ID())
+
+	_, ok = tree.ParentOf(tree.Root())
+	assert.False(t, ok)
+	_, ok = tree.ParentOf(NewNode("missing", ""))
+	assert.False(t, ok)
+}
+
+func TestChild(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", "b"), "a"))
+
+	a, _ := tree.Find("a")
+	child, ok := tree.Child(a, "b")
+	require.True(t, ok)
+	assert.Equal(t, "b", child.Value())
+
+	_, ok = tree.Child(tree.Root(), "b")
+	assert.False(t, ok)
+	_, ok = tree.Child(a, "missing")
+	assert.False(t, ok)
+	_, ok = tree.Child(NewNode("missing", ""), "b")
+	assert.False(t, ok)
+}
+
+func TestNodeError(t *testing.T) {
+	tree := NewTree[string]()
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+
+	err := tree.Add(newTestNode("node1", "node1"), newTestNode("rogue", "rogue"))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrParentNodeNotFound)
+	var nodeErr *NodeError
+	require.ErrorAs(t, err, &nodeErr)
+	assert.Equal(t, "add", nodeErr.Op())
+	assert.Equal(t, "node1", nodeErr.NodeID())
+	assert.Equal(t, "rogue", nodeErr.ParentID())
+	assert.Equal(t, `add node "node1" under parent "rogue": parent node not found`, err.Error())
+
+	err = tree.Delete(newTestNode("rogue", "rogue"))
+	require.ErrorAs(t, err, &nodeErr)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, "delete", nodeErr.Op())
+	assert.Equal(t, "rogue", nodeErr.NodeID())
+	assert.Empty(t, nodeErr.ParentID())
+	assert.Equal(t, `delete node "rogue": node not found`, err.Error())
+}
+
+func TestDuplicateID(t *testing.T) {
+	tree := NewTree[string]()
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	node1 := newTestNode("node1", "node1")
+	require.NoError(t, tree.Add(node1, root))
+	node2 := newTestNode("node2", "node2")
+	require.NoError(t, tree.Add(node2, node1))
+
+	err := tree.Add(newTestNode("node1", "duplicate"), root)
+	assert.ErrorIs(t, err, ErrDuplicateID)
+	assert.EqualValues(t, 3, tree.Size())
+
+	actual, ok := tree.Find("node1")
+	require.True(t, ok)
+	assert.Equal(t, "node1", actual.Value())
+}
+
+func TestAddOrReplace(t *testing.T) {
+	tree := NewTree[string]()
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.AddOrReplace(root, nil))
+	node1 := newTestNode("node1", "node1")
+	require.NoError(t, tree.AddOrReplace(node1, root))
+	node2 := newTestNode("node2", "node2")
+	require.NoError(t, tree.AddOrReplace(node2, node1))
+	node3 := newTestNode("node3", "node3")
+	require.NoError(t, tree.AddOrReplace(node3, root))
+	assert.EqualValues(t, 4, tree.Size())
+
+	// replace the value in place and keep the descendants
+	require.NoError(t, tree.AddOrReplace(newTestNode("node1", "updated"), root))
+	actual, ok := tree.Find("node1")
+	require.True(t, ok)
+	assert.Equal(t, "updated", actual.Value())
+	descendants, ok := tree.Descendants(node1)
+	require.True(t, ok)
+	assert.Len(t, descendants, 1)
+	assert.EqualValues(t, 4, tree.Size())
+
+	// replace the root value
+	require.NoError(t, tree.AddOrReplace(newTestNode("root", "new root"), nil))
+	assert.Equal(t, "new root", tree.Root().Value())
+	assert.EqualValues(t, 4, tree.Size())
+
+	// a second root is not allowed
+	err := tree.AddOrReplace(newTestNode("node1", "node1"), nil)
+	assert.ErrorIs(t, err, ErrInvalidOperation)
+
+	// a node cannot be replaced under its own descendant
+	err = tree.AddOrReplace(newTestNode("node1", "node1"), node2)
+	assert.ErrorIs(t, err, ErrInvalidOperation)
+
+	// unknown parent
+	err = tree.AddOrReplace(newTestNode("node1", "node1"), newTestNode("rogue", "rogue"))
+	assert.ErrorIs(t, err, ErrParentNodeNotFound)
+
+	// replacing under a different parent drops the previous subtree
+	require.NoError(t, tree.AddOrReplace(newTestNode("node1", "moved"), node3))
+	assert.EqualValues(t, 3, tree.Size())
+	_, ok = tree.Find("node2")
+	assert.False(t, ok)
+	parent, ok := tree.ParentAt(node1, 0)
+	require.True(t, ok)
+	assert.Equal(t, "node3", parent.ID())
+}
+
+func TestReset(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []ResetOption
+	}{
+		{name: "default"},
+		{name: "release memory", opts: []ResetOption{WithReleaseMemory()}},
+		{name: "keep capacity", opts: []ResetOption{WithKeepCapacity()}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewTree[string]()
+			root := newTestNode("root", "root")
+			require.NoError(t, tree.Add(root, nil))
+			node1 := newTestNode("node1", "node1")
+			require.NoError(t, tree.Add(node1, root))
+
+			tree.Reset(tc.opts...)
+			assert.Zero(t, tree.Size())
+			assert.Empty(t, tree.Nodes())
+			assert.Nil(t, tree.rootNode.Load())
+			_, ok := tree.Find("node1")
+			assert.False(t, ok)
+
+			// a new root can be added after the reset
+			newRoot := newTestNode("newRoot", "newRoot")
+			require.NoError(t, tree.Add(newRoot, nil))
+			require.NoError(t, tree.Add(node1, newRoot))
+			assert.Equal(t, "newRoot", tree.Root().ID())
+			assert.EqualValues(t, 2, tree.Size())
+			descendants, ok := tree.Descendants(newRoot)
+			require.True(t, ok)
+			assert.Len(t, descendants, 1)
+		})
+	}
+}
+
+func TestRoot(t *testing.T) {
+	tree := NewTree[string]()
+	assert.Nil(t, tree.Root())
+	root, ok := tree.RootOK()
+	assert.False(t, ok)
+	assert.Nil(t, root)
+
+	require.NoError(t, tree.Add(newTestNode("root", "root"), nil))
+	assert.Equal(t, "root", tree.Root().ID())
+	root, ok = tree.RootOK()
+	assert.True(t, ok)
+	assert.Equal(t, "root", root.ID())
+
+	tree.Reset()
+	assert.Nil(t, tree.Root())
+	_, ok = tree.RootOK()
+	assert.False(t, ok)
+}
+
+func TestDeleteRoot(t *testing.T) {
+	tree := NewTree[string]()
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	require.NoError(t, tree.Add(newTestNode("node1", "node1"), root))
+
+	require.NoError(t, tree.Delete(root))
+	assert.Zero(t, tree.Size())
+	assert.Nil(t, tree.rootNode.Load())
+	require.NoError(t, tree.Add(newTestNode("newRoot", "newRoot"), nil))
+}
+
+func TestConstraints(t *testing.T) {
+	t.Run("max depth", func(t *testing.T) {
+		tree := NewTree[string](WithMaxDepth(1))
+		root := newTestNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		node1 := newTestNode("node1", "node1")
+		require.NoError(t, tree.Add(node1, root))
+
+		err := tree.Add(newTestNode("node2", "node2"), node1)
+		assert.ErrorIs(t, err, ErrMaxDepthExceeded)
+		assert.EqualValues(t, 2, tree.Size())
+	})
+	t.Run("max children", func(t *testing.T) {
+		tree := NewTree[string](WithMaxChildren(2))
+		root := newTestNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(newTestNode("node1", "node1"), root))
+		require.NoError(t, tree.Add(newTestNode("node2", "node2"), root))
+
+		err := tree.Add(newTestNode("node3", "node3"), root)
+		assert.ErrorIs(t, err, ErrMaxChildrenExceeded)
+		var nodeErr *NodeError
+		require.ErrorAs(t, err, &nodeErr)
+		assert.Equal(t, "root", nodeErr.ParentID())
+		assert.EqualValues(t, 3, tree.Size())
+		_, ok := tree.Find("node3")
+		assert.False(t, ok)
+	})
+	t.Run("max size", func(t *testing.T) {
+		tree := NewTree[string](WithMaxSize(2))
+		root := newTestNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		node1 := newTestNode("node1", "node1")
+		require.NoError(t, tree.Add(node1, root))
+
+		err := tree.Add(newTestNode("node2", "node2"), root)
+		assert.ErrorIs(t, err, ErrMaxSizeExceeded)
+		assert.EqualValues(t, 2, tree.Size())
+
+		// deleting frees a slot
+		require.NoError(t, tree.Delete(node1))
+		require.NoError(t, tree.Add(newTestNode("node2", "node2"), root))
+	})
+	t.Run("concurrent max children", func(t *testing.T) {
+		tree := NewTree[string](WithMaxChildren(10))
+		root := newTestNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				id := fmt.Sprintf("%d", i)
+				_ = tree.Add(newTestNode(id, id), root)
+			}()
+		}
+		wg.Wait()
+
+		assert.EqualValues(t, 11, tree.Size())
+		assert.Empty(t, tree.Validate())
+	})
+}
+
+func TestIDValidator(t *testing.T) {
+	errEmptyID := errors.New("empty ID")
+	tree := NewTree[string](WithIDValidator(func(id string) error {
+		if id == "" {
+			return errEmptyID
+		}
+		return nil
+	}))
+
+	err := tree.Add(newTestNode("", "root"), nil)
+	assert.ErrorIs(t, err, ErrInvalidID)
+	assert.ErrorIs(t, err, errEmptyID)
+	assert.Zero(t, tree.Size())
+
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	err = tree.Add(newTestNode("", "child"), root)
+	assert.ErrorIs(t, err, ErrInvalidID)
+	var nodeErr *NodeError
+	require.ErrorAs(t, err, &nodeErr)
+	assert.Equal(t, "root", nodeErr.ParentID())
+	assert.EqualValues(t, 1, tree.Size())
+}
+
+func TestVersioning(t *testing.T) {
+	tree := NewTree[string]()
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+
+	_, version, ok := tree.FindWithVersion("root")
+	require.True(t, ok)
+	assert.EqualValues(t, 1, version)
+
+	// structural changes increment the parent version
+	node1 := newTestNode("node1", "node1")
+	require.NoError(t, tree.Add(node1, root))
+	_, version, _ = tree.FindWithVersion("root")
+	assert.EqualValues(t, 2, version)
+
+	// update
+	require.NoError(t, tree.Update(newTestNode("node1", "updated")))
+	item, version, ok := tree.FindWithVersion("node1")
+	require.True(t, ok)
+	assert.Equal(t, "updated", item.Value())
+	assert.EqualValues(t, 2, version)
+	assert.ErrorIs(t, tree.Update(newTestNode("rogue", "rogue")), ErrNotFound)
+
+	// compare-and-swap
+	require.NoError(t, tree.UpdateIf("node1", 2, newTestNode("node1", "cas")))
+	err := tree.UpdateIf("node1", 2, newTestNode("node1", "lost"))
+	assert.ErrorIs(t, err, ErrVersionMismatch)
+	item, version, _ = tree.FindWithVersion("node1")
+	assert.Equal(t, "cas", item.Value())
+	assert.EqualValues(t, 3, version)
+
+	assert.ErrorIs(t, tree.UpdateIf("rogue", 1, newTestNode("rogue", "rogue")), ErrNotFound)
+	assert.ErrorIs(t, tree.UpdateIf("node1", 3, newTestNode("node2", "node2")), ErrInvalidOperation)
+
+	// in place replacement increments the version
+	require.NoError(t, tree.AddOrReplace(newTestNode("node1", "replaced"), root))
+	_, version, _ = tree.FindWithVersion("node1")
+	assert.EqualValues(t, 4, version)
+
+	require.NoError(t, tree.Delete(node1))
+	_, version, _ = tree.FindWithVersion("root")
+	assert.EqualValues(t, 3, version)
+
+	_, _, ok = tree.FindWithVersion("node1")
+	assert.False(t, ok)
+}
+
+func TestConcurrentUpdateIf(t *testing.T) {
+	tree := NewTree[string]()
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+
+	var succeeded atomic.Int32
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := tree.UpdateIf("root", 1, newTestNode("root", fmt.Sprintf("%d", i))); err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 1, succeeded.Load())
+	_, version, _ := tree.FindWithVersion("root")
+	assert.EqualValues(t, 2, version)
+}
+
+func TestModes(t *testing.T) {
+	newCorruptedTree := func(mode Mode) *Tree[string] {
+		tree := NewTree[string](WithMode(mode))
+		root := newTestNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		node1 := newTestNode("node1", "node1")
+		require.NoError(t, tree.Add(node1, root))
+		require.NoError(t, tree.Add(newTestNode("node2", "node2"), node1))
+		// corrupt the tree by dropping node1 from the nodes map only
+		tree.nodes.Delete("node1")
+		return tree
+	}
+
+	t.Run("lenient", func(t *testing.T) {
+		tree := newCorruptedTree(LenientMode)
+		ancestors, ok := tree.Ancestors(newTestNode("node2", "node2"))
+		assert.True(t, ok)
+		assert.Len(t, ancestors, 1)
+		descendants, ok := tree.Descendants(tree.Root())
+		assert.True(t, ok)
+		assert.Len(t, descendants, 2)
+		assert.NoError(t, tree.Delete(newTestNode("node2", "node2")))
+	})
+	t.Run("strict", func(t *testing.T) {
+		tree := newCorruptedTree(StrictMode)
+		assert.PanicsWithError(t, `tree invariant violated: ancestor "node1" of node "node2" is not part of the tree`, func() {
+			_, _ = tree.Ancestors(newTestNode("node2", "node2"))
+		})
+		assert.Panics(t, func() {
+			_, _ = tree.Descendants(tree.Root())
+		})
+		assert.Panics(t, func() {
+			_ = tree.Delete(newTestNode("node2", "node2"))
+		})
+
+		// operations on unknown nodes return detailed errors
+		err := tree.Add(newTestNode("node3", "node3"), newTestNode("node1", "node1"))
+		var nodeErr *NodeError
+		require.ErrorAs(t, err, &nodeErr)
+		assert.Equal(t, "node1", nodeErr.ParentID())
+	})
+	t.Run("strict on a consistent tree", func(t *testing.T) {
+		tree := NewTree[string](WithMode(StrictMode))
+		root := newTestNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		node1 := newTestNode("node1", "node1")
+		require.NoError(t, tree.Add(node1, root))
+		require.NoError(t, tree.Add(newTestNode("node2", "node2"), node1))
+		assert.NotPanics(t, func() {
+			_, _ = tree.Ancestors(newTestNode("node2", "node2"))
+			_, _ = tree.Descendants(root)
+			_ = tree.Delete(node1)
+			_ = tree.Delete(root)
+		})
+	})
+}
+
 func TestMultithreading(t *testing.T) {
 	tree := NewTree[string]()
 	root := newTestNode("root", "root")
@@ -165,6 +789,52 @@ func TestMultithreading(t *testing.T) {
 	tree.Reset()
 }
 
+func TestConcurrentAddAndDelete(t *testing.T) {
+	tree := NewTree[string]()
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+
+	numBranches := 20
+	branches := make([]*testNode, numBranches)
+	for i := range branches {
+		id := fmt.Sprintf("branch-%d", i)
+		branches[i] = newTestNode(id, id)
+		require.NoError(t, tree.Add(branches[i], root))
+	}
+
+	wg := sync.WaitGroup{}
+	for i := range branches {
+		wg.Add(2)
+		// keep adding under the branch while it is being deleted
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				id := fmt.Sprintf("leaf-%d-%d", i, j)
+				_ = tree.Add(newTestNode(id, id), branches[i])
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_ = tree.Delete(branches[i])
+		}()
+	}
+	wg.Wait()
+
+	// no ancestor chain references a node that is no longer in the tree
+	assert.Empty(t, tree.Validate())
+	for _, node := range tree.Nodes() {
+		ancestors, ok := tree.getAncestors(node.ID())
+		if node.ID() == root.ID() {
+			continue
+		}
+		require.True(t, ok)
+		for _, ancestorID := range ancestors {
+			_, ok := tree.Find(ancestorID)
+			assert.True(t, ok)
+		}
+	}
+}
+
 func BenchmarkAdd(b *testing.B) {
 	tree := NewTree[string]()
 	root := newTestNode("root", "root")
@@ -182,3 +852,54 @@ func BenchmarkAdd(b *testing.B) {
 	b.StopTimer()
 	tree.Reset()
 }
+
+func TestByID(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", "b"), "a"))
+	require.NoError(t, tree.AddByID(NewNode("c", "c"), "a"))
+
+	err := tree.AddByID(NewNode("d", "d"), "x")
+	require.ErrorIs(t, err, ErrParentNodeNotFound)
+	var nodeErr *NodeError
+	require.ErrorAs(t, err, &nodeErr)
+	assert.Equal(t, "x", nodeErr.ParentID())
+
+	err = tree.AddByID(NewNode("root2", "root2"), "")
+	require.ErrorIs(t, err, ErrInvalidOperation)
+
+	ancestors, ok := tree.AncestorsByID("b")
+	require.True(t, ok)
+	assert.Equal(t, []string{"a", "root"}, queryIDs(ancestors))
+
+	descendants, ok := tree.DescendantsByID("a")
+	require.True(t, ok)
+	assert.Equal(t, []string{"b", "c"}, queryIDs(descendants))
+
+	_, ok = tree.AncestorsByID("x")
+	assert.False(t, ok)
+	_, ok = tree.DescendantsByID("x")
+	assert.False(t, ok)
+
+	require.NoError(t, tree.DeleteByID("a"))
+	assert.EqualValues(t, 1, tree.Size())
+	require.ErrorIs(t, tree.DeleteByID("a"), ErrNotFound)
+}
+
+func TestValuesAndIDs(t *testing.T) {
+	tree := NewTree[string]()
+	assert.Empty(t, tree.Values())
+	assert.Empty(t, tree.IDs())
+
+	require.NoError(t, tree.AddByID(NewNode("root", "root value"), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", "a value"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", "b value"), "a"))
+
+	assert.ElementsMatch(t, []string{"root value", "a value", "b value"}, tree.Values())
+	assert.ElementsMatch(t, []string{"root", "a", "b"}, tree.IDs())
+
+	require.NoError(t, tree.DeleteByID("a"))
+	assert.Equal(t, []string{"root value"}, tree.Values())
+	assert.Equal(t, []string{"root"}, tree.IDs())
+}
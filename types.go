@@ -30,6 +30,8 @@ import "sync/atomic"
 type value[T any] struct {
 	// Data represents the actual treeNode value
 	data Node[T]
+	// version is the treeNode version the value belongs to
+	version uint64
 }
 
 // Data returns the actual pidValue value
@@ -43,8 +45,18 @@ type treeNode[T any] struct {
 	ID string
 	// Value represents the actual treeNode value
 	Value atomic.Pointer[value[T]]
-	// Descendants hold the list of descendants
-	Descendants *Slice[*treeNode[T]]
+	// Descendants hold the ordered set of direct descendants
+	Descendants *children[T]
+	// Path represents the interned ancestry chain starting at the treeNode itself.
+	// It is replaced when the treeNode is moved under another parent
+	Path atomic.Pointer[ancestry]
+	// Meta holds the treeNode metadata. The map is never modified once stored
+	Meta atomic.Pointer[map[string]any]
+	// Edge holds the data of the edge linking the treeNode to its parent
+	Edge atomic.Pointer[EdgeData]
+	// Generation is incremented every time the treeNode is recycled, which tells
+	// the NodeRef handles bound to a removed treeNode apart from the live ones
+	Generation atomic.Uint64
 }
 
 // SetValue sets a node value
@@ -52,7 +64,215 @@ func (x *treeNode[T]) SetValue(v *value[T]) {
 	x.Value.Store(v)
 }
 
-// GetValue returns the underlying value of the node
+// GetVersion returns the node version. It is nil safe and returns zero for a nil node
+func (x *treeNode[T]) GetVersion() uint64 {
+	if x == nil {
+		return 0
+	}
+	if v := x.Value.Load(); v != nil {
+		return v.version
+	}
+	return 0
+}
+
+// ReplaceValue replaces the node value and increments the node version.
+// When expected is not nil, the value is only replaced if the current version
+// matches it. It returns the new version and whether the value has been replaced
+func (x *treeNode[T]) ReplaceValue(data Node[T], expected *uint64) (uint64, bool) {
+	for {
+		current := x.Value.Load()
+		if expected != nil && current.version != *expected {
+			return current.version, false
+		}
+
+		next := &value[T]{
+			data:    data,
+			version: current.version + 1,
+		}
+		if x.Value.CompareAndSwap(current, next) {
+			return next.version, true
+		}
+	}
+}
+
+// BumpVersion increments the node version following a structural change
+func (x *treeNode[T]) BumpVersion() {
+	x.ReplaceValue(x.GetValue(), nil)
+}
+
+// GetMeta returns a copy of the node metadata
+func (x *treeNode[T]) GetMeta() map[string]any {
+	return copyMeta(x.Meta.Load())
+}
+
+// UpdateMeta applies the given function to a copy of the node metadata
+// and atomically stores the result
+func (x *treeNode[T]) UpdateMeta(fn func(meta map[string]any)) {
+	for {
+		current := x.Meta.Load()
+		meta := copyMeta(current)
+		fn(meta)
+		if x.Meta.CompareAndSwap(current, &meta) {
+			return
+		}
+	}
+}
+
+// GetEdge returns the data of the edge linking the node to its parent
+func (x *treeNode[T]) GetEdge() EdgeData {
+	if edge := x.Edge.Load(); edge != nil {
+		return *edge
+	}
+	return EdgeData{}
+}
+
+// GetID returns the node identifier. It is nil safe
+func (x *treeNode[T]) GetID() string {
+	if x == nil {
+		return ""
+	}
+	return x.ID
+}
+
+// GetPath returns the node ancestry chain. It is nil safe
+func (x *treeNode[T]) GetPath() *ancestry {
+	if x == nil {
+		return nil
+	}
+	return x.Path.Load()
+}
+
+// SetPath sets the node ancestry chain
+func (x *treeNode[T]) SetPath(path *ancestry) {
+	x.Path.Store(path)
+}
+
+// GetValue returns the underlying value of the node. It is nil safe
 func (x *treeNode[T]) GetValue() Node[T] {
-	return x.Value.Load().Data()
+	if x == nil {
+		return nil
+	}
+	if v := x.Value.Load(); v != nil {
+		return v.Data()
+	}
+	return nil
+}
+
+// ancestry is an immutable link in an ancestor chain.
+//
+// Every treeNode owns exactly one ancestry link pointing at its parent's link.
+// Siblings therefore share the very same parent chain instead of holding
+// their own copy, which keeps the memory cost of the ancestors hierarchy
+// constant per node regardless of the depth of the Tree.
+type ancestry struct {
+	// id represents the treeNode identifier
+	id string
+	// parent points to the parent link. It is nil for the root
+	parent *ancestry
+	// depth is the number of links above this one, the root being at depth zero
+	depth int
+	// jump points at a farther ancestor when the chain is indexed, nil otherwise.
+	// The jumps follow a skew-binary pattern so that any ancestor is reached in a logarithmic
+	// number of hops, and since the target depth only depends on the depth of the link,
+	// the links of the same depth jump to the same level.
+	jump *ancestry
+}
+
+// newAncestry creates an ancestry link for the given id on top of the parent chain.
+// The link holds a jump pointer when indexed is set
+func newAncestry(id string, parent *ancestry, indexed bool) *ancestry {
+	link := &ancestry{
+		id:     id,
+		parent: parent,
+	}
+	if parent != nil {
+		link.depth = parent.depth + 1
+	}
+
+	if !indexed {
+		return link
+	}
+
+	switch {
+	case parent == nil:
+		link.jump = link
+	case parent.jump != nil && parent.jump.jump != nil &&
+		parent.depth-parent.jump.depth == parent.jump.depth-parent.jump.jump.depth:
+		// two jumps of the same length merge into one twice as long, plus one
+		link.jump = parent.jump.jump
+	default:
+		link.jump = parent
+	}
+	return link
+}
+
+// Depth returns the depth of the link. It is nil safe and returns -1 for a nil link
+func (a *ancestry) Depth() int {
+	if a == nil {
+		return -1
+	}
+	return a.depth
+}
+
+// IDs returns the identifiers of the chain, nearest first
+func (a *ancestry) IDs() []string {
+	var ids []string
+	for link := a; link != nil; link = link.parent {
+		ids = append(ids, link.id)
+	}
+	return ids
+}
+
+// At returns the link located at the given level of the chain,
+// 0 being the link itself
+func (a *ancestry) At(level int) (*ancestry, bool) {
+	if a == nil || level < 0 || level > a.depth {
+		return nil, false
+	}
+	return a.atDepth(a.depth - level), true
+}
+
+// atDepth returns the ancestor link located at the given depth, which must not exceed the depth of the link.
+// It follows the jump pointers of indexed chains and the parent links otherwise
+func (a *ancestry) atDepth(depth int) *ancestry {
+	link := a
+	for link.depth > depth {
+		if link.jump != nil && link.jump.depth >= depth {
+			link = link.jump
+			continue
+		}
+		link = link.parent
+	}
+	return link
+}
+
+// Common returns the deepest link shared by the given chains, nil when they do not share any
+func (a *ancestry) Common(other *ancestry) *ancestry {
+	if a == nil || other == nil {
+		return nil
+	}
+
+	depth := min(a.depth, other.depth)
+	a, other = a.atDepth(depth), other.atDepth(depth)
+	for a != nil && other != nil && a.id != other.id {
+		// links of the same depth jump to the same level: jump when it lands below the common ancestor
+		if a.jump != nil && other.jump != nil && a.jump != a && a.jump.id != other.jump.id {
+			a, other = a.jump, other.jump
+			continue
+		}
+		a, other = a.parent, other.parent
+	}
+	return a
+}
+
+// copyMeta returns a copy of the given metadata
+func copyMeta(current *map[string]any) map[string]any {
+	if current == nil {
+		return map[string]any{}
+	}
+	meta := make(map[string]any, len(*current))
+	for k, v := range *current {
+		meta[k] = v
+	}
+	return meta
 }
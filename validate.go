@@ -0,0 +1,328 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Validate checks the structural invariants of the Tree and returns every violation found.
+//
+// The following invariants are checked:
+//   - the size of the Tree matches the number of Nodes it holds.
+//   - a non-empty Tree has a root Node and the root Node has no parent.
+//   - every non-root Node has a parent entry whose ancestor chain only references
+//     existing Nodes and matches the chain of its parent.
+//   - every Node is listed among the children of its parent, and every child refers to
+//     an existing Node.
+//   - every Node is reachable from the root and no Node is reachable twice (no cycles).
+//
+// The violations caused by a cycle wrap ErrCycleDetected as well.
+//
+// Returns:
+//   - []error: The violations found, each one wrapping ErrInvariantViolation. The slice is
+//     empty when the Tree is consistent.
+//
+// Notes:
+//   - Validate blocks the structural changes of the Tree while it runs.
+//   - This method does not modify the Tree. Use Repair to fix recoverable inconsistencies.
+//
+// Example usage:
+//
+//	if errs := tree.Validate(); len(errs) > 0 {
+//	    fmt.Println("Tree is inconsistent:", errors.Join(errs...))
+//	}
+func (x *Tree[T]) Validate() []error {
+	end := x.trace(OperationValidate)
+	x.mu.Lock()
+	defer func() {
+		end(int(x.size.Load()), nil)
+		x.mu.Unlock()
+	}()
+
+	var errs []error
+	violation := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]any{ErrInvariantViolation}, args...)...))
+	}
+
+	nodes := x.treeNodes()
+	if size := x.Size(); size != int64(len(nodes)) {
+		violation("size %d does not match the %d nodes held", size, len(nodes))
+	}
+
+	root := x.rootNode.Load()
+	switch {
+	case root == nil && len(nodes) > 0:
+		violation("tree has %d nodes but no root", len(nodes))
+	case root != nil && nodes[root.ID] != root:
+		violation("root %q is not part of the tree", root.ID)
+	case root != nil:
+		if _, ok := x.parents.Load(root.ID); ok {
+			violation("root %q has a parent entry", root.ID)
+		}
+	}
+
+	for id, node := range nodes {
+		if root != nil && id == root.ID {
+			continue
+		}
+		errs = append(errs, x.validateAncestry(node, nodes)...)
+	}
+
+	x.parents.Range(func(key string, _ *ancestry) bool {
+		if _, ok := nodes[key]; !ok {
+			violation("parent entry of unknown node %q", key)
+		}
+		return true
+	})
+
+	// walk the tree from the root to detect unreachable nodes and cycles
+	visited := make(map[string]struct{}, len(nodes))
+	if root != nil && nodes[root.ID] == root {
+		var walk func(node *treeNode[T])
+		walk = func(node *treeNode[T]) {
+			visited[node.ID] = struct{}{}
+			for _, child := range node.Descendants.Items() {
+				if nodes[child.ID] != child {
+					violation("node %q has unknown child %q", node.ID, child.ID)
+					continue
+				}
+				if _, ok := visited[child.ID]; ok {
+					violation("%w: node %q is reachable more than once under %q", ErrCycleDetected, child.ID, node.ID)
+					continue
+				}
+				walk(child)
+			}
+		}
+		walk(root)
+	}
+
+	for id := range nodes {
+		if _, ok := visited[id]; !ok && root != nil {
+			violation("node %q is not reachable from the root", id)
+		}
+	}
+	return errs
+}
+
+// Repair fixes the recoverable structural inconsistencies of the Tree and
+// returns the number of fixes applied.
+//
+// The children of the Nodes are considered the source of truth of the hierarchy.
+// Repair therefore:
+//   - re-attaches a Node missing from the children of its existing parent.
+//   - removes the children referring to Nodes that are no longer part of the Tree, and
+//     the children reachable more than once (cycles).
+//   - rebuilds the ancestor chains of every Node reachable from the root.
+//   - deletes the Nodes that cannot be reached from the root (orphans) and the parent
+//     entries of unknown Nodes.
+//   - clears a root that is no longer part of the Tree, promotes the only parentless Node
+//     as root when the root is missing, and re-synchronizes the size.
+//
+// Returns:
+//   - int: The number of fixes applied, zero when the Tree was already consistent.
+//
+// Notes:
+//   - Repair blocks the structural changes of the Tree while it runs.
+//   - Run Validate afterward to confirm that the Tree is consistent.
+//
+// Example usage:
+//
+//	if errs := tree.Validate(); len(errs) > 0 {
+//	    fixed := tree.Repair()
+//	    fmt.Println("Fixes applied:", fixed)
+//	}
+func (x *Tree[T]) Repair() int {
+	end := x.trace(OperationRepair)
+	x.mu.Lock()
+	defer func() {
+		end(int(x.size.Load()), nil)
+		x.mu.Unlock()
+	}()
+
+	fixed := 0
+	nodes := x.treeNodes()
+
+	root := x.rootNode.Load()
+	if root != nil && nodes[root.ID] != root {
+		x.rootNode.CompareAndSwap(root, nil)
+		root = nil
+		fixed++
+	}
+
+	// promote the only parentless node as root when the root is missing
+	if root == nil {
+		var candidates []*treeNode[T]
+		for id, node := range nodes {
+			if _, ok := x.parents.Load(id); !ok {
+				candidates = append(candidates, node)
+			}
+		}
+		if len(candidates) == 1 && x.rootNode.CompareAndSwap(nil, candidates[0]) {
+			root = candidates[0]
+			fixed++
+		}
+	}
+
+	// re-attach the nodes missing from their parent children
+	for id, node := range nodes {
+		if parent, ok := x.parentNode(id); ok && parent != node {
+			if _, ok := parent.Descendants.Get(id); !ok {
+				parent.Descendants.Append(node)
+				fixed++
+			}
+		}
+	}
+
+	// rebuild the hierarchy from the root
+	visited := make(map[string]struct{}, len(nodes))
+	if root != nil {
+		if _, ok := x.parents.Load(root.ID); ok {
+			x.parents.Delete(root.ID)
+			fixed++
+		}
+
+		if root.GetPath() == nil || root.GetPath().id != root.ID || root.GetPath().parent != nil {
+			root.SetPath(newAncestry(root.ID, nil, x.cfg.ancestorIndex))
+			fixed++
+		}
+
+		var walk func(node *treeNode[T])
+		walk = func(node *treeNode[T]) {
+			visited[node.ID] = struct{}{}
+			for _, child := range node.Descendants.Items() {
+				_, seen := visited[child.ID]
+				if nodes[child.ID] != child || seen {
+					node.Descendants.Remove(child.ID)
+					fixed++
+					continue
+				}
+
+				if value, ok := x.parents.Load(child.ID); !ok || value != node.GetPath() {
+					x.updateAncestors(node, child)
+					fixed++
+				}
+
+				if child.GetPath() == nil || child.GetPath().id != child.ID || child.GetPath().parent != node.GetPath() {
+					child.SetPath(newAncestry(child.ID, node.GetPath(), x.cfg.ancestorIndex))
+					fixed++
+				}
+				walk(child)
+			}
+		}
+		walk(root)
+	}
+
+	// delete the orphans
+	for id, orphan := range nodes {
+		if _, ok := visited[id]; !ok {
+			orphan.Generation.Add(1)
+			x.nodes.Delete(id)
+			x.parents.Delete(id)
+			x.unindex(id)
+			x.tags.removeNodes(id)
+			x.links.removeNodes(id)
+			x.resolver.forget(id)
+			x.history.delete(id)
+			fixed++
+		}
+	}
+
+	// the entries are deleted once the range is over to not deadlock on the shard locks
+	var unknown []string
+	x.parents.Range(func(key string, _ *ancestry) bool {
+		if _, ok := visited[key]; !ok {
+			unknown = append(unknown, key)
+		}
+		return true
+	})
+
+	for _, id := range unknown {
+		x.parents.Delete(id)
+		fixed++
+	}
+
+	if size := int64(len(visited)); x.size.Load() != size {
+		x.size.Store(size)
+		fixed++
+	}
+
+	if fixed > 0 {
+		x.invalidateCache()
+		x.rebuildRollups()
+		x.recountQuotas()
+		x.log(slog.LevelWarn, "tree repaired", slog.Int("fixes", fixed))
+	}
+	return fixed
+}
+
+// validateAncestry checks the ancestor chain of the given non-root treeNode
+func (x *Tree[T]) validateAncestry(node *treeNode[T], nodes map[string]*treeNode[T]) []error {
+	var errs []error
+	violation := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]any{ErrInvariantViolation}, args...)...))
+	}
+
+	value, ok := x.parents.Load(node.ID)
+	if !ok {
+		return append(errs, fmt.Errorf("%w: node %q has no parent entry", ErrInvariantViolation, node.ID))
+	}
+
+	chain := value
+	parent, ok := nodes[chain.id]
+	if !ok {
+		violation("parent %q of node %q is not part of the tree", chain.id, node.ID)
+	} else {
+		if parent.GetPath() != chain {
+			violation("ancestor chain of node %q is stale", node.ID)
+		}
+		if _, ok := parent.Descendants.Get(node.ID); !ok {
+			violation("node %q is not a child of its parent %q", node.ID, parent.ID)
+		}
+	}
+
+	steps := 0
+	for link := chain; link != nil; link = link.parent {
+		if steps++; steps > len(nodes) || link.id == node.ID {
+			violation("%w: ancestor chain of node %q contains a cycle", ErrCycleDetected, node.ID)
+			break
+		}
+		if _, ok := nodes[link.id]; !ok {
+			violation("ancestor chain of node %q references unknown node %q", node.ID, link.id)
+		}
+	}
+	return errs
+}
+
+// treeNodes returns a snapshot of the treeNodes held by the Tree keyed by their ID
+func (x *Tree[T]) treeNodes() map[string]*treeNode[T] {
+	nodes := make(map[string]*treeNode[T])
+	x.nodes.Range(func(key string, value *treeNode[T]) bool {
+		nodes[key] = value
+		return true
+	})
+	return nodes
+}
@@ -0,0 +1,120 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("consistent tree", func(t *testing.T) {
+		tree := newValidateTestTree(t)
+		assert.Empty(t, tree.Validate())
+		assert.Zero(t, tree.Repair())
+
+		require.NoError(t, tree.Delete(newTestNode("node1", "node1")))
+		assert.Empty(t, tree.Validate())
+		assert.Empty(t, NewTree[string]().Validate())
+	})
+	t.Run("size mismatch", func(t *testing.T) {
+		tree := newValidateTestTree(t)
+		tree.size.Add(3)
+		errs := tree.Validate()
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], ErrInvariantViolation)
+
+		assert.Equal(t, 1, tree.Repair())
+		assert.Empty(t, tree.Validate())
+		assert.EqualValues(t, 4, tree.Size())
+	})
+	t.Run("child missing from its parent", func(t *testing.T) {
+		tree := newValidateTestTree(t)
+		parent, _ := tree.getNode("node1")
+		parent.Descendants.Remove("node2")
+		assert.NotEmpty(t, tree.Validate())
+
+		assert.Positive(t, tree.Repair())
+		assert.Empty(t, tree.Validate())
+		descendants, ok := tree.Descendants(newTestNode("node1", "node1"))
+		require.True(t, ok)
+		assert.Len(t, descendants, 1)
+	})
+	t.Run("stale parent entries and orphans", func(t *testing.T) {
+		tree := newValidateTestTree(t)
+		// simulate a half-applied deletion of node1
+		tree.nodes.Delete("node1")
+		tree.size.Add(-1)
+		errs := tree.Validate()
+		assert.NotEmpty(t, errs)
+		for _, err := range errs {
+			assert.ErrorIs(t, err, ErrInvariantViolation)
+		}
+
+		assert.Positive(t, tree.Repair())
+		assert.Empty(t, tree.Validate())
+		assert.EqualValues(t, 2, tree.Size())
+		_, ok := tree.Find("node2")
+		assert.False(t, ok)
+	})
+	t.Run("cycle", func(t *testing.T) {
+		tree := newValidateTestTree(t)
+		node2, _ := tree.getNode("node2")
+		root := tree.rootNode.Load()
+		node2.Descendants.Append(root)
+		errs := tree.Validate()
+		assert.NotEmpty(t, errs)
+		assert.ErrorIs(t, errors.Join(errs...), ErrCycleDetected)
+
+		assert.Positive(t, tree.Repair())
+		assert.Empty(t, tree.Validate())
+		assert.EqualValues(t, 4, tree.Size())
+	})
+	t.Run("missing root", func(t *testing.T) {
+		tree := newValidateTestTree(t)
+		tree.rootNode.Store(nil)
+		assert.NotEmpty(t, tree.Validate())
+
+		assert.Positive(t, tree.Repair())
+		assert.Empty(t, tree.Validate())
+		assert.Equal(t, "root", tree.Root().ID())
+	})
+}
+
+// newValidateTestTree creates the following tree:
+// root -> node1 -> node2 and root -> node3
+func newValidateTestTree(t *testing.T) *Tree[string] {
+	tree := NewTree[string]()
+	root := newTestNode("root", "root")
+	require.NoError(t, tree.Add(root, nil))
+	node1 := newTestNode("node1", "node1")
+	require.NoError(t, tree.Add(node1, root))
+	require.NoError(t, tree.Add(newTestNode("node2", "node2"), node1))
+	require.NoError(t, tree.Add(newTestNode("node3", "node3"), root))
+	return tree
+}
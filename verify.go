@@ -0,0 +1,265 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+// SnapshotInfo describes a serialized Tree checked by VerifySnapshot
+type SnapshotInfo struct {
+	// Version is the version of the file format
+	Version int
+	// Compression is the compression algorithm of the content
+	Compression Compression
+	// Schema is the schema version of the values, zero when no Migrations were set
+	Schema int
+	// Checksum is the CRC-32C checksum of the payload
+	Checksum uint32
+	// Nodes is the number of Nodes
+	Nodes int
+	// RootID is the identifier of the root, empty when the Tree is empty
+	RootID string
+	// MaxDepth is the depth of the deepest Node, the root being at depth zero
+	MaxDepth int
+}
+
+// fileRecordRef is a Node of the payload stripped of its value
+type fileRecordRef struct {
+	ID     string `json:"id"`
+	Parent string `json:"parent,omitempty"`
+}
+
+// VerifySnapshot checks a Tree serialized by SaveFile or EncodeCompressed without loading it.
+//
+// The header, the checksum and the referential integrity of the Nodes are checked: every Node
+// has a unique identifier, the root comes first and every other Node follows its parent. The values
+// are skipped, hence VerifySnapshot does not need the type of the values and suits the pre-flight
+// checks of the deployment pipelines.
+//
+// Parameters:
+//   - r: The reader providing the serialized Tree.
+//
+// Returns:
+//   - SnapshotInfo: The description of the serialized Tree.
+//   - err: An error indicating the outcome of the verification. Possible values:
+//   - nil: The serialized Tree can be loaded.
+//   - ErrCorruptedFile: The content is damaged or the Nodes are inconsistent.
+//   - ErrUnsupportedVersion: The content was written by a newer version of the library.
+//   - ErrInvalidOperation: The content is encrypted and cannot be checked.
+//
+// Notes:
+//   - The content is streamed: the Nodes are checked one at a time while the payload is hashed,
+//     hence the memory used grows with the number of Nodes, whose identifiers and depths are
+//     retained, rather than with the size of the content.
+//   - The values are not decoded: a value that does not match the type of the Tree or requires
+//     an unavailable migration is only detected when loading the Tree.
+//
+// Example usage:
+//
+//	file, _ := os.Open("tree.gotree")
+//	defer file.Close()
+//	info, err := VerifySnapshot(bufio.NewReader(file))
+//	if err != nil {
+//	    log.Fatal("Invalid snapshot:", err)
+//	}
+//	fmt.Println("Nodes:", info.Nodes, "depth:", info.MaxDepth)
+func VerifySnapshot(r io.Reader) (SnapshotInfo, error) {
+	info, err := verifySnapshot(r)
+	if err != nil {
+		return info, fmt.Errorf("gotree: cannot verify snapshot: %w", err)
+	}
+	return info, nil
+}
+
+// verifySnapshot streams the payload of the given content through the checks of VerifySnapshot
+func verifySnapshot(r io.Reader) (SnapshotInfo, error) {
+	_, version, err := readFileHeader(r)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	if version == fileVersionEncrypted {
+		return SnapshotInfo{}, fmt.Errorf("%w: content is encrypted", ErrInvalidOperation)
+	}
+
+	info := SnapshotInfo{Version: int(version)}
+	checksum := &trailingHash{hash: crc32.New(crcTable)}
+	body := r
+	if version == 1 {
+		// the checksum precedes the payload
+		trailer := make([]byte, fileChecksumSize)
+		if _, err := io.ReadFull(r, trailer); err != nil {
+			return info, fmt.Errorf("%w: missing checksum", ErrCorruptedFile)
+		}
+		checksum.held, checksum.leading = trailer, true
+	} else {
+		compression := make([]byte, 1)
+		if _, err := io.ReadFull(r, compression); err != nil {
+			return info, fmt.Errorf("%w: missing header", ErrCorruptedFile)
+		}
+		info.Compression = Compression(compression[0])
+
+		reader, release, err := decompressor(r, info.Compression)
+		if err != nil {
+			return info, err
+		}
+		defer release()
+		body = reader
+	}
+
+	// the payload is hashed as it is decoded, then up to its end
+	payload := io.TeeReader(body, checksum)
+	if err := verifyPayload(json.NewDecoder(payload), &info); err != nil {
+		return info, fmt.Errorf("%w: %w", ErrCorruptedFile, err)
+	}
+	if _, err := io.Copy(io.Discard, payload); err != nil {
+		return info, fmt.Errorf("%w: %w", ErrCorruptedFile, err)
+	}
+
+	if len(checksum.held) < fileChecksumSize {
+		return info, fmt.Errorf("%w: missing checksum", ErrCorruptedFile)
+	}
+	info.Checksum = binary.BigEndian.Uint32(checksum.held)
+	if checksum.hash.Sum32() != info.Checksum {
+		return info, fmt.Errorf("%w: checksum mismatch", ErrCorruptedFile)
+	}
+	return info, nil
+}
+
+// verifyPayload decodes the payload one token at a time, checking its Nodes as they are read
+func verifyPayload(decoder *json.Decoder, info *SnapshotInfo) error {
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case "schema":
+			err = decoder.Decode(&info.Schema)
+		case "records":
+			err = verifyRecords(decoder, info)
+		default:
+			var skipped json.RawMessage
+			err = decoder.Decode(&skipped)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return expectDelim(decoder, '}')
+}
+
+// verifyRecords checks the array of Nodes read from the given decoder
+func verifyRecords(decoder *json.Decoder, info *SnapshotInfo) error {
+	token, err := decoder.Token()
+	if err != nil || token == nil {
+		return err
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("unexpected %v", token)
+	}
+
+	depths := make(map[string]int)
+	for i := 0; decoder.More(); i++ {
+		var record fileRecordRef
+		if err := decoder.Decode(&record); err != nil {
+			return err
+		}
+
+		depth, found := depths[record.Parent]
+		_, duplicate := depths[record.ID]
+		switch {
+		case record.ID == "":
+			return fmt.Errorf("node %d has no id", i)
+		case duplicate:
+			return fmt.Errorf("node %q is duplicated", record.ID)
+		case i == 0 && record.Parent != "":
+			return fmt.Errorf("root %q has a parent", record.ID)
+		case i > 0 && record.Parent == "":
+			return fmt.Errorf("node %q is a second root", record.ID)
+		case i > 0 && !found:
+			return fmt.Errorf("parent %q of node %q is missing or follows it", record.Parent, record.ID)
+		}
+
+		if i == 0 {
+			info.RootID, depth = record.ID, -1
+		}
+		depths[record.ID] = depth + 1
+		info.MaxDepth = max(info.MaxDepth, depth+1)
+		info.Nodes++
+	}
+	return expectDelim(decoder, ']')
+}
+
+// expectDelim reads the given delimiter from the given decoder
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("unexpected %v, expecting %v", token, delim)
+	}
+	return nil
+}
+
+// trailingHash hashes the bytes written to it but the last fileChecksumSize ones, which it holds
+// back as the checksum trailing the payload. When leading is set, the checksum was read before the
+// payload and every byte is hashed
+type trailingHash struct {
+	hash    hash.Hash32
+	held    []byte
+	leading bool
+}
+
+// Write implements io.Writer
+func (h *trailingHash) Write(p []byte) (int, error) {
+	if h.leading {
+		return h.hash.Write(p)
+	}
+
+	if len(p) >= fileChecksumSize {
+		h.hash.Write(h.held)
+		h.hash.Write(p[:len(p)-fileChecksumSize])
+		h.held = append(h.held[:0], p[len(p)-fileChecksumSize:]...)
+		return len(p), nil
+	}
+
+	held := append(h.held, p...)
+	cut := max(len(held)-fileChecksumSize, 0)
+	h.hash.Write(held[:cut])
+	h.held = append(h.held[:0], held[cut:]...)
+	return len(p), nil
+}
@@ -0,0 +1,123 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySnapshot(t *testing.T) {
+	// encodePayload returns the given payload in the version 2 of the file format, uncompressed
+	encodePayload := func(payload string) []byte {
+		data := append([]byte(fileMagic), 0, 2, byte(NoCompression))
+		data = append(data, payload...)
+		return binary.BigEndian.AppendUint32(data, crc32.Checksum([]byte(payload), crcTable))
+	}
+
+	t.Run("describes a valid snapshot", func(t *testing.T) {
+		tree, err := FromSExpr[int](`(root 1 (a 2 (a1 3 (a11))) (b 4))`)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, tree.EncodeCompressed(&buf, Zstd))
+		info, err := VerifySnapshot(&buf)
+		require.NoError(t, err)
+		assert.NotZero(t, info.Checksum)
+		info.Checksum = 0
+		assert.Equal(t, SnapshotInfo{Version: 2, Compression: Zstd, Nodes: 5, RootID: "root", MaxDepth: 3}, info)
+
+		info, err = VerifySnapshot(bytes.NewReader(encodePayload(`{"records":[]}`)))
+		require.NoError(t, err)
+		assert.Zero(t, info.Nodes)
+		assert.Empty(t, info.RootID)
+	})
+
+	t.Run("streams every version and compression", func(t *testing.T) {
+		tree, err := FromSExpr[int](`(root 1 (a 2 (a1 3)) (b 4))`)
+		require.NoError(t, err)
+		for _, compression := range []Compression{NoCompression, Gzip, Zstd} {
+			var buf bytes.Buffer
+			require.NoError(t, tree.EncodeCompressed(&buf, compression))
+			info, err := VerifySnapshot(&buf)
+			require.NoError(t, err, compression)
+			assert.Equal(t, compression, info.Compression)
+			assert.Equal(t, 4, info.Nodes)
+			assert.Equal(t, 2, info.MaxDepth)
+		}
+
+		// the version 1 holds the checksum before the payload and the unknown keys are skipped
+		payload := `{"schema":2,"extra":{"a":[1]},"records":[{"id":"root","value":{"n":[1,2]}},{"id":"a","parent":"root","value":null}]}` + "\n"
+		data := append([]byte(fileMagic), 0, 1)
+		data = binary.BigEndian.AppendUint32(data, crc32.Checksum([]byte(payload), crcTable))
+		info, err := VerifySnapshot(bytes.NewReader(append(data, payload...)))
+		require.NoError(t, err)
+		assert.Equal(t, SnapshotInfo{Version: 1, Schema: 2, Checksum: crc32.Checksum([]byte(payload), crcTable), Nodes: 2, RootID: "root", MaxDepth: 1}, info)
+	})
+
+	t.Run("detects the corrupted content", func(t *testing.T) {
+		tree, err := FromSExpr[string](`(root (a) (b))`)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, tree.EncodeCompressed(&buf, NoCompression))
+		data := buf.Bytes()
+		data[len(data)/2] ^= 0xff
+
+		_, err = VerifySnapshot(bytes.NewReader(data))
+		assert.ErrorIs(t, err, ErrCorruptedFile)
+		_, err = VerifySnapshot(bytes.NewReader([]byte("not a tree")))
+		assert.ErrorIs(t, err, ErrCorruptedFile)
+		_, err = VerifySnapshot(bytes.NewReader(encodePayload(`{"records":`)))
+		assert.ErrorIs(t, err, ErrCorruptedFile)
+		_, err = VerifySnapshot(bytes.NewReader(encodePayload(`{"records":[]}`)[:len(fileMagic)+5]))
+		assert.ErrorIs(t, err, ErrCorruptedFile)
+	})
+
+	t.Run("detects the inconsistent nodes", func(t *testing.T) {
+		for payload, message := range map[string]string{
+			`{"records":[{"id":"root"},{"id":""}]}`:                                           "node 1 has no id",
+			`{"records":[{"id":"root"},{"id":"a","parent":"root"},{"id":"root"}]}`:            `node "root" is duplicated`,
+			`{"records":[{"id":"a","parent":"root"}]}`:                                        `root "a" has a parent`,
+			`{"records":[{"id":"root"},{"id":"other"}]}`:                                      `node "other" is a second root`,
+			`{"records":[{"id":"root"},{"id":"a1","parent":"a"},{"id":"a","parent":"root"}]}`: `parent "a" of node "a1" is missing`,
+		} {
+			_, err := VerifySnapshot(bytes.NewReader(encodePayload(payload)))
+			require.ErrorIs(t, err, ErrCorruptedFile, payload)
+			assert.Contains(t, err.Error(), message, payload)
+		}
+	})
+
+	t.Run("rejects the encrypted and newer content", func(t *testing.T) {
+		_, err := VerifySnapshot(bytes.NewReader(append([]byte(fileMagic), 0, 3, 0)))
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+		_, err = VerifySnapshot(bytes.NewReader(append([]byte(fileMagic), 0, 9)))
+		assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+}
@@ -0,0 +1,156 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+// Visitor receives the Nodes of a depth-first walk of the Tree.
+//
+// Unlike a per-node callback, a Visitor is told both when the walk enters a Node and when it leaves it,
+// once all the descendants have been visited, which lets it track the depth, maintain a scope or
+// close the output opened for a Node.
+type Visitor[T any] interface {
+	// Enter is called when the walk reaches the Node, before its children.
+	// Returning false skips the children of the Node; Leave is still called for it
+	Enter(node Node[T]) (descend bool)
+	// Leave is called once the walk is done with the Node and its children
+	Leave(node Node[T])
+}
+
+// Accept walks the subtree rooted at the given Node depth-first and hands the Nodes to the Visitor.
+//
+// Every Node is entered before its children, which are visited in their order, and left after them, so
+// that the Enter and Leave calls are properly nested. The Visitor can prune the walk by returning false
+// from Enter.
+//
+// Parameters:
+//   - start: The Node[T] at which the walk starts. It is entered first and left last.
+//   - visitor: The Visitor[T] receiving the Nodes.
+//
+// Returns:
+//   - ok: A boolean indicating whether the start Node exists in the Tree.
+//
+// Notes:
+//   - The walk does not block the changes of the Tree: the Nodes added or removed during the walk
+//     may or may not be visited, but the walk never visits a Node twice.
+//   - The walk is iterative, hence deep Trees do not exhaust the stack.
+//
+// Example usage:
+//
+//	type printer struct{ depth int }
+//
+//	func (p *printer) Enter(node gotree.Node[string]) bool {
+//	    fmt.Printf("%s%s\n", strings.Repeat("  ", p.depth), node.ID())
+//	    p.depth++
+//	    return true
+//	}
+//
+//	func (p *printer) Leave(gotree.Node[string]) { p.depth-- }
+//
+//	ok := tree.Accept(root, &printer{})
+func (x *Tree[T]) Accept(start Node[T], visitor Visitor[T]) (ok bool) {
+	startNode, ok := x.getNode(start.ID())
+	if !ok {
+		return false
+	}
+
+	type frame struct {
+		node     *treeNode[T]
+		children []*treeNode[T]
+	}
+
+	guard := x.newCycleGuard(false, startNode)
+	enter := func(node *treeNode[T]) frame {
+		current := frame{node: node}
+		if visitor.Enter(node.GetValue()) {
+			current.children = node.Descendants.Items()
+		}
+		return current
+	}
+
+	stack := []frame{enter(startNode)}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if len(top.children) == 0 {
+			stack = stack[:len(stack)-1]
+			visitor.Leave(top.node.GetValue())
+			continue
+		}
+
+		child := top.children[0]
+		top.children = top.children[1:]
+		if guard.visit(top.node, child) {
+			stack = append(stack, enter(child))
+		}
+	}
+	return true
+}
+
+// WalkUp visits the ancestors of the given Node, nearest first, up to the root.
+//
+// Unlike Ancestors, WalkUp neither allocates nor sorts the ancestors: it follows the ancestor chain of
+// the Node and hands every ancestor to the given function, which can stop the walk early. It suits
+// bubbling an event up the hierarchy or looking for the nearest ancestor matching a condition.
+//
+// Parameters:
+//   - node: The Node[T] whose ancestors are visited. It is not visited itself.
+//   - fn: The function receiving the ancestors. Returning false stops the walk.
+//
+// Returns:
+//   - ok: A boolean indicating whether the Node exists in the Tree.
+//
+// Notes:
+//   - The ancestors are the ones of the Node when WalkUp starts: a concurrent move of the Node or of
+//     one of its ancestors is not reflected by the walk.
+//
+// Example usage:
+//
+//	// find the nearest ancestor holding a configuration
+//	var owner Node[Config]
+//	tree.WalkUp(node, func(ancestor Node[Config]) bool {
+//	    if ancestor.Value().Defined {
+//	        owner = ancestor
+//	        return false
+//	    }
+//	    return true
+//	})
+func (x *Tree[T]) WalkUp(node Node[T], fn func(ancestor Node[T]) bool) (ok bool) {
+	chain, ok := x.parents.Load(node.ID())
+	if !ok {
+		// the root has no parent entry
+		_, ok = x.getNode(node.ID())
+		return ok
+	}
+
+	for link := chain; link != nil; link = link.parent {
+		ancestor, ok := x.getNode(link.id)
+		if !ok {
+			x.invariant("ancestor %q of node %q is not part of the tree", link.id, node.ID())
+			continue
+		}
+		if !fn(ancestor.GetValue()) {
+			break
+		}
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// printer is a Visitor printing the Nodes indented by their depth
+type printer struct {
+	depth  int
+	output strings.Builder
+	skip   string
+}
+
+func (p *printer) Enter(node Node[string]) bool {
+	fmt.Fprintf(&p.output, "%s<%s>\n", strings.Repeat("  ", p.depth), node.ID())
+	p.depth++
+	return node.ID() != p.skip
+}
+
+func (p *printer) Leave(node Node[string]) {
+	p.depth--
+	fmt.Fprintf(&p.output, "%s</%s>\n", strings.Repeat("  ", p.depth), node.ID())
+}
+
+func TestAccept(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("a1", ""), "a"))
+	require.NoError(t, tree.AddByID(NewNode("b1", ""), "b"))
+
+	t.Run("nests the enter and leave calls", func(t *testing.T) {
+		visitor := &printer{}
+		root, _ := tree.Find("root")
+		require.True(t, tree.Accept(root, visitor))
+		assert.Equal(t, "<root>\n  <a>\n    <a1>\n    </a1>\n  </a>\n  <b>\n    <b1>\n    </b1>\n  </b>\n</root>\n", visitor.output.String())
+		assert.Zero(t, visitor.depth)
+	})
+
+	t.Run("skips the children when told so", func(t *testing.T) {
+		visitor := &printer{skip: "a"}
+		root, _ := tree.Find("root")
+		require.True(t, tree.Accept(root, visitor))
+		assert.Equal(t, "<root>\n  <a>\n  </a>\n  <b>\n    <b1>\n    </b1>\n  </b>\n</root>\n", visitor.output.String())
+	})
+
+	t.Run("starts anywhere in the tree", func(t *testing.T) {
+		visitor := &printer{}
+		b, _ := tree.Find("b")
+		require.True(t, tree.Accept(b, visitor))
+		assert.Equal(t, "<b>\n  <b1>\n  </b1>\n</b>\n", visitor.output.String())
+		assert.False(t, tree.Accept(NewNode("missing", ""), visitor))
+	})
+}
+
+func TestWalkUp(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+	require.NoError(t, tree.AddByID(NewNode("z", ""), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", ""), "z"))
+	require.NoError(t, tree.AddByID(NewNode("c", ""), "b"))
+
+	c, _ := tree.Find("c")
+	var visited []string
+	require.True(t, tree.WalkUp(c, func(ancestor Node[string]) bool {
+		visited = append(visited, ancestor.ID())
+		return true
+	}))
+	assert.Equal(t, []string{"b", "z", "root"}, visited)
+
+	visited = nil
+	require.True(t, tree.WalkUp(c, func(ancestor Node[string]) bool {
+		visited = append(visited, ancestor.ID())
+		return ancestor.ID() != "z"
+	}))
+	assert.Equal(t, []string{"b", "z"}, visited)
+
+	called := false
+	assert.True(t, tree.WalkUp(tree.Root(), func(Node[string]) bool {
+		called = true
+		return true
+	}))
+	assert.False(t, called)
+	assert.False(t, tree.WalkUp(NewNode("missing", ""), func(Node[string]) bool { return true }))
+}
@@ -0,0 +1,529 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// walFile is the name of the log file of a WAL
+	walFile = "wal.log"
+	// snapshotFile is the name of the snapshot file of a WAL
+	snapshotFile = "snapshot.json"
+	// walHeaderSize is the size of the header of a log frame: the length and the checksum of the payload
+	walHeaderSize = 8
+)
+
+// crcTable is the table of the checksums of the log entries
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// walEntry is an event logged in a frame along with the other events of the same change
+type walEntry struct {
+	Seq    uint64          `json:"seq"`
+	Type   EventType       `json:"type"`
+	ID     string          `json:"id"`
+	Parent string          `json:"parent,omitempty"`
+	Before string          `json:"before,omitempty"`
+	Value  json.RawMessage `json:"value,omitempty"`
+	Edge   *walEdge        `json:"edge,omitempty"`
+	Meta   map[string]any  `json:"meta,omitempty"`
+	Tags   []string        `json:"tags,omitempty"`
+}
+
+// walEdge is the data of an edge logged or recorded in the snapshot file
+type walEdge struct {
+	Weight float64 `json:"weight,omitempty"`
+	Label  string  `json:"label,omitempty"`
+}
+
+// walRecord is a record of the snapshot file
+type walRecord[T any] struct {
+	ID     string         `json:"id"`
+	Parent string         `json:"parent,omitempty"`
+	Value  T              `json:"value"`
+	Edge   *walEdge       `json:"edge,omitempty"`
+	Meta   map[string]any `json:"meta,omitempty"`
+	Tags   []string       `json:"tags,omitempty"`
+	Links  []string       `json:"links,omitempty"`
+}
+
+// walSnapshot is the content of the snapshot file
+type walSnapshot[T any] struct {
+	Seq     uint64         `json:"seq"`
+	Records []walRecord[T] `json:"records"`
+}
+
+// WAL is a Storage keeping the state of a Tree in a directory: the changes are appended to a
+// write-ahead log file and synced to disk before they are applied, and the latest snapshot
+// is kept in a file of its own. The values are encoded with encoding/json.
+//
+// The events of every change are logged as a single checksummed frame, so that a change torn by
+// a crash is detected and discarded as a whole when the WAL is opened again. Saving a snapshot
+// replaces the snapshot file atomically and compacts the log down to the changes following it.
+type WAL[T any] struct {
+	mu  sync.Mutex
+	dir string
+	log *os.File
+	// size is the length of the valid entries of the log
+	size int64
+}
+
+// enforce compilation error
+var _ Storage[any] = (*WAL[any])(nil)
+
+// OpenWAL opens, or creates, the WAL kept in the given directory. The changes torn by a crash
+// at the end of the log are discarded. Close releases the log file.
+func OpenWAL[T any](dir string) (*WAL[T], error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("gotree: cannot create %s: %w", dir, err)
+	}
+
+	w := &WAL[T]{dir: dir}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// OpenTree opens the Tree persisted in the given directory, creating an empty one when the directory
+// does not exist yet.
+//
+// The Tree is persisted to a WAL: every change is appended to the log and synced to disk before
+// it is applied, so that the Tree survives crashes. On startup, the latest snapshot is loaded and
+// the changes logged after it are replayed, which turns the Tree into an embedded durable hierarchy store.
+//
+// Parameters:
+//   - dir: The directory holding the log and the snapshot of the Tree.
+//   - opts: The options of the Tree, as for NewTree.
+//
+// Returns:
+//   - tree: The recovered Tree. Close releases its log file.
+//   - err: An error when the directory cannot be opened or its content cannot be recovered.
+//
+// Notes:
+//   - The values are encoded with encoding/json and must round-trip through it. So are the metadata
+//     values, which are recovered as decoded by encoding/json, e.g. numbers as float64.
+//   - The edges, metadata, tags and links of the Nodes are persisted along with them. A Tree created
+//     with WithMultiParent must be opened with it again.
+//   - Call Persist from time to time to save a snapshot and compact the log, which speeds up
+//     the next startup.
+//   - A change is durable once its mutation returns without error. A change that cannot be logged
+//     is rejected: the Tree is left unchanged and the mutation returns an error wrapping ErrNotPersisted.
+//
+// Example usage:
+//
+//	tree, err := OpenTree[string]("/var/lib/app/tree")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer tree.Close()
+//
+//	err = tree.AddByID(NewNode("root", "Root"), "") // durable once returned
+func OpenTree[T any](dir string, opts ...Option) (*Tree[T], error) {
+	wal, err := OpenWAL[T](dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := NewTree[T](append(opts, WithStorage[T](wal))...)
+	if err := tree.recover(); err != nil {
+		_ = wal.Close()
+		return nil, err
+	}
+	return tree, nil
+}
+
+// Close closes the Storage of the Tree, set with WithStorage or OpenTree, when it implements io.Closer.
+// The Tree must not be changed afterward.
+func (x *Tree[T]) Close() error {
+	if closer, ok := x.feed.storage.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Close closes the log file
+func (w *WAL[T]) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.log == nil {
+		return nil
+	}
+	err := w.log.Close()
+	w.log = nil
+	return err
+}
+
+// LogSize returns the size in bytes of the log, which shrinks once a snapshot is saved
+func (w *WAL[T]) LogSize() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.size
+}
+
+// SaveSnapshot replaces the snapshot file and compacts the log down to the changes following the snapshot
+func (w *WAL[T]) SaveSnapshot(snapshot Snapshot[T]) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	content := walSnapshot[T]{Seq: snapshot.Seq, Records: make([]walRecord[T], len(snapshot.Records))}
+	for i, record := range snapshot.Records {
+		content.Records[i] = walRecord[T]{
+			ID:     record.ID,
+			Parent: record.ParentID,
+			Value:  record.Value,
+			Edge:   toWALEdge(record.Edge),
+			Meta:   record.Meta,
+			Tags:   record.Tags,
+			Links:  record.Links,
+		}
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("cannot encode the snapshot: %w", err)
+	}
+
+	if err := w.replace(snapshotFile, func(file *os.File) error {
+		_, err := file.Write(data)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	// keep the entries following the snapshot
+	var kept [][]byte
+	if err := w.scan(func(entries []walEntry, frame []byte) error {
+		// a change is never split by a snapshot, hence its last event tells whether it follows it
+		if entries[len(entries)-1].Seq > snapshot.Seq {
+			kept = append(kept, frame)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := w.log.Close(); err != nil {
+		return err
+	}
+	w.log = nil
+
+	err = w.replace(walFile, func(file *os.File) error {
+		for _, frame := range kept {
+			if _, err := file.Write(frame); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// the log is reopened whether or not it has been compacted
+	if oerr := w.open(); err == nil {
+		err = oerr
+	}
+	return err
+}
+
+// LoadSnapshot reads the snapshot file, returning an empty snapshot when none has been saved
+func (w *WAL[T]) LoadSnapshot() (Snapshot[T], error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data, err := os.ReadFile(filepath.Join(w.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return Snapshot[T]{}, nil
+	}
+	if err != nil {
+		return Snapshot[T]{}, err
+	}
+
+	var content walSnapshot[T]
+	if err := json.Unmarshal(data, &content); err != nil {
+		return Snapshot[T]{}, fmt.Errorf("cannot decode the snapshot: %w", err)
+	}
+
+	snapshot := Snapshot[T]{Seq: content.Seq, Records: make([]Record[T], len(content.Records))}
+	for i, record := range content.Records {
+		snapshot.Records[i] = Record[T]{
+			ID:       record.ID,
+			ParentID: record.Parent,
+			Value:    record.Value,
+			Edge:     record.Edge.data(),
+			Meta:     record.Meta,
+			Tags:     record.Tags,
+			Links:    record.Links,
+		}
+	}
+	return snapshot, nil
+}
+
+// AppendChanges appends the events of a change to the log, as a single checksummed frame, and syncs
+// it to disk. A frame torn by a crash is discarded as a whole, along with the change it holds.
+func (w *WAL[T]) AppendChanges(changes []Event[T]) error {
+	entries := make([]walEntry, len(changes))
+	for i, change := range changes {
+		entry := walEntry{
+			Seq:    change.Seq,
+			Type:   change.Type,
+			ID:     change.Node.ID(),
+			Parent: parentID(change.Parent),
+			Before: change.Before,
+			Edge:   toWALEdge(change.Edge),
+			Meta:   change.Meta,
+			Tags:   change.Tags,
+		}
+		if change.Type == EventAdd || change.Type == EventUpdate {
+			value, err := json.Marshal(change.Node.Value())
+			if err != nil {
+				return fmt.Errorf("cannot encode the value of %q: %w", entry.ID, err)
+			}
+			entry.Value = value
+		}
+		entries[i] = entry
+	}
+
+	payload, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("cannot encode change %d: %w", changes[0].Seq, err)
+	}
+
+	frame := make([]byte, walHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(len(payload)))
+	binary.BigEndian.PutUint32(frame[4:], crc32.Checksum(payload, crcTable))
+	copy(frame[walHeaderSize:], payload)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.log == nil {
+		return os.ErrClosed
+	}
+
+	if _, err := w.log.Write(frame); err != nil {
+		// drop the partial frame so that the following ones remain readable
+		_ = w.log.Truncate(w.size)
+		return err
+	}
+
+	if err := w.log.Sync(); err != nil {
+		_ = w.log.Truncate(w.size)
+		return err
+	}
+	w.size += int64(len(frame))
+	return nil
+}
+
+// ReplayChanges calls apply with the logged changes following the given sequence number
+func (w *WAL[T]) ReplayChanges(after uint64, apply func(change Event[T]) error) error {
+	// the changes are read before being applied since applying them may append to the log
+	var changes []Event[T]
+	w.mu.Lock()
+	err := w.scan(func(entries []walEntry, _ []byte) error {
+		for _, entry := range entries {
+			if entry.Seq <= after {
+				continue
+			}
+
+			change := Event[T]{Seq: entry.Seq, Type: entry.Type, Before: entry.Before, Edge: entry.Edge.data(), Meta: entry.Meta, Tags: entry.Tags}
+			var value T
+			if len(entry.Value) > 0 {
+				if err := json.Unmarshal(entry.Value, &value); err != nil {
+					return fmt.Errorf("cannot decode the value of change %d: %w", entry.Seq, err)
+				}
+			}
+
+			change.Node = NewNode(entry.ID, value)
+			if entry.Parent != "" {
+				// only the identifier of the parent is needed to replay the change
+				change.Parent = NewNode(entry.Parent, *new(T))
+			}
+			changes = append(changes, change)
+		}
+		return nil
+	})
+	w.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		if err := apply(change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// open opens the log file for appending after discarding the torn frames at its end.
+// It must be called with the lock held, or before the WAL is shared
+func (w *WAL[T]) open() error {
+	path := filepath.Join(w.dir, walFile)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("gotree: cannot open %s: %w", path, err)
+	}
+
+	w.log = file
+	w.size = 0
+	if err := w.scan(func([]walEntry, []byte) error { return nil }); err != nil {
+		_ = file.Close()
+		w.log = nil
+		return fmt.Errorf("gotree: cannot read %s: %w", path, err)
+	}
+
+	info, err := file.Stat()
+	if err == nil && info.Size() > w.size {
+		if err = file.Truncate(w.size); err == nil {
+			err = file.Sync()
+		}
+	}
+	if err == nil {
+		_, err = file.Seek(w.size, io.SeekStart)
+	}
+	if err != nil {
+		_ = file.Close()
+		w.log = nil
+		return fmt.Errorf("gotree: cannot repair %s: %w", path, err)
+	}
+	return nil
+}
+
+// scan calls fn with the entries of every valid frame of the log along with the frame, and records the
+// length of the valid frames. It stops at the first torn or corrupted frame.
+// It must be called with the lock held
+func (w *WAL[T]) scan(fn func(entries []walEntry, frame []byte) error) error {
+	info, err := w.log.Stat()
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(io.NewSectionReader(w.log, 0, info.Size()))
+	var offset int64
+	defer func() {
+		w.size = offset
+	}()
+
+	header := make([]byte, walHeaderSize)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			// the end of the log or a torn header
+			return nil
+		}
+
+		// a corrupted length must not exceed the log
+		length := int64(binary.BigEndian.Uint32(header))
+		if offset+walHeaderSize+length > info.Size() {
+			return nil
+		}
+
+		frame := make([]byte, walHeaderSize+length)
+		copy(frame, header)
+		if _, err := io.ReadFull(reader, frame[walHeaderSize:]); err != nil {
+			return nil
+		}
+
+		payload := frame[walHeaderSize:]
+		if crc32.Checksum(payload, crcTable) != binary.BigEndian.Uint32(header[4:]) {
+			return nil
+		}
+
+		var entries []walEntry
+		if err := json.Unmarshal(payload, &entries); err != nil || len(entries) == 0 {
+			return nil
+		}
+
+		if err := fn(entries, frame); err != nil {
+			return err
+		}
+		offset += int64(len(frame))
+	}
+}
+
+// replace atomically replaces the given file of the directory with the content written by fn
+func (w *WAL[T]) replace(name string, fn func(file *os.File) error) error {
+	return replaceFile(filepath.Join(w.dir, name), fn)
+}
+
+// replaceFile atomically replaces the given file with the content written by fn, which is synced to disk
+func replaceFile(path string, fn func(file *os.File) error) error {
+	dir, name := filepath.Split(path)
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := fn(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	// persist the rename, which is not supported on every platform
+	if parent, err := os.Open(filepath.Dir(path)); err == nil {
+		_ = parent.Sync()
+		_ = parent.Close()
+	}
+	return nil
+}
+
+// toWALEdge converts the given edge data, nil when it is zero
+func toWALEdge(edge EdgeData) *walEdge {
+	if edge == (EdgeData{}) {
+		return nil
+	}
+	return &walEdge{Weight: edge.Weight, Label: edge.Label}
+}
+
+// data converts the edge back to edge data. It is nil safe and returns a zero EdgeData for a nil edge
+func (e *walEdge) data() EdgeData {
+	if e == nil {
+		return EdgeData{}
+	}
+	return EdgeData{Weight: e.Weight, Label: e.Label}
+}
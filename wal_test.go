@@ -0,0 +1,220 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenTree(t *testing.T) {
+	populate := func(t *testing.T, tree *Tree[string]) {
+		t.Helper()
+		require.NoError(t, tree.AddByID(NewNode("root", "Root"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "A"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", "B"), "root"))
+		require.NoError(t, tree.AddByID(NewNode("a1", "A1"), "a"))
+	}
+
+	reopen := func(t *testing.T, dir string) *Tree[string] {
+		t.Helper()
+		tree, err := OpenTree[string](dir)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = tree.Close()
+		})
+		return tree
+	}
+
+	t.Run("keeps the order of the children", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "tree")
+		tree, err := OpenTree[string](dir)
+		require.NoError(t, err)
+		populate(t, tree)
+		require.NoError(t, tree.InsertChildAt(NewNode("root", ""), NewNode("first", "First"), 0))
+		require.NoError(t, tree.MoveBefore(NewNode("b", ""), NewNode("a", "")))
+		dump := tree.Dump()
+		require.NoError(t, tree.Close())
+
+		assert.Equal(t, dump, reopen(t, dir).Dump())
+	})
+
+	t.Run("survives a restart", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "tree")
+		tree, err := OpenTree[string](dir)
+		require.NoError(t, err)
+		assert.Zero(t, tree.Size())
+
+		populate(t, tree)
+		require.NoError(t, tree.Update(NewNode("a", "A'")))
+		require.NoError(t, tree.MoveByID("a1", "b"))
+		require.NoError(t, tree.DeleteByID("a"))
+		dump := tree.Dump()
+		require.NoError(t, tree.Close())
+
+		recovered := reopen(t, dir)
+		assert.Equal(t, dump, recovered.Dump())
+		assert.EqualValues(t, 7, recovered.LastSeq())
+		assert.Empty(t, recovered.Validate())
+	})
+
+	t.Run("keeps the edges, metadata, tags and links", func(t *testing.T) {
+		dir := t.TempDir()
+		tree, err := OpenTree[string](dir, WithMultiParent())
+		require.NoError(t, err)
+		populate(t, tree)
+		a, _ := tree.Find("a")
+		require.NoError(t, tree.AddWithEdge(NewNode("a2", "A2"), a, EdgeData{Label: "second", Weight: 2}))
+		require.NoError(t, tree.SetMeta("a", "owner", "alice"))
+		require.NoError(t, tree.Tag("a1", "critical"))
+		require.NoError(t, tree.Link(NewNode("a1", ""), NewNode("b", "")))
+		require.NoError(t, tree.Persist())
+
+		// the changes following the snapshot are logged along with it
+		require.NoError(t, tree.SetMeta("b", "weight", 3))
+		require.NoError(t, tree.SetEdge(NewNode("a1", ""), EdgeData{Label: "first"}))
+		require.NoError(t, tree.Tag("b", "draft"))
+		require.NoError(t, tree.Link(NewNode("a2", ""), NewNode("b", "")))
+		snapshot := tree.Snapshot()
+		require.NoError(t, tree.Close())
+
+		recovered, err := OpenTree[string](dir, WithMultiParent())
+		require.NoError(t, err)
+
+		// the metadata values are decoded by encoding/json
+		meta, _ := recovered.Meta("b")
+		assert.Equal(t, map[string]any{"weight": float64(3)}, meta)
+		assert.Equal(t, "b", snapshot.Records[4].ID)
+		snapshot.Records[4].Meta = meta
+		assert.Equal(t, snapshot, recovered.Snapshot())
+		require.NoError(t, recovered.Close())
+
+		// the links require the multi-parent mode
+		_, err = OpenTree[string](dir)
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+	})
+
+	t.Run("compacts the log into a snapshot", func(t *testing.T) {
+		dir := t.TempDir()
+		tree := reopen(t, dir)
+		populate(t, tree)
+
+		require.NoError(t, tree.Persist())
+		info, err := os.Stat(filepath.Join(dir, walFile))
+		require.NoError(t, err)
+		assert.Zero(t, info.Size())
+
+		require.NoError(t, tree.AddByID(NewNode("b1", "B1"), "b"))
+		info, err = os.Stat(filepath.Join(dir, walFile))
+		require.NoError(t, err)
+		assert.NotZero(t, info.Size())
+		dump := tree.Dump()
+		require.NoError(t, tree.Close())
+
+		recovered := reopen(t, dir)
+		assert.Equal(t, dump, recovered.Dump())
+		assert.EqualValues(t, 5, recovered.LastSeq())
+	})
+
+	t.Run("discards a torn entry", func(t *testing.T) {
+		dir := t.TempDir()
+		tree := reopen(t, dir)
+		populate(t, tree)
+		require.NoError(t, tree.Close())
+
+		// simulate a crash in the middle of the last append
+		path := filepath.Join(dir, walFile)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(path, info.Size()-3))
+
+		recovered := reopen(t, dir)
+		assert.EqualValues(t, 3, recovered.Size())
+		_, ok := recovered.Find("a1")
+		assert.False(t, ok)
+
+		// the entries appended after the repair are readable
+		require.NoError(t, recovered.AddByID(NewNode("a2", "A2"), "a"))
+		require.NoError(t, recovered.Close())
+
+		again := reopen(t, dir)
+		_, ok = again.Find("a2")
+		assert.True(t, ok)
+		assert.EqualValues(t, 4, again.LastSeq())
+	})
+
+	t.Run("discards a torn change as a whole", func(t *testing.T) {
+		dir := t.TempDir()
+		tree := reopen(t, dir)
+		populate(t, tree)
+		require.NoError(t, tree.DeleteByID("a"))
+		require.NoError(t, tree.Close())
+
+		// the deletions of a and a1 are logged together, hence they are torn together
+		path := filepath.Join(dir, walFile)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(path, info.Size()-3))
+
+		recovered := reopen(t, dir)
+		assert.EqualValues(t, 4, recovered.Size())
+		_, ok := recovered.Find("a1")
+		assert.True(t, ok)
+		assert.EqualValues(t, 4, recovered.LastSeq())
+	})
+
+	t.Run("discards a corrupted entry", func(t *testing.T) {
+		dir := t.TempDir()
+		tree := reopen(t, dir)
+		populate(t, tree)
+		require.NoError(t, tree.Close())
+
+		path := filepath.Join(dir, walFile)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		data[len(data)-2] ^= 0xff
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+
+		recovered := reopen(t, dir)
+		assert.EqualValues(t, 3, recovered.Size())
+	})
+
+	t.Run("rejects the changes appended once closed", func(t *testing.T) {
+		tree := reopen(t, t.TempDir())
+		populate(t, tree)
+		require.NoError(t, tree.Close())
+		assert.ErrorIs(t, tree.AddByID(NewNode("c", "C"), "root"), ErrNotPersisted)
+		_, ok := tree.Find("c")
+		assert.False(t, ok)
+	})
+
+	t.Run("closes a Tree without storage", func(t *testing.T) {
+		assert.NoError(t, NewTree[string]().Close())
+	})
+}
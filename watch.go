@@ -0,0 +1,449 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventType defines the kind of change described by an Event
+type EventType int
+
+const (
+	// EventAdd describes the addition of a Node
+	EventAdd EventType = iota + 1
+	// EventUpdate describes the replacement of the value of a Node
+	EventUpdate
+	// EventDelete describes the removal of a Node
+	EventDelete
+	// EventMove describes the move of a Node, along with its descendants, under another parent
+	EventMove
+	// EventMeta describes the replacement of the metadata of a Node
+	EventMeta
+	// EventTag describes the replacement of the tags of a Node
+	EventTag
+	// EventEdge describes the replacement of the data of the edge linking a Node to its parent
+	EventEdge
+	// EventLink describes the link of a Node under another parent in the multi-parent mode
+	EventLink
+	// EventUnlink describes the removal of the link of a Node under another parent in the multi-parent mode
+	EventUnlink
+)
+
+// String returns the name of the EventType
+func (t EventType) String() string {
+	switch t {
+	case EventAdd:
+		return "add"
+	case EventUpdate:
+		return "update"
+	case EventDelete:
+		return "delete"
+	case EventMove:
+		return "move"
+	case EventMeta:
+		return "meta"
+	case EventTag:
+		return "tag"
+	case EventEdge:
+		return "edge"
+	case EventLink:
+		return "link"
+	case EventUnlink:
+		return "unlink"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a change of the Tree
+type Event[T any] struct {
+	// Seq is the sequence number of the change. Sequence numbers increase
+	// monotonically, starting at 1, in the order the changes were applied.
+	Seq uint64
+	// Type is the kind of change
+	Type EventType
+	// Node is the affected Node. For updates, it holds the new value.
+	Node Node[T]
+	// Parent is the parent of the affected Node. It is nil for the root.
+	// For moves, it holds the new parent and for links and unlinks, the linked parent.
+	Parent Node[T]
+	// Before is the identifier of the sibling the affected Node has been placed before, for the
+	// additions and the moves. It is empty when the Node has been placed as the last child.
+	Before string
+	// Edge is the data of the edge linking the affected Node to its parent, for the additions
+	// and the edge changes. It is a zero EdgeData when the Node has been added without edge data.
+	Edge EdgeData
+	// Meta is the whole metadata of the affected Node once changed, for the metadata changes.
+	// It is shared with the other consumers of the Event, hence it must not be modified.
+	Meta map[string]any
+	// Tags are the sorted tags of the affected Node once changed, for the tag changes
+	Tags []string
+	// Time is the time the change was written, right before it was applied
+	Time time.Time
+}
+
+// Watch subscribes to the changes of the Tree.
+//
+// The returned channel delivers an Event for every Node added, updated, deleted or moved after
+// the call, and for every change of their metadata, tags, edges and links, in the order the
+// changes were applied: an Event is never delivered before the Events of the changes it depends
+// on (e.g. a Node is added after its parent).
+// Deleting a Node along with its descendants delivers an Event for every removed Node
+// in depth-first order, and Reset delivers one for every Node of the Tree.
+//
+// The Events are buffered so that publishing them does not wait for the consumer. When the
+// consumer does not keep up and the buffer is full, the Backpressure set with WithBackpressure
+// applies; by default the channel is closed.
+//
+// Parameters:
+//   - ctx: The context of the subscription. The channel is closed once it is done.
+//   - filter: An optional function selecting the Events to deliver. A nil filter delivers
+//     every Event. It is invoked while the Tree is being modified, hence it must be fast
+//     and must not call the Tree.
+//   - opts: Optional settings of the subscription (e.g., WithBufferSize).
+//
+// Returns:
+//   - events: The channel delivering the Events. It is closed when the context is done
+//     or the watcher is disconnected.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//
+//	events := tree.Watch(ctx, func(event Event[string]) bool {
+//	    return event.Type == EventDelete
+//	})
+//	for event := range events {
+//	    fmt.Println("Deleted:", event.Node.ID())
+//	}
+func (x *Tree[T]) Watch(ctx context.Context, filter func(event Event[T]) bool, opts ...WatchOption) (events <-chan Event[T]) {
+	cfg := newWatchConfig(opts...)
+	w := &watcher[T]{
+		ctx:          ctx,
+		events:       make(chan Event[T], cfg.bufferSize),
+		done:         make(chan struct{}),
+		filter:       filter,
+		backpressure: cfg.backpressure,
+	}
+
+	x.feed.subscribe(w)
+	go func() {
+		select {
+		case <-ctx.Done():
+			x.feed.unsubscribe(w)
+		case <-w.done:
+		}
+	}()
+	return w.events
+}
+
+// watcher is a subscriber of the Tree changes
+type watcher[T any] struct {
+	ctx          context.Context
+	events       chan Event[T]
+	filter       func(event Event[T]) bool
+	backpressure Backpressure
+	// done is closed once the watcher is removed
+	done chan struct{}
+}
+
+// deliver sends the given Event to the watcher according to its backpressure.
+// It returns false when the watcher has to be disconnected.
+func (w *watcher[T]) deliver(event Event[T]) bool {
+	if w.filter != nil && !w.filter(event) {
+		return true
+	}
+
+	select {
+	case w.events <- event:
+		return true
+	default:
+	}
+
+	switch w.backpressure {
+	case BackpressureDropNewest:
+		return true
+	case BackpressureDropOldest:
+		// the publisher is the only sender, hence there is room once an event is dropped
+		select {
+		case <-w.events:
+		default:
+		}
+		select {
+		case w.events <- event:
+		default:
+		}
+		return true
+	case BackpressureBlock:
+		select {
+		case w.events <- event:
+			return true
+		case <-w.ctx.Done():
+			return false
+		}
+	default:
+		return false
+	}
+}
+
+// publisher delivers the changes of the Tree to the watchers, the change feed and the storage in order
+type publisher[T any] struct {
+	// mu serializes the publications and guards the watchers and the change feed
+	mu       sync.Mutex
+	watchers map[*watcher[T]]struct{}
+	// seq is the sequence number of the last published event
+	seq uint64
+	// retained is the ring buffer of the latest events when the change feed is enabled
+	retained []Event[T]
+	// head is the position of the oldest retained event
+	head int
+	// count is the number of retained events
+	count int
+	// active states whether the events are published, sparing the lock otherwise
+	active atomic.Bool
+	// logger emits the records of the disconnected watchers and the changes not persisted
+	logger *slog.Logger
+	// storage persists the events when set
+	storage Storage[T]
+	// replaying states whether the events are recovered from the storage, which already holds them
+	replaying atomic.Bool
+	// persisted is the sequence number of the last snapshot saved to the storage
+	persisted atomic.Uint64
+	// appended signals the checkpointer that events were appended to the storage
+	appended chan struct{}
+	// batching states whether a batch is open, in which case the events are held back until it is committed
+	batching atomic.Bool
+	// held are the events of the changes applied within the open batch
+	held []Event[T]
+	// staged states whether the events of the changes applied are already written by a staged change
+	staged atomic.Bool
+}
+
+// newPublisher creates a publisher without watchers retaining the given number of events
+// and appending them to the given storage, which can be nil
+func newPublisher[T any](retention int, logger *slog.Logger, storage Storage[T]) *publisher[T] {
+	p := &publisher[T]{
+		watchers: make(map[*watcher[T]]struct{}),
+		logger:   logger,
+		storage:  storage,
+		appended: make(chan struct{}, 1),
+	}
+	if retention > 0 {
+		p.retained = make([]Event[T], retention)
+	}
+	p.active.Store(retention > 0 || storage != nil)
+	return p
+}
+
+// begin starts the publication of a change. The events of the change are written ahead with the returned
+// write function before the change is applied: write assigns the next sequence numbers to the events and
+// appends them to the storage at once, and returns an error wrapping ErrNotPersisted when the storage fails,
+// in which case the change must not be applied. The returned commit function publishes the written events
+// once the change is applied, or discards them when it is not, and must be called in any case so that the
+// events of concurrent changes are published in the order the changes were applied.
+func (p *publisher[T]) begin() (write func(events ...Event[T]) error, commit func(applied bool)) {
+	if !p.active.Load() {
+		return func(...Event[T]) error { return nil }, func(bool) {}
+	}
+
+	// the publisher lock is already held by the staged change, whose events are written
+	if p.staged.Load() {
+		return func(...Event[T]) error { return nil }, func(bool) {}
+	}
+
+	// the publisher lock is already held by the batch
+	if p.batching.Load() {
+		return p.hold, func(bool) {}
+	}
+
+	p.mu.Lock()
+	var written []Event[T]
+	write = func(events ...Event[T]) error {
+		events, err := p.write(events)
+		written = append(written, events...)
+		return err
+	}
+	return write, func(applied bool) {
+		defer p.mu.Unlock()
+		if !applied {
+			p.seq -= uint64(len(written))
+			return
+		}
+		p.deliver(written)
+	}
+}
+
+// batch starts the publication of a change made of several changes, as done by begin. Every change applied
+// until commit is called is written ahead on its own, the changes refused by the storage not being applied,
+// and the written events are held back to be published all at once. It must be called with the structural
+// lock held exclusively, so that no other change begins in the meantime
+func (p *publisher[T]) batch() (write func(events ...Event[T]) error, commit func()) {
+	if !p.active.Load() {
+		return func(...Event[T]) error { return nil }, func() {}
+	}
+
+	p.mu.Lock()
+	p.batching.Store(true)
+	return p.hold, func() {
+		defer p.mu.Unlock()
+		p.batching.Store(false)
+		held := p.held
+		p.held = nil
+		p.deliver(held)
+	}
+}
+
+// stage writes ahead at once the events of a change made of several changes, which are then applied without
+// being written again and published all at once on commit: the change is refused as a whole when the storage
+// fails to append it. It must be called with the structural lock held exclusively, once the changes are known
+// to apply, so that no other change begins in the meantime
+func (p *publisher[T]) stage(events []Event[T]) (commit func(), err error) {
+	if !p.active.Load() {
+		return func() {}, nil
+	}
+
+	p.mu.Lock()
+	written, err := p.write(events)
+	if err != nil {
+		p.mu.Unlock()
+		return nil, err
+	}
+
+	p.staged.Store(true)
+	return func() {
+		defer p.mu.Unlock()
+		p.staged.Store(false)
+		p.deliver(written)
+	}, nil
+}
+
+// hold writes ahead the events of a change applied within the open batch and holds them back until the
+// batch is committed. The caller must hold the publisher lock
+func (p *publisher[T]) hold(events ...Event[T]) error {
+	written, err := p.write(events)
+	p.held = append(p.held, written...)
+	return err
+}
+
+// write assigns the next sequence numbers to the given events of a change and appends them to the storage,
+// when set, before the change is applied. When the storage fails, the events are discarded along with their
+// sequence numbers and an error wrapping ErrNotPersisted is returned.
+// The caller must hold the publisher lock
+func (p *publisher[T]) write(events []Event[T]) ([]Event[T], error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	written := make([]Event[T], len(events))
+	for i, event := range events {
+		event.Seq = p.seq + uint64(i) + 1
+		event.Time = now
+		written[i] = event
+	}
+
+	if p.storage != nil && !p.replaying.Load() {
+		if err := p.storage.AppendChanges(written); err != nil {
+			logRecord(p.logger, slog.LevelError, "tree change not persisted", slog.Uint64("seq", written[0].Seq), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%w: change %d: %w", ErrNotPersisted, written[0].Seq, err)
+		}
+
+		select {
+		case p.appended <- struct{}{}:
+		default:
+		}
+	}
+
+	p.seq += uint64(len(written))
+	return written, nil
+}
+
+// deliver retains the given written events when the change feed is enabled and delivers them to the watchers.
+// The caller must hold the publisher lock
+func (p *publisher[T]) deliver(events []Event[T]) {
+	for _, event := range events {
+		if len(p.retained) > 0 {
+			if p.count == len(p.retained) {
+				// evict the oldest event
+				p.head = (p.head + 1) % len(p.retained)
+				p.count--
+			}
+			p.retained[(p.head+p.count)%len(p.retained)] = event
+			p.count++
+		}
+
+		for w := range p.watchers {
+			if !w.deliver(event) {
+				if w.ctx.Err() == nil {
+					logRecord(p.logger, slog.LevelWarn, "tree watcher disconnected", slog.Uint64("seq", event.Seq))
+				}
+				p.remove(w)
+			}
+		}
+	}
+}
+
+// subscribe registers the given watcher
+func (p *publisher[T]) subscribe(w *watcher[T]) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.watchers[w] = struct{}{}
+	p.active.Store(true)
+}
+
+// unsubscribe removes the given watcher and closes its channel
+func (p *publisher[T]) unsubscribe(w *watcher[T]) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.remove(w)
+}
+
+// remove removes the given watcher and closes its channel. The caller must hold the publisher lock
+func (p *publisher[T]) remove(w *watcher[T]) {
+	if _, ok := p.watchers[w]; !ok {
+		return
+	}
+
+	close(w.events)
+	close(w.done)
+	delete(p.watchers, w)
+	p.active.Store(len(p.watchers) > 0 || len(p.retained) > 0 || p.storage != nil)
+}
+
+// toEvents converts the given mutations into events of the given type
+func toEvents[T any](eventType EventType, mutations []mutation[T]) []Event[T] {
+	events := make([]Event[T], len(mutations))
+	for i, m := range mutations {
+		events[i] = Event[T]{Type: eventType, Node: m.node, Parent: m.parent}
+	}
+	return events
+}
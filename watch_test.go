@@ -0,0 +1,220 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drain returns the events buffered in the given channel
+func drain[T any](events <-chan Event[T]) []string {
+	var result []string
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return result
+			}
+			result = append(result, fmt.Sprintf("%s %s", event.Type, event.Node.ID()))
+		default:
+			return result
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("Delivers the changes in order", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tree := NewTree[string]()
+		events := tree.Watch(ctx, nil)
+
+		root := NewNode("root", "root")
+		a := NewNode("a", "a")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(a, root))
+		require.NoError(t, tree.Add(NewNode("b", "b"), a))
+		require.NoError(t, tree.Update(NewNode("b", "b2")))
+		require.NoError(t, tree.AddOrReplace(NewNode("a", "a2"), root))
+		require.NoError(t, tree.Delete(a))
+		require.NoError(t, tree.Add(NewNode("c", "c"), root))
+		tree.Reset()
+
+		assert.Equal(t, []string{
+			"add root", "add a", "add b",
+			"update b", "update a",
+			"delete a", "delete b",
+			"add c",
+			"delete root", "delete c",
+		}, drain(events))
+	})
+	t.Run("With the event details", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tree := NewTree[string]()
+		events := tree.Watch(ctx, nil)
+		root := NewNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Add(NewNode("a", "a"), root))
+		require.NoError(t, tree.Update(NewNode("a", "a2")))
+
+		event := <-events
+		assert.Equal(t, EventAdd, event.Type)
+		assert.Nil(t, event.Parent)
+
+		event = <-events
+		assert.Equal(t, EventAdd, event.Type)
+		assert.Equal(t, "root", event.Parent.ID())
+
+		event = <-events
+		assert.Equal(t, EventUpdate, event.Type)
+		assert.Equal(t, "a2", event.Node.Value())
+		assert.Equal(t, "root", event.Parent.ID())
+	})
+	t.Run("With a filter", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tree := NewTree[string]()
+		events := tree.Watch(ctx, func(event Event[string]) bool {
+			return event.Type == EventDelete
+		})
+		root := NewNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		require.NoError(t, tree.Delete(root))
+		assert.Equal(t, []string{"delete root"}, drain(events))
+	})
+	t.Run("Closes the channel once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		tree := NewTree[string]()
+		events := tree.Watch(ctx, nil)
+		cancel()
+
+		require.Eventually(t, func() bool {
+			select {
+			case _, ok := <-events:
+				return !ok
+			default:
+				return false
+			}
+		}, time.Second, 10*time.Millisecond)
+
+		// the tree keeps working without watchers
+		require.NoError(t, tree.Add(NewNode("root", "root"), nil))
+		assert.False(t, tree.feed.active.Load())
+	})
+	t.Run("With backpressure", func(t *testing.T) {
+		fill := func(tree *Tree[string]) {
+			root := NewNode("root", "root")
+			require.NoError(t, tree.Add(root, nil))
+			for i := 0; i < 4; i++ {
+				require.NoError(t, tree.Add(NewNode(fmt.Sprintf("%d", i), ""), root))
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tree := NewTree[string]()
+		disconnected := tree.Watch(ctx, nil, WithBufferSize(2))
+		dropNewest := tree.Watch(ctx, nil, WithBufferSize(2), WithBackpressure(BackpressureDropNewest))
+		dropOldest := tree.Watch(ctx, nil, WithBufferSize(2), WithBackpressure(BackpressureDropOldest))
+		fill(tree)
+
+		assert.Equal(t, []string{"add root", "add 0"}, drain(disconnected))
+		_, ok := <-disconnected
+		assert.False(t, ok)
+		assert.Equal(t, []string{"add root", "add 0"}, drain(dropNewest))
+		assert.Equal(t, []string{"add 2", "add 3"}, drain(dropOldest))
+	})
+	t.Run("With blocking backpressure", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tree := NewTree[string]()
+		events := tree.Watch(ctx, nil, WithBufferSize(0), WithBackpressure(BackpressureBlock))
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			root := NewNode("root", "root")
+			_ = tree.Add(root, nil)
+			_ = tree.Add(NewNode("a", "a"), root)
+		}()
+
+		assert.Equal(t, "root", (<-events).Node.ID())
+		assert.Equal(t, "a", (<-events).Node.ID())
+		<-done
+	})
+	t.Run("With concurrent changes", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tree := NewTree[string]()
+		root := NewNode("root", "root")
+		require.NoError(t, tree.Add(root, nil))
+		events := tree.Watch(ctx, nil, WithBufferSize(1000))
+
+		// every goroutine builds a chain, the other goroutines adding children to it concurrently
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					parent := root
+					if j > 0 {
+						parent = NewNode(fmt.Sprintf("%d-%d", (i+j)%10, j-1), "")
+					}
+					_ = tree.Add(NewNode(fmt.Sprintf("%d-%d", i, j), ""), parent)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		// a node is never published before its parent
+		published := map[string]bool{"root": true}
+		for _, event := range drain(events) {
+			var id string
+			_, _ = fmt.Sscanf(event, "add %s", &id)
+			node, ok := tree.Find(id)
+			require.True(t, ok)
+			parent, ok := tree.ParentAt(node, 0)
+			require.True(t, ok)
+			assert.True(t, published[parent.ID()], "%s published before its parent %s", id, parent.ID())
+			published[id] = true
+		}
+		assert.Len(t, published, int(tree.Size()))
+	})
+}
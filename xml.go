@@ -0,0 +1,449 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// XMLOption configures the XML encoding of a Tree
+type XMLOption func(cfg *xmlConfig)
+
+// xmlConfig holds the XML encoding settings
+type xmlConfig struct {
+	// element names the element of a Node, a func(Node[T]) string
+	element any
+	// idAttribute is the name of the attribute holding the ID of the Nodes
+	idAttribute string
+	// mapper maps the values to attributes, a *xmlMapper[T]
+	mapper any
+	// indent is the indentation of the nested elements, none when empty
+	indent string
+}
+
+// xmlMapper converts the values of the Nodes from and to XML attributes
+type xmlMapper[T any] struct {
+	marshal   func(value T) ([]xml.Attr, error)
+	unmarshal func(attrs []xml.Attr) (T, error)
+}
+
+// WithXMLElement sets the function naming the element of every Node. The elements are named "node"
+// by default. The decoding recognizes the Nodes by their ID attribute whatever their element name.
+func WithXMLElement[T any](name func(node Node[T]) string) XMLOption {
+	return func(cfg *xmlConfig) {
+		cfg.element = name
+	}
+}
+
+// WithXMLIDAttribute sets the name of the attribute holding the ID of the Nodes. It defaults to "id".
+func WithXMLIDAttribute(name string) XMLOption {
+	return func(cfg *xmlConfig) {
+		cfg.idAttribute = name
+	}
+}
+
+// WithXMLMapper sets the functions converting the values of the Nodes from and to the attributes of
+// their element, for the values encoding/xml cannot handle or the formats imposed by a partner.
+// The content of the elements other than the child Nodes is ignored when a mapper is set.
+func WithXMLMapper[T any](marshal func(value T) ([]xml.Attr, error), unmarshal func(attrs []xml.Attr) (T, error)) XMLOption {
+	return func(cfg *xmlConfig) {
+		cfg.mapper = &xmlMapper[T]{marshal: marshal, unmarshal: unmarshal}
+	}
+}
+
+// WithXMLIndent indents the nested elements with the given string, one level per depth
+func WithXMLIndent(indent string) XMLOption {
+	return func(cfg *xmlConfig) {
+		cfg.indent = indent
+	}
+}
+
+// resolvedXMLConfig holds the XML encoding settings of a Tree of T values
+type resolvedXMLConfig[T any] struct {
+	element     func(node Node[T]) string
+	idAttribute string
+	mapper      *xmlMapper[T]
+	indent      string
+}
+
+// newXMLConfig applies the given options for a Tree of T values. It returns an error wrapping
+// ErrInvalidOperation when an option was built for another type of values
+func newXMLConfig[T any](opts []XMLOption) (*resolvedXMLConfig[T], error) {
+	cfg := &xmlConfig{idAttribute: "id"}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	resolved := &resolvedXMLConfig[T]{
+		element:     func(Node[T]) string { return "node" },
+		idAttribute: cfg.idAttribute,
+		indent:      cfg.indent,
+	}
+	if cfg.element != nil {
+		element, ok := cfg.element.(func(node Node[T]) string)
+		if !ok {
+			return nil, fmt.Errorf("%w: XML element mapping does not name %s nodes", ErrInvalidOperation, reflect.TypeFor[T]())
+		}
+		resolved.element = element
+	}
+	if cfg.mapper != nil {
+		mapper, ok := cfg.mapper.(*xmlMapper[T])
+		if !ok {
+			return nil, fmt.Errorf("%w: XML mapper does not map %s values", ErrInvalidOperation, reflect.TypeFor[T]())
+		}
+		resolved.mapper = mapper
+	}
+	return resolved, nil
+}
+
+// EncodeXML writes the Nodes of the Tree to the given writer as an XML document whose nesting mirrors
+// the hierarchy: the element of the root is the document element and every Node is nested in the
+// element of its parent, in the order of the children.
+//
+// Every element carries the ID of its Node in the "id" attribute. The value of the Node is encoded with
+// encoding/xml, the struct fields tagged as attributes becoming attributes of the element and the other
+// fields its content, unless a mapper set with WithXMLMapper converts the values to attributes.
+//
+// Parameters:
+//   - w: The writer receiving the document.
+//   - opts: The XMLOption settings, such as the element names, the ID attribute or the mapper.
+//
+// Returns:
+//   - err: An error when a value cannot be encoded, holds an attribute named like the ID attribute,
+//     or the writer fails, or an error wrapping ErrInvalidOperation when an option was built for
+//     another type of values.
+//
+// Notes:
+//   - The metadata, edges and tags of the Nodes are not encoded.
+//   - EncodeXML blocks the structural changes of the Tree while it captures the Nodes.
+//
+// Example usage:
+//
+//	type Part struct {
+//	    Name string `xml:"name,attr"`
+//	    Qty  int    `xml:"qty,attr"`
+//	}
+//
+//	err := tree.EncodeXML(w, WithXMLIndent("  "), WithXMLElement(func(node Node[Part]) string {
+//	    return "part"
+//	}))
+//	// <part id="root" name="engine" qty="1">
+//	//   <part id="p1" name="piston" qty="4"></part>
+//	// </part>
+func (x *Tree[T]) EncodeXML(w io.Writer, opts ...XMLOption) error {
+	cfg, err := newXMLConfig[T](opts)
+	if err != nil {
+		return fmt.Errorf("gotree: cannot encode tree: %w", err)
+	}
+	records := x.snapshot().Records
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", cfg.indent)
+
+	var open []xml.StartElement
+	var ids []string
+	for _, record := range records {
+		// close the elements of the Nodes the record does not descend from
+		for len(ids) > 0 && ids[len(ids)-1] != record.ParentID {
+			if err := encoder.EncodeToken(open[len(open)-1].End()); err != nil {
+				return fmt.Errorf("gotree: cannot encode tree: %w", err)
+			}
+			open, ids = open[:len(open)-1], ids[:len(ids)-1]
+		}
+
+		start, content, err := cfg.open(record)
+		if err != nil {
+			return fmt.Errorf("gotree: cannot encode node %q: %w", record.ID, err)
+		}
+		if err := encoder.EncodeToken(start); err != nil {
+			return fmt.Errorf("gotree: cannot encode tree: %w", err)
+		}
+		for _, token := range content {
+			if err := encoder.EncodeToken(token); err != nil {
+				return fmt.Errorf("gotree: cannot encode node %q: %w", record.ID, err)
+			}
+		}
+		open, ids = append(open, start), append(ids, record.ID)
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		if err := encoder.EncodeToken(open[i].End()); err != nil {
+			return fmt.Errorf("gotree: cannot encode tree: %w", err)
+		}
+	}
+	if err := encoder.Flush(); err != nil {
+		return fmt.Errorf("gotree: cannot encode tree: %w", err)
+	}
+	return nil
+}
+
+// open returns the start element of the Node of the given record along with the content encoding its value
+func (cfg *resolvedXMLConfig[T]) open(record Record[T]) (xml.StartElement, []xml.Token, error) {
+	start := xml.StartElement{
+		Name: xml.Name{Local: cfg.element(NewNode(record.ID, record.Value))},
+		Attr: []xml.Attr{{Name: xml.Name{Local: cfg.idAttribute}, Value: record.ID}},
+	}
+
+	var (
+		attrs   []xml.Attr
+		content []xml.Token
+		err     error
+	)
+	if cfg.mapper != nil {
+		attrs, err = cfg.mapper.marshal(record.Value)
+	} else {
+		attrs, content, err = marshalXMLValue(record.Value, start.Name)
+	}
+	if err != nil {
+		return start, nil, err
+	}
+
+	for _, attr := range attrs {
+		if attr.Name.Space == "" && attr.Name.Local == cfg.idAttribute {
+			return start, nil, fmt.Errorf("%w: the value holds the %q attribute", ErrInvalidOperation, cfg.idAttribute)
+		}
+	}
+	start.Attr = append(start.Attr, attrs...)
+	return start, content, nil
+}
+
+// marshalXMLValue encodes the given value with encoding/xml as an element with the given name
+// and returns the attributes and the content of the element
+func marshalXMLValue[T any](value T, name xml.Name) (attrs []xml.Attr, content []xml.Token, err error) {
+	var buf bytes.Buffer
+	if err := xml.NewEncoder(&buf).EncodeElement(value, xml.StartElement{Name: name}); err != nil {
+		return nil, nil, err
+	}
+
+	decoder := xml.NewDecoder(&buf)
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return attrs, content, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 1 {
+				attrs = token.Copy().Attr
+				continue
+			}
+		case xml.EndElement:
+			depth--
+			if depth == 0 {
+				continue
+			}
+		}
+		content = append(content, xml.CopyToken(token))
+	}
+}
+
+// DecodeXML replaces the Nodes of the Tree with the Nodes of the given XML document, as written by
+// EncodeXML with the same options.
+//
+// The elements carrying the ID attribute are the Nodes, nested in the element of their parent, whatever
+// their name. The other attributes and the rest of the content of an element make up the value of its
+// Node, decoded with encoding/xml or the mapper set with WithXMLMapper.
+//
+// Parameters:
+//   - r: The reader providing the document.
+//   - opts: The XMLOption settings used to encode the document.
+//
+// Returns:
+//   - err: An error when the document is malformed, its document element is not a Node, a value
+//     cannot be decoded, or a Node cannot be added, in which case the Tree holds the Nodes added so far.
+//     The error wraps ErrInvalidOperation when an option was built for another type of values.
+//
+// Notes:
+//   - The text surrounding the child Nodes of an element is trimmed, which drops the indentation.
+//   - The hooks, the watchers and the Storage are notified of the decoded Nodes.
+//
+// Example usage:
+//
+//	tree := NewTree[Part]()
+//	if err := tree.DecodeXML(resp.Body); err != nil {
+//	    log.Println("Cannot decode the tree:", err)
+//	}
+func (x *Tree[T]) DecodeXML(r io.Reader, opts ...XMLOption) error {
+	if err := x.requireContext(opLoad, "", ""); err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+
+	cfg, err := newXMLConfig[T](opts)
+	if err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+	decoder := xml.NewDecoder(r)
+
+	var records []Record[T]
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("gotree: cannot decode tree: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if len(records) > 0 {
+			return fmt.Errorf("gotree: cannot decode tree: %w: more than one document element", ErrCorruptedFile)
+		}
+		if _, ok := cfg.id(start); !ok {
+			return fmt.Errorf("gotree: cannot decode tree: %w: element %q has no %q attribute", ErrCorruptedFile, start.Name.Local, cfg.idAttribute)
+		}
+		if records, err = cfg.decode(decoder, start.Copy(), "", records); err != nil {
+			return fmt.Errorf("gotree: cannot decode tree: %w", err)
+		}
+	}
+
+	if err := x.restore(context.Background(), records); err != nil {
+		return fmt.Errorf("gotree: cannot decode tree: %w", err)
+	}
+	return nil
+}
+
+// id returns the Node ID carried by the given element
+func (cfg *resolvedXMLConfig[T]) id(start xml.StartElement) (string, bool) {
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "" && attr.Name.Local == cfg.idAttribute {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// decode decodes the Node of the given element, up to its end, and its descendants
+// and appends them to the given records in depth-first order
+func (cfg *resolvedXMLConfig[T]) decode(decoder *xml.Decoder, start xml.StartElement, parentID string, records []Record[T]) ([]Record[T], error) {
+	id, _ := cfg.id(start)
+	position := len(records)
+	records = append(records, Record[T]{ID: id, ParentID: parentID})
+
+	var (
+		attrs   []xml.Attr
+		content []xml.Token
+		depth   int
+		trim    bool
+	)
+	for _, attr := range start.Attr {
+		if attr.Name.Space != "" || attr.Name.Local != cfg.idAttribute {
+			attrs = append(attrs, attr)
+		}
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		switch current := token.(type) {
+		case xml.StartElement:
+			if _, ok := cfg.id(current); ok && depth == 0 {
+				// trim the text preceding the child Node
+				if last := len(content) - 1; last >= 0 {
+					if text, ok := content[last].(xml.CharData); ok {
+						content = content[:last]
+						if text = bytes.TrimRight(text, " \t\r\n"); len(text) > 0 {
+							content = append(content, text)
+						}
+					}
+				}
+				if records, err = cfg.decode(decoder, current.Copy(), id, records); err != nil {
+					return nil, err
+				}
+				trim = true
+				continue
+			}
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				value, err := cfg.value(start.Name, attrs, content)
+				if err != nil {
+					return nil, fmt.Errorf("cannot decode node %q: %w", id, err)
+				}
+				records[position].Value = value
+				return records, nil
+			}
+			depth--
+		case xml.CharData:
+			if trim {
+				// trim the text following the child Node
+				if current = bytes.TrimLeft(current, " \t\r\n"); len(current) == 0 {
+					continue
+				}
+			}
+			token = current
+		case xml.Comment, xml.ProcInst, xml.Directive:
+			continue
+		}
+		trim = false
+		content = append(content, xml.CopyToken(token))
+	}
+}
+
+// value decodes the value of a Node from the attributes and the content of its element
+func (cfg *resolvedXMLConfig[T]) value(name xml.Name, attrs []xml.Attr, content []xml.Token) (T, error) {
+	if cfg.mapper != nil {
+		return cfg.mapper.unmarshal(attrs)
+	}
+
+	var buf bytes.Buffer
+	encoder := xml.NewEncoder(&buf)
+	start := xml.StartElement{Name: xml.Name{Local: name.Local}, Attr: attrs}
+	tokens := append(append([]xml.Token{start}, content...), start.End())
+	for _, token := range tokens {
+		if err := encoder.EncodeToken(token); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	if err := encoder.Flush(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	var value T
+	err := xml.Unmarshal(buf.Bytes(), &value)
+	return value, err
+}
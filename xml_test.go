@@ -0,0 +1,142 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// part is a value encoded as XML attributes and content
+type part struct {
+	Name string `xml:"name,attr"`
+	Qty  int    `xml:"qty,attr"`
+	Note string `xml:"note,omitempty"`
+}
+
+func TestXML(t *testing.T) {
+	t.Run("round-trips the struct values", func(t *testing.T) {
+		tree := NewTree[part]()
+		require.NoError(t, tree.AddByID(NewNode("root", part{Name: "engine", Qty: 1, Note: "v8"}), ""))
+		require.NoError(t, tree.AddByID(NewNode("p1", part{Name: "piston", Qty: 8}), "root"))
+		require.NoError(t, tree.AddByID(NewNode("p2", part{Name: "valve", Qty: 16, Note: "steel"}), "root"))
+		require.NoError(t, tree.AddByID(NewNode("p11", part{Name: "ring", Qty: 3}), "p1"))
+
+		element := WithXMLElement(func(node Node[part]) string {
+			if node.ID() == "root" {
+				return "assembly"
+			}
+			return "part"
+		})
+
+		var compact bytes.Buffer
+		require.NoError(t, tree.EncodeXML(&compact, element))
+		assert.Equal(t, `<assembly id="root" name="engine" qty="1"><note>v8</note>`+
+			`<part id="p1" name="piston" qty="8"><part id="p11" name="ring" qty="3"></part></part>`+
+			`<part id="p2" name="valve" qty="16"><note>steel</note></part></assembly>`, compact.String())
+
+		for _, opts := range [][]XMLOption{{element}, {element, WithXMLIndent("  ")}} {
+			var buf bytes.Buffer
+			require.NoError(t, tree.EncodeXML(&buf, opts...))
+
+			decoded := NewTree[part]()
+			require.NoError(t, decoded.DecodeXML(&buf, opts...))
+			assert.Equal(t, tree.Snapshot().Records, decoded.Snapshot().Records)
+		}
+	})
+
+	t.Run("round-trips the text values", func(t *testing.T) {
+		tree := NewTree[string]()
+		require.NoError(t, tree.AddByID(NewNode("root", "a <root>"), ""))
+		require.NoError(t, tree.AddByID(NewNode("a", "  spaced  "), "root"))
+		require.NoError(t, tree.AddByID(NewNode("b", ""), "root"))
+
+		var buf bytes.Buffer
+		require.NoError(t, tree.EncodeXML(&buf, WithXMLIndent("\t"), WithXMLIDAttribute("key")))
+		assert.Contains(t, buf.String(), `<node key="a">  spaced  </node>`)
+
+		decoded := NewTree[string]()
+		require.NoError(t, decoded.DecodeXML(&buf, WithXMLIDAttribute("key")))
+		assert.Equal(t, tree.Snapshot().Records, decoded.Snapshot().Records)
+	})
+
+	t.Run("maps the values with a mapper", func(t *testing.T) {
+		mapper := WithXMLMapper(func(value int) ([]xml.Attr, error) {
+			return []xml.Attr{{Name: xml.Name{Local: "value"}, Value: strconv.Itoa(value)}}, nil
+		}, func(attrs []xml.Attr) (int, error) {
+			for _, attr := range attrs {
+				if attr.Name.Local == "value" {
+					return strconv.Atoi(attr.Value)
+				}
+			}
+			return 0, nil
+		})
+
+		decoded := NewTree[int]()
+		require.NoError(t, decoded.DecodeXML(strings.NewReader(`<?xml version="1.0"?>
+<!-- partner export -->
+<item id="root" value="1">
+  <label>ignored</label>
+  <item id="a" value="2"/>
+  <item id="b"/>
+</item>`), mapper))
+		assert.Equal(t, []Record[int]{
+			{ID: "root", Value: 1},
+			{ID: "a", ParentID: "root", Value: 2},
+			{ID: "b", ParentID: "root"},
+		}, decoded.Snapshot().Records)
+
+		var buf bytes.Buffer
+		require.NoError(t, decoded.EncodeXML(&buf, mapper))
+		assert.Equal(t, `<node id="root" value="1"><node id="a" value="2"></node><node id="b" value="0"></node></node>`, buf.String())
+	})
+
+	t.Run("rejects the invalid documents", func(t *testing.T) {
+		tree := NewTree[string]()
+		assert.ErrorIs(t, tree.DecodeXML(strings.NewReader(`<node>x</node>`)), ErrCorruptedFile)
+		assert.ErrorIs(t, tree.DecodeXML(strings.NewReader(`<node id="a"/><node id="b"/>`)), ErrCorruptedFile)
+		assert.Error(t, tree.DecodeXML(strings.NewReader(`<node id="a"><node id="b">`)))
+		assert.ErrorIs(t, tree.DecodeXML(strings.NewReader(`<node id="a"><node id="a"/></node>`)), ErrDuplicateID)
+
+		tree.Reset()
+		require.NoError(t, tree.AddByID(NewNode("root", ""), ""))
+		err := tree.EncodeXML(&bytes.Buffer{}, WithXMLMapper(func(string) ([]xml.Attr, error) {
+			return []xml.Attr{{Name: xml.Name{Local: "id"}, Value: "x"}}, nil
+		}, nil))
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+		err = tree.EncodeXML(&bytes.Buffer{}, WithXMLElement(func(Node[int]) string { return "x" }))
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+		err = tree.DecodeXML(strings.NewReader(`<node id="a"/>`), WithXMLMapper(func(int) ([]xml.Attr, error) { return nil, nil }, nil))
+		assert.ErrorIs(t, err, ErrInvalidOperation)
+		_, ok := tree.Find("root")
+		assert.True(t, ok)
+	})
+}
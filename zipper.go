@@ -0,0 +1,192 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+// Zipper navigates and edits an immutable view of a Tree in functional style.
+//
+// A Zipper is focused on a Node of the view. Every operation returns a new Zipper and leaves the
+// original untouched, hence a Zipper can be kept around as a checkpoint, shared between goroutines
+// and discarded at will: the edits are localized to the view and never reach the Tree. Only the Nodes
+// on the path from the edited Nodes to the root are copied, the rest of the view is shared.
+//
+// The view is captured when the Zipper is created: the later changes of the Tree are not reflected.
+type Zipper[T any] struct {
+	// focus is the Node the Zipper is focused on
+	focus *zipperNode[T]
+	// crumb locates the focus in its parent, nil at the root
+	crumb *zipperCrumb[T]
+}
+
+// zipperNode is an immutable Node of the view of a Zipper
+type zipperNode[T any] struct {
+	id       string
+	value    T
+	children []*zipperNode[T]
+}
+
+// zipperCrumb records the way down from a parent to one of its children
+type zipperCrumb[T any] struct {
+	// parent is the parent of the focus. Its child at index may be outdated when the focus is edited
+	parent *zipperNode[T]
+	// index is the position of the focus among the children of the parent
+	index int
+	// up is the crumb of the parent, nil when the parent is the root
+	up *zipperCrumb[T]
+}
+
+// Zipper creates a Zipper focused on the root of an immutable view of the Tree.
+//
+// Returns:
+//   - zipper: The Zipper focused on the root.
+//   - ok: false when the Tree is empty.
+//
+// Notes:
+//   - Zipper blocks the structural changes of the Tree while it captures the view.
+//
+// Example usage:
+//
+//	zipper, _ := tree.Zipper()
+//	zipper, ok := zipper.Down()
+//	if ok {
+//	    zipper = zipper.Edit(strings.ToUpper)
+//	    edited := zipper.Records()
+//	}
+func (x *Tree[T]) Zipper() (zipper *Zipper[T], ok bool) {
+	records := x.snapshot().Records
+	if len(records) == 0 {
+		return nil, false
+	}
+
+	// the records are in depth-first order: the parents come before their children
+	nodes := make(map[string]*zipperNode[T], len(records))
+	for _, record := range records {
+		node := &zipperNode[T]{id: record.ID, value: record.Value}
+		nodes[record.ID] = node
+		if parent, ok := nodes[record.ParentID]; ok {
+			parent.children = append(parent.children, node)
+		}
+	}
+	return &Zipper[T]{focus: nodes[records[0].ID]}, true
+}
+
+// Focus returns the Node the Zipper is focused on
+func (z *Zipper[T]) Focus() Node[T] {
+	return NewNode(z.focus.id, z.focus.value)
+}
+
+// Up returns a Zipper focused on the parent of the focus.
+// It returns false when the focus is the root.
+func (z *Zipper[T]) Up() (*Zipper[T], bool) {
+	if z.crumb == nil {
+		return nil, false
+	}
+	return &Zipper[T]{focus: z.parent(), crumb: z.crumb.up}, true
+}
+
+// Down returns a Zipper focused on the first child of the focus.
+// It returns false when the focus has no children.
+func (z *Zipper[T]) Down() (*Zipper[T], bool) {
+	if len(z.focus.children) == 0 {
+		return nil, false
+	}
+	return &Zipper[T]{
+		focus: z.focus.children[0],
+		crumb: &zipperCrumb[T]{parent: z.focus, up: z.crumb},
+	}, true
+}
+
+// Left returns a Zipper focused on the previous sibling of the focus.
+// It returns false when the focus is the first child of its parent or the root.
+func (z *Zipper[T]) Left() (*Zipper[T], bool) {
+	return z.sibling(-1)
+}
+
+// Right returns a Zipper focused on the next sibling of the focus.
+// It returns false when the focus is the last child of its parent or the root.
+func (z *Zipper[T]) Right() (*Zipper[T], bool) {
+	return z.sibling(1)
+}
+
+// Edit returns a Zipper whose focus holds the value returned by the given function,
+// which receives the current value. The identifier and the children of the focus are kept.
+func (z *Zipper[T]) Edit(fn func(value T) T) *Zipper[T] {
+	focus := &zipperNode[T]{id: z.focus.id, value: fn(z.focus.value), children: z.focus.children}
+	return &Zipper[T]{focus: focus, crumb: z.crumb}
+}
+
+// Top returns a Zipper focused on the root of the view, carrying the edits along the way
+func (z *Zipper[T]) Top() *Zipper[T] {
+	for z.crumb != nil {
+		z, _ = z.Up()
+	}
+	return z
+}
+
+// Records returns the Nodes of the whole view, edits included, in depth-first order.
+// They can be restored into a Tree with Restore.
+func (z *Zipper[T]) Records() []Record[T] {
+	var records []Record[T]
+	var walk func(node *zipperNode[T], parentID string)
+	walk = func(node *zipperNode[T], parentID string) {
+		records = append(records, Record[T]{ID: node.id, ParentID: parentID, Value: node.value})
+		for _, child := range node.children {
+			walk(child, node.id)
+		}
+	}
+
+	walk(z.Top().focus, "")
+	return records
+}
+
+// parent returns the parent of the focus holding the focus as child, copying it when the focus has been edited
+func (z *Zipper[T]) parent() *zipperNode[T] {
+	parent := z.crumb.parent
+	if parent.children[z.crumb.index] == z.focus {
+		return parent
+	}
+
+	children := make([]*zipperNode[T], len(parent.children))
+	copy(children, parent.children)
+	children[z.crumb.index] = z.focus
+	return &zipperNode[T]{id: parent.id, value: parent.value, children: children}
+}
+
+// sibling returns a Zipper focused on the sibling of the focus located at the given offset
+func (z *Zipper[T]) sibling(offset int) (*Zipper[T], bool) {
+	if z.crumb == nil {
+		return nil, false
+	}
+
+	index := z.crumb.index + offset
+	if index < 0 || index >= len(z.crumb.parent.children) {
+		return nil, false
+	}
+
+	parent := z.parent()
+	return &Zipper[T]{
+		focus: parent.children[index],
+		crumb: &zipperCrumb[T]{parent: parent, index: index, up: z.crumb.up},
+	}, true
+}
@@ -0,0 +1,122 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Arsene Tochemey Gandote
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gotree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipper(t *testing.T) {
+	tree := NewTree[string]()
+	require.NoError(t, tree.AddByID(NewNode("root", "root"), ""))
+	require.NoError(t, tree.AddByID(NewNode("a", "a"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b", "b"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("c", "c"), "root"))
+	require.NoError(t, tree.AddByID(NewNode("b1", "b1"), "b"))
+
+	t.Run("fails on an empty tree", func(t *testing.T) {
+		_, ok := NewTree[string]().Zipper()
+		assert.False(t, ok)
+	})
+
+	t.Run("navigates the view", func(t *testing.T) {
+		zipper, ok := tree.Zipper()
+		require.True(t, ok)
+		assert.Equal(t, "root", zipper.Focus().ID())
+
+		_, ok = zipper.Up()
+		assert.False(t, ok)
+		_, ok = zipper.Left()
+		assert.False(t, ok)
+
+		a, ok := zipper.Down()
+		require.True(t, ok)
+		assert.Equal(t, "a", a.Focus().ID())
+		_, ok = a.Left()
+		assert.False(t, ok)
+		_, ok = a.Down()
+		assert.False(t, ok)
+
+		b, ok := a.Right()
+		require.True(t, ok)
+		assert.Equal(t, "b", b.Focus().ID())
+
+		b1, ok := b.Down()
+		require.True(t, ok)
+		assert.Equal(t, "b1", b1.Focus().ID())
+
+		c, ok := b.Right()
+		require.True(t, ok)
+		assert.Equal(t, "c", c.Focus().ID())
+		_, ok = c.Right()
+		assert.False(t, ok)
+
+		up, ok := b1.Up()
+		require.True(t, ok)
+		assert.Equal(t, "b", up.Focus().ID())
+		assert.Equal(t, "root", b1.Top().Focus().ID())
+	})
+
+	t.Run("edits the view", func(t *testing.T) {
+		zipper, ok := tree.Zipper()
+		require.True(t, ok)
+		a, _ := zipper.Down()
+		b, _ := a.Right()
+		b1, _ := b.Down()
+
+		edited := b1.Edit(strings.ToUpper)
+		assert.Equal(t, "B1", edited.Focus().Value())
+		assert.Equal(t, "b1", b1.Focus().Value())
+
+		// the edits are carried along the navigation
+		edited, _ = edited.Up()
+		edited = edited.Edit(strings.ToUpper)
+		edited, _ = edited.Left()
+		edited = edited.Edit(strings.ToUpper)
+		assert.Equal(t, []Record[string]{
+			{ID: "root", Value: "root"},
+			{ID: "a", ParentID: "root", Value: "A"},
+			{ID: "b", ParentID: "root", Value: "B"},
+			{ID: "b1", ParentID: "b", Value: "B1"},
+			{ID: "c", ParentID: "root", Value: "c"},
+		}, edited.Records())
+
+		// the original zipper and the tree are untouched
+		assert.Equal(t, "b", b1.Top().Records()[2].Value)
+		node, ok := tree.Find("b1")
+		require.True(t, ok)
+		assert.Equal(t, "b1", node.Value())
+
+		copied := NewTree[string]()
+		require.NoError(t, copied.Restore(Snapshot[string]{Records: edited.Records()}))
+		node, ok = copied.Find("b")
+		require.True(t, ok)
+		assert.Equal(t, "B", node.Value())
+	})
+}